@@ -148,6 +148,10 @@ const (
 	// inclusion of another HTTPProxy resource.
 	ConditionTypeIncludeError = "IncludeError"
 
+	// ConditionTypeJWTVerificationError describes an error condition
+	// related to JWT verification.
+	ConditionTypeJWTVerificationError = "JWTVerificationError"
+
 	// ConditionTypeOrphanedError describes an error condition
 	// with an HTTPProxy resource which is not part of a delegation chain.
 	ConditionTypeOrphanedError = "Orphaned"
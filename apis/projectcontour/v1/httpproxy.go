@@ -54,22 +54,36 @@ type Include struct {
 	// structs.
 	// When applied, they are merged using AND, with one exception:
 	// There can be only one Prefix MatchCondition per Conditions slice.
-	// More than one Prefix, or contradictory Conditions, will make the
-	// include invalid.
+	// More than one Prefix, any Exact or Regex MatchCondition, or
+	// contradictory Conditions, will make the include invalid.
 	// +optional
 	Conditions []MatchCondition `json:"conditions,omitempty"`
 }
 
 // MatchCondition are a general holder for matching rules for HTTPProxies.
-// One of Prefix or Header must be provided.
+// One of Prefix, Exact, Regex, Header or QueryParameter must be provided.
 type MatchCondition struct {
 	// Prefix defines a prefix match for a request.
 	// +optional
 	Prefix string `json:"prefix,omitempty"`
 
+	// Exact defines a exact match for a request.
+	// This field is not allowed in include match conditions.
+	// +optional
+	Exact string `json:"exact,omitempty"`
+
+	// Regex defines a regex match for a request.
+	// This field is not allowed in include match conditions.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
 	// Header specifies the header condition to match.
 	// +optional
 	Header *HeaderMatchCondition `json:"header,omitempty"`
+
+	// QueryParameter specifies the query parameter condition to match.
+	// +optional
+	QueryParameter *QueryParameterMatchCondition `json:"queryParameter,omitempty"`
 }
 
 // HeaderMatchCondition specifies how to conditionally match against HTTP
@@ -113,6 +127,32 @@ type HeaderMatchCondition struct {
 	NotExact string `json:"notexact,omitempty"`
 }
 
+// QueryParameterMatchCondition specifies how to conditionally match against
+// HTTP query parameters. The Name field is required, but only one of the
+// remaining fields should be be provided.
+type QueryParameterMatchCondition struct {
+	// Name is the name of the query parameter to match against. Name is required.
+	// Query parameter names are case sensitive.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Exact specifies a string that the query parameter value must be equal to.
+	// +optional
+	Exact string `json:"exact,omitempty"`
+
+	// Regex specifies a regular expression pattern that must match the
+	// query parameter value.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
+	// Present specifies that condition is true when the named query
+	// parameter is present, regardless of its value. Note that setting
+	// Present to false does not make the condition true if the named
+	// query parameter is absent.
+	// +optional
+	Present bool `json:"present,omitempty"`
+}
+
 // ExtensionServiceReference names an ExtensionService resource.
 type ExtensionServiceReference struct {
 	// API version of the referent.
@@ -244,6 +284,187 @@ type VirtualHost struct {
 	// The policy for rate limiting on the virtual host.
 	// +optional
 	RateLimitPolicy *RateLimitPolicy `json:"rateLimitPolicy,omitempty"`
+	// Providers to use for verifying JSON Web Tokens (JWTs) on the virtual host.
+	// JWT verification can only be configured on virtual hosts that have
+	// TLS enabled.
+	// +optional
+	JWTProviders []JWTProvider `json:"jwtProviders,omitempty"`
+	// IPAllowFilterPolicy is a list of ipv4/6 filter rules for which matching
+	// requests should be allowed. All other requests will be denied.
+	// Only one of IPAllowFilterPolicy and IPDenyFilterPolicy can be defined.
+	// The rules defined here may be overridden in a Route.
+	// +optional
+	IPAllowFilterPolicy []IPFilterPolicy `json:"ipAllowPolicy,omitempty"`
+	// IPDenyFilterPolicy is a list of ipv4/6 filter rules for which matching
+	// requests should be denied. All other requests will be allowed.
+	// Only one of IPAllowFilterPolicy and IPDenyFilterPolicy can be defined.
+	// The rules defined here may be overridden in a Route.
+	// +optional
+	IPDenyFilterPolicy []IPFilterPolicy `json:"ipDenyPolicy,omitempty"`
+	// The access log policy for requests to this virtual host.
+	// Overrides the globally configured access log format and
+	// filtering for this virtual host.
+	// +optional
+	AccessLogPolicy *AccessLogPolicy `json:"accessLogPolicy,omitempty"`
+}
+
+// AccessLogPolicy defines how requests are access logged.
+type AccessLogPolicy struct {
+	// Disabled disables access logging for matching requests.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Format sets the access log format, overriding the globally
+	// configured format. One of `envoy` or `json`.
+	// +optional
+	// +kubebuilder:validation:Enum=envoy;json
+	Format string `json:"format,omitempty"`
+
+	// FormatString sets the Envoy format string used when the
+	// format is `envoy`. The string must end in a newline.
+	// +optional
+	FormatString string `json:"formatString,omitempty"`
+
+	// Fields sets the fields logged when the format is `json`.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+
+	// MinStatusCode restricts logging to requests whose response
+	// status code is greater than or equal to this value.
+	// +optional
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	MinStatusCode int `json:"minStatusCode,omitempty"`
+
+	// SamplingPercent restricts logging to the given percentage of
+	// requests, chosen at random. Defaults to logging all requests.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercent *int `json:"samplingPercent,omitempty"`
+}
+
+// IPFilterSource indicates which address an IPFilterPolicy is matched against.
+type IPFilterSource string
+
+const (
+	// IPFilterSourcePeer filters on the address of the network peer,
+	// ignoring PROXY protocol and X-Forwarded-For.
+	IPFilterSourcePeer IPFilterSource = "Peer"
+	// IPFilterSourceRemote filters on the address of the client,
+	// accounting for PROXY protocol and X-Forwarded-For as configured.
+	IPFilterSourceRemote IPFilterSource = "Remote"
+)
+
+// IPFilterPolicy is a single rule in an IP allow or deny list.
+type IPFilterPolicy struct {
+	// Source indicates how to determine the ip address to filter on, and can be
+	// one of two values:
+	//  - `Remote` filters on the ip address of the client, accounting for PROXY and
+	//    X-Forwarded-For as needed.
+	//  - `Peer` filters on the ip of the network request, ignoring PROXY and
+	//    X-Forwarded-For.
+	// +kubebuilder:validation:Enum=Peer;Remote
+	Source IPFilterSource `json:"source"`
+
+	// CIDR is a CIDR block of ipv4 or ipv6 addresses to filter on. This can also be
+	// a bare IP address (without a mask) to filter on exactly one address.
+	CIDR string `json:"cidr"`
+}
+
+// JWTProvider defines how to verify JWTs on requests.
+type JWTProvider struct {
+	// Unique name for the provider.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Whether the provider should apply to all
+	// routes in the HTTPProxy/its includes by
+	// default. At most one provider can be marked
+	// as the default. If no provider is marked
+	// as the default, individual routes must explicitly
+	// identify the provider they require.
+	// +optional
+	Default bool `json:"default,omitempty"`
+
+	// Issuer that JWTs are required to have in the "iss" field.
+	// If not provided, JWT issuers are not checked.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Audiences that JWTs are allowed to have in the "aud" field.
+	// If not provided, JWT audiences are not checked.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// Remote JWKS to use for verifying JWT signatures.
+	// Exactly one of RemoteJWKS or LocalJWKS must be specified.
+	// +optional
+	RemoteJWKS *RemoteJWKS `json:"remoteJWKS,omitempty"`
+
+	// Local JWKS to use for verifying JWT signatures.
+	// Exactly one of RemoteJWKS or LocalJWKS must be specified.
+	// +optional
+	LocalJWKS *LocalJWKS `json:"localJWKS,omitempty"`
+
+	// Whether the JWT should be forwarded to the backend
+	// service after successful verification. By default,
+	// the JWT is not forwarded.
+	// +optional
+	ForwardJWT bool `json:"forwardJWT,omitempty"`
+}
+
+// RemoteJWKS defines how to fetch a JWKS from an HTTP endpoint.
+type RemoteJWKS struct {
+	// The URI for the JWKS.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	URI string `json:"uri"`
+
+	// How long to wait for a response from the URI.
+	// If not specified, a default of 1s applies.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+)$`
+	Timeout string `json:"timeout,omitempty"`
+
+	// How long to cache the JWKS locally. If not specified,
+	// Envoy's default of 5m applies.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+)$`
+	CacheDuration string `json:"cacheDuration,omitempty"`
+
+	// UpstreamValidation defines how to verify the JWKS's TLS certificate.
+	// +optional
+	UpstreamValidation *UpstreamValidation `json:"validation,omitempty"`
+}
+
+// LocalJWKS defines a JWKS stored in a Kubernetes Secret.
+type LocalJWKS struct {
+	// Name of the Secret in the HTTPProxy's namespace
+	// containing the JWKS. The JWKS must be stored in
+	// the Secret's "jwks" key.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
+
+// JWTVerificationPolicy defines whether a route requires JWT verification.
+type JWTVerificationPolicy struct {
+	// Require names a specific JWT provider (defined in the virtual host)
+	// to require for the route. If specified, this field overrides the
+	// default provider if one exists. If this field is not specified,
+	// the default provider will be required if one exists. At most one
+	// of this field or the "disabled" field can be specified.
+	// +optional
+	Require string `json:"require,omitempty"`
+
+	// Disabled defines whether to disable all JWT verification for this
+	// route. This can be used to opt specific routes out of the default
+	// JWT provider for the HTTPProxy. At most one of this field or the
+	// "require" field can be specified.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
 }
 
 // TLS describes tls properties. The SNI names that will be matched on
@@ -321,9 +542,9 @@ type CORSPolicy struct {
 type Route struct {
 	// Conditions are a set of rules that are applied to a Route.
 	// When applied, they are merged using AND, with one exception:
-	// There can be only one Prefix MatchCondition per Conditions slice.
-	// More than one Prefix, or contradictory Conditions, will make the
-	// route invalid.
+	// There can be only one Prefix, Exact or Regex MatchCondition
+	// per Conditions slice. More than one of these condition types,
+	// or contradictory Conditions, will make the route invalid.
 	// +optional
 	Conditions []MatchCondition `json:"conditions,omitempty"`
 	// Services are the services to proxy traffic.
@@ -341,6 +562,9 @@ type Route struct {
 	// match this route.
 	// +optional
 	AuthPolicy *AuthorizationPolicy `json:"authPolicy,omitempty"`
+	// The policy for verifying JWTs for requests to this route.
+	// +optional
+	JWTVerificationPolicy *JWTVerificationPolicy `json:"jwtVerificationPolicy,omitempty"`
 	// The timeout policy for this route.
 	// +optional
 	TimeoutPolicy *TimeoutPolicy `json:"timeoutPolicy,omitempty"`
@@ -350,6 +574,12 @@ type Route struct {
 	// The health check policy for this route.
 	// +optional
 	HealthCheckPolicy *HTTPHealthCheckPolicy `json:"healthCheckPolicy,omitempty"`
+	// The outlier detection policy for the services of this route.
+	// +optional
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+	// The circuit breaker policy for the services of this route.
+	// +optional
+	CircuitBreakerPolicy *CircuitBreakerPolicy `json:"circuitBreakerPolicy,omitempty"`
 	// The load balancing policy for this route.
 	// +optional
 	LoadBalancerPolicy *LoadBalancerPolicy `json:"loadBalancerPolicy,omitempty"`
@@ -374,9 +604,28 @@ type Route struct {
 	// +optional
 	RateLimitPolicy *RateLimitPolicy `json:"rateLimitPolicy,omitempty"`
 
+	// IPAllowFilterPolicy is a list of ipv4/6 filter rules for which matching
+	// requests should be allowed. All other requests will be denied.
+	// Only one of IPAllowFilterPolicy and IPDenyFilterPolicy can be defined.
+	// The rules defined here override any rules set on the root HTTPProxy.
+	// +optional
+	IPAllowFilterPolicy []IPFilterPolicy `json:"ipAllowPolicy,omitempty"`
+
+	// IPDenyFilterPolicy is a list of ipv4/6 filter rules for which matching
+	// requests should be denied. All other requests will be allowed.
+	// Only one of IPAllowFilterPolicy and IPDenyFilterPolicy can be defined.
+	// The rules defined here override any rules set on the root HTTPProxy.
+	// +optional
+	IPDenyFilterPolicy []IPFilterPolicy `json:"ipDenyPolicy,omitempty"`
+
 	// RequestRedirectPolicy defines an HTTP redirection.
 	// +optional
 	RequestRedirectPolicy *HTTPRequestRedirectPolicy `json:"requestRedirectPolicy,omitempty"`
+
+	// The access log policy for requests to this route. Overrides
+	// the policy of the virtual host.
+	// +optional
+	AccessLogPolicy *AccessLogPolicy `json:"accessLogPolicy,omitempty"`
 }
 
 // HTTPRequestRedirectPolicy defines configuration for redirecting a request.
@@ -701,6 +950,14 @@ type Service struct {
 	// The policies for rewriting Set-Cookie header attributes.
 	// +optional
 	CookieRewritePolicies []CookieRewritePolicy `json:"cookieRewritePolicies,omitempty"`
+	// The outlier detection policy for this service.
+	// If present, it replaces the outlier detection policy of the route.
+	// +optional
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+	// The circuit breaker policy for this service.
+	// Thresholds set here take precedence over those of the route.
+	// +optional
+	CircuitBreakerPolicy *CircuitBreakerPolicy `json:"circuitBreakerPolicy,omitempty"`
 }
 
 // HTTPHealthCheckPolicy defines health checks on the upstream service.
@@ -743,6 +1000,68 @@ type TCPHealthCheckPolicy struct {
 	HealthyThresholdCount uint32 `json:"healthyThresholdCount"`
 }
 
+// CircuitBreakerPolicy defines the circuit breaker thresholds of an
+// upstream service. Each threshold that is set takes precedence over
+// the matching `projectcontour.io/max-*` annotation on the Kubernetes
+// Service, which in turn takes precedence over the global default.
+// Thresholds that are not set anywhere use the Envoy defaults.
+// A threshold set to 0 allows no connections, requests or retries.
+type CircuitBreakerPolicy struct {
+	// The maximum number of connections that Envoy will make to the service.
+	// +optional
+	MaxConnections *uint32 `json:"maxConnections,omitempty"`
+	// The maximum number of pending requests that Envoy will allow to the service.
+	// +optional
+	MaxPendingRequests *uint32 `json:"maxPendingRequests,omitempty"`
+	// The maximum number of parallel requests that Envoy will make to the service.
+	// +optional
+	MaxRequests *uint32 `json:"maxRequests,omitempty"`
+	// The maximum number of parallel retries that Envoy will allow to the service.
+	// +optional
+	MaxRetries *uint32 `json:"maxRetries,omitempty"`
+}
+
+// OutlierDetection defines passive health checking, which ejects
+// upstream endpoints that return errors from the load balancing pool.
+//
+// Durations are expressed in the Go [Duration format](https://godoc.org/time#ParseDuration).
+// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+//
+// See https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/outlier
+// for more information.
+type OutlierDetection struct {
+	// The number of consecutive 5xx responses from an endpoint
+	// before it is ejected. If not supplied, Envoy's default of 5 applies.
+	// If set to 0, endpoints are not ejected for consecutive 5xx responses.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ConsecutiveServerErrors *uint32 `json:"consecutiveServerErrors,omitempty"`
+	// The number of consecutive 502, 503 and 504 responses from an
+	// endpoint before it is ejected. If not supplied, or set to 0,
+	// endpoints are not ejected for gateway errors.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ConsecutiveGatewayErrors *uint32 `json:"consecutiveGatewayErrors,omitempty"`
+	// The interval between ejection sweeps. If not supplied,
+	// Envoy's default of 10s applies.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+)$`
+	Interval string `json:"interval,omitempty"`
+	// The base time that an endpoint is ejected for. The actual time
+	// is the base time multiplied by the number of times the endpoint
+	// has been ejected. If not supplied, Envoy's default of 30s applies.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(((\d*(\.\d*)?h)|(\d*(\.\d*)?m)|(\d*(\.\d*)?s)|(\d*(\.\d*)?ms)|(\d*(\.\d*)?us)|(\d*(\.\d*)?µs)|(\d*(\.\d*)?ns))+)$`
+	BaseEjectionTime string `json:"baseEjectionTime,omitempty"`
+	// The maximum percentage of endpoints that can be ejected at
+	// the same time. If not supplied, Envoy's default of 10% applies.
+	// If set to 0, no endpoints are ejected.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxEjectionPercent *uint32 `json:"maxEjectionPercent,omitempty"`
+}
+
 // TimeoutPolicy configures timeouts that are used for handling network requests.
 //
 // TimeoutPolicy durations are expressed in the Go [Duration format](https://godoc.org/time#ParseDuration).
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // DefaultFields are fields that will be included by default when JSON logging is enabled.
@@ -44,6 +45,38 @@ var DefaultFields = AccessLogFields([]string{
 	"x_forwarded_for",
 })
 
+// envoyResponseFlags are the response flags that an access log
+// filter may match, see
+// https://www.envoyproxy.io/docs/envoy/v1.21.0/configuration/observability/access_log/usage#config-access-log-format-response-flags
+var envoyResponseFlags = map[string]struct{}{
+	"LH":    {},
+	"UH":    {},
+	"UT":    {},
+	"LR":    {},
+	"UR":    {},
+	"UF":    {},
+	"UC":    {},
+	"UO":    {},
+	"NR":    {},
+	"DI":    {},
+	"FI":    {},
+	"RL":    {},
+	"UAEX":  {},
+	"RLSE":  {},
+	"DC":    {},
+	"URX":   {},
+	"SI":    {},
+	"IH":    {},
+	"DPE":   {},
+	"UMSDR": {},
+	"RFCF":  {},
+	"NFCF":  {},
+	"DT":    {},
+	"UPE":   {},
+	"NC":    {},
+	"OM":    {},
+}
+
 // DEFAULT_ACCESS_LOG_TYPE is the default access log format.
 // nolint:revive
 const DEFAULT_ACCESS_LOG_TYPE = EnvoyAccessLog
@@ -64,8 +97,10 @@ var jsonFields = map[string]string{
 	"x_trace_id":            "%REQ(X-TRACE-ID)%",
 }
 
-// envoySimpleOperators is the list of known supported Envoy log template keywords that do not
-// have arguments nor require canonical translations.
+// envoySimpleOperators is the list of known supported Envoy log template keywords that may
+// be used without arguments and do not require canonical translations. The list matches
+// the command operators of Envoy v1.21, the version bundled with Contour, see
+// https://www.envoyproxy.io/docs/envoy/v1.21.0/configuration/observability/access_log/usage#command-operators
 var envoySimpleOperators = map[string]struct{}{
 	"BYTES_RECEIVED":                                {},
 	"BYTES_SENT":                                    {},
@@ -73,6 +108,8 @@ var envoySimpleOperators = map[string]struct{}{
 	"CONNECTION_TERMINATION_DETAILS":                {},
 	"DOWNSTREAM_DIRECT_REMOTE_ADDRESS":              {},
 	"DOWNSTREAM_DIRECT_REMOTE_ADDRESS_WITHOUT_PORT": {},
+	"DOWNSTREAM_HEADER_BYTES_RECEIVED":              {},
+	"DOWNSTREAM_HEADER_BYTES_SENT":                  {},
 	"DOWNSTREAM_LOCAL_ADDRESS":                      {},
 	"DOWNSTREAM_LOCAL_ADDRESS_WITHOUT_PORT":         {},
 	"DOWNSTREAM_LOCAL_PORT":                         {},
@@ -92,6 +129,8 @@ var envoySimpleOperators = map[string]struct{}{
 	"DOWNSTREAM_TLS_CIPHER":                         {},
 	"DOWNSTREAM_TLS_SESSION_ID":                     {},
 	"DOWNSTREAM_TLS_VERSION":                        {},
+	"DOWNSTREAM_WIRE_BYTES_RECEIVED":                {},
+	"DOWNSTREAM_WIRE_BYTES_SENT":                    {},
 	"DURATION":                                      {},
 	"GRPC_STATUS":                                   {},
 	"HOSTNAME":                                      {},
@@ -99,27 +138,43 @@ var envoySimpleOperators = map[string]struct{}{
 	"PROTOCOL":                                      {},
 	"REQUESTED_SERVER_NAME":                         {},
 	"REQUEST_DURATION":                              {},
+	"REQUEST_HEADERS_BYTES":                         {},
+	"REQUEST_TX_DURATION":                           {},
 	"RESPONSE_CODE":                                 {},
 	"RESPONSE_CODE_DETAILS":                         {},
 	"RESPONSE_DURATION":                             {},
 	"RESPONSE_FLAGS":                                {},
+	"RESPONSE_HEADERS_BYTES":                        {},
+	"RESPONSE_TRAILERS_BYTES":                       {},
 	"RESPONSE_TX_DURATION":                          {},
 	"ROUTE_NAME":                                    {},
 	"START_TIME":                                    {},
 	"UPSTREAM_CLUSTER":                              {},
+	"UPSTREAM_HEADER_BYTES_RECEIVED":                {},
+	"UPSTREAM_HEADER_BYTES_SENT":                    {},
 	"UPSTREAM_HOST":                                 {},
 	"UPSTREAM_LOCAL_ADDRESS":                        {},
+	"UPSTREAM_REQUEST_ATTEMPT_COUNT":                {},
 	"UPSTREAM_TRANSPORT_FAILURE_REASON":             {},
+	"UPSTREAM_WIRE_BYTES_RECEIVED":                  {},
+	"UPSTREAM_WIRE_BYTES_SENT":                      {},
 }
 
-// envoyComplexOperators is the list of known Envoy log template keywords that require
-// arguments.
+// envoyComplexOperators is the list of known Envoy log template keywords that accept
+// arguments. Operators that are not also in envoySimpleOperators require arguments.
 var envoyComplexOperators = map[string]struct{}{
-	"REQ":               {},
-	"RESP":              {},
-	"START_TIME":        {},
-	"TRAILER":           {},
-	"REQ_WITHOUT_QUERY": {},
+	"CLUSTER_METADATA":             {},
+	"DOWNSTREAM_PEER_CERT_V_END":   {},
+	"DOWNSTREAM_PEER_CERT_V_START": {},
+	"DYNAMIC_METADATA":             {},
+	"FILTER_STATE":                 {},
+	"GRPC_STATUS":                  {},
+	"REQ":                          {},
+	"REQ_WITHOUT_QUERY":            {},
+	"RESP":                         {},
+	"START_TIME":                   {},
+	"TRAILER":                      {},
+	"UPSTREAM_METADATA":            {},
 }
 
 // AccessLogType is the name of a supported access logging mechanism.
@@ -159,6 +214,44 @@ func (a AccessLogFields) Validate() error {
 	return nil
 }
 
+// Validate ensures the status code ranges, duration, response
+// flags and sampling percentage of the filter are valid.
+func (f *AccessLogFilter) Validate() error {
+	for _, r := range f.StatusCodes {
+		if r.Min < 100 || r.Max > 599 || r.Min > r.Max {
+			return fmt.Errorf("invalid status code range %d-%d", r.Min, r.Max)
+		}
+	}
+
+	if f.MinDuration != nil {
+		d, err := time.ParseDuration(*f.MinDuration)
+		if err != nil {
+			return fmt.Errorf("invalid minimum duration %q: %v", *f.MinDuration, err)
+		}
+		if d < 0 {
+			return fmt.Errorf("invalid minimum duration %q: must not be negative", *f.MinDuration)
+		}
+	}
+
+	for _, flag := range f.ResponseFlags {
+		if _, ok := envoyResponseFlags[flag]; !ok {
+			return fmt.Errorf("invalid response flag %q", flag)
+		}
+	}
+
+	for _, h := range f.RequestHeaders {
+		if h == "" {
+			return fmt.Errorf("request header name must not be empty")
+		}
+	}
+
+	if f.SamplingPercent != nil && *f.SamplingPercent > 100 {
+		return fmt.Errorf("invalid sampling percent %d", *f.SamplingPercent)
+	}
+
+	return nil
+}
+
 func (a AccessLogFields) AsFieldMap() map[string]string {
 	fieldMap := map[string]string{}
 
@@ -189,33 +282,76 @@ func (a AccessLogFields) AsFieldMap() map[string]string {
 	return fieldMap
 }
 
-// TODO: Commented code since it's not yet in use, but makes the linter unhappy.
-// func validateAccessLogFormatString(format string) error {
-//	// Empty format means use default format, defined by Envoy.
-//	if format == "" {
-//		return nil
-//	}
-//	err := parseAccessLogFormat(format)
-//	if err != nil {
-//		return fmt.Errorf("invalid access log format: %s", err)
-//	}
-//	if !strings.HasSuffix(format, "\n") {
-//		return fmt.Errorf("invalid access log format: must end in newline")
-//	}
-//	return nil
-//}
+// ValidateAccessLogFormatString checks that the given Envoy access log
+// format string uses only known command operators and ends in a newline.
+func ValidateAccessLogFormatString(format string) error {
+	// Empty format means use default format, defined by Envoy.
+	if format == "" {
+		return nil
+	}
+	err := parseAccessLogFormat(format)
+	if err != nil {
+		return fmt.Errorf("invalid access log format: %s", err)
+	}
+	if !strings.HasSuffix(format, "\n") {
+		return fmt.Errorf("invalid access log format: must end in newline")
+	}
+	return nil
+}
+
+// AccessLogFormatterExtensions returns a list of formatter extension names required by the access log format.
+//
+// Note: When adding support for new formatter, update the list of extensions here and
+// add corresponding configuration in internal/envoy/v3/accesslog.go extensionConfig().
+// Currently only one extension exist in Envoy.
+func AccessLogFormatterExtensions(accessLogFormat AccessLogType, accessLogFields AccessLogFields,
+	accessLogFormatString *string) []string {
+	// Function that finds out if command operator is present in a format string.
+	contains := func(format, command string) bool {
+		tokens := commandOperatorRegexp.FindAllStringSubmatch(format, -1)
+		for _, t := range tokens {
+			if t[2] == command {
+				return true
+			}
+		}
+		return false
+	}
+
+	extensionsMap := make(map[string]bool)
+	switch accessLogFormat {
+	case EnvoyAccessLog:
+		if accessLogFormatString != nil {
+			if contains(*accessLogFormatString, "REQ_WITHOUT_QUERY") {
+				extensionsMap["envoy.formatter.req_without_query"] = true
+			}
+		}
+	case JSONAccessLog:
+		for _, f := range accessLogFields.AsFieldMap() {
+			if contains(f, "REQ_WITHOUT_QUERY") {
+				extensionsMap["envoy.formatter.req_without_query"] = true
+			}
+		}
+	}
+
+	var extensions []string
+	for k := range extensionsMap {
+		extensions = append(extensions, k)
+	}
+
+	return extensions
+}
 
 // commandOperatorRegexp parses the command operators used in Envoy access log config
 //
 // Capture Groups:
 // Given string "the start time is %START_TIME(%s):3% wow!"
 //
-//   0. Whole match "%START_TIME(%s):3%"
-//   1. Full operator: "START_TIME(%s):3%"
-//   2. Operator Name: "START_TIME"
-//   3. Arguments: "(%s)"
-//   4. Truncation length: ":3"
-var commandOperatorRegexp = regexp.MustCompile(`%(([A-Z_]+)(\([^)]+\)(:[0-9]+)?)?%)?`)
+//  0. Whole match "%START_TIME(%s):3%"
+//  1. Full operator: "START_TIME(%s):3%"
+//  2. Operator Name: "START_TIME"
+//  3. Arguments: "(%s)"
+//  4. Truncation length: ":3"
+var commandOperatorRegexp = regexp.MustCompile(`%(([A-Z0-9_]+)(\([^)]+\)(:[0-9]+)?)?%)?`)
 
 func parseAccessLogFormat(format string) error {
 
@@ -238,10 +374,14 @@ func parseAccessLogFormat(format string) error {
 			return fmt.Errorf("invalid Envoy format: %s, invalid Envoy operator: %s", f, op)
 		}
 
-		if (op == "REQ" || op == "RESP" || op == "TRAILER" || op == "REQ_WITHOUT_QUERY") && f[3] == "" {
+		if !okSimple && f[3] == "" {
 			return fmt.Errorf("invalid Envoy format: %s, arguments required for operator: %s", f, op)
 		}
 
+		if !okComplex && f[3] != "" {
+			return fmt.Errorf("invalid Envoy format: %s, operator %s cannot have arguments", f, op)
+		}
+
 		// START_TIME cannot not have truncation length.
 		if op == "START_TIME" && f[4] != "" {
 			return fmt.Errorf("invalid Envoy format: %s, operator %s cannot have truncation length", f, op)
@@ -64,11 +64,25 @@ type ContourConfigurationSpec struct {
 	// +kubebuilder:default=false
 	EnableExternalNameService bool `json:"enableExternalNameService"`
 
+	// EnableEndpointSlices configures Contour to watch discovery.k8s.io/v1
+	// EndpointSlices, rather than v1 Endpoints, to determine the addresses
+	// of the endpoints of each Service. EndpointSlices carry topology
+	// zones, which are mapped to Envoy localities.
+	// Defaults to disabled.
+	// +optional
+	// +kubebuilder:default=false
+	EnableEndpointSlices bool `json:"enableEndpointSlices"`
+
 	// RateLimitService optionally holds properties of the Rate Limit Service
 	// to be used for global rate limiting.
 	// +optional
 	RateLimitService *RateLimitServiceConfig `json:"rateLimitService,omitempty"`
 
+	// Tracing optionally holds properties of the collector to which Envoy
+	// exports the spans of the requests it proxies.
+	// +optional
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
 	// Policy specifies default policy applied if not overridden by the user
 	// +optional
 	Policy *PolicyConfig `json:"policy,omitempty"`
@@ -110,6 +124,13 @@ type GatewayConfig struct {
 	// If unset, the gatewayclass controller will not be started.
 	// +kubebuilder:default="projectcontour.io/projectcontour/contour"
 	ControllerName string `json:"controllerName"`
+
+	// GatewayRef defines a specific Gateway that this Contour
+	// instance corresponds to. If set, Contour will reconcile
+	// only this Gateway, rather than every Gateway of its
+	// GatewayClass.
+	// +optional
+	GatewayRef *NamespacedName `json:"gatewayRef,omitempty"`
 }
 
 // TLS holds TLS file config details.
@@ -349,6 +370,108 @@ type EnvoyLogging struct {
 	// output when AccessLogFormat is json.
 	// +optional
 	AccessLogFields AccessLogFields `json:"jsonFields,omitempty"`
+
+	// AccessLogService optionally configures Envoy to stream access logs
+	// to an access log service over gRPC, alongside or instead of the
+	// file access logs.
+	// +optional
+	AccessLogService *AccessLogServiceConfig `json:"accessLogService,omitempty"`
+
+	// AccessLogFilter optionally restricts the HTTP requests that
+	// are access logged. When unset, every request is logged.
+	// +optional
+	AccessLogFilter *AccessLogFilter `json:"accessLogFilter,omitempty"`
+}
+
+// AccessLogFilter selects the HTTP requests that are access logged.
+// A request is logged only if it matches all of the rules that are set.
+type AccessLogFilter struct {
+	// StatusCodes restricts logging to responses with a status
+	// code within any of the given ranges.
+	// +optional
+	StatusCodes []StatusCodeRange `json:"statusCodes,omitempty"`
+
+	// MinDuration restricts logging to requests that take at
+	// least the given duration, for example "500ms".
+	// +optional
+	MinDuration *string `json:"minDuration,omitempty"`
+
+	// ResponseFlags restricts logging to responses with any of
+	// the given Envoy response flags, for example "UH" or "UF".
+	// +optional
+	ResponseFlags []string `json:"responseFlags,omitempty"`
+
+	// RequestHeaders restricts logging to requests in which all
+	// of the given headers are present.
+	// +optional
+	RequestHeaders []string `json:"requestHeaders,omitempty"`
+
+	// SamplingPercent restricts logging to the given percentage
+	// of requests, chosen at random.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercent *uint32 `json:"samplingPercent,omitempty"`
+}
+
+// StatusCodeRange is an inclusive range of HTTP status codes.
+type StatusCodeRange struct {
+	// Min is the lowest status code in the range.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	Min uint32 `json:"min"`
+
+	// Max is the highest status code in the range.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	Max uint32 `json:"max"`
+}
+
+// AccessLogServiceType is the protocol used to stream access logs
+// to an access log service.
+type AccessLogServiceType string
+
+// GRPCAccessLogService streams access log entries using Envoy's
+// gRPC access log service protocol.
+const GRPCAccessLogService AccessLogServiceType = "grpc"
+
+// OpenTelemetryAccessLogService streams access log entries as
+// OpenTelemetry log records. The entries are formatted according to
+// the access log format, format string and JSON fields.
+const OpenTelemetryAccessLogService AccessLogServiceType = "opentelemetry"
+
+// AccessLogServiceConfig defines properties of an access log service.
+type AccessLogServiceConfig struct {
+	// Type is the protocol used to stream access logs to the service.
+	// Valid options are 'grpc' or 'opentelemetry'.
+	// +kubebuilder:validation:Enum=grpc;opentelemetry
+	Type AccessLogServiceType `json:"type"`
+
+	// ExtensionService identifies the extension service defining the
+	// access log service.
+	ExtensionService NamespacedName `json:"extensionService"`
+
+	// LogName identifies the access log stream to the service.
+	// Defaults to "contour".
+	// +optional
+	LogName string `json:"logName,omitempty"`
+
+	// BufferFlushInterval is the interval after which Envoy flushes
+	// buffered access log entries to the service.
+	// Defaults to 1s.
+	// +optional
+	BufferFlushInterval *string `json:"bufferFlushInterval,omitempty"`
+
+	// BufferSizeBytes is the size of the buffer of access log entries
+	// which, when exceeded, is flushed to the service.
+	// Defaults to 16384.
+	// +optional
+	BufferSizeBytes *uint32 `json:"bufferSizeBytes,omitempty"`
+
+	// DisableFileAccessLog disables the file access logs, so that
+	// access logs are only sent to the service.
+	// +optional
+	DisableFileAccessLog bool `json:"disableFileAccessLog,omitempty"`
 }
 
 // TimeoutParameters holds various configurable proxy timeout values.
@@ -438,6 +561,56 @@ type ClusterParameters struct {
 	// +kubebuilder:default="auto"
 	// +kubebuilder:validation:Enum="auto";"v4";"v6"
 	DNSLookupFamily ClusterDNSFamilyType `json:"dnsLookupFamily"`
+
+	// ZoneAwareRouting configures Envoy to prefer upstream endpoints
+	// in the same zone as the Envoy handling the request.
+	//
+	// Zone aware routing requires each Envoy to be bootstrapped with its
+	// local zone (see `contour bootstrap --local-zone`), and endpoint
+	// zones are read from EndpointSlices.
+	//
+	// See https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/load_balancing/zone_aware
+	// for more information.
+	// +optional
+	ZoneAwareRouting *ZoneAwareRoutingConfig `json:"zoneAwareRouting,omitempty"`
+
+	// CircuitBreakers defines the default circuit breaker thresholds
+	// for clusters backed by Kubernetes Services. A threshold set by the
+	// `projectcontour.io/max-*` Service annotations, or by an HTTPProxy
+	// circuitBreakerPolicy, takes precedence over the default.
+	// +optional
+	CircuitBreakers *CircuitBreakers `json:"circuitBreakers,omitempty"`
+}
+
+// CircuitBreakers defines circuit breaker thresholds for upstream clusters.
+// A threshold set to 0 allows no connections, requests or retries.
+type CircuitBreakers struct {
+	// The maximum number of connections that Envoy will make to an upstream cluster.
+	// +optional
+	MaxConnections *uint32 `json:"maxConnections,omitempty"`
+	// The maximum number of pending requests that Envoy will allow to an upstream cluster.
+	// +optional
+	MaxPendingRequests *uint32 `json:"maxPendingRequests,omitempty"`
+	// The maximum number of parallel requests that Envoy will make to an upstream cluster.
+	// +optional
+	MaxRequests *uint32 `json:"maxRequests,omitempty"`
+	// The maximum number of parallel retries that Envoy will allow to an upstream cluster.
+	// +optional
+	MaxRetries *uint32 `json:"maxRetries,omitempty"`
+}
+
+// ZoneAwareRoutingConfig defines parameters for Envoy's zone aware routing.
+type ZoneAwareRoutingConfig struct {
+	// Enabled enables zone aware routing for clusters backed
+	// by Kubernetes Services.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MinClusterSize is the minimum number of endpoints an upstream
+	// cluster must have for zone aware routing to be applied.
+	// Defaults to 6 when unset.
+	// +optional
+	MinClusterSize uint32 `json:"minClusterSize,omitempty"`
 }
 
 // HTTPProxyConfig defines parameters on HTTPProxy.
@@ -495,6 +668,76 @@ type RateLimitServiceConfig struct {
 	EnableXRateLimitHeaders bool `json:"enableXRateLimitHeaders"`
 }
 
+// TracingProvider is the protocol used to export spans to a
+// trace collector.
+type TracingProvider string
+
+// ZipkinTracingProvider sends spans in the Zipkin v2 JSON format, so
+// the collector must accept Zipkin spans over HTTP/2, as used for all
+// ExtensionService clusters. The service name reported in spans is the
+// Envoy cluster name, set with Envoy's --service-cluster flag.
+const ZipkinTracingProvider TracingProvider = "zipkin"
+
+// OpenTelemetryTracingProvider sends spans to the collector's
+// OpenTelemetry (OTLP) gRPC trace service. It is not supported yet,
+// because Envoy's OpenTelemetry tracer requires Envoy 1.24 or later,
+// and configurations using it are rejected.
+const OpenTelemetryTracingProvider TracingProvider = "opentelemetry"
+
+// TracingConfig defines properties for exporting trace data to a collector.
+type TracingConfig struct {
+	// Provider is the protocol used to export spans to the collector.
+	// The only supported option is 'zipkin'; 'opentelemetry' requires
+	// Envoy 1.24 or later and is rejected.
+	// Defaults to 'zipkin'.
+	// +kubebuilder:validation:Enum=zipkin;opentelemetry
+	// +optional
+	Provider TracingProvider `json:"provider,omitempty"`
+
+	// ExtensionService identifies the extension service defining the
+	// trace collector.
+	ExtensionService NamespacedName `json:"extensionService"`
+
+	// CollectorEndpoint is the path of the collector's API endpoint to
+	// which spans are sent.
+	// Defaults to "/api/v2/spans".
+	// +optional
+	CollectorEndpoint string `json:"collectorEndpoint,omitempty"`
+
+	// OverallSampling is the percentage of requests that are traced,
+	// from 0 to 100, e.g. "12.5".
+	// Defaults to "100".
+	// +optional
+	OverallSampling string `json:"overallSampling,omitempty"`
+
+	// MaxPathTagLength is the maximum length of the request path to
+	// include in the http.url span tag.
+	// Defaults to 256.
+	// +optional
+	MaxPathTagLength *uint32 `json:"maxPathTagLength,omitempty"`
+
+	// CustomTags defines additional tags to add to each span.
+	// +optional
+	CustomTags []CustomTag `json:"customTags,omitempty"`
+}
+
+// CustomTag defines a span tag whose value is either a literal
+// or the value of a request header.
+type CustomTag struct {
+	// TagName is the unique name of the tag.
+	// +kubebuilder:validation:MinLength=1
+	TagName string `json:"tagName"`
+
+	// Literal is a static value to set the tag to.
+	// +optional
+	Literal string `json:"literal,omitempty"`
+
+	// RequestHeaderName is the name of the request header whose
+	// value the tag is set to.
+	// +optional
+	RequestHeaderName string `json:"requestHeaderName,omitempty"`
+}
+
 // PolicyConfig holds default policy used if not explicitly set by the user
 type PolicyConfig struct {
 	// RequestHeadersPolicy defines the request headers set/removed on all routes
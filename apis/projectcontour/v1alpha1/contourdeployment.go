@@ -36,7 +36,10 @@ type ContourDeploymentSpec struct {
 type ContourDeploymentStatus struct {
 	// Conditions contains the current status of the Contour resource.
 	//
-	// Contour will update a single condition, `Valid`, that is in normal-true polarity.
+	// Contour will update the `Available` and `Progressing` conditions,
+	// which are in normal-true polarity. `Available` is true when both
+	// the Contour Deployment and the Envoy DaemonSet have available
+	// pods. `Progressing` is true while either is rolling out.
 	//
 	// Contour will not modify any other Conditions set in this block,
 	// in case some other controller wants to add a Condition.
@@ -49,6 +52,16 @@ type ContourDeploymentStatus struct {
 	Conditions []contour_api_v1.DetailedCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+const (
+	// ContourDeploymentAvailableConditionType describes whether the
+	// Contour and Envoy of a ContourDeployment are available.
+	ContourDeploymentAvailableConditionType = "Available"
+
+	// ContourDeploymentProgressingConditionType describes whether the
+	// Contour and Envoy of a ContourDeployment are rolling out.
+	ContourDeploymentProgressingConditionType = "Progressing"
+)
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
@@ -15,6 +15,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 )
@@ -31,12 +33,68 @@ func (status *ExtensionServiceStatus) GetConditionFor(condType string) *contour_
 	return nil
 }
 
+// GetConditionFor returns the a pointer to the condition for a given type,
+// or nil if there are none currently present.
+func (status *ContourDeploymentStatus) GetConditionFor(condType string) *contour_api_v1.DetailedCondition {
+	for i, cond := range status.Conditions {
+		if cond.Type == condType {
+			return &status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
 // Validate configuration that is not already covered by CRD validation.
 func (c *ContourConfigurationSpec) Validate() error {
 	if err := endpointsInConfict(c.Health, c.Metrics); err != nil {
 		return fmt.Errorf("invalid contour configuration: %v", err)
 	}
-	return c.Envoy.Validate()
+	if err := c.Envoy.Validate(); err != nil {
+		return err
+	}
+	if c.Tracing != nil {
+		if err := c.Tracing.Validate(); err != nil {
+			return fmt.Errorf("invalid tracing configuration: %v", err)
+		}
+	}
+	return nil
+}
+
+// Validate configuration that cannot be handled with CRD validation.
+func (t *TracingConfig) Validate() error {
+	if t.ExtensionService.Name == "" || t.ExtensionService.Namespace == "" {
+		return fmt.Errorf("extension service must be specified as a namespace and name")
+	}
+
+	switch t.Provider {
+	case "", ZipkinTracingProvider:
+	case OpenTelemetryTracingProvider:
+		return fmt.Errorf("tracing provider %q requires Envoy 1.24 or later", t.Provider)
+	default:
+		return fmt.Errorf("invalid tracing provider %q", t.Provider)
+	}
+
+	if t.OverallSampling != "" {
+		sampling, err := strconv.ParseFloat(t.OverallSampling, 64)
+		if err != nil || sampling < 0 || sampling > 100 {
+			return fmt.Errorf("overall sampling %q must be a number from 0 to 100", t.OverallSampling)
+		}
+	}
+
+	tagNames := map[string]bool{}
+	for _, tag := range t.CustomTags {
+		if tagNames[tag.TagName] {
+			return fmt.Errorf("duplicate custom tag %q", tag.TagName)
+		}
+		tagNames[tag.TagName] = true
+
+		if (tag.Literal == "") == (tag.RequestHeaderName == "") {
+			return fmt.Errorf("custom tag %q must set exactly one of literal or requestHeaderName", tag.TagName)
+		}
+	}
+
+	return nil
 }
 
 // Validate configuration that cannot be handled with CRD validation.
@@ -44,6 +102,37 @@ func (e *EnvoyConfig) Validate() error {
 	if err := endpointsInConfict(e.Health, e.Metrics); err != nil {
 		return fmt.Errorf("invalid envoy configuration: %v", err)
 	}
+	if e.Logging.AccessLogService != nil {
+		if err := e.Logging.AccessLogService.Validate(); err != nil {
+			return fmt.Errorf("invalid access log service configuration: %v", err)
+		}
+	}
+	if e.Logging.AccessLogFilter != nil {
+		if err := e.Logging.AccessLogFilter.Validate(); err != nil {
+			return fmt.Errorf("invalid access log filter: %v", err)
+		}
+	}
+	return nil
+}
+
+// Validate configuration that cannot be handled with CRD validation.
+func (a *AccessLogServiceConfig) Validate() error {
+	switch a.Type {
+	case GRPCAccessLogService, OpenTelemetryAccessLogService:
+	default:
+		return fmt.Errorf("invalid access log service type %q", a.Type)
+	}
+
+	if a.ExtensionService.Name == "" || a.ExtensionService.Namespace == "" {
+		return fmt.Errorf("extension service must be specified as a namespace and name")
+	}
+
+	if a.BufferFlushInterval != nil {
+		if _, err := time.ParseDuration(*a.BufferFlushInterval); err != nil {
+			return fmt.Errorf("buffer flush interval %q is invalid: %v", *a.BufferFlushInterval, err)
+		}
+	}
+
 	return nil
 }
 
@@ -61,7 +61,9 @@ func main() {
 	sds := cli.Command("sds", "Watch secrets.")
 	sds.Arg("resources", "SDS resource filter").StringsVar(&resources)
 
+	gatewayProvisioner, gatewayProvisionerCtx := registerGatewayProvisioner(app)
 	serve, serveCtx := registerServe(app)
+	translate, translateCtx := registerTranslate(app)
 	version := app.Command("version", "Build information for Contour.")
 
 	args := os.Args[1:]
@@ -97,6 +99,10 @@ func main() {
 	case sds.FullCommand():
 		stream := client.RouteStream()
 		watchstream(stream, resource_v3.SecretType, resources)
+	case gatewayProvisioner.FullCommand():
+		if err := doGatewayProvisioner(log, gatewayProvisionerCtx); err != nil {
+			log.WithError(err).Fatal("gateway provisioner failed")
+		}
 	case serve.FullCommand():
 		// Parse args a second time so cli flags are applied
 		// on top of any values sourced from -c's config file.
@@ -129,6 +135,14 @@ func main() {
 		if err := serve.doServe(); err != nil {
 			log.WithError(err).Fatal("Contour server failed")
 		}
+	case translate.FullCommand():
+		if translateCtx.Debug {
+			log.SetLevel(logrus.DebugLevel)
+		}
+
+		if err := doTranslate(log, translateCtx, os.Stdin, os.Stdout); err != nil {
+			log.WithError(err).Fatal("failed to translate manifests")
+		}
 	case version.FullCommand():
 		println(build.PrintBuildInfo())
 	default:
@@ -0,0 +1,113 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/projectcontour/contour/internal/controller"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/provisioner"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+// gatewayProvisionerContext holds the configuration of the
+// gateway-provisioner subcommand.
+type gatewayProvisionerContext struct {
+	provisioner.Config
+
+	InCluster  bool
+	Kubeconfig string
+
+	DisableLeaderElection   bool
+	LeaderElectionName      string
+	LeaderElectionNamespace string
+	LeaseDuration           time.Duration
+	RenewDeadline           time.Duration
+	RetryPeriod             time.Duration
+}
+
+// registerGatewayProvisioner registers the gateway-provisioner subcommand and flags
+// with the Application provided.
+func registerGatewayProvisioner(app *kingpin.Application) (*kingpin.CmdClause, *gatewayProvisionerContext) {
+	var ctx gatewayProvisionerContext
+	cmd := app.Command("gateway-provisioner", "Deploy a Contour and Envoy for each Gateway of a GatewayClass and each ContourDeployment.")
+
+	cmd.Flag("gateway-controller-name", "Provision Gateways whose GatewayClass has this controller name.").Default("projectcontour.io/projectcontour/contour").StringVar(&ctx.GatewayControllerName)
+	cmd.Flag("contour-image", "Container image for the provisioned Contour.").Default("ghcr.io/projectcontour/contour:main").StringVar(&ctx.ContourImage)
+	cmd.Flag("envoy-image", "Container image for the provisioned Envoy.").Default("docker.io/envoyproxy/envoy:v1.21.0").StringVar(&ctx.EnvoyImage)
+
+	cmd.Flag("incluster", "Use in cluster configuration.").BoolVar(&ctx.InCluster)
+	cmd.Flag("kubeconfig", "Path to kubeconfig (if not in running inside a cluster).").PlaceHolder("/path/to/file").StringVar(&ctx.Kubeconfig)
+
+	cmd.Flag("disable-leader-election", "Disable leader election mechanism.").BoolVar(&ctx.DisableLeaderElection)
+	cmd.Flag("leader-election-lease-duration", "The duration of the leadership lease.").Default("15s").DurationVar(&ctx.LeaseDuration)
+	cmd.Flag("leader-election-renew-deadline", "The duration leader will retry refreshing leadership before giving up.").Default("10s").DurationVar(&ctx.RenewDeadline)
+	cmd.Flag("leader-election-retry-period", "The interval which the provisioner will attempt to acquire leadership lease.").Default("2s").DurationVar(&ctx.RetryPeriod)
+	cmd.Flag("leader-election-resource-name", "The name of the resource (Lease) leader election will lease.").Default("gateway-provisioner").StringVar(&ctx.LeaderElectionName)
+	cmd.Flag("leader-election-resource-namespace", "The namespace of the resource (Lease) leader election will lease.").Default("projectcontour").Envar("CONTOUR_NAMESPACE").StringVar(&ctx.LeaderElectionNamespace)
+
+	return cmd, &ctx
+}
+
+// doGatewayProvisioner runs the gateway-provisioner subcommand.
+func doGatewayProvisioner(log logrus.FieldLogger, ctx *gatewayProvisionerContext) error {
+	restConfig, err := k8s.NewRestConfig(ctx.Kubeconfig, ctx.InCluster)
+	if err != nil {
+		return fmt.Errorf("failed to create REST config for Kubernetes clients: %w", err)
+	}
+
+	scheme, err := k8s.NewContourScheme()
+	if err != nil {
+		return fmt.Errorf("unable to create scheme: %w", err)
+	}
+
+	options := manager.Options{
+		Scheme:                 scheme,
+		MetricsBindAddress:     "0",
+		HealthProbeBindAddress: "0",
+	}
+	if ctx.DisableLeaderElection {
+		log.Info("Leader election disabled")
+	} else {
+		options.LeaderElection = true
+		options.LeaderElectionResourceLock = "leases"
+		options.LeaderElectionNamespace = ctx.LeaderElectionNamespace
+		options.LeaderElectionID = ctx.LeaderElectionName
+		options.LeaseDuration = &ctx.LeaseDuration
+		options.RenewDeadline = &ctx.RenewDeadline
+		options.RetryPeriod = &ctx.RetryPeriod
+		options.LeaderElectionReleaseOnCancel = true
+	}
+
+	mgr, err := manager.New(restConfig, options)
+	if err != nil {
+		return fmt.Errorf("unable to set up controller manager: %w", err)
+	}
+
+	if err := controller.RegisterGatewayProvisioner(log.WithField("context", "gateway-provisioner"), mgr, ctx.Config); err != nil {
+		return fmt.Errorf("unable to create gateway provisioner: %w", err)
+	}
+
+	if err := controller.RegisterContourDeploymentController(log.WithField("context", "contourdeployment-controller"), mgr, ctx.Config); err != nil {
+		return fmt.Errorf("unable to create contourdeployment controller: %w", err)
+	}
+
+	log.WithField("gateway-controller-name", ctx.GatewayControllerName).Info("starting gateway provisioner")
+	return mgr.Start(signals.SetupSignalHandler())
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	networking_v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -53,6 +54,7 @@ type loadBalancerStatusWriter struct {
 	statusUpdater         k8s.StatusUpdater
 	ingressClassName      string
 	gatewayControllerName string
+	gatewayRef            *types.NamespacedName
 }
 
 func (isw *loadBalancerStatusWriter) NeedLeaderElection() bool {
@@ -73,6 +75,7 @@ func (isw *loadBalancerStatusWriter) Start(ctx context.Context) error {
 		Cache:                 isw.cache,
 		IngressClassName:      isw.ingressClassName,
 		GatewayControllerName: isw.gatewayControllerName,
+		GatewayRef:            isw.gatewayRef,
 		StatusUpdater:         isw.statusUpdater,
 	}
 
@@ -20,7 +20,6 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"regexp"
 	"strconv"
 	"time"
 
@@ -33,6 +32,7 @@ import (
 	"github.com/projectcontour/contour/internal/controller"
 	"github.com/projectcontour/contour/internal/dag"
 	"github.com/projectcontour/contour/internal/debug"
+	"github.com/projectcontour/contour/internal/envoy"
 	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
 	"github.com/projectcontour/contour/internal/health"
 	"github.com/projectcontour/contour/internal/httpsvc"
@@ -50,6 +50,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/alecthomas/kingpin.v2"
 	corev1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
 	networking_v1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -294,53 +295,16 @@ func (s *Server) doServe() error {
 		}
 	}
 
-	cipherSuites := []string{}
-	for _, cs := range contourConfiguration.Envoy.Listener.TLS.CipherSuites {
-		cipherSuites = append(cipherSuites, string(cs))
-	}
-
-	timeouts, err := contourconfig.ParseTimeoutPolicy(contourConfiguration.Envoy.Timeouts)
+	listenerConfig, err := listenerConfigFor(contourConfiguration)
 	if err != nil {
 		return err
 	}
 
-	accessLogFormatString := ""
-	if contourConfiguration.Envoy.Logging.AccessLogFormatString != nil {
-		accessLogFormatString = *contourConfiguration.Envoy.Logging.AccessLogFormatString
-	}
-
-	listenerConfig := xdscache_v3.ListenerConfig{
-		UseProxyProto: contourConfiguration.Envoy.Listener.UseProxyProto,
-		HTTPListeners: map[string]xdscache_v3.Listener{
-			xdscache_v3.ENVOY_HTTP_LISTENER: {
-				Name:    xdscache_v3.ENVOY_HTTP_LISTENER,
-				Address: contourConfiguration.Envoy.HTTPListener.Address,
-				Port:    contourConfiguration.Envoy.HTTPListener.Port,
-			},
-		},
-		HTTPAccessLog: contourConfiguration.Envoy.HTTPListener.AccessLog,
-		HTTPSListeners: map[string]xdscache_v3.Listener{
-			xdscache_v3.ENVOY_HTTPS_LISTENER: {
-				Name:    xdscache_v3.ENVOY_HTTPS_LISTENER,
-				Address: contourConfiguration.Envoy.HTTPSListener.Address,
-				Port:    contourConfiguration.Envoy.HTTPSListener.Port,
-			},
-		},
-		HTTPSAccessLog:               contourConfiguration.Envoy.HTTPSListener.AccessLog,
-		AccessLogType:                contourConfiguration.Envoy.Logging.AccessLogFormat,
-		AccessLogFields:              contourConfiguration.Envoy.Logging.AccessLogFields,
-		AccessLogFormatString:        accessLogFormatString,
-		AccessLogFormatterExtensions: AccessLogFormatterExtensions(contourConfiguration.Envoy.Logging.AccessLogFormat, contourConfiguration.Envoy.Logging.AccessLogFields, contourConfiguration.Envoy.Logging.AccessLogFormatString),
-		MinimumTLSVersion:            annotation.MinTLSVersion(contourConfiguration.Envoy.Listener.TLS.MinimumProtocolVersion, "1.2"),
-		CipherSuites:                 config.SanitizeCipherSuites(cipherSuites),
-		Timeouts:                     timeouts,
-		DefaultHTTPVersions:          parseDefaultHTTPVersions(contourConfiguration.Envoy.DefaultHTTPVersions),
-		AllowChunkedLength:           !contourConfiguration.Envoy.Listener.DisableAllowChunkedLength,
-		XffNumTrustedHops:            contourConfiguration.Envoy.Network.XffNumTrustedHops,
-		ConnectionBalancer:           contourConfiguration.Envoy.Listener.ConnectionBalancer,
+	if listenerConfig.RateLimitConfig, err = s.setupRateLimitService(contourConfiguration); err != nil {
+		return err
 	}
 
-	if listenerConfig.RateLimitConfig, err = s.setupRateLimitService(contourConfiguration); err != nil {
+	if listenerConfig.TracingConfig, err = s.setupTracingService(contourConfiguration); err != nil {
 		return err
 	}
 
@@ -348,7 +312,31 @@ func (s *Server) doServe() error {
 
 	// Endpoints updates are handled directly by the EndpointsTranslator
 	// due to their high update rate and their orthogonal nature.
-	endpointHandler := xdscache_v3.NewEndpointsTranslator(s.log.WithField("context", "endpointstranslator"))
+	var endpointHandler *xdscache_v3.EndpointsTranslator
+	if contourConfiguration.EnableEndpointSlices {
+		endpointHandler = xdscache_v3.NewEndpointSliceTranslator(s.log.WithField("context", "endpointstranslator"))
+	} else {
+		endpointHandler = xdscache_v3.NewEndpointsTranslator(s.log.WithField("context", "endpointstranslator"))
+	}
+
+	if zoneAwareRouting := contourConfiguration.Envoy.Cluster.ZoneAwareRouting; zoneAwareRouting != nil && zoneAwareRouting.Enabled {
+		if !contourConfiguration.EnableEndpointSlices {
+			s.log.Warn("zone aware routing requires enableEndpointSlices, endpoint zones will not be known")
+		}
+
+		// Serve the endpoints of the Envoy service as the local
+		// cluster that each Envoy compares its upstream zones to.
+		// Only the proportion of the fleet in each zone matters, so
+		// the port is left unnamed to match every Envoy port.
+		endpointHandler.LocalCluster = &dag.ServiceCluster{
+			ClusterName: envoy.LocalClusterName,
+			Services: []dag.WeightedService{{
+				Weight:           1,
+				ServiceName:      contourConfiguration.Envoy.Service.Name,
+				ServiceNamespace: contourConfiguration.Envoy.Service.Namespace,
+			}},
+		}
+	}
 
 	resources := []xdscache.ResourceCache{
 		xdscache_v3.NewListenerCache(contourConfiguration.Envoy, listenerConfig),
@@ -372,36 +360,13 @@ func (s *Server) doServe() error {
 		s.log.WithField("context", "envoy-client-certificate").Infof("enabled client certificate with secret: %q", contourConfiguration.Envoy.ClientCertificate)
 	}
 
-	ingressClassName := ""
-	if contourConfiguration.Ingress != nil && contourConfiguration.Ingress.ClassName != nil {
-		ingressClassName = *contourConfiguration.Ingress.ClassName
-	}
-
-	var clientCert *types.NamespacedName
-	var fallbackCert *types.NamespacedName
-	if contourConfiguration.Envoy.ClientCertificate != nil {
-		clientCert = &types.NamespacedName{Name: contourConfiguration.Envoy.ClientCertificate.Name, Namespace: contourConfiguration.Envoy.ClientCertificate.Namespace}
-	}
-	if contourConfiguration.HTTPProxy.FallbackCertificate != nil {
-		fallbackCert = &types.NamespacedName{Name: contourConfiguration.HTTPProxy.FallbackCertificate.Name, Namespace: contourConfiguration.HTTPProxy.FallbackCertificate.Namespace}
-	}
-
 	sh := k8s.NewStatusUpdateHandler(s.log.WithField("context", "StatusUpdateHandler"), s.mgr.GetClient())
 	if err := s.mgr.Add(sh); err != nil {
 		return err
 	}
 
-	builder := s.getDAGBuilder(dagBuilderConfig{
-		ingressClassName:          ingressClassName,
-		rootNamespaces:            contourConfiguration.HTTPProxy.RootNamespaces,
-		gatewayAPIConfigured:      contourConfiguration.Gateway != nil,
-		disablePermitInsecure:     contourConfiguration.HTTPProxy.DisablePermitInsecure,
-		enableExternalNameService: contourConfiguration.EnableExternalNameService,
-		dnsLookupFamily:           contourConfiguration.Envoy.Cluster.DNSLookupFamily,
-		headersPolicy:             contourConfiguration.Policy,
-		clientCert:                clientCert,
-		fallbackCert:              fallbackCert,
-	})
+	dbc := dagBuilderConfigFor(contourConfiguration)
+	builder := s.getDAGBuilder(dbc)
 
 	// Build the core Kubernetes event handler.
 	observer := contour.NewRebuildMetricsObserver(
@@ -453,12 +418,26 @@ func (s *Server) doServe() error {
 		s.log.WithError(err).WithField("resource", "secrets").Fatal("failed to create informer")
 	}
 
-	// Inform on endpoints.
-	if err := informOnResource(&corev1.Endpoints{}, &contour.EventRecorder{
+	// Inform on endpoints, or endpointslices if enabled.
+	endpointsName, endpointsObj := "endpoints", client.Object(&corev1.Endpoints{})
+	if contourConfiguration.EnableEndpointSlices {
+		endpointsName, endpointsObj = "endpointslices", &discovery_v1.EndpointSlice{}
+	}
+	if err := informOnResource(endpointsObj, &contour.EventRecorder{
 		Next:    endpointHandler,
 		Counter: contourMetrics.EventHandlerOperations,
 	}, s.mgr.GetCache()); err != nil {
-		s.log.WithError(err).WithField("resource", "endpoints").Fatal("failed to create informer")
+		s.log.WithError(err).WithField("resource", endpointsName).Fatal("failed to create informer")
+	}
+
+	// Inform on nodes for the zones of endpoints that don't set their own.
+	if zoneAwareRouting := contourConfiguration.Envoy.Cluster.ZoneAwareRouting; zoneAwareRouting != nil && zoneAwareRouting.Enabled && contourConfiguration.EnableEndpointSlices {
+		if err := informOnResource(&corev1.Node{}, &contour.EventRecorder{
+			Next:    endpointHandler,
+			Counter: contourMetrics.EventHandlerOperations,
+		}, s.mgr.GetCache()); err != nil {
+			s.log.WithError(err).WithField("resource", "nodes").Fatal("failed to create informer")
+		}
 	}
 
 	// Register our event handler with the manager.
@@ -482,8 +461,10 @@ func (s *Server) doServe() error {
 	}
 
 	var gatewayControllerName string
+	var gatewayRef *types.NamespacedName
 	if contourConfiguration.Gateway != nil {
 		gatewayControllerName = contourConfiguration.Gateway.ControllerName
+		gatewayRef = gatewayRefOf(contourConfiguration.Gateway)
 	}
 
 	// Set up ingress load balancer status writer.
@@ -491,8 +472,9 @@ func (s *Server) doServe() error {
 		log:                   s.log.WithField("context", "loadBalancerStatusWriter"),
 		cache:                 s.mgr.GetCache(),
 		lbStatus:              make(chan corev1.LoadBalancerStatus, 1),
-		ingressClassName:      ingressClassName,
+		ingressClassName:      dbc.ingressClassName,
 		gatewayControllerName: gatewayControllerName,
+		gatewayRef:            gatewayRef,
 		statusUpdater:         sh.Writer(),
 	}
 	if err := s.mgr.Add(lbsw); err != nil {
@@ -568,6 +550,14 @@ func (s *Server) setupRateLimitService(contourConfiguration contour_api_v1alpha1
 		return nil, fmt.Errorf("error getting rate limit extension service %s: %v", key, err)
 	}
 
+	return rateLimitConfigFor(contourConfiguration.RateLimitService, extensionSvc)
+}
+
+// rateLimitConfigFor returns the xDS rate limit configuration for
+// the supplied rate limit service and its ExtensionService.
+func rateLimitConfigFor(rateLimitService *contour_api_v1alpha1.RateLimitServiceConfig, extensionSvc *contour_api_v1alpha1.ExtensionService) (*xdscache_v3.RateLimitConfig, error) {
+	key := k8s.NamespacedNameOf(extensionSvc)
+
 	// get the response timeout from the ExtensionService
 	var responseTimeout timeout.Setting
 	var err error
@@ -581,10 +571,72 @@ func (s *Server) setupRateLimitService(contourConfiguration contour_api_v1alpha1
 
 	return &xdscache_v3.RateLimitConfig{
 		ExtensionService:        key,
-		Domain:                  contourConfiguration.RateLimitService.Domain,
+		Domain:                  rateLimitService.Domain,
 		Timeout:                 responseTimeout,
-		FailOpen:                contourConfiguration.RateLimitService.FailOpen,
-		EnableXRateLimitHeaders: contourConfiguration.RateLimitService.EnableXRateLimitHeaders,
+		FailOpen:                rateLimitService.FailOpen,
+		EnableXRateLimitHeaders: rateLimitService.EnableXRateLimitHeaders,
+	}, nil
+}
+
+func (s *Server) setupTracingService(contourConfiguration contour_api_v1alpha1.ContourConfigurationSpec) (*xdscache_v3.TracingConfig, error) {
+	if contourConfiguration.Tracing == nil {
+		return nil, nil
+	}
+
+	// ensure the specified ExtensionService exists
+	extensionSvc := &contour_api_v1alpha1.ExtensionService{}
+	key := client.ObjectKey{
+		Namespace: contourConfiguration.Tracing.ExtensionService.Namespace,
+		Name:      contourConfiguration.Tracing.ExtensionService.Name,
+	}
+
+	// Using GetAPIReader() here because the manager's caches won't be started yet,
+	// so reads from the manager's client (which uses the caches for reads) will fail.
+	if err := s.mgr.GetAPIReader().Get(context.Background(), key, extensionSvc); err != nil {
+		return nil, fmt.Errorf("error getting tracing extension service %s: %v", key, err)
+	}
+
+	return tracingConfigFor(contourConfiguration.Tracing, extensionSvc)
+}
+
+// tracingConfigFor returns the xDS tracing configuration for the
+// supplied tracing configuration and its ExtensionService.
+func tracingConfigFor(tracing *contour_api_v1alpha1.TracingConfig, extensionSvc *contour_api_v1alpha1.ExtensionService) (*xdscache_v3.TracingConfig, error) {
+	key := k8s.NamespacedNameOf(extensionSvc)
+
+	collectorEndpoint := "/api/v2/spans"
+	if tracing.CollectorEndpoint != "" {
+		collectorEndpoint = tracing.CollectorEndpoint
+	}
+
+	overallSampling := 100.0
+	if tracing.OverallSampling != "" {
+		var err error
+		if overallSampling, err = strconv.ParseFloat(tracing.OverallSampling, 64); err != nil {
+			return nil, fmt.Errorf("error parsing tracing extension service %s overall sampling: %v", key, err)
+		}
+	}
+
+	maxPathTagLength := uint32(256)
+	if tracing.MaxPathTagLength != nil {
+		maxPathTagLength = *tracing.MaxPathTagLength
+	}
+
+	var customTags []*xdscache_v3.CustomTag
+	for _, tag := range tracing.CustomTags {
+		customTags = append(customTags, &xdscache_v3.CustomTag{
+			TagName:           tag.TagName,
+			Literal:           tag.Literal,
+			RequestHeaderName: tag.RequestHeaderName,
+		})
+	}
+
+	return &xdscache_v3.TracingConfig{
+		ExtensionService:  key,
+		CollectorEndpoint: collectorEndpoint,
+		OverallSampling:   overallSampling,
+		MaxPathTagLength:  maxPathTagLength,
+		CustomTags:        customTags,
 	}, nil
 }
 
@@ -742,6 +794,7 @@ func (s *Server) setupGatewayAPI(contourConfiguration contour_api_v1alpha1.Conto
 			eventHandler,
 			sh.Writer(),
 			gatewayClassControllerName,
+			gatewayRefOf(contourConfiguration.Gateway),
 		)
 		if err != nil {
 			s.log.WithError(err).Fatal("failed to create gateway-controller")
@@ -758,6 +811,16 @@ func (s *Server) setupGatewayAPI(contourConfiguration contour_api_v1alpha1.Conto
 			s.log.WithError(err).Fatal("failed to create tlsroute-controller")
 		}
 
+		// Create and register the TCPRoute controller with the manager.
+		if err := controller.RegisterTCPRouteController(s.log.WithField("context", "tcproute-controller"), mgr, eventHandler); err != nil {
+			s.log.WithError(err).Fatal("failed to create tcproute-controller")
+		}
+
+		// Create and register the UDPRoute controller with the manager.
+		if err := controller.RegisterUDPRouteController(s.log.WithField("context", "udproute-controller"), mgr, eventHandler); err != nil {
+			s.log.WithError(err).Fatal("failed to create udproute-controller")
+		}
+
 		// Inform on ReferencePolicies.
 		if err := informOnResource(&gatewayapi_v1alpha2.ReferencePolicy{}, eventHandler, mgr.GetCache()); err != nil {
 			s.log.WithError(err).WithField("resource", "referencepolicies").Fatal("failed to create informer")
@@ -771,6 +834,19 @@ func (s *Server) setupGatewayAPI(contourConfiguration contour_api_v1alpha1.Conto
 	return needLeadershipNotification
 }
 
+// gatewayRefOf returns the Gateway that gatewayConfig restricts Contour
+// to, or nil if Contour should select a Gateway by its GatewayClass.
+func gatewayRefOf(gatewayConfig *contour_api_v1alpha1.GatewayConfig) *types.NamespacedName {
+	if gatewayConfig == nil || gatewayConfig.GatewayRef == nil {
+		return nil
+	}
+
+	return &types.NamespacedName{
+		Namespace: gatewayConfig.GatewayRef.Namespace,
+		Name:      gatewayConfig.GatewayRef.Name,
+	}
+}
+
 type dagBuilderConfig struct {
 	ingressClassName          string
 	rootNamespaces            []string
@@ -781,6 +857,173 @@ type dagBuilderConfig struct {
 	headersPolicy             *contour_api_v1alpha1.PolicyConfig
 	clientCert                *types.NamespacedName
 	fallbackCert              *types.NamespacedName
+	zoneAwareRouting          *contour_api_v1alpha1.ZoneAwareRoutingConfig
+	circuitBreakers           *contour_api_v1alpha1.CircuitBreakers
+	envoyListenerPorts        []int
+}
+
+// listenerConfigFor returns the xDS listener configuration
+// for the supplied ContourConfigurationSpec.
+func listenerConfigFor(contourConfiguration contour_api_v1alpha1.ContourConfigurationSpec) (xdscache_v3.ListenerConfig, error) {
+	cipherSuites := []string{}
+	for _, cs := range contourConfiguration.Envoy.Listener.TLS.CipherSuites {
+		cipherSuites = append(cipherSuites, string(cs))
+	}
+
+	timeouts, err := contourconfig.ParseTimeoutPolicy(contourConfiguration.Envoy.Timeouts)
+	if err != nil {
+		return xdscache_v3.ListenerConfig{}, err
+	}
+
+	accessLogFormatString := ""
+	if contourConfiguration.Envoy.Logging.AccessLogFormatString != nil {
+		accessLogFormatString = *contourConfiguration.Envoy.Logging.AccessLogFormatString
+	}
+
+	accessLogService, err := accessLogServiceConfigFor(contourConfiguration.Envoy.Logging.AccessLogService)
+	if err != nil {
+		return xdscache_v3.ListenerConfig{}, err
+	}
+
+	accessLogFilter, err := accessLogFilterConfigFor(contourConfiguration.Envoy.Logging.AccessLogFilter)
+	if err != nil {
+		return xdscache_v3.ListenerConfig{}, err
+	}
+
+	return xdscache_v3.ListenerConfig{
+		UseProxyProto: contourConfiguration.Envoy.Listener.UseProxyProto,
+		HTTPListeners: map[string]xdscache_v3.Listener{
+			xdscache_v3.ENVOY_HTTP_LISTENER: {
+				Name:    xdscache_v3.ENVOY_HTTP_LISTENER,
+				Address: contourConfiguration.Envoy.HTTPListener.Address,
+				Port:    contourConfiguration.Envoy.HTTPListener.Port,
+			},
+		},
+		HTTPAccessLog: contourConfiguration.Envoy.HTTPListener.AccessLog,
+		HTTPSListeners: map[string]xdscache_v3.Listener{
+			xdscache_v3.ENVOY_HTTPS_LISTENER: {
+				Name:    xdscache_v3.ENVOY_HTTPS_LISTENER,
+				Address: contourConfiguration.Envoy.HTTPSListener.Address,
+				Port:    contourConfiguration.Envoy.HTTPSListener.Port,
+			},
+		},
+		HTTPSAccessLog:               contourConfiguration.Envoy.HTTPSListener.AccessLog,
+		AccessLogType:                contourConfiguration.Envoy.Logging.AccessLogFormat,
+		AccessLogFields:              contourConfiguration.Envoy.Logging.AccessLogFields,
+		AccessLogFormatString:        accessLogFormatString,
+		AccessLogFormatterExtensions: contour_api_v1alpha1.AccessLogFormatterExtensions(contourConfiguration.Envoy.Logging.AccessLogFormat, contourConfiguration.Envoy.Logging.AccessLogFields, contourConfiguration.Envoy.Logging.AccessLogFormatString),
+		MinimumTLSVersion:            annotation.MinTLSVersion(contourConfiguration.Envoy.Listener.TLS.MinimumProtocolVersion, "1.2"),
+		CipherSuites:                 config.SanitizeCipherSuites(cipherSuites),
+		Timeouts:                     timeouts,
+		DefaultHTTPVersions:          parseDefaultHTTPVersions(contourConfiguration.Envoy.DefaultHTTPVersions),
+		AllowChunkedLength:           !contourConfiguration.Envoy.Listener.DisableAllowChunkedLength,
+		XffNumTrustedHops:            contourConfiguration.Envoy.Network.XffNumTrustedHops,
+		ConnectionBalancer:           contourConfiguration.Envoy.Listener.ConnectionBalancer,
+		AccessLogService:             accessLogService,
+		AccessLogFilter:              accessLogFilter,
+	}, nil
+}
+
+// accessLogFilterConfigFor returns the Envoy access log filter
+// configuration for the supplied access log filter, or nil if
+// no access log filter is configured.
+func accessLogFilterConfigFor(alf *contour_api_v1alpha1.AccessLogFilter) (*envoy_v3.AccessLogFilterConfig, error) {
+	if alf == nil {
+		return nil, nil
+	}
+
+	var statusCodes []envoy_v3.StatusCodeRange
+	for _, r := range alf.StatusCodes {
+		statusCodes = append(statusCodes, envoy_v3.StatusCodeRange{Min: r.Min, Max: r.Max})
+	}
+
+	var minDuration time.Duration
+	if alf.MinDuration != nil {
+		var err error
+		if minDuration, err = time.ParseDuration(*alf.MinDuration); err != nil {
+			return nil, fmt.Errorf("error parsing access log filter minimum duration: %v", err)
+		}
+	}
+
+	return &envoy_v3.AccessLogFilterConfig{
+		StatusCodes:     statusCodes,
+		MinDuration:     minDuration,
+		ResponseFlags:   alf.ResponseFlags,
+		RequestHeaders:  alf.RequestHeaders,
+		SamplingPercent: alf.SamplingPercent,
+	}, nil
+}
+
+// accessLogServiceConfigFor returns the xDS access log service
+// configuration for the supplied access log service, or nil if
+// no access log service is configured.
+func accessLogServiceConfigFor(als *contour_api_v1alpha1.AccessLogServiceConfig) (*xdscache_v3.AccessLogServiceConfig, error) {
+	if als == nil {
+		return nil, nil
+	}
+
+	logName := "contour"
+	if als.LogName != "" {
+		logName = als.LogName
+	}
+
+	var bufferFlushInterval timeout.Setting
+	if als.BufferFlushInterval != nil {
+		var err error
+		if bufferFlushInterval, err = timeout.Parse(*als.BufferFlushInterval); err != nil {
+			return nil, fmt.Errorf("error parsing access log service buffer flush interval: %v", err)
+		}
+	}
+
+	var bufferSizeBytes uint32
+	if als.BufferSizeBytes != nil {
+		bufferSizeBytes = *als.BufferSizeBytes
+	}
+
+	return &xdscache_v3.AccessLogServiceConfig{
+		Type:                 als.Type,
+		ExtensionService:     types.NamespacedName{Namespace: als.ExtensionService.Namespace, Name: als.ExtensionService.Name},
+		LogName:              logName,
+		BufferFlushInterval:  bufferFlushInterval,
+		BufferSizeBytes:      bufferSizeBytes,
+		DisableFileAccessLog: als.DisableFileAccessLog,
+	}, nil
+}
+
+// dagBuilderConfigFor returns the DAG builder configuration
+// for the supplied ContourConfigurationSpec.
+func dagBuilderConfigFor(contourConfiguration contour_api_v1alpha1.ContourConfigurationSpec) dagBuilderConfig {
+	ingressClassName := ""
+	if contourConfiguration.Ingress != nil && contourConfiguration.Ingress.ClassName != nil {
+		ingressClassName = *contourConfiguration.Ingress.ClassName
+	}
+
+	var clientCert *types.NamespacedName
+	var fallbackCert *types.NamespacedName
+	if contourConfiguration.Envoy.ClientCertificate != nil {
+		clientCert = &types.NamespacedName{Name: contourConfiguration.Envoy.ClientCertificate.Name, Namespace: contourConfiguration.Envoy.ClientCertificate.Namespace}
+	}
+	if contourConfiguration.HTTPProxy.FallbackCertificate != nil {
+		fallbackCert = &types.NamespacedName{Name: contourConfiguration.HTTPProxy.FallbackCertificate.Name, Namespace: contourConfiguration.HTTPProxy.FallbackCertificate.Namespace}
+	}
+
+	return dagBuilderConfig{
+		ingressClassName:          ingressClassName,
+		rootNamespaces:            contourConfiguration.HTTPProxy.RootNamespaces,
+		gatewayAPIConfigured:      contourConfiguration.Gateway != nil,
+		disablePermitInsecure:     contourConfiguration.HTTPProxy.DisablePermitInsecure,
+		enableExternalNameService: contourConfiguration.EnableExternalNameService,
+		dnsLookupFamily:           contourConfiguration.Envoy.Cluster.DNSLookupFamily,
+		headersPolicy:             contourConfiguration.Policy,
+		clientCert:                clientCert,
+		fallbackCert:              fallbackCert,
+		zoneAwareRouting:          contourConfiguration.Envoy.Cluster.ZoneAwareRouting,
+		circuitBreakers:           contourConfiguration.Envoy.Cluster.CircuitBreakers,
+		envoyListenerPorts: []int{
+			contourConfiguration.Envoy.HTTPListener.Port,
+			contourConfiguration.Envoy.HTTPSListener.Port,
+		},
+	}
 }
 
 func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
@@ -829,6 +1072,23 @@ func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
 		responseHeadersPolicyIngress = responseHeadersPolicy
 	}
 
+	var zoneAwareRouting *dag.ZoneAwareRouting
+	if dbc.zoneAwareRouting != nil && dbc.zoneAwareRouting.Enabled {
+		zoneAwareRouting = &dag.ZoneAwareRouting{
+			MinClusterSize: dbc.zoneAwareRouting.MinClusterSize,
+		}
+	}
+
+	var circuitBreakerDefaults *dag.CircuitBreakers
+	if dbc.circuitBreakers != nil {
+		circuitBreakerDefaults = &dag.CircuitBreakers{
+			MaxConnections:     dbc.circuitBreakers.MaxConnections,
+			MaxPendingRequests: dbc.circuitBreakers.MaxPendingRequests,
+			MaxRequests:        dbc.circuitBreakers.MaxRequests,
+			MaxRetries:         dbc.circuitBreakers.MaxRetries,
+		}
+	}
+
 	s.log.Debugf("EnableExternalNameService is set to %t", dbc.enableExternalNameService)
 
 	// Get the appropriate DAG processors.
@@ -839,6 +1099,8 @@ func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
 			ClientCertificate:         dbc.clientCert,
 			RequestHeadersPolicy:      &requestHeadersPolicyIngress,
 			ResponseHeadersPolicy:     &responseHeadersPolicyIngress,
+			ZoneAwareRouting:          zoneAwareRouting,
+			CircuitBreakerDefaults:    circuitBreakerDefaults,
 		},
 		&dag.ExtensionServiceProcessor{
 			// Note that ExtensionService does not support ExternalName, if it does get added,
@@ -854,6 +1116,8 @@ func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
 			ClientCertificate:         dbc.clientCert,
 			RequestHeadersPolicy:      &requestHeadersPolicy,
 			ResponseHeadersPolicy:     &responseHeadersPolicy,
+			ZoneAwareRouting:          zoneAwareRouting,
+			CircuitBreakerDefaults:    circuitBreakerDefaults,
 		},
 	}
 
@@ -861,6 +1125,9 @@ func (s *Server) getDAGBuilder(dbc dagBuilderConfig) *dag.Builder {
 		dagProcessors = append(dagProcessors, &dag.GatewayAPIProcessor{
 			EnableExternalNameService: dbc.enableExternalNameService,
 			FieldLogger:               s.log.WithField("context", "GatewayAPIProcessor"),
+			ZoneAwareRouting:          zoneAwareRouting,
+			CircuitBreakerDefaults:    circuitBreakerDefaults,
+			ReservedPorts:             dbc.envoyListenerPorts,
 		})
 	}
 
@@ -910,57 +1177,3 @@ func informOnResource(obj client.Object, handler cache.ResourceEventHandler, cac
 	inf.AddEventHandler(handler)
 	return nil
 }
-
-// commandOperatorRegexp parses the command operators used in Envoy access log configuration
-//
-// Capture Groups:
-// Given string "the start time is %START_TIME(%s):3% wow!"
-//
-//   0. Whole match "%START_TIME(%s):3%"
-//   1. Full operator: "START_TIME(%s):3%"
-//   2. Operator Name: "START_TIME"
-//   3. Arguments: "(%s)"
-//   4. Truncation length: ":3"
-var commandOperatorRegexp = regexp.MustCompile(`%(([A-Z_]+)(\([^)]+\)(:[0-9]+)?)?%)?`)
-
-// AccessLogFormatterExtensions returns a list of formatter extension names required by the access log format.
-//
-// Note: When adding support for new formatter, update the list of extensions here and
-// add corresponding configuration in internal/envoy/v3/accesslog.go extensionConfig().
-// Currently only one extension exist in Envoy.
-func AccessLogFormatterExtensions(accessLogFormat contour_api_v1alpha1.AccessLogType, accessLogFields contour_api_v1alpha1.AccessLogFields,
-	accessLogFormatString *string) []string {
-	// Function that finds out if command operator is present in a format string.
-	contains := func(format, command string) bool {
-		tokens := commandOperatorRegexp.FindAllStringSubmatch(format, -1)
-		for _, t := range tokens {
-			if t[2] == command {
-				return true
-			}
-		}
-		return false
-	}
-
-	extensionsMap := make(map[string]bool)
-	switch accessLogFormat {
-	case contour_api_v1alpha1.EnvoyAccessLog:
-		if accessLogFormatString != nil {
-			if contains(*accessLogFormatString, "REQ_WITHOUT_QUERY") {
-				extensionsMap["envoy.formatter.req_without_query"] = true
-			}
-		}
-	case contour_api_v1alpha1.JSONAccessLog:
-		for _, f := range accessLogFields.AsFieldMap() {
-			if contains(f, "REQ_WITHOUT_QUERY") {
-				extensionsMap["envoy.formatter.req_without_query"] = true
-			}
-		}
-	}
-
-	var extensions []string
-	for k := range extensionsMap {
-		extensions = append(extensions, k)
-	}
-
-	return extensions
-}
@@ -15,14 +15,20 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 
 	"github.com/projectcontour/contour/internal/dag"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/timeout"
+	xdscache_v3 "github.com/projectcontour/contour/internal/xdscache/v3"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 )
 
 func TestGetDAGBuilder(t *testing.T) {
@@ -155,9 +161,174 @@ func TestGetDAGBuilder(t *testing.T) {
 		assert.ElementsMatch(t, policy.ResponseHeadersPolicy.Remove, ingressProcessor.ResponseHeadersPolicy.Remove)
 	})
 
+	t.Run("zone aware routing is set on the processors", func(t *testing.T) {
+		serve := &Server{
+			log: logrus.StandardLogger(),
+		}
+		got := serve.getDAGBuilder(dagBuilderConfig{
+			rootNamespaces:  []string{},
+			dnsLookupFamily: contour_api_v1alpha1.AutoClusterDNSFamily,
+			zoneAwareRouting: &contour_api_v1alpha1.ZoneAwareRoutingConfig{
+				Enabled:        true,
+				MinClusterSize: 3,
+			},
+		})
+		commonAssertions(t, got)
+
+		want := &dag.ZoneAwareRouting{MinClusterSize: 3}
+		assert.Equal(t, want, mustGetHTTPProxyProcessor(t, got).ZoneAwareRouting)
+		assert.Equal(t, want, mustGetIngressProcessor(t, got).ZoneAwareRouting)
+	})
+
+	t.Run("circuit breaker defaults are set on the processors", func(t *testing.T) {
+		uint32Ptr := func(v uint32) *uint32 { return &v }
+
+		serve := &Server{
+			log: logrus.StandardLogger(),
+		}
+		got := serve.getDAGBuilder(dagBuilderConfig{
+			rootNamespaces:  []string{},
+			dnsLookupFamily: contour_api_v1alpha1.AutoClusterDNSFamily,
+			circuitBreakers: &contour_api_v1alpha1.CircuitBreakers{
+				MaxConnections: uint32Ptr(100),
+				MaxRetries:     uint32Ptr(3),
+			},
+		})
+		commonAssertions(t, got)
+
+		want := &dag.CircuitBreakers{MaxConnections: uint32Ptr(100), MaxRetries: uint32Ptr(3)}
+		assert.Equal(t, want, mustGetHTTPProxyProcessor(t, got).CircuitBreakerDefaults)
+		assert.Equal(t, want, mustGetIngressProcessor(t, got).CircuitBreakerDefaults)
+	})
+
+	t.Run("zone aware routing is not set when disabled", func(t *testing.T) {
+		serve := &Server{
+			log: logrus.StandardLogger(),
+		}
+		got := serve.getDAGBuilder(dagBuilderConfig{
+			rootNamespaces:   []string{},
+			dnsLookupFamily:  contour_api_v1alpha1.AutoClusterDNSFamily,
+			zoneAwareRouting: &contour_api_v1alpha1.ZoneAwareRoutingConfig{},
+		})
+		commonAssertions(t, got)
+
+		assert.Nil(t, mustGetHTTPProxyProcessor(t, got).ZoneAwareRouting)
+		assert.Nil(t, mustGetIngressProcessor(t, got).ZoneAwareRouting)
+	})
+
 	// TODO(3453): test additional properties of the DAG builder (processor fields, cache fields, Gateway tests (requires a client fake))
 }
 
+func TestTracingConfigFor(t *testing.T) {
+	extensionSvc := &contour_api_v1alpha1.ExtensionService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "zipkin"},
+	}
+
+	// Unset fields get their defaults.
+	got, err := tracingConfigFor(&contour_api_v1alpha1.TracingConfig{}, extensionSvc)
+	require.NoError(t, err)
+	assert.Equal(t, &xdscache_v3.TracingConfig{
+		ExtensionService:  types.NamespacedName{Namespace: "projectcontour", Name: "zipkin"},
+		CollectorEndpoint: "/api/v2/spans",
+		OverallSampling:   100,
+		MaxPathTagLength:  256,
+	}, got)
+
+	maxPathTagLength := uint32(64)
+	got, err = tracingConfigFor(&contour_api_v1alpha1.TracingConfig{
+		CollectorEndpoint: "/zipkin",
+		OverallSampling:   "0.5",
+		MaxPathTagLength:  &maxPathTagLength,
+		CustomTags: []contour_api_v1alpha1.CustomTag{
+			{TagName: "user-agent", RequestHeaderName: "User-Agent"},
+		},
+	}, extensionSvc)
+	require.NoError(t, err)
+	assert.Equal(t, &xdscache_v3.TracingConfig{
+		ExtensionService:  types.NamespacedName{Namespace: "projectcontour", Name: "zipkin"},
+		CollectorEndpoint: "/zipkin",
+		OverallSampling:   0.5,
+		MaxPathTagLength:  64,
+		CustomTags: []*xdscache_v3.CustomTag{
+			{TagName: "user-agent", RequestHeaderName: "User-Agent"},
+		},
+	}, got)
+
+	_, err = tracingConfigFor(&contour_api_v1alpha1.TracingConfig{OverallSampling: "half"}, extensionSvc)
+	assert.Error(t, err)
+}
+
+func TestAccessLogServiceConfigFor(t *testing.T) {
+	got, err := accessLogServiceConfigFor(nil)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Unset fields get their defaults.
+	got, err = accessLogServiceConfigFor(&contour_api_v1alpha1.AccessLogServiceConfig{
+		Type:             contour_api_v1alpha1.GRPCAccessLogService,
+		ExtensionService: contour_api_v1alpha1.NamespacedName{Namespace: "projectcontour", Name: "als"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &xdscache_v3.AccessLogServiceConfig{
+		Type:             contour_api_v1alpha1.GRPCAccessLogService,
+		ExtensionService: types.NamespacedName{Namespace: "projectcontour", Name: "als"},
+		LogName:          "contour",
+	}, got)
+
+	bufferSizeBytes := uint32(4096)
+	got, err = accessLogServiceConfigFor(&contour_api_v1alpha1.AccessLogServiceConfig{
+		Type:                 contour_api_v1alpha1.OpenTelemetryAccessLogService,
+		ExtensionService:     contour_api_v1alpha1.NamespacedName{Namespace: "projectcontour", Name: "otel"},
+		LogName:              "envoy",
+		BufferFlushInterval:  pointer.StringPtr("5s"),
+		BufferSizeBytes:      &bufferSizeBytes,
+		DisableFileAccessLog: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &xdscache_v3.AccessLogServiceConfig{
+		Type:                 contour_api_v1alpha1.OpenTelemetryAccessLogService,
+		ExtensionService:     types.NamespacedName{Namespace: "projectcontour", Name: "otel"},
+		LogName:              "envoy",
+		BufferFlushInterval:  timeout.DurationSetting(5 * time.Second),
+		BufferSizeBytes:      4096,
+		DisableFileAccessLog: true,
+	}, got)
+
+	_, err = accessLogServiceConfigFor(&contour_api_v1alpha1.AccessLogServiceConfig{
+		Type:                contour_api_v1alpha1.GRPCAccessLogService,
+		BufferFlushInterval: pointer.StringPtr("soon"),
+	})
+	assert.Error(t, err)
+}
+
+func TestAccessLogFilterConfigFor(t *testing.T) {
+	got, err := accessLogFilterConfigFor(nil)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	percent := uint32(10)
+	got, err = accessLogFilterConfigFor(&contour_api_v1alpha1.AccessLogFilter{
+		StatusCodes:     []contour_api_v1alpha1.StatusCodeRange{{Min: 500, Max: 599}},
+		MinDuration:     pointer.StringPtr("250ms"),
+		ResponseFlags:   []string{"UH", "UF"},
+		RequestHeaders:  []string{"x-debug"},
+		SamplingPercent: &percent,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &envoy_v3.AccessLogFilterConfig{
+		StatusCodes:     []envoy_v3.StatusCodeRange{{Min: 500, Max: 599}},
+		MinDuration:     250 * time.Millisecond,
+		ResponseFlags:   []string{"UH", "UF"},
+		RequestHeaders:  []string{"x-debug"},
+		SamplingPercent: &percent,
+	}, got)
+
+	_, err = accessLogFilterConfigFor(&contour_api_v1alpha1.AccessLogFilter{
+		MinDuration: pointer.StringPtr("slow"),
+	})
+	assert.Error(t, err)
+}
+
 func mustGetHTTPProxyProcessor(t *testing.T, builder *dag.Builder) *dag.HTTPProxyProcessor {
 	t.Helper()
 	for i := range builder.Processors {
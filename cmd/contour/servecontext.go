@@ -281,6 +281,13 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_api_v1alpha
 		gatewayConfig = &contour_api_v1alpha1.GatewayConfig{
 			ControllerName: ctx.Config.GatewayConfig.ControllerName,
 		}
+
+		if ctx.Config.GatewayConfig.GatewayRef != nil {
+			gatewayConfig.GatewayRef = &contour_api_v1alpha1.NamespacedName{
+				Namespace: ctx.Config.GatewayConfig.GatewayRef.Namespace,
+				Name:      ctx.Config.GatewayConfig.GatewayRef.Name,
+			}
+		}
 	}
 
 	var cipherSuites []contour_api_v1alpha1.TLSCipherType
@@ -341,6 +348,24 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_api_v1alpha
 		dnsLookupFamily = contour_api_v1alpha1.IPv4ClusterDNSFamily
 	}
 
+	var zoneAwareRouting *contour_api_v1alpha1.ZoneAwareRoutingConfig
+	if ctx.Config.Cluster.ZoneAwareRouting.Enabled {
+		zoneAwareRouting = &contour_api_v1alpha1.ZoneAwareRoutingConfig{
+			Enabled:        true,
+			MinClusterSize: ctx.Config.Cluster.ZoneAwareRouting.MinClusterSize,
+		}
+	}
+
+	var circuitBreakers *contour_api_v1alpha1.CircuitBreakers
+	if cb := ctx.Config.Cluster.CircuitBreakers; cb != (config.CircuitBreakerParameters{}) {
+		circuitBreakers = &contour_api_v1alpha1.CircuitBreakers{
+			MaxConnections:     cb.MaxConnections,
+			MaxPendingRequests: cb.MaxPendingRequests,
+			MaxRequests:        cb.MaxRequests,
+			MaxRetries:         cb.MaxRetries,
+		}
+	}
+
 	var rateLimitService *contour_api_v1alpha1.RateLimitServiceConfig
 	if ctx.Config.RateLimitService.ExtensionService != "" {
 		rateLimitService = &contour_api_v1alpha1.RateLimitServiceConfig{
@@ -354,6 +379,38 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_api_v1alpha
 		}
 	}
 
+	var tracing *contour_api_v1alpha1.TracingConfig
+	if tp := ctx.Config.Tracing; tp != nil {
+		var customTags []contour_api_v1alpha1.CustomTag
+		for _, tag := range tp.CustomTags {
+			customTags = append(customTags, contour_api_v1alpha1.CustomTag{
+				TagName:           tag.TagName,
+				Literal:           tag.Literal,
+				RequestHeaderName: tag.RequestHeaderName,
+			})
+		}
+
+		var provider contour_api_v1alpha1.TracingProvider
+		switch tp.Provider {
+		case config.ZipkinTracingProvider:
+			provider = contour_api_v1alpha1.ZipkinTracingProvider
+		case config.OpenTelemetryTracingProvider:
+			provider = contour_api_v1alpha1.OpenTelemetryTracingProvider
+		}
+
+		tracing = &contour_api_v1alpha1.TracingConfig{
+			Provider: provider,
+			ExtensionService: contour_api_v1alpha1.NamespacedName{
+				Name:      k8s.NamespacedNameFrom(tp.ExtensionService).Name,
+				Namespace: k8s.NamespacedNameFrom(tp.ExtensionService).Namespace,
+			},
+			CollectorEndpoint: tp.CollectorEndpoint,
+			OverallSampling:   tp.OverallSampling,
+			MaxPathTagLength:  tp.MaxPathTagLength,
+			CustomTags:        customTags,
+		}
+	}
+
 	policy := &contour_api_v1alpha1.PolicyConfig{
 		RequestHeadersPolicy: &contour_api_v1alpha1.HeadersPolicy{
 			Set:    ctx.Config.Policy.RequestHeadersPolicy.Set,
@@ -379,6 +436,58 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_api_v1alpha
 		accessLogFormatString = pointer.StringPtr(ctx.Config.AccessLogFormatString)
 	}
 
+	var accessLogService *contour_api_v1alpha1.AccessLogServiceConfig
+	if als := ctx.Config.AccessLogService; als != nil {
+		var serviceType contour_api_v1alpha1.AccessLogServiceType
+		switch als.Type {
+		case config.GRPCAccessLogService:
+			serviceType = contour_api_v1alpha1.GRPCAccessLogService
+		case config.OpenTelemetryAccessLogService:
+			serviceType = contour_api_v1alpha1.OpenTelemetryAccessLogService
+		}
+
+		var bufferFlushInterval *string
+		if len(als.BufferFlushInterval) > 0 {
+			bufferFlushInterval = pointer.StringPtr(als.BufferFlushInterval)
+		}
+
+		accessLogService = &contour_api_v1alpha1.AccessLogServiceConfig{
+			Type: serviceType,
+			ExtensionService: contour_api_v1alpha1.NamespacedName{
+				Name:      k8s.NamespacedNameFrom(als.ExtensionService).Name,
+				Namespace: k8s.NamespacedNameFrom(als.ExtensionService).Namespace,
+			},
+			LogName:              als.LogName,
+			BufferFlushInterval:  bufferFlushInterval,
+			BufferSizeBytes:      als.BufferSizeBytes,
+			DisableFileAccessLog: als.DisableFileAccessLog,
+		}
+	}
+
+	var accessLogFilter *contour_api_v1alpha1.AccessLogFilter
+	if alf := ctx.Config.AccessLogFilter; alf != nil {
+		var statusCodes []contour_api_v1alpha1.StatusCodeRange
+		for _, r := range alf.StatusCodes {
+			statusCodes = append(statusCodes, contour_api_v1alpha1.StatusCodeRange{
+				Min: r.Min,
+				Max: r.Max,
+			})
+		}
+
+		var minDuration *string
+		if len(alf.MinDuration) > 0 {
+			minDuration = pointer.StringPtr(alf.MinDuration)
+		}
+
+		accessLogFilter = &contour_api_v1alpha1.AccessLogFilter{
+			StatusCodes:     statusCodes,
+			MinDuration:     minDuration,
+			ResponseFlags:   alf.ResponseFlags,
+			RequestHeaders:  alf.RequestHeaders,
+			SamplingPercent: alf.SamplingPercent,
+		}
+	}
+
 	var fallbackCertificate *contour_api_v1alpha1.NamespacedName
 	if len(ctx.Config.TLS.FallbackCertificate.Name) > 0 {
 		fallbackCertificate = &contour_api_v1alpha1.NamespacedName{
@@ -454,11 +563,15 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_api_v1alpha
 				AccessLogFormat:       accessLogFormat,
 				AccessLogFormatString: accessLogFormatString,
 				AccessLogFields:       accessLogFields,
+				AccessLogService:      accessLogService,
+				AccessLogFilter:       accessLogFilter,
 			},
 			DefaultHTTPVersions: defaultHTTPVersions,
 			Timeouts:            timeoutParams,
 			Cluster: contour_api_v1alpha1.ClusterParameters{
-				DNSLookupFamily: dnsLookupFamily,
+				DNSLookupFamily:  dnsLookupFamily,
+				ZoneAwareRouting: zoneAwareRouting,
+				CircuitBreakers:  circuitBreakers,
 			},
 			Network: contour_api_v1alpha1.NetworkParameters{
 				XffNumTrustedHops: ctx.Config.Network.XffNumTrustedHops,
@@ -472,7 +585,9 @@ func (ctx *serveContext) convertToContourConfigurationSpec() contour_api_v1alpha
 			FallbackCertificate:   fallbackCertificate,
 		},
 		EnableExternalNameService: ctx.Config.EnableExternalNameService,
+		EnableEndpointSlices:      ctx.Config.EnableEndpointSlices,
 		RateLimitService:          rateLimitService,
+		Tracing:                   tracing,
 		Policy:                    policy,
 		Metrics:                   contourMetrics,
 	}
@@ -389,6 +389,10 @@ func TestConvertServeContext(t *testing.T) {
 	gatewayContext := newServeContext()
 	gatewayContext.Config.GatewayConfig = &config.GatewayParameters{
 		ControllerName: "projectcontour.io/projectcontour/contour",
+		GatewayRef: &config.NamespacedName{
+			Namespace: "projectcontour",
+			Name:      "contour",
+		},
 	}
 
 	ingressContext := newServeContext()
@@ -416,6 +420,19 @@ func TestConvertServeContext(t *testing.T) {
 		EnableXRateLimitHeaders: true,
 	}
 
+	maxPathTagLength := uint32(128)
+	tracing := newServeContext()
+	tracing.Config.Tracing = &config.TracingParameters{
+		Provider:         config.ZipkinTracingProvider,
+		ExtensionService: "tracing/zipkin",
+		OverallSampling:  "10",
+		MaxPathTagLength: &maxPathTagLength,
+		CustomTags: []config.CustomTag{
+			{TagName: "cluster", Literal: "production"},
+			{TagName: "user-agent", RequestHeaderName: "User-Agent"},
+		},
+	}
+
 	defaultHTTPVersions := newServeContext()
 	defaultHTTPVersions.Config.DefaultHTTPVersions = []config.HTTPVersionType{
 		config.HTTPVersion1,
@@ -425,6 +442,18 @@ func TestConvertServeContext(t *testing.T) {
 	accessLog.Config.AccessLogFormat = config.JSONAccessLog
 	accessLog.Config.AccessLogFormatString = "foo-bar-baz"
 	accessLog.Config.AccessLogFields = []string{"custom_field"}
+	accessLog.Config.AccessLogService = &config.AccessLogServiceParameters{
+		Type:                 config.OpenTelemetryAccessLogService,
+		ExtensionService:     "projectcontour/otel-collector",
+		BufferFlushInterval:  "500ms",
+		DisableFileAccessLog: true,
+	}
+	accessLog.Config.AccessLogFilter = &config.AccessLogFilterParameters{
+		StatusCodes:    []config.StatusCodeRange{{Min: 500, Max: 599}},
+		MinDuration:    "1s",
+		ResponseFlags:  []string{"UH"},
+		RequestHeaders: []string{"x-debug"},
+	}
 
 	cases := map[string]struct {
 		serveContext  *serveContext
@@ -843,6 +872,10 @@ func TestConvertServeContext(t *testing.T) {
 				},
 				Gateway: &contour_api_v1alpha1.GatewayConfig{
 					ControllerName: "projectcontour.io/projectcontour/contour",
+					GatewayRef: &contour_api_v1alpha1.NamespacedName{
+						Namespace: "projectcontour",
+						Name:      "contour",
+					},
 				},
 				HTTPProxy: contour_api_v1alpha1.HTTPProxyConfig{
 					DisablePermitInsecure: false,
@@ -1190,6 +1223,124 @@ func TestConvertServeContext(t *testing.T) {
 				},
 			},
 		},
+		"tracing": {
+			serveContext: tracing,
+			contourConfig: contour_api_v1alpha1.ContourConfigurationSpec{
+				XDSServer: contour_api_v1alpha1.XDSServerConfig{
+					Type:    contour_api_v1alpha1.ContourServerType,
+					Address: "127.0.0.1",
+					Port:    8001,
+					TLS: &contour_api_v1alpha1.TLS{
+						Insecure: false,
+					},
+				},
+				Ingress: &contour_api_v1alpha1.IngressConfig{
+					ClassName:     nil,
+					StatusAddress: nil,
+				},
+				Debug: contour_api_v1alpha1.DebugConfig{
+					Address:                 "127.0.0.1",
+					Port:                    6060,
+					DebugLogLevel:           contour_api_v1alpha1.InfoLog,
+					KubernetesDebugLogLevel: 0,
+				},
+				Health: contour_api_v1alpha1.HealthConfig{
+					Address: "0.0.0.0",
+					Port:    8000,
+				},
+				Envoy: contour_api_v1alpha1.EnvoyConfig{
+					Service: contour_api_v1alpha1.NamespacedName{
+						Name:      "envoy",
+						Namespace: "projectcontour",
+					},
+					HTTPListener: contour_api_v1alpha1.EnvoyListener{
+						Address:   "0.0.0.0",
+						Port:      8080,
+						AccessLog: "/dev/stdout",
+					},
+					HTTPSListener: contour_api_v1alpha1.EnvoyListener{
+						Address:   "0.0.0.0",
+						Port:      8443,
+						AccessLog: "/dev/stdout",
+					},
+					Health: contour_api_v1alpha1.HealthConfig{
+						Address: "0.0.0.0",
+						Port:    8002,
+					},
+					Metrics: contour_api_v1alpha1.MetricsConfig{
+						Address: "0.0.0.0",
+						Port:    8002,
+					},
+					ClientCertificate: nil,
+					Logging: contour_api_v1alpha1.EnvoyLogging{
+						AccessLogFormat:       contour_api_v1alpha1.EnvoyAccessLog,
+						AccessLogFormatString: nil,
+						AccessLogFields: contour_api_v1alpha1.AccessLogFields([]string{
+							"@timestamp",
+							"authority",
+							"bytes_received",
+							"bytes_sent",
+							"downstream_local_address",
+							"downstream_remote_address",
+							"duration",
+							"method",
+							"path",
+							"protocol",
+							"request_id",
+							"requested_server_name",
+							"response_code",
+							"response_flags",
+							"uber_trace_id",
+							"upstream_cluster",
+							"upstream_host",
+							"upstream_local_address",
+							"upstream_service_time",
+							"user_agent",
+							"x_forwarded_for",
+						}),
+					},
+					DefaultHTTPVersions: nil,
+					Timeouts: &contour_api_v1alpha1.TimeoutParameters{
+						ConnectionIdleTimeout: pointer.StringPtr("60s"),
+					},
+					Cluster: contour_api_v1alpha1.ClusterParameters{
+						DNSLookupFamily: contour_api_v1alpha1.AutoClusterDNSFamily,
+					},
+					Network: contour_api_v1alpha1.NetworkParameters{
+						EnvoyAdminPort: 9001,
+					},
+				},
+				Gateway: nil,
+				HTTPProxy: contour_api_v1alpha1.HTTPProxyConfig{
+					DisablePermitInsecure: false,
+					FallbackCertificate:   nil,
+				},
+				EnableExternalNameService: false,
+				RateLimitService:          nil,
+				Tracing: &contour_api_v1alpha1.TracingConfig{
+					Provider: contour_api_v1alpha1.ZipkinTracingProvider,
+					ExtensionService: contour_api_v1alpha1.NamespacedName{
+						Name:      "zipkin",
+						Namespace: "tracing",
+					},
+					OverallSampling:  "10",
+					MaxPathTagLength: &maxPathTagLength,
+					CustomTags: []contour_api_v1alpha1.CustomTag{
+						{TagName: "cluster", Literal: "production"},
+						{TagName: "user-agent", RequestHeaderName: "User-Agent"},
+					},
+				},
+				Policy: &contour_api_v1alpha1.PolicyConfig{
+					RequestHeadersPolicy:  &contour_api_v1alpha1.HeadersPolicy{},
+					ResponseHeadersPolicy: &contour_api_v1alpha1.HeadersPolicy{},
+					ApplyToIngress:        false,
+				},
+				Metrics: contour_api_v1alpha1.MetricsConfig{
+					Address: "0.0.0.0",
+					Port:    8000,
+				},
+			},
+		},
 		"default http versions": {
 			serveContext: defaultHTTPVersions,
 			contourConfig: contour_api_v1alpha1.ContourConfigurationSpec{
@@ -1352,6 +1503,21 @@ func TestConvertServeContext(t *testing.T) {
 						AccessLogFields: contour_api_v1alpha1.AccessLogFields([]string{
 							"custom_field",
 						}),
+						AccessLogService: &contour_api_v1alpha1.AccessLogServiceConfig{
+							Type: contour_api_v1alpha1.OpenTelemetryAccessLogService,
+							ExtensionService: contour_api_v1alpha1.NamespacedName{
+								Name:      "otel-collector",
+								Namespace: "projectcontour",
+							},
+							BufferFlushInterval:  pointer.StringPtr("500ms"),
+							DisableFileAccessLog: true,
+						},
+						AccessLogFilter: &contour_api_v1alpha1.AccessLogFilter{
+							StatusCodes:    []contour_api_v1alpha1.StatusCodeRange{{Min: 500, Max: 599}},
+							MinDuration:    pointer.StringPtr("1s"),
+							ResponseFlags:  []string{"UH"},
+							RequestHeaders: []string{"x-debug"},
+						},
 					},
 					DefaultHTTPVersions: nil,
 					Timeouts: &contour_api_v1alpha1.TimeoutParameters{
@@ -0,0 +1,394 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	// Register the Envoy extension types that are only referenced
+	// by their type URL, so that they can be rendered as JSON.
+	_ "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_web/v3"
+	_ "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
+	"github.com/golang/protobuf/proto"
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/dag"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/xdscache"
+	xdscache_v3 "github.com/projectcontour/contour/internal/xdscache/v3"
+	"github.com/projectcontour/contour/pkg/config"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+	corev1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	apimachinery_util_yaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+// translateContext holds the flags of the translate subcommand.
+type translateContext struct {
+	// ConfigPath is the path to a Contour configuration file.
+	ConfigPath string
+
+	// Files are the manifest files to translate. A file
+	// name of "-" reads manifests from standard input.
+	Files []string
+
+	// Output is the output format, either "json" or "yaml".
+	Output string
+
+	// Debug enables debug logging.
+	Debug bool
+}
+
+// registerTranslate registers the translate subcommand and flags
+// with the Application provided.
+func registerTranslate(app *kingpin.Application) (*kingpin.CmdClause, *translateContext) {
+	var ctx translateContext
+
+	translate := app.Command("translate", "Translate Kubernetes manifests into Envoy configuration without a running cluster.")
+	translate.Flag("file", "Manifest file to translate, or --file=- for standard input. May be repeated.").Short('f').Required().StringsVar(&ctx.Files)
+	translate.Flag("config-path", "Path to base configuration.").Short('c').PlaceHolder("/path/to/file").ExistingFileVar(&ctx.ConfigPath)
+	translate.Flag("output", "Output format.").Short('o').Default("yaml").EnumVar(&ctx.Output, "json", "yaml")
+	translate.Flag("debug", "Enable debug logging.").Short('d').BoolVar(&ctx.Debug)
+	return translate, &ctx
+}
+
+// translateObject is a Kubernetes object loaded from a manifest,
+// together with the kind it was decoded from.
+type translateObject struct {
+	kind string
+	obj  client.Object
+}
+
+// doTranslate runs the contour translate subcommand.
+func doTranslate(log logrus.FieldLogger, ctx *translateContext, stdin io.Reader, stdout io.Writer) error {
+	serveCtx := newServeContext()
+	if ctx.ConfigPath != "" {
+		f, err := os.Open(ctx.ConfigPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		params, err := config.Parse(f)
+		if err != nil {
+			return err
+		}
+		if err := params.Validate(); err != nil {
+			return fmt.Errorf("invalid Contour configuration: %w", err)
+		}
+		serveCtx.Config = *params
+	}
+
+	contourConfiguration := serveCtx.convertToContourConfigurationSpec()
+	if err := contourConfiguration.Validate(); err != nil {
+		return err
+	}
+
+	var objs []translateObject
+	for _, file := range ctx.Files {
+		var r io.Reader
+		if file == "-" {
+			r = stdin
+		} else {
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		loaded, err := decodeManifests(r)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", file, err)
+		}
+		objs = append(objs, loaded...)
+	}
+
+	listenerConfig, err := listenerConfigFor(contourConfiguration)
+	if err != nil {
+		return err
+	}
+
+	if rls := contourConfiguration.RateLimitService; rls != nil {
+		key := types.NamespacedName{Namespace: rls.ExtensionService.Namespace, Name: rls.ExtensionService.Name}
+		extensionSvc, ok := findObject(objs, key).(*contour_api_v1alpha1.ExtensionService)
+		if !ok {
+			return fmt.Errorf("rate limit extension service %s not found", key)
+		}
+		if listenerConfig.RateLimitConfig, err = rateLimitConfigFor(rls, extensionSvc); err != nil {
+			return err
+		}
+	}
+
+	if tracing := contourConfiguration.Tracing; tracing != nil {
+		key := types.NamespacedName{Namespace: tracing.ExtensionService.Namespace, Name: tracing.ExtensionService.Name}
+		extensionSvc, ok := findObject(objs, key).(*contour_api_v1alpha1.ExtensionService)
+		if !ok {
+			return fmt.Errorf("tracing extension service %s not found", key)
+		}
+		if listenerConfig.TracingConfig, err = tracingConfigFor(tracing, extensionSvc); err != nil {
+			return err
+		}
+	}
+
+	dbc := dagBuilderConfigFor(contourConfiguration)
+	for _, o := range objs {
+		if _, ok := o.obj.(*gatewayapi_v1alpha2.Gateway); ok {
+			dbc.gatewayAPIConfigured = true
+		}
+	}
+
+	builder := (&Server{log: log}).getDAGBuilder(dbc)
+
+	var endpoints []client.Object
+	for _, o := range objs {
+		switch o.obj.(type) {
+		case *corev1.Endpoints, *discovery_v1.EndpointSlice:
+			endpoints = append(endpoints, o.obj)
+		default:
+			builder.Source.Insert(o.obj)
+		}
+	}
+
+	var endpointHandler *xdscache_v3.EndpointsTranslator
+	if contourConfiguration.EnableEndpointSlices {
+		endpointHandler = xdscache_v3.NewEndpointSliceTranslator(log.WithField("context", "endpointstranslator"))
+	} else {
+		endpointHandler = xdscache_v3.NewEndpointsTranslator(log.WithField("context", "endpointstranslator"))
+	}
+
+	listeners := xdscache_v3.NewListenerCache(contourConfiguration.Envoy, listenerConfig)
+	secrets := xdscache_v3.NewSecretsCache(envoy_v3.StatsSecrets(contourConfiguration.Envoy.Metrics.TLS))
+	routes := &xdscache_v3.RouteCache{}
+	clusters := &xdscache_v3.ClusterCache{}
+
+	resources := []xdscache.ResourceCache{listeners, secrets, routes, clusters, endpointHandler}
+
+	built := builder.Build()
+	dag.ComposeObservers(xdscache.ObserversOf(resources)...).OnChange(built)
+
+	// Endpoints are only retained for the clusters that
+	// are present in the DAG, so add them after the build.
+	for _, ep := range endpoints {
+		endpointHandler.OnAdd(ep)
+	}
+
+	result := map[string]interface{}{}
+	for name, resource := range map[string]xdscache.ResourceCache{
+		"listeners": listeners,
+		"secrets":   secrets,
+		"routes":    routes,
+		"clusters":  clusters,
+		"endpoints": endpointHandler,
+	} {
+		messages, err := marshalResources(resource.Contents())
+		if err != nil {
+			return err
+		}
+		result[name] = messages
+	}
+
+	statuses, err := computedStatus(objs, built)
+	if err != nil {
+		return err
+	}
+	result["status"] = statuses
+
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if ctx.Output == "yaml" {
+		if out, err = yaml.JSONToYAML(out); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(stdout, string(bytes.TrimSpace(out)))
+	return err
+}
+
+// decodeManifests decodes the YAML or JSON Kubernetes manifests in r.
+func decodeManifests(r io.Reader) ([]translateObject, error) {
+	scheme, err := k8s.NewContourScheme()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create scheme: %w", err)
+	}
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var objs []translateObject
+	reader := apimachinery_util_yaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Skip documents that only contain comments or whitespace.
+		if data, err := apimachinery_util_yaml.ToJSON(doc); err != nil {
+			return nil, err
+		} else if string(data) == "null" {
+			continue
+		}
+
+		decoded, err := decodeObject(decoder, doc)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, decoded...)
+	}
+}
+
+// decodeObject decodes a single manifest, expanding the
+// items of a List such as the output of "kubectl get".
+func decodeObject(decoder runtime.Decoder, data []byte) ([]translateObject, error) {
+	obj, gvk, err := decoder.Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch obj := obj.(type) {
+	case *corev1.List:
+		var objs []translateObject
+		for _, item := range obj.Items {
+			decoded, err := decodeObject(decoder, item.Raw)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, decoded...)
+		}
+		return objs, nil
+	case client.Object:
+		if obj.GetNamespace() == "" && !clusterScoped(obj) {
+			obj.SetNamespace(corev1.NamespaceDefault)
+		}
+		return []translateObject{{kind: gvk.Kind, obj: obj}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported object of kind %s", gvk.Kind)
+	}
+}
+
+// clusterScoped returns true if obj is one of the
+// cluster scoped kinds that Contour is interested in.
+func clusterScoped(obj runtime.Object) bool {
+	switch obj.(type) {
+	case *corev1.Namespace, *networking_v1.IngressClass, *gatewayapi_v1alpha2.GatewayClass:
+		return true
+	default:
+		return false
+	}
+}
+
+// findObject returns the loaded object with the given name, or nil.
+func findObject(objs []translateObject, name types.NamespacedName) client.Object {
+	for _, o := range objs {
+		if k8s.NamespacedNameOf(o.obj) == name {
+			return o.obj
+		}
+	}
+	return nil
+}
+
+// marshalResources renders xDS resources as JSON.
+func marshalResources(messages []proto.Message) ([]json.RawMessage, error) {
+	out := []json.RawMessage{}
+	for _, m := range messages {
+		data, err := protojson.Marshal(proto.MessageV2(m))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// translateStatus is the computed status of a loaded object.
+type translateStatus struct {
+	Kind      string          `json:"kind"`
+	Namespace string          `json:"namespace,omitempty"`
+	Name      string          `json:"name"`
+	Status    json.RawMessage `json:"status"`
+}
+
+// computedStatus applies the status updates computed while
+// building the DAG to the loaded objects and returns the
+// resulting status of each.
+func computedStatus(objs []translateObject, built *dag.DAG) ([]translateStatus, error) {
+	statuses := []translateStatus{}
+
+	for _, update := range built.StatusCache.GetStatusUpdates() {
+		for _, o := range objs {
+			if fmt.Sprintf("%T", o.obj) != fmt.Sprintf("%T", update.Resource) ||
+				k8s.NamespacedNameOf(o.obj) != update.NamespacedName {
+				continue
+			}
+
+			mutated := update.Mutator.Mutate(o.obj.DeepCopyObject().(client.Object))
+
+			data, err := json.Marshal(mutated)
+			if err != nil {
+				return nil, err
+			}
+
+			var fields struct {
+				Status json.RawMessage `json:"status"`
+			}
+			if err := json.Unmarshal(data, &fields); err != nil {
+				return nil, err
+			}
+
+			statuses = append(statuses, translateStatus{
+				Kind:      o.kind,
+				Namespace: o.obj.GetNamespace(),
+				Name:      o.obj.GetName(),
+				Status:    fields.Status,
+			})
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Kind != statuses[j].Kind {
+			return statuses[i].Kind < statuses[j].Kind
+		}
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses, nil
+}
@@ -0,0 +1,172 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+)
+
+const translateManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: kuard
+spec:
+  ports:
+  - port: 80
+    targetPort: 8080
+---
+# Comment only documents are ignored.
+---
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: kuard
+subsets:
+- addresses:
+  - ip: 10.0.0.1
+  ports:
+  - port: 8080
+---
+apiVersion: projectcontour.io/v1
+kind: HTTPProxy
+metadata:
+  name: kuard
+spec:
+  virtualhost:
+    fqdn: kuard.example.com
+  routes:
+  - services:
+    - name: kuard
+      port: 80
+---
+apiVersion: projectcontour.io/v1
+kind: HTTPProxy
+metadata:
+  name: missing
+  namespace: other
+spec:
+  virtualhost:
+    fqdn: missing.example.com
+  routes:
+  - services:
+    - name: missing
+      port: 80
+---
+apiVersion: networking.k8s.io/v1
+kind: IngressClass
+metadata:
+  name: contour
+spec:
+  controller: projectcontour.io/contour
+`
+
+func TestDecodeManifests(t *testing.T) {
+	objs, err := decodeManifests(strings.NewReader(translateManifests))
+	require.NoError(t, err)
+	require.Len(t, objs, 5)
+
+	assert.Equal(t, "Service", objs[0].kind)
+	assert.IsType(t, &corev1.Service{}, objs[0].obj)
+	assert.Equal(t, "default", objs[0].obj.GetNamespace())
+
+	assert.Equal(t, "Endpoints", objs[1].kind)
+	assert.IsType(t, &corev1.Endpoints{}, objs[1].obj)
+
+	assert.Equal(t, "HTTPProxy", objs[2].kind)
+	assert.IsType(t, &contour_api_v1.HTTPProxy{}, objs[2].obj)
+	assert.Equal(t, "default", objs[2].obj.GetNamespace())
+	assert.Equal(t, "other", objs[3].obj.GetNamespace())
+
+	// Cluster scoped objects do not get a namespace.
+	assert.Equal(t, "IngressClass", objs[4].kind)
+	assert.IsType(t, &networking_v1.IngressClass{}, objs[4].obj)
+	assert.Equal(t, "", objs[4].obj.GetNamespace())
+
+	// The items of a List are expanded.
+	objs, err = decodeManifests(strings.NewReader(`
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: kuard
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: tls
+    namespace: secrets
+`))
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	assert.IsType(t, &corev1.Service{}, objs[0].obj)
+	assert.Equal(t, "default", objs[0].obj.GetNamespace())
+	assert.IsType(t, &corev1.Secret{}, objs[1].obj)
+	assert.Equal(t, "secrets", objs[1].obj.GetNamespace())
+
+	_, err = decodeManifests(strings.NewReader("apiVersion: v1\nkind: Unknown\n"))
+	assert.Error(t, err)
+}
+
+func TestDoTranslate(t *testing.T) {
+	var stdout bytes.Buffer
+
+	ctx := &translateContext{
+		Files:  []string{"-"},
+		Output: "json",
+	}
+	require.NoError(t, doTranslate(fixture.NewTestLogger(t), ctx, strings.NewReader(translateManifests), &stdout))
+
+	var result struct {
+		Clusters  []map[string]interface{} `json:"clusters"`
+		Endpoints []map[string]interface{} `json:"endpoints"`
+		Routes    []map[string]interface{} `json:"routes"`
+		Status    []translateStatus        `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+
+	require.Len(t, result.Clusters, 1)
+	assert.Equal(t, "default/kuard/80/da39a3ee5e", result.Clusters[0]["name"])
+
+	require.Len(t, result.Endpoints, 1)
+	assert.Equal(t, "default/kuard", result.Endpoints[0]["clusterName"])
+
+	require.Len(t, result.Routes, 1)
+	assert.Equal(t, "ingress_http", result.Routes[0]["name"])
+	assert.Contains(t, stdout.String(), "kuard.example.com")
+
+	require.Len(t, result.Status, 2)
+
+	var status contour_api_v1.HTTPProxyStatus
+	assert.Equal(t, "default", result.Status[0].Namespace)
+	assert.Equal(t, "kuard", result.Status[0].Name)
+	require.NoError(t, json.Unmarshal(result.Status[0].Status, &status))
+	assert.Equal(t, "valid", status.CurrentStatus)
+
+	assert.Equal(t, "other", result.Status[1].Namespace)
+	assert.Equal(t, "missing", result.Status[1].Name)
+	require.NoError(t, json.Unmarshal(result.Status[1].Status, &status))
+	assert.Equal(t, "invalid", status.CurrentStatus)
+}
@@ -0,0 +1,277 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/provisioner"
+	"github.com/sirupsen/logrus"
+	apps_v1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// Reasons for the ContourDeployment Available condition.
+	reasonContourDeploymentAvailable = "ContourAndEnvoyAvailable"
+	reasonContourUnavailable         = "ContourUnavailable"
+	reasonEnvoyUnavailable           = "EnvoyUnavailable"
+
+	// Reasons for the ContourDeployment Progressing condition.
+	reasonRolloutInProgress  = "RolloutInProgress"
+	reasonRolloutComplete    = "RolloutComplete"
+	reasonProvisioningFailed = "ProvisioningFailed"
+)
+
+type contourDeploymentReconciler struct {
+	instanceProvisioner
+	config provisioner.Config
+}
+
+// RegisterContourDeploymentController creates the ContourDeployment controller
+// from mgr. The controller deploys a Contour and Envoy for each ContourDeployment,
+// configured by a generated ContourConfiguration, and reports their availability
+// and rollout progress in the ContourDeployment's status. Like the gateway
+// provisioner, it only runs while it is the elected leader.
+func RegisterContourDeploymentController(log logrus.FieldLogger, mgr manager.Manager, config provisioner.Config) error {
+	r := &contourDeploymentReconciler{
+		instanceProvisioner: instanceProvisioner{
+			client: mgr.GetClient(),
+			log:    log,
+		},
+		config: config,
+	}
+
+	c, err := controller.New("contourdeployment-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Only reconcile on spec changes, since the reconciler
+	// updates the ContourDeployment's status itself.
+	if err := c.Watch(
+		&source.Kind{Type: &contour_api_v1alpha1.ContourDeployment{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.GenerationChangedPredicate{},
+	); err != nil {
+		return err
+	}
+
+	return watchOwned(c, &contour_api_v1alpha1.ContourDeployment{}, &contour_api_v1alpha1.ContourConfiguration{})
+}
+
+// Reconcile ensures that the objects provisioned for the requested ContourDeployment
+// exist and match its spec, and updates its status conditions.
+func (r *contourDeploymentReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithField("namespace", request.Namespace).WithField("name", request.Name)
+	log.Info("reconciling contourdeployment")
+
+	contourDeployment := &contour_api_v1alpha1.ContourDeployment{}
+	if err := r.client.Get(ctx, request.NamespacedName, contourDeployment); err != nil {
+		if !errors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("error getting contourdeployment %s: %w", request.NamespacedName, err)
+		}
+
+		log.Info("contourdeployment not found, deleting provisioned cluster-scoped objects")
+		err := r.deleteClusterRoleBindings(ctx, provisioner.ContourDeploymentInstance(&contour_api_v1alpha1.ContourDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: request.Namespace,
+				Name:      request.Name,
+			},
+		}).Labels)
+		return reconcile.Result{}, err
+	}
+
+	if err := contourDeployment.Spec.Config.Validate(); err != nil {
+		return reconcile.Result{}, r.updateStatus(ctx, contourDeployment, provisioningFailed(contourDeployment, err))
+	}
+
+	instance := provisioner.ContourDeploymentInstance(contourDeployment)
+	if err := r.provision(ctx, contourDeployment, instance, r.config,
+		r.ensureContourConfiguration(provisioner.ContourConfiguration(contourDeployment)),
+	); err != nil {
+		if statusErr := r.updateStatus(ctx, contourDeployment, provisioningFailed(contourDeployment, err)); statusErr != nil {
+			log.WithError(statusErr).Error("failed to update contourdeployment status")
+		}
+		return reconcile.Result{}, err
+	}
+
+	deployment := &apps_v1.Deployment{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: provisioner.ContourName(instance)}, deployment); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error getting deployment for contourdeployment %s: %w", request.NamespacedName, err)
+	}
+
+	daemonSet := &apps_v1.DaemonSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: provisioner.EnvoyName(instance)}, daemonSet); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error getting daemonset for contourdeployment %s: %w", request.NamespacedName, err)
+	}
+
+	return reconcile.Result{}, r.updateStatus(ctx, contourDeployment, computeContourDeploymentConditions(contourDeployment, deployment, daemonSet))
+}
+
+// updateStatus sets conditions on the status of contourDeployment, and
+// updates it if they have changed.
+func (r *contourDeploymentReconciler) updateStatus(ctx context.Context, contourDeployment *contour_api_v1alpha1.ContourDeployment, conditions []contour_api_v1.DetailedCondition) error {
+	updated := contourDeployment.DeepCopy()
+
+	for _, cond := range conditions {
+		curr := updated.Status.GetConditionFor(cond.Type)
+		if curr == nil {
+			cond.LastTransitionTime = metav1.Now()
+			updated.Status.Conditions = append(updated.Status.Conditions, cond)
+			continue
+		}
+
+		// Only move the transition time when the status changes.
+		cond.LastTransitionTime = curr.LastTransitionTime
+		if curr.Status != cond.Status {
+			cond.LastTransitionTime = metav1.Now()
+		}
+		*curr = cond
+	}
+
+	if equalConditions(contourDeployment.Status.Conditions, updated.Status.Conditions) {
+		return nil
+	}
+
+	if err := r.client.Status().Update(ctx, updated); err != nil {
+		return fmt.Errorf("error updating status of contourdeployment %s/%s: %w", contourDeployment.Namespace, contourDeployment.Name, err)
+	}
+	return nil
+}
+
+// computeContourDeploymentConditions returns the Available and Progressing
+// conditions of contourDeployment, based on the status of its provisioned
+// Contour Deployment and Envoy DaemonSet.
+func computeContourDeploymentConditions(contourDeployment *contour_api_v1alpha1.ContourDeployment, deployment *apps_v1.Deployment, daemonSet *apps_v1.DaemonSet) []contour_api_v1.DetailedCondition {
+	available := newContourDeploymentCondition(contourDeployment, contour_api_v1alpha1.ContourDeploymentAvailableConditionType)
+	switch {
+	case deployment.Status.AvailableReplicas == 0:
+		available.Status = contour_api_v1.ConditionFalse
+		available.Reason = reasonContourUnavailable
+		available.Message = fmt.Sprintf("Deployment %s has no available replicas.", deployment.Name)
+	case daemonSet.Status.NumberAvailable == 0:
+		available.Status = contour_api_v1.ConditionFalse
+		available.Reason = reasonEnvoyUnavailable
+		available.Message = fmt.Sprintf("DaemonSet %s has no available pods.", daemonSet.Name)
+	default:
+		available.Status = contour_api_v1.ConditionTrue
+		available.Reason = reasonContourDeploymentAvailable
+		available.Message = fmt.Sprintf("%d/%d Contour replicas and %d/%d Envoy pods are available.",
+			deployment.Status.AvailableReplicas, desiredReplicas(deployment),
+			daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+	}
+
+	progressing := newContourDeploymentCondition(contourDeployment, contour_api_v1alpha1.ContourDeploymentProgressingConditionType)
+	switch {
+	case deploymentRollingOut(deployment):
+		progressing.Status = contour_api_v1.ConditionTrue
+		progressing.Reason = reasonRolloutInProgress
+		progressing.Message = fmt.Sprintf("Deployment %s rollout in progress: %d/%d replicas updated, %d available.",
+			deployment.Name, deployment.Status.UpdatedReplicas, desiredReplicas(deployment), deployment.Status.AvailableReplicas)
+	case daemonSetRollingOut(daemonSet):
+		progressing.Status = contour_api_v1.ConditionTrue
+		progressing.Reason = reasonRolloutInProgress
+		progressing.Message = fmt.Sprintf("DaemonSet %s rollout in progress: %d/%d pods updated, %d available.",
+			daemonSet.Name, daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled, daemonSet.Status.NumberAvailable)
+	default:
+		progressing.Status = contour_api_v1.ConditionFalse
+		progressing.Reason = reasonRolloutComplete
+		progressing.Message = "Contour and Envoy rollouts are complete."
+	}
+
+	return []contour_api_v1.DetailedCondition{available, progressing}
+}
+
+// provisioningFailed returns a Progressing condition reporting that
+// provisioning contourDeployment failed with err.
+func provisioningFailed(contourDeployment *contour_api_v1alpha1.ContourDeployment, err error) []contour_api_v1.DetailedCondition {
+	progressing := newContourDeploymentCondition(contourDeployment, contour_api_v1alpha1.ContourDeploymentProgressingConditionType)
+	progressing.Status = contour_api_v1.ConditionFalse
+	progressing.Reason = reasonProvisioningFailed
+	progressing.Message = err.Error()
+
+	return []contour_api_v1.DetailedCondition{progressing}
+}
+
+func newContourDeploymentCondition(contourDeployment *contour_api_v1alpha1.ContourDeployment, condType string) contour_api_v1.DetailedCondition {
+	return contour_api_v1.DetailedCondition{
+		Condition: contour_api_v1.Condition{
+			Type:               condType,
+			Status:             contour_api_v1.ConditionUnknown,
+			ObservedGeneration: contourDeployment.Generation,
+		},
+	}
+}
+
+func desiredReplicas(deployment *apps_v1.Deployment) int32 {
+	if deployment.Spec.Replicas == nil {
+		return 1
+	}
+	return *deployment.Spec.Replicas
+}
+
+// deploymentRollingOut returns true if the latest spec of deployment
+// has not yet been rolled out to all its replicas.
+func deploymentRollingOut(deployment *apps_v1.Deployment) bool {
+	return deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Status.UpdatedReplicas < desiredReplicas(deployment) ||
+		deployment.Status.Replicas > deployment.Status.UpdatedReplicas ||
+		deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas
+}
+
+// daemonSetRollingOut returns true if the latest spec of daemonSet
+// has not yet been rolled out to all its pods.
+func daemonSetRollingOut(daemonSet *apps_v1.DaemonSet) bool {
+	return daemonSet.Status.ObservedGeneration < daemonSet.Generation ||
+		daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled ||
+		daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled
+}
+
+// equalConditions returns true if a and b have the same conditions,
+// ignoring their order.
+func equalConditions(a, b []contour_api_v1.DetailedCondition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, cond := range a {
+		var found bool
+		for _, other := range b {
+			if other.Type == cond.Type {
+				found = other.Status == cond.Status &&
+					other.Reason == cond.Reason &&
+					other.Message == cond.Message &&
+					other.ObservedGeneration == cond.ObservedGeneration
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
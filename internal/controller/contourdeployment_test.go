@@ -0,0 +1,223 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/provisioner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	rbac_v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestContourDeploymentReconcile(t *testing.T) {
+	scheme, err := k8s.NewContourScheme()
+	require.NoError(t, err)
+
+	contourDeployment := &contour_api_v1alpha1.ContourDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "example", Generation: 1},
+		Spec: contour_api_v1alpha1.ContourDeploymentSpec{
+			Replicas: 3,
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(contourDeployment).Build()
+	r := &contourDeploymentReconciler{
+		instanceProvisioner: instanceProvisioner{
+			client: c,
+			log:    fixture.NewTestLogger(t),
+		},
+		config: provisioner.Config{ContourImage: "contour:test", EnvoyImage: "envoy:test"},
+	}
+
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "projectcontour", Name: "example"}}
+	namespaced := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "projectcontour", Name: name}
+	}
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	// The generated ContourConfiguration is owned by the ContourDeployment.
+	config := &contour_api_v1alpha1.ContourConfiguration{}
+	require.NoError(t, c.Get(ctx, namespaced("contour-example"), config))
+	require.Len(t, config.OwnerReferences, 1)
+	assert.Equal(t, "ContourDeployment", config.OwnerReferences[0].Kind)
+	assert.Equal(t, "envoy-example", config.Spec.Envoy.Service.Name)
+
+	deployment := &apps_v1.Deployment{}
+	require.NoError(t, c.Get(ctx, namespaced("contour-example"), deployment))
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Args, "--contour-config-name=contour-example")
+
+	crb := &rbac_v1.ClusterRoleBinding{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "contourdeployment-projectcontour-example"}, crb))
+
+	// Nothing is available yet.
+	require.NoError(t, c.Get(ctx, request.NamespacedName, contourDeployment))
+	available := contourDeployment.Status.GetConditionFor(contour_api_v1alpha1.ContourDeploymentAvailableConditionType)
+	require.NotNil(t, available)
+	assert.Equal(t, contour_api_v1.ConditionFalse, available.Status)
+	assert.Equal(t, reasonContourUnavailable, available.Reason)
+	assert.Equal(t, int64(1), available.ObservedGeneration)
+
+	// Simulate the rollouts completing.
+	deployment.Status = apps_v1.DeploymentStatus{
+		ObservedGeneration: deployment.Generation,
+		Replicas:           3,
+		UpdatedReplicas:    3,
+		AvailableReplicas:  3,
+	}
+	require.NoError(t, c.Status().Update(ctx, deployment))
+
+	daemonSet := &apps_v1.DaemonSet{}
+	require.NoError(t, c.Get(ctx, namespaced("envoy-example"), daemonSet))
+	daemonSet.Status = apps_v1.DaemonSetStatus{
+		ObservedGeneration:     daemonSet.Generation,
+		DesiredNumberScheduled: 2,
+		UpdatedNumberScheduled: 2,
+		NumberAvailable:        2,
+	}
+	require.NoError(t, c.Status().Update(ctx, daemonSet))
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, request.NamespacedName, contourDeployment))
+	available = contourDeployment.Status.GetConditionFor(contour_api_v1alpha1.ContourDeploymentAvailableConditionType)
+	require.NotNil(t, available)
+	assert.Equal(t, contour_api_v1.ConditionTrue, available.Status)
+	assert.Equal(t, "3/3 Contour replicas and 2/2 Envoy pods are available.", available.Message)
+
+	progressing := contourDeployment.Status.GetConditionFor(contour_api_v1alpha1.ContourDeploymentProgressingConditionType)
+	require.NotNil(t, progressing)
+	assert.Equal(t, contour_api_v1.ConditionFalse, progressing.Status)
+	assert.Equal(t, reasonRolloutComplete, progressing.Reason)
+
+	// Deleting the ContourDeployment removes the ClusterRoleBinding.
+	require.NoError(t, c.Delete(ctx, contourDeployment))
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	err = c.Get(ctx, types.NamespacedName{Name: "contourdeployment-projectcontour-example"}, &rbac_v1.ClusterRoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestComputeContourDeploymentConditions(t *testing.T) {
+	contourDeployment := &contour_api_v1alpha1.ContourDeployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 4},
+	}
+
+	deployment := func(generation, observed int64, replicas, updated, available int32) *apps_v1.Deployment {
+		return &apps_v1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "contour-example", Generation: generation},
+			Spec:       apps_v1.DeploymentSpec{Replicas: pointer.Int32Ptr(2)},
+			Status: apps_v1.DeploymentStatus{
+				ObservedGeneration: observed,
+				Replicas:           replicas,
+				UpdatedReplicas:    updated,
+				AvailableReplicas:  available,
+			},
+		}
+	}
+	daemonSet := func(generation, observed int64, desired, updated, available int32) *apps_v1.DaemonSet {
+		return &apps_v1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "envoy-example", Generation: generation},
+			Status: apps_v1.DaemonSetStatus{
+				ObservedGeneration:     observed,
+				DesiredNumberScheduled: desired,
+				UpdatedNumberScheduled: updated,
+				NumberAvailable:        available,
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		deployment      *apps_v1.Deployment
+		daemonSet       *apps_v1.DaemonSet
+		wantAvailable   string
+		wantProgressing string
+	}{
+		"rolled out": {
+			deployment:      deployment(1, 1, 2, 2, 2),
+			daemonSet:       daemonSet(1, 1, 3, 3, 3),
+			wantAvailable:   reasonContourDeploymentAvailable,
+			wantProgressing: reasonRolloutComplete,
+		},
+		"contour unavailable": {
+			deployment:      deployment(1, 1, 2, 2, 0),
+			daemonSet:       daemonSet(1, 1, 3, 3, 3),
+			wantAvailable:   reasonContourUnavailable,
+			wantProgressing: reasonRolloutInProgress,
+		},
+		"envoy unavailable": {
+			deployment:      deployment(1, 1, 2, 2, 2),
+			daemonSet:       daemonSet(1, 1, 3, 0, 0),
+			wantAvailable:   reasonEnvoyUnavailable,
+			wantProgressing: reasonRolloutInProgress,
+		},
+		"deployment spec not yet observed": {
+			deployment:      deployment(2, 1, 2, 2, 2),
+			daemonSet:       daemonSet(1, 1, 3, 3, 3),
+			wantAvailable:   reasonContourDeploymentAvailable,
+			wantProgressing: reasonRolloutInProgress,
+		},
+		"old deployment replicas remaining": {
+			deployment:      deployment(2, 2, 3, 2, 3),
+			daemonSet:       daemonSet(1, 1, 3, 3, 3),
+			wantAvailable:   reasonContourDeploymentAvailable,
+			wantProgressing: reasonRolloutInProgress,
+		},
+		"daemonset partially updated": {
+			deployment:      deployment(1, 1, 2, 2, 2),
+			daemonSet:       daemonSet(2, 2, 3, 1, 3),
+			wantAvailable:   reasonContourDeploymentAvailable,
+			wantProgressing: reasonRolloutInProgress,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conditions := computeContourDeploymentConditions(contourDeployment, tc.deployment, tc.daemonSet)
+			require.Len(t, conditions, 2)
+
+			assert.Equal(t, contour_api_v1alpha1.ContourDeploymentAvailableConditionType, conditions[0].Type)
+			assert.Equal(t, tc.wantAvailable, conditions[0].Reason)
+			assert.Equal(t, tc.wantAvailable == reasonContourDeploymentAvailable, conditions[0].Status == contour_api_v1.ConditionTrue)
+
+			assert.Equal(t, contour_api_v1alpha1.ContourDeploymentProgressingConditionType, conditions[1].Type)
+			assert.Equal(t, tc.wantProgressing, conditions[1].Reason)
+			assert.Equal(t, tc.wantProgressing == reasonRolloutInProgress, conditions[1].Status == contour_api_v1.ConditionTrue)
+
+			for _, cond := range conditions {
+				assert.Equal(t, int64(4), cond.ObservedGeneration)
+			}
+		})
+	}
+}
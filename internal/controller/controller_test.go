@@ -20,6 +20,7 @@ import (
 	"github.com/projectcontour/contour/internal/controller"
 	"github.com/projectcontour/contour/internal/controller/mocks"
 	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/provisioner"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -30,7 +31,7 @@ import (
 func TestRegisterControllers(t *testing.T) {
 	tests := map[string]func(*mocks.Manager) error{
 		"gateway controller": func(mockManager *mocks.Manager) error {
-			_, err := controller.RegisterGatewayController(fixture.NewTestLogger(t), mockManager, nil, nil, "some-controller")
+			_, err := controller.RegisterGatewayController(fixture.NewTestLogger(t), mockManager, nil, nil, "some-controller", nil)
 			return err
 		},
 		"gatewayclass controller": func(mockManager *mocks.Manager) error {
@@ -43,6 +44,12 @@ func TestRegisterControllers(t *testing.T) {
 		"tlsroute controller": func(mockManager *mocks.Manager) error {
 			return controller.RegisterTLSRouteController(fixture.NewTestLogger(t), mockManager, nil)
 		},
+		"tcproute controller": func(mockManager *mocks.Manager) error {
+			return controller.RegisterTCPRouteController(fixture.NewTestLogger(t), mockManager, nil)
+		},
+		"udproute controller": func(mockManager *mocks.Manager) error {
+			return controller.RegisterUDPRouteController(fixture.NewTestLogger(t), mockManager, nil)
+		},
 	}
 
 	for name, test := range tests {
@@ -65,3 +72,39 @@ func TestRegisterControllers(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterGatewayProvisioner(t *testing.T) {
+	mockManager := &mocks.Manager{}
+
+	mockManager.On("GetClient").Return(nil).Maybe()
+	mockManager.On("GetLogger").Return(logr_testing.NewTestLogger(t)).Maybe()
+	mockManager.On("SetFields", mock.Anything).Return(nil).Maybe()
+
+	// The provisioner must only run on the elected leader, so it should
+	// not opt out of leader election.
+	mockManager.On("Add", mock.MatchedBy(func(r manager.Runnable) bool {
+		_, ok := r.(manager.LeaderElectionRunnable)
+		return !ok
+	})).Return(nil).Once()
+
+	require.NoError(t, controller.RegisterGatewayProvisioner(fixture.NewTestLogger(t), mockManager, provisioner.Config{}))
+
+	require.True(t, mockManager.AssertExpectations(t))
+}
+
+func TestRegisterContourDeploymentController(t *testing.T) {
+	mockManager := &mocks.Manager{}
+
+	mockManager.On("GetClient").Return(nil).Maybe()
+	mockManager.On("GetLogger").Return(logr_testing.NewTestLogger(t)).Maybe()
+	mockManager.On("SetFields", mock.Anything).Return(nil).Maybe()
+
+	mockManager.On("Add", mock.MatchedBy(func(r manager.Runnable) bool {
+		_, ok := r.(manager.LeaderElectionRunnable)
+		return !ok
+	})).Return(nil).Once()
+
+	require.NoError(t, controller.RegisterContourDeploymentController(fixture.NewTestLogger(t), mockManager, provisioner.Config{}))
+
+	require.True(t, mockManager.AssertExpectations(t))
+}
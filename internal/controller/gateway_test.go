@@ -0,0 +1,114 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestGatewayReconcile(t *testing.T) {
+	scheme, err := k8s.NewContourScheme()
+	require.NoError(t, err)
+
+	accepted := []metav1.Condition{{
+		Type:   string(gatewayapi_v1alpha2.GatewayClassConditionStatusAccepted),
+		Status: metav1.ConditionTrue,
+	}}
+	gatewayClass := &gatewayapi_v1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "contour"},
+		Spec:       gatewayapi_v1alpha2.GatewayClassSpec{ControllerName: "projectcontour.io/projectcontour/contour"},
+		Status:     gatewayapi_v1alpha2.GatewayClassStatus{Conditions: accepted},
+	}
+	otherClass := &gatewayapi_v1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		Spec:       gatewayapi_v1alpha2.GatewayClassSpec{ControllerName: "example.com/other"},
+		Status:     gatewayapi_v1alpha2.GatewayClassStatus{Conditions: accepted},
+	}
+	gateway := func(name, className string) *gatewayapi_v1alpha2.Gateway {
+		return &gatewayapi_v1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: name},
+			Spec:       gatewayapi_v1alpha2.GatewaySpec{GatewayClassName: gatewayapi_v1alpha2.ObjectName(className)},
+		}
+	}
+
+	tests := map[string]struct {
+		gatewayRef  *types.NamespacedName
+		request     string
+		wantAdded   []string
+		wantDeleted []string
+	}{
+		"first gateway of the class": {
+			request:   "first",
+			wantAdded: []string{"first"},
+		},
+		"second gateway of the class": {
+			request:   "second",
+			wantAdded: []string{"second"},
+		},
+		"gateway of another controller's class": {
+			request:     "other",
+			wantDeleted: []string{"other"},
+		},
+		"deleted gateway": {
+			request:     "deleted",
+			wantDeleted: []string{"deleted"},
+		},
+		"gateway that is not the gatewayRef": {
+			gatewayRef: &types.NamespacedName{Namespace: "projectcontour", Name: "first"},
+			request:    "second",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var added, deleted []string
+			r := &gatewayReconciler{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					gatewayClass,
+					otherClass,
+					gateway("first", "contour"),
+					gateway("second", "contour"),
+					gateway("other", "other"),
+				).Build(),
+				eventHandler: cache.ResourceEventHandlerFuncs{
+					AddFunc:    func(obj interface{}) { added = append(added, obj.(*gatewayapi_v1alpha2.Gateway).Name) },
+					DeleteFunc: func(obj interface{}) { deleted = append(deleted, obj.(*gatewayapi_v1alpha2.Gateway).Name) },
+				},
+				log:                        fixture.NewTestLogger(t),
+				gatewayClassControllerName: "projectcontour.io/projectcontour/contour",
+				gatewayRef:                 tc.gatewayRef,
+			}
+
+			_, err := r.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "projectcontour", Name: tc.request},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantAdded, added)
+			assert.Equal(t, tc.wantDeleted, deleted)
+		})
+	}
+}
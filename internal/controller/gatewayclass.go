@@ -17,10 +17,12 @@ import (
 	"context"
 	"fmt"
 
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 	"github.com/projectcontour/contour/internal/k8s"
 	"github.com/projectcontour/contour/internal/leadership"
 	"github.com/projectcontour/contour/internal/status"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
@@ -92,9 +94,41 @@ func RegisterGatewayClassController(
 		return nil, err
 	}
 
+	// Revalidate GatewayClasses when the ContourConfigurations
+	// that they reference as parameters change.
+	if err := c.Watch(
+		&source.Kind{Type: &contour_api_v1alpha1.ContourConfiguration{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapContourConfigurationToGatewayClasses),
+	); err != nil {
+		return nil, err
+	}
+
 	return r, nil
 }
 
+// mapContourConfigurationToGatewayClasses returns a reconcile request for each
+// GatewayClass with a matching controller that references contourConfig as its
+// parameters.
+func (r *gatewayClassReconciler) mapContourConfigurationToGatewayClasses(contourConfig client.Object) []reconcile.Request {
+	var gatewayClasses gatewayapi_v1alpha2.GatewayClassList
+	if err := r.client.List(context.Background(), &gatewayClasses); err != nil {
+		r.log.WithError(err).Error("error listing gatewayclasses")
+		return nil
+	}
+
+	var reconciles []reconcile.Request
+	for i := range gatewayClasses.Items {
+		gc := &gatewayClasses.Items[i]
+		if gc.Spec.ControllerName == r.controller && refersToContourConfiguration(gc, contourConfig) {
+			reconciles = append(reconciles, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: gc.Name},
+			})
+		}
+	}
+
+	return reconciles
+}
+
 func (r *gatewayClassReconciler) OnElectedLeader() {
 	r.log.Info("elected leader, triggering reconciles for all gatewayclasses")
 
@@ -167,33 +201,51 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, request reconcil
 	}
 
 	for _, gc := range controlledClasses.notAcceptedClasses() {
-		if r.statusUpdater != nil {
-			r.statusUpdater.Send(k8s.StatusUpdate{
-				NamespacedName: types.NamespacedName{Name: gc.Name},
-				Resource:       &gatewayapi_v1alpha2.GatewayClass{},
-				Mutator: k8s.StatusMutatorFunc(func(obj client.Object) client.Object {
-					gc, ok := obj.(*gatewayapi_v1alpha2.GatewayClass)
-					if !ok {
-						panic(fmt.Sprintf("unsupported object type %T", obj))
-					}
-
-					copy := gc.DeepCopy()
-					return status.SetGatewayClassAccepted(context.Background(), r.client, copy, false)
-				}),
-			})
-		} else {
-			// this branch makes testing easier by not going through the StatusUpdater.
-			copy := status.SetGatewayClassAccepted(context.Background(), r.client, gc.DeepCopy(), false)
+		if err := r.updateStatus(gc, func(gc *gatewayapi_v1alpha2.GatewayClass) *gatewayapi_v1alpha2.GatewayClass {
+			return status.SetGatewayClassAccepted(context.Background(), r.client, gc, false)
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// The oldest GatewayClass is only accepted if its parameters
+	// are valid. Otherwise Contour has no GatewayClass.
+	acceptedClass := controlledClasses.acceptedClass()
+
+	_, invalid, err := gatewayClassParameters(ctx, r.client, acceptedClass)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if invalid != "" {
+		r.log.WithField("name", acceptedClass.Name).WithField("reason", invalid).Info("gatewayclass has invalid parameters")
 
-			if err := r.client.Status().Update(context.Background(), copy); err != nil {
-				return reconcile.Result{}, fmt.Errorf("error updating status of gateway class %s: %v", copy.Name, err)
-			}
+		if err := r.updateStatus(acceptedClass, func(gc *gatewayapi_v1alpha2.GatewayClass) *gatewayapi_v1alpha2.GatewayClass {
+			return status.SetGatewayClassInvalidParameters(gc, invalid)
+		}); err != nil {
+			return reconcile.Result{}, err
 		}
+
+		r.eventHandler.OnDelete(acceptedClass)
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.updateStatus(acceptedClass, func(gc *gatewayapi_v1alpha2.GatewayClass) *gatewayapi_v1alpha2.GatewayClass {
+		return status.SetGatewayClassAccepted(context.Background(), r.client, gc, true)
+	}); err != nil {
+		return reconcile.Result{}, err
 	}
 
+	r.eventHandler.OnAdd(acceptedClass)
+
+	return reconcile.Result{}, nil
+}
+
+// updateStatus applies mutate to the status of gatewayClass through the
+// status updater, or directly if there is no status updater.
+func (r *gatewayClassReconciler) updateStatus(gatewayClass *gatewayapi_v1alpha2.GatewayClass, mutate func(*gatewayapi_v1alpha2.GatewayClass) *gatewayapi_v1alpha2.GatewayClass) error {
 	if r.statusUpdater != nil {
 		r.statusUpdater.Send(k8s.StatusUpdate{
-			NamespacedName: types.NamespacedName{Name: controlledClasses.acceptedClass().Name},
+			NamespacedName: types.NamespacedName{Name: gatewayClass.Name},
 			Resource:       &gatewayapi_v1alpha2.GatewayClass{},
 			Mutator: k8s.StatusMutatorFunc(func(obj client.Object) client.Object {
 				gc, ok := obj.(*gatewayapi_v1alpha2.GatewayClass)
@@ -201,20 +253,64 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, request reconcil
 					panic(fmt.Sprintf("unsupported object type %T", obj))
 				}
 
-				return status.SetGatewayClassAccepted(context.Background(), r.client, gc.DeepCopy(), true)
+				return mutate(gc.DeepCopy())
 			}),
 		})
-	} else {
-		// this branch makes testing easier by not going through the StatusUpdater.
-		copy := status.SetGatewayClassAccepted(context.Background(), r.client, controlledClasses.acceptedClass().DeepCopy(), true)
-		if err := r.client.Status().Update(context.Background(), copy); err != nil {
-			return reconcile.Result{}, fmt.Errorf("error updating status of gateway class %s: %v", copy.Name, err)
+		return nil
+	}
+
+	// this branch makes testing easier by not going through the StatusUpdater.
+	copy := mutate(gatewayClass.DeepCopy())
+	if err := r.client.Status().Update(context.Background(), copy); err != nil {
+		return fmt.Errorf("error updating status of gateway class %s: %v", copy.Name, err)
+	}
+	return nil
+}
+
+// gatewayClassParameters returns the ContourConfiguration referenced by the
+// parametersRef of gatewayClass, or nil if it has no parametersRef. If the
+// reference is invalid, the reason is returned as invalid. An error is only
+// returned if the reference could not be checked.
+func gatewayClassParameters(ctx context.Context, cli client.Reader, gatewayClass *gatewayapi_v1alpha2.GatewayClass) (params *contour_api_v1alpha1.ContourConfiguration, invalid string, err error) {
+	ref := gatewayClass.Spec.ParametersRef
+	if ref == nil {
+		return nil, "", nil
+	}
+
+	if string(ref.Group) != contour_api_v1alpha1.GroupVersion.Group || ref.Kind != "ContourConfiguration" {
+		return nil, fmt.Sprintf("parametersRef must refer to a %s ContourConfiguration, not %s %s.", contour_api_v1alpha1.GroupVersion.Group, ref.Group, ref.Kind), nil
+	}
+	if ref.Namespace == nil || *ref.Namespace == "" {
+		return nil, "parametersRef.namespace must be set.", nil
+	}
+
+	key := types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}
+	params = &contour_api_v1alpha1.ContourConfiguration{}
+	if err := cli.Get(ctx, key, params); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Sprintf("ContourConfiguration %q not found.", key), nil
 		}
+		return nil, "", fmt.Errorf("error getting parameters of gatewayclass %s: %w", gatewayClass.Name, err)
+	}
+
+	if err := params.Spec.Validate(); err != nil {
+		return nil, fmt.Sprintf("ContourConfiguration %q is invalid: %s.", key, err), nil
 	}
 
-	r.eventHandler.OnAdd(controlledClasses.acceptedClass())
+	return params, "", nil
+}
 
-	return reconcile.Result{}, nil
+// refersToContourConfiguration returns true if the parametersRef of
+// gatewayClass refers to contourConfig.
+func refersToContourConfiguration(gatewayClass *gatewayapi_v1alpha2.GatewayClass, contourConfig client.Object) bool {
+	ref := gatewayClass.Spec.ParametersRef
+
+	return ref != nil &&
+		string(ref.Group) == contour_api_v1alpha1.GroupVersion.Group &&
+		ref.Kind == "ContourConfiguration" &&
+		ref.Namespace != nil &&
+		string(*ref.Namespace) == contourConfig.GetNamespace() &&
+		ref.Name == contourConfig.GetName()
 }
 
 // controlledClasses helps organize a list of GatewayClasses
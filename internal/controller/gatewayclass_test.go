@@ -0,0 +1,162 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestGatewayClassReconcileParameters(t *testing.T) {
+	namespace := gatewayapi_v1alpha2.Namespace("projectcontour")
+
+	params := &contour_api_v1alpha1.ContourConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "params"},
+	}
+	invalidParams := &contour_api_v1alpha1.ContourConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "invalid"},
+		Spec: contour_api_v1alpha1.ContourConfigurationSpec{
+			Metrics: contour_api_v1alpha1.MetricsConfig{
+				Address: "0.0.0.0",
+				Port:    8000,
+				TLS:     &contour_api_v1alpha1.MetricsTLS{},
+			},
+			Health: contour_api_v1alpha1.HealthConfig{
+				Address: "0.0.0.0",
+				Port:    8000,
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		ref          *gatewayapi_v1alpha2.ParametersReference
+		wantAccepted bool
+		wantReason   string
+	}{
+		"no parameters": {
+			wantAccepted: true,
+			wantReason:   "Valid",
+		},
+		"valid parameters": {
+			ref: &gatewayapi_v1alpha2.ParametersReference{
+				Group:     "projectcontour.io",
+				Kind:      "ContourConfiguration",
+				Name:      "params",
+				Namespace: &namespace,
+			},
+			wantAccepted: true,
+			wantReason:   "Valid",
+		},
+		"unsupported kind": {
+			ref: &gatewayapi_v1alpha2.ParametersReference{
+				Group:     "",
+				Kind:      "ConfigMap",
+				Name:      "params",
+				Namespace: &namespace,
+			},
+			wantReason: string(gatewayapi_v1alpha2.GatewayClassReasonInvalidParameters),
+		},
+		"no namespace": {
+			ref: &gatewayapi_v1alpha2.ParametersReference{
+				Group: "projectcontour.io",
+				Kind:  "ContourConfiguration",
+				Name:  "params",
+			},
+			wantReason: string(gatewayapi_v1alpha2.GatewayClassReasonInvalidParameters),
+		},
+		"not found": {
+			ref: &gatewayapi_v1alpha2.ParametersReference{
+				Group:     "projectcontour.io",
+				Kind:      "ContourConfiguration",
+				Name:      "missing",
+				Namespace: &namespace,
+			},
+			wantReason: string(gatewayapi_v1alpha2.GatewayClassReasonInvalidParameters),
+		},
+		"invalid configuration": {
+			ref: &gatewayapi_v1alpha2.ParametersReference{
+				Group:     "projectcontour.io",
+				Kind:      "ContourConfiguration",
+				Name:      "invalid",
+				Namespace: &namespace,
+			},
+			wantReason: string(gatewayapi_v1alpha2.GatewayClassReasonInvalidParameters),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme, err := k8s.NewContourScheme()
+			require.NoError(t, err)
+
+			gatewayClass := &gatewayapi_v1alpha2.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "contour"},
+				Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+					ControllerName: "projectcontour.io/projectcontour/contour",
+					ParametersRef:  tc.ref,
+				},
+			}
+
+			var added, deleted bool
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(params, invalidParams, gatewayClass).Build()
+			r := &gatewayClassReconciler{
+				client: c,
+				eventHandler: cache.ResourceEventHandlerFuncs{
+					AddFunc:    func(obj interface{}) { added = true },
+					DeleteFunc: func(obj interface{}) { deleted = true },
+				},
+				log:        fixture.NewTestLogger(t),
+				controller: "projectcontour.io/projectcontour/contour",
+			}
+
+			_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "contour"}})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantAccepted, added)
+			assert.Equal(t, !tc.wantAccepted, deleted)
+
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "contour"}, gatewayClass))
+			require.Len(t, gatewayClass.Status.Conditions, 1)
+
+			cond := gatewayClass.Status.Conditions[0]
+			assert.Equal(t, string(gatewayapi_v1alpha2.GatewayClassConditionStatusAccepted), cond.Type)
+			assert.Equal(t, tc.wantReason, cond.Reason)
+			if tc.wantAccepted {
+				assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			} else {
+				assert.Equal(t, metav1.ConditionFalse, cond.Status)
+			}
+
+			// Changes to the parameters requeue the GatewayClass.
+			requests := r.mapContourConfigurationToGatewayClasses(params)
+			if tc.ref != nil && tc.ref.Name == "params" && tc.ref.Namespace != nil && tc.ref.Kind == "ContourConfiguration" {
+				assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "contour"}}}, requests)
+			} else {
+				assert.Empty(t, requests)
+			}
+		})
+	}
+}
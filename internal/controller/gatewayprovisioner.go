@@ -0,0 +1,215 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/provisioner"
+	"github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+type gatewayProvisionerReconciler struct {
+	instanceProvisioner
+	config provisioner.Config
+}
+
+// RegisterGatewayProvisioner creates the gateway provisioner controller from mgr.
+// The controller watches Gateways whose GatewayClass has a controller name matching
+// config.GatewayControllerName, and deploys a Contour and Envoy for each of them.
+// Unlike the other Gateway API controllers, the provisioner only runs while it
+// is the elected leader.
+func RegisterGatewayProvisioner(log logrus.FieldLogger, mgr manager.Manager, config provisioner.Config) error {
+	r := &gatewayProvisionerReconciler{
+		instanceProvisioner: instanceProvisioner{
+			client: mgr.GetClient(),
+			log:    log,
+		},
+		config: config,
+	}
+
+	c, err := controller.New("gateway-provisioner", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(
+		&source.Kind{Type: &gatewayapi_v1alpha2.Gateway{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.NewPredicateFuncs(r.hasMatchingController),
+	); err != nil {
+		return err
+	}
+
+	// Reprovision Gateways when their GatewayClass, or the
+	// ContourConfiguration that it references as parameters, changes.
+	if err := c.Watch(
+		&source.Kind{Type: &gatewayapi_v1alpha2.GatewayClass{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapGatewayClassToGateways),
+	); err != nil {
+		return err
+	}
+	if err := c.Watch(
+		&source.Kind{Type: &contour_api_v1alpha1.ContourConfiguration{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapContourConfigurationToGateways),
+	); err != nil {
+		return err
+	}
+
+	return watchOwned(c, &gatewayapi_v1alpha2.Gateway{}, &core_v1.ConfigMap{}, &contour_api_v1alpha1.ContourConfiguration{})
+}
+
+// mapGatewayClassToGateways returns a reconcile request for each Gateway of
+// gatewayClass, if it has a controller name matching the provisioner's.
+func (r *gatewayProvisionerReconciler) mapGatewayClassToGateways(gatewayClass client.Object) []reconcile.Request {
+	gc, ok := gatewayClass.(*gatewayapi_v1alpha2.GatewayClass)
+	if !ok || string(gc.Spec.ControllerName) != r.config.GatewayControllerName {
+		return nil
+	}
+
+	var gateways gatewayapi_v1alpha2.GatewayList
+	if err := r.client.List(context.Background(), &gateways); err != nil {
+		r.log.WithError(err).Error("error listing gateways")
+		return nil
+	}
+
+	var reconciles []reconcile.Request
+	for _, gw := range gateways.Items {
+		if string(gw.Spec.GatewayClassName) == gc.Name {
+			reconciles = append(reconciles, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name},
+			})
+		}
+	}
+
+	return reconciles
+}
+
+// mapContourConfigurationToGateways returns a reconcile request for each
+// Gateway whose GatewayClass references contourConfig as its parameters.
+func (r *gatewayProvisionerReconciler) mapContourConfigurationToGateways(contourConfig client.Object) []reconcile.Request {
+	var gatewayClasses gatewayapi_v1alpha2.GatewayClassList
+	if err := r.client.List(context.Background(), &gatewayClasses); err != nil {
+		r.log.WithError(err).Error("error listing gatewayclasses")
+		return nil
+	}
+
+	var reconciles []reconcile.Request
+	for i := range gatewayClasses.Items {
+		if refersToContourConfiguration(&gatewayClasses.Items[i], contourConfig) {
+			reconciles = append(reconciles, r.mapGatewayClassToGateways(&gatewayClasses.Items[i])...)
+		}
+	}
+
+	return reconciles
+}
+
+// hasMatchingController returns true if the provided object is a Gateway
+// using a GatewayClass with a Spec.Controller string matching the
+// provisioner's controller string, or false otherwise.
+func (r *gatewayProvisionerReconciler) hasMatchingController(obj client.Object) bool {
+	log := r.log.WithFields(logrus.Fields{
+		"namespace": obj.GetNamespace(),
+		"name":      obj.GetName(),
+	})
+
+	gw, ok := obj.(*gatewayapi_v1alpha2.Gateway)
+	if !ok {
+		log.Debugf("unexpected object type %T, bypassing reconciliation.", obj)
+		return false
+	}
+
+	gc := &gatewayapi_v1alpha2.GatewayClass{}
+	if err := r.client.Get(context.Background(), types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}, gc); err != nil {
+		log.WithError(err).Errorf("failed to get gatewayclass %s", gw.Spec.GatewayClassName)
+		return false
+	}
+	if string(gc.Spec.ControllerName) != r.config.GatewayControllerName {
+		log.Debugf("gateway's class controller is not %s; bypassing reconciliation", r.config.GatewayControllerName)
+		return false
+	}
+
+	return true
+}
+
+// Reconcile ensures that the Contour and Envoy objects for the requested Gateway
+// exist and match the provisioner's desired state.
+func (r *gatewayProvisionerReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithField("namespace", request.Namespace).WithField("name", request.Name)
+	log.Info("reconciling gateway")
+
+	gateway := &gatewayapi_v1alpha2.Gateway{}
+	if err := r.client.Get(ctx, request.NamespacedName, gateway); err != nil {
+		if !errors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("error getting gateway %s: %w", request.NamespacedName, err)
+		}
+
+		log.Info("gateway not found, deleting provisioned cluster-scoped objects")
+		err := r.deleteClusterRoleBindings(ctx, provisioner.OwnerLabels(&gatewayapi_v1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: request.Namespace,
+				Name:      request.Name,
+			},
+		}))
+		return reconcile.Result{}, err
+	}
+
+	gatewayClass := &gatewayapi_v1alpha2.GatewayClass{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: string(gateway.Spec.GatewayClassName)}, gatewayClass); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error getting gatewayclass %s: %w", gateway.Spec.GatewayClassName, err)
+	}
+
+	params, invalid, err := gatewayClassParameters(ctx, r.client, gatewayClass)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if invalid != "" {
+		// The GatewayClass status reports the invalid parameters. The
+		// Gateway is provisioned once they are fixed.
+		log.WithField("reason", invalid).Info("gatewayclass has invalid parameters, not provisioning gateway")
+		return reconcile.Result{}, nil
+	}
+
+	// Without parameters, the provisioned Contour is configured by a
+	// ConfigMap. Otherwise, it is configured by a copy of the parameters.
+	instance := provisioner.GatewayInstance(gateway, params)
+	if params == nil {
+		configMap, err := provisioner.ContourConfigMap(gateway, r.config)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		err = r.provision(ctx, gateway, instance, r.config, r.ensureConfigMap(configMap))
+		return reconcile.Result{}, err
+	}
+
+	err = r.provision(ctx, gateway, instance, r.config,
+		r.ensureContourConfiguration(provisioner.GatewayContourConfiguration(gateway, params, r.config)),
+	)
+	return reconcile.Result{}, err
+}
@@ -0,0 +1,243 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/provisioner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	rbac_v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestGatewayProvisionerReconcile(t *testing.T) {
+	scheme, err := k8s.NewContourScheme()
+	require.NoError(t, err)
+
+	gatewayClass := &gatewayapi_v1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "contour"},
+		Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+			ControllerName: "projectcontour.io/gateway-provisioner",
+		},
+	}
+	gateway := &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "example"},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: "contour",
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Name:     "http",
+				Port:     80,
+				Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+			}},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gatewayClass, gateway).Build()
+	r := &gatewayProvisionerReconciler{
+		instanceProvisioner: instanceProvisioner{
+			client: c,
+			log:    fixture.NewTestLogger(t),
+		},
+		config: provisioner.Config{
+			GatewayControllerName: "projectcontour.io/gateway-provisioner",
+			ContourImage:          "contour:test",
+			EnvoyImage:            "envoy:test",
+		},
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "projectcontour", Name: "example"}}
+
+	assert.True(t, r.hasMatchingController(gateway))
+
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	namespaced := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "projectcontour", Name: name}
+	}
+
+	// Every namespaced object is owned by the Gateway.
+	for _, owned := range []struct {
+		name string
+		obj  client.Object
+	}{
+		{"contour-example", &core_v1.ConfigMap{}},
+		{"contourcert-example", &core_v1.Secret{}},
+		{"envoycert-example", &core_v1.Secret{}},
+		{"contour-example", &core_v1.ServiceAccount{}},
+		{"envoy-example", &core_v1.ServiceAccount{}},
+		{"contour-example", &core_v1.Service{}},
+		{"contour-example", &apps_v1.Deployment{}},
+	} {
+		require.NoError(t, c.Get(ctx, namespaced(owned.name), owned.obj))
+		require.Len(t, owned.obj.GetOwnerReferences(), 1)
+		assert.Equal(t, "example", owned.obj.GetOwnerReferences()[0].Name)
+	}
+
+	envoyService := &core_v1.Service{}
+	require.NoError(t, c.Get(ctx, namespaced("envoy-example"), envoyService))
+	assert.Equal(t, core_v1.ServiceTypeLoadBalancer, envoyService.Spec.Type)
+
+	daemonSet := &apps_v1.DaemonSet{}
+	require.NoError(t, c.Get(ctx, namespaced("envoy-example"), daemonSet))
+	assert.Equal(t, "envoy:test", daemonSet.Spec.Template.Spec.Containers[1].Image)
+
+	// The ClusterRoleBinding is cluster-scoped so has no owner.
+	crb := &rbac_v1.ClusterRoleBinding{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "contour-projectcontour-example"}, crb))
+	assert.Empty(t, crb.OwnerReferences)
+
+	// Reconciling again does not regenerate the certificates.
+	secret := &core_v1.Secret{}
+	require.NoError(t, c.Get(ctx, namespaced("envoycert-example"), secret))
+
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+
+	reconciled := &core_v1.Secret{}
+	require.NoError(t, c.Get(ctx, namespaced("envoycert-example"), reconciled))
+	assert.Equal(t, secret.Data, reconciled.Data)
+
+	// Deleting the Gateway removes the ClusterRoleBinding.
+	require.NoError(t, c.Delete(ctx, gateway))
+
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+
+	err = c.Get(ctx, types.NamespacedName{Name: "contour-projectcontour-example"}, &rbac_v1.ClusterRoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestGatewayProvisionerIgnoresOtherControllers(t *testing.T) {
+	scheme, err := k8s.NewContourScheme()
+	require.NoError(t, err)
+
+	gatewayClass := &gatewayapi_v1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+			ControllerName: "example.com/other",
+		},
+	}
+	gateway := &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "example"},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: "other",
+		},
+	}
+
+	r := &gatewayProvisionerReconciler{
+		instanceProvisioner: instanceProvisioner{
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(gatewayClass, gateway).Build(),
+			log:    fixture.NewTestLogger(t),
+		},
+		config: provisioner.Config{GatewayControllerName: "projectcontour.io/gateway-provisioner"},
+	}
+
+	assert.False(t, r.hasMatchingController(gateway))
+}
+
+func TestGatewayProvisionerReconcileWithParameters(t *testing.T) {
+	scheme, err := k8s.NewContourScheme()
+	require.NoError(t, err)
+
+	params := &contour_api_v1alpha1.ContourConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "params"},
+		Spec: contour_api_v1alpha1.ContourConfigurationSpec{
+			EnableExternalNameService: true,
+		},
+	}
+	namespace := gatewayapi_v1alpha2.Namespace("projectcontour")
+	gatewayClass := &gatewayapi_v1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "contour"},
+		Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+			ControllerName: "projectcontour.io/gateway-provisioner",
+			ParametersRef: &gatewayapi_v1alpha2.ParametersReference{
+				Group:     "projectcontour.io",
+				Kind:      "ContourConfiguration",
+				Name:      "params",
+				Namespace: &namespace,
+			},
+		},
+	}
+	gateway := &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "example"},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: "contour",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(params, gatewayClass, gateway).Build()
+	r := &gatewayProvisionerReconciler{
+		instanceProvisioner: instanceProvisioner{
+			client: c,
+			log:    fixture.NewTestLogger(t),
+		},
+		config: provisioner.Config{GatewayControllerName: "projectcontour.io/gateway-provisioner"},
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "projectcontour", Name: "example"}}
+
+	ctx := context.Background()
+	namespaced := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "projectcontour", Name: name}
+	}
+
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	// The provisioned Contour is configured by a copy of the parameters.
+	contourConfig := &contour_api_v1alpha1.ContourConfiguration{}
+	require.NoError(t, c.Get(ctx, namespaced("contour-example"), contourConfig))
+	assert.True(t, contourConfig.Spec.EnableExternalNameService)
+	assert.Equal(t, &contour_api_v1alpha1.NamespacedName{Namespace: "projectcontour", Name: "example"}, contourConfig.Spec.Gateway.GatewayRef)
+
+	deployment := &apps_v1.Deployment{}
+	require.NoError(t, c.Get(ctx, namespaced("contour-example"), deployment))
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Args, "--contour-config-name=contour-example")
+
+	err = c.Get(ctx, namespaced("contour-example"), &core_v1.ConfigMap{})
+	assert.True(t, errors.IsNotFound(err))
+
+	// Changes to the parameters requeue the Gateway.
+	assert.Equal(t, []reconcile.Request{request}, r.mapContourConfigurationToGateways(params))
+
+	// A Gateway whose GatewayClass has invalid parameters is not provisioned.
+	other := &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "other"},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: "contour",
+		},
+	}
+	require.NoError(t, c.Create(ctx, other))
+	require.NoError(t, c.Delete(ctx, params))
+
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: namespaced("other")})
+	require.NoError(t, err)
+
+	err = c.Get(ctx, namespaced("contour-other"), &apps_v1.Deployment{})
+	assert.True(t, errors.IsNotFound(err))
+}
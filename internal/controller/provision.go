@@ -0,0 +1,339 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/provisioner"
+	"github.com/sirupsen/logrus"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	rbac_v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// specHashAnnotation records a hash of the provisioner's desired spec on
+// Deployments, DaemonSets, Services and ContourConfigurations, so that
+// objects are only updated when the desired spec changes rather than
+// whenever the API server has defaulted fields that the provisioner does
+// not set.
+const specHashAnnotation = "projectcontour.io/provisioner-spec-hash"
+
+// ensureFunc creates or updates a provisioned object owned by owner.
+type ensureFunc func(ctx context.Context, owner client.Object) error
+
+// instanceProvisioner creates and updates the objects that make up a
+// provisioned Contour and Envoy.
+type instanceProvisioner struct {
+	client client.Client
+	log    logrus.FieldLogger
+}
+
+// watchOwned configures c to reconcile ownerType when any of the objects
+// provisioned for it change, so that deleted or modified objects are
+// restored.
+func watchOwned(c controller.Controller, ownerType client.Object, extra ...client.Object) error {
+	owned := append([]client.Object{
+		&core_v1.Secret{},
+		&core_v1.Service{},
+		&core_v1.ServiceAccount{},
+		&apps_v1.Deployment{},
+		&apps_v1.DaemonSet{},
+	}, extra...)
+
+	for _, obj := range owned {
+		if err := c.Watch(
+			&source.Kind{Type: obj},
+			&handler.EnqueueRequestForOwner{OwnerType: ownerType, IsController: true},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// provision ensures that the objects that make up instance exist, owned by
+// owner, followed by any extra objects.
+func (p *instanceProvisioner) provision(ctx context.Context, owner client.Object, instance *provisioner.Instance, cfg provisioner.Config, extra ...ensureFunc) error {
+	if err := p.ensureCertificates(ctx, owner, instance); err != nil {
+		return err
+	}
+
+	for _, ensure := range append(extra,
+		p.ensureServiceAccount(provisioner.ContourServiceAccount(instance)),
+		p.ensureServiceAccount(provisioner.EnvoyServiceAccount(instance)),
+		p.ensureClusterRoleBinding(provisioner.ContourClusterRoleBinding(instance)),
+		p.ensureService(provisioner.ContourService(instance)),
+		p.ensureService(provisioner.EnvoyService(instance)),
+		p.ensureDeployment(provisioner.ContourDeployment(instance, cfg)),
+		p.ensureDaemonSet(provisioner.EnvoyDaemonSet(instance, cfg)),
+	) {
+		if err := ensure(ctx, owner); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteClusterRoleBindings deletes the ClusterRoleBindings with the given
+// labels. Namespaced objects are garbage collected through their owner
+// references, but ClusterRoleBindings are cluster scoped so have to be
+// deleted explicitly.
+func (p *instanceProvisioner) deleteClusterRoleBindings(ctx context.Context, labels map[string]string) error {
+	if err := p.client.DeleteAllOf(ctx, &rbac_v1.ClusterRoleBinding{}, client.MatchingLabels(labels)); err != nil {
+		return fmt.Errorf("error deleting cluster role bindings: %w", err)
+	}
+	return nil
+}
+
+// ensureCertificates creates the xDS TLS certificate Secrets for instance if
+// they do not exist. Existing Secrets are never modified, so certificates
+// are not rotated on every reconcile.
+func (p *instanceProvisioner) ensureCertificates(ctx context.Context, owner client.Object, instance *provisioner.Instance) error {
+	var missing bool
+	for _, name := range []string{provisioner.ContourCertName(instance), provisioner.EnvoyCertName(instance)} {
+		err := p.client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: name}, &core_v1.Secret{})
+		switch {
+		case errors.IsNotFound(err):
+			missing = true
+		case err != nil:
+			return fmt.Errorf("error getting secret %s/%s: %w", instance.Namespace, name, err)
+		}
+	}
+
+	if !missing {
+		return nil
+	}
+
+	// The Contour and Envoy certificates must be signed by the
+	// same CA, so regenerate both if either is missing.
+	secrets, err := provisioner.CertificateSecrets(instance)
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		desired := secret
+		if err := p.createOrUpdate(ctx, owner, &core_v1.Secret{ObjectMeta: objectMetaOf(desired)}, func(current client.Object) {
+			s := current.(*core_v1.Secret)
+			s.Labels = desired.Labels
+			s.Type = desired.Type
+			s.Data = desired.Data
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *instanceProvisioner) ensureConfigMap(desired *core_v1.ConfigMap) ensureFunc {
+	return func(ctx context.Context, owner client.Object) error {
+		return p.createOrUpdate(ctx, owner, &core_v1.ConfigMap{ObjectMeta: objectMetaOf(desired)}, func(current client.Object) {
+			cm := current.(*core_v1.ConfigMap)
+			cm.Labels = desired.Labels
+			cm.Data = desired.Data
+		})
+	}
+}
+
+func (p *instanceProvisioner) ensureContourConfiguration(desired *contour_api_v1alpha1.ContourConfiguration) ensureFunc {
+	return func(ctx context.Context, owner client.Object) error {
+		hash, err := specHash(desired.ObjectMeta, desired.Spec)
+		if err != nil {
+			return err
+		}
+
+		return p.createOrUpdate(ctx, owner, &contour_api_v1alpha1.ContourConfiguration{ObjectMeta: objectMetaOf(desired)}, func(current client.Object) {
+			config := current.(*contour_api_v1alpha1.ContourConfiguration)
+			if config.Annotations[specHashAnnotation] == hash {
+				return
+			}
+
+			config.Labels = desired.Labels
+			config.Annotations = withSpecHash(desired.Annotations, hash)
+			config.Spec = desired.Spec
+		})
+	}
+}
+
+func (p *instanceProvisioner) ensureServiceAccount(desired *core_v1.ServiceAccount) ensureFunc {
+	return func(ctx context.Context, owner client.Object) error {
+		return p.createOrUpdate(ctx, owner, &core_v1.ServiceAccount{ObjectMeta: objectMetaOf(desired)}, func(current client.Object) {
+			current.SetLabels(desired.Labels)
+		})
+	}
+}
+
+func (p *instanceProvisioner) ensureClusterRoleBinding(desired *rbac_v1.ClusterRoleBinding) ensureFunc {
+	return func(ctx context.Context, owner client.Object) error {
+		return p.createOrUpdate(ctx, owner, &rbac_v1.ClusterRoleBinding{ObjectMeta: objectMetaOf(desired)}, func(current client.Object) {
+			crb := current.(*rbac_v1.ClusterRoleBinding)
+			crb.Labels = desired.Labels
+			crb.Subjects = desired.Subjects
+			// The role reference of a binding cannot be changed once created.
+			if crb.RoleRef.Name == "" {
+				crb.RoleRef = desired.RoleRef
+			}
+		})
+	}
+}
+
+func (p *instanceProvisioner) ensureService(desired *core_v1.Service) ensureFunc {
+	return func(ctx context.Context, owner client.Object) error {
+		hash, err := specHash(desired.ObjectMeta, desired.Spec)
+		if err != nil {
+			return err
+		}
+
+		return p.createOrUpdate(ctx, owner, &core_v1.Service{ObjectMeta: objectMetaOf(desired)}, func(current client.Object) {
+			svc := current.(*core_v1.Service)
+			if svc.Annotations[specHashAnnotation] == hash {
+				return
+			}
+
+			// Keep the node ports the API server allocated for ports
+			// that are still exposed, so that load balancers pointing
+			// at them keep working.
+			nodePorts := map[int32]int32{}
+			for _, port := range svc.Spec.Ports {
+				nodePorts[port.Port] = port.NodePort
+			}
+
+			svc.Labels = desired.Labels
+			svc.Annotations = withSpecHash(desired.Annotations, hash)
+			svc.Spec.Type = desired.Spec.Type
+			svc.Spec.ExternalTrafficPolicy = desired.Spec.ExternalTrafficPolicy
+			svc.Spec.Selector = desired.Spec.Selector
+			svc.Spec.Ports = nil
+			for _, port := range desired.Spec.Ports {
+				port.NodePort = nodePorts[port.Port]
+				svc.Spec.Ports = append(svc.Spec.Ports, port)
+			}
+		})
+	}
+}
+
+func (p *instanceProvisioner) ensureDeployment(desired *apps_v1.Deployment) ensureFunc {
+	return func(ctx context.Context, owner client.Object) error {
+		hash, err := specHash(desired.ObjectMeta, desired.Spec)
+		if err != nil {
+			return err
+		}
+
+		return p.createOrUpdate(ctx, owner, &apps_v1.Deployment{ObjectMeta: objectMetaOf(desired)}, func(current client.Object) {
+			deployment := current.(*apps_v1.Deployment)
+			if deployment.Annotations[specHashAnnotation] == hash {
+				return
+			}
+
+			deployment.Labels = desired.Labels
+			deployment.Annotations = withSpecHash(desired.Annotations, hash)
+			deployment.Spec = desired.Spec
+		})
+	}
+}
+
+func (p *instanceProvisioner) ensureDaemonSet(desired *apps_v1.DaemonSet) ensureFunc {
+	return func(ctx context.Context, owner client.Object) error {
+		hash, err := specHash(desired.ObjectMeta, desired.Spec)
+		if err != nil {
+			return err
+		}
+
+		return p.createOrUpdate(ctx, owner, &apps_v1.DaemonSet{ObjectMeta: objectMetaOf(desired)}, func(current client.Object) {
+			daemonSet := current.(*apps_v1.DaemonSet)
+			if daemonSet.Annotations[specHashAnnotation] == hash {
+				return
+			}
+
+			daemonSet.Labels = desired.Labels
+			daemonSet.Annotations = withSpecHash(desired.Annotations, hash)
+			daemonSet.Spec = desired.Spec
+		})
+	}
+}
+
+// createOrUpdate creates current if it does not exist, or otherwise updates
+// it after applying mutate. Namespaced objects are owned by owner so that
+// they are garbage collected with it.
+func (p *instanceProvisioner) createOrUpdate(ctx context.Context, owner client.Object, current client.Object, mutate func(client.Object)) error {
+	result, err := controllerutil.CreateOrUpdate(ctx, p.client, current, func() error {
+		mutate(current)
+
+		if current.GetNamespace() == "" {
+			return nil
+		}
+		return controllerutil.SetControllerReference(owner, current, p.client.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("error provisioning %T %s for %s/%s: %w", current, client.ObjectKeyFromObject(current), owner.GetNamespace(), owner.GetName(), err)
+	}
+
+	if result != controllerutil.OperationResultNone {
+		p.log.WithField("namespace", current.GetNamespace()).
+			WithField("name", current.GetName()).
+			WithField("kind", fmt.Sprintf("%T", current)).
+			Infof("provisioned object %s", result)
+	}
+
+	return nil
+}
+
+// objectMetaOf returns the namespace and name of obj, for
+// use as the key of the object to create or update.
+func objectMetaOf(obj metav1.Object) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+// specHash returns a hash of the labels, annotations and spec of a desired object.
+func specHash(meta metav1.ObjectMeta, spec interface{}) (string, error) {
+	data, err := json.Marshal(struct {
+		Labels      map[string]string
+		Annotations map[string]string
+		Spec        interface{}
+	}{meta.Labels, meta.Annotations, spec})
+	if err != nil {
+		return "", fmt.Errorf("error hashing spec of %s/%s: %w", meta.Namespace, meta.Name, err)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return fmt.Sprintf("%x", h.Sum64()), nil
+}
+
+func withSpecHash(annotations map[string]string, hash string) map[string]string {
+	res := map[string]string{specHashAnnotation: hash}
+	for k, v := range annotations {
+		res[k] = v
+	}
+	return res
+}
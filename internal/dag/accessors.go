@@ -33,7 +33,22 @@ func (d *DAG) EnsureService(meta types.NamespacedName, port intstr.IntOrString,
 		return nil, err
 	}
 
-	err = validateExternalName(svc, enableExternalNameSvc)
+	return ensureService(svc, svcPort, enableExternalNameSvc)
+}
+
+// EnsureUDPService is like EnsureService, but matches a UDP port
+// on the Kubernetes service rather than a TCP one.
+func (d *DAG) EnsureUDPService(meta types.NamespacedName, port intstr.IntOrString, cache *KubernetesCache, enableExternalNameSvc bool) (*Service, error) {
+	svc, svcPort, err := cache.LookupUDPService(meta, port)
+	if err != nil {
+		return nil, err
+	}
+
+	return ensureService(svc, svcPort, enableExternalNameSvc)
+}
+
+func ensureService(svc *v1.Service, svcPort v1.ServicePort, enableExternalNameSvc bool) (*Service, error) {
+	err := validateExternalName(svc, enableExternalNameSvc)
 	if err != nil {
 		return nil, err
 	}
@@ -110,16 +125,17 @@ func externalName(svc *v1.Service) string {
 }
 
 // GetSecureVirtualHost returns the secure virtual host in the DAG that
-// matches the provided name, or nil if no matching secure virtual host
-// is found.
-func (d *DAG) GetSecureVirtualHost(hostname string) *SecureVirtualHost {
-	return d.SecureVirtualHosts[hostname]
+// matches the provided listener port and name, or nil if no matching
+// secure virtual host is found.
+func (d *DAG) GetSecureVirtualHost(port int, hostname string) *SecureVirtualHost {
+	return d.SecureVirtualHosts[port][hostname]
 }
 
 // EnsureSecureVirtualHost adds a secure virtual host with the provided
-// name to the DAG if it does not already exist, and returns it.
-func (d *DAG) EnsureSecureVirtualHost(hostname string) *SecureVirtualHost {
-	if svh := d.GetSecureVirtualHost(hostname); svh != nil {
+// listener port and name to the DAG if it does not already exist, and
+// returns it.
+func (d *DAG) EnsureSecureVirtualHost(port int, hostname string) *SecureVirtualHost {
+	if svh := d.GetSecureVirtualHost(port, hostname); svh != nil {
 		return svh
 	}
 
@@ -128,30 +144,61 @@ func (d *DAG) EnsureSecureVirtualHost(hostname string) *SecureVirtualHost {
 			Name: hostname,
 		},
 	}
-	d.SecureVirtualHosts[hostname] = svh
+	if d.SecureVirtualHosts[port] == nil {
+		d.SecureVirtualHosts[port] = map[string]*SecureVirtualHost{}
+	}
+	d.SecureVirtualHosts[port][hostname] = svh
 	return svh
 }
 
 // GetVirtualHost returns the virtual host in the DAG that matches the
-// provided name, or nil if no matching virtual host is found.
-func (d *DAG) GetVirtualHost(hostname string) *VirtualHost {
-	return d.VirtualHosts[hostname]
+// provided listener port and name, or nil if no matching virtual host
+// is found.
+func (d *DAG) GetVirtualHost(port int, hostname string) *VirtualHost {
+	return d.VirtualHosts[port][hostname]
 }
 
-// EnsureVirtualHost adds a virtual host with the provided name to the
-// DAG if it does not already exist, and returns it.
-func (d *DAG) EnsureVirtualHost(hostname string) *VirtualHost {
-	if vhost := d.GetVirtualHost(hostname); vhost != nil {
+// EnsureVirtualHost adds a virtual host with the provided listener port
+// and name to the DAG if it does not already exist, and returns it.
+func (d *DAG) EnsureVirtualHost(port int, hostname string) *VirtualHost {
+	if vhost := d.GetVirtualHost(port, hostname); vhost != nil {
 		return vhost
 	}
 
 	vhost := &VirtualHost{
 		Name: hostname,
 	}
-	d.VirtualHosts[hostname] = vhost
+	if d.VirtualHosts[port] == nil {
+		d.VirtualHosts[port] = map[string]*VirtualHost{}
+	}
+	d.VirtualHosts[port][hostname] = vhost
 	return vhost
 }
 
+// EnsureTCPProxy adds a TCP proxy for the provided listener
+// port to the DAG if it does not already exist, and returns it.
+func (d *DAG) EnsureTCPProxy(port int) *TCPProxy {
+	if proxy, ok := d.TCPProxies[port]; ok {
+		return proxy
+	}
+
+	proxy := &TCPProxy{}
+	d.TCPProxies[port] = proxy
+	return proxy
+}
+
+// EnsureUDPProxy adds a UDP proxy for the provided listener
+// port to the DAG if it does not already exist, and returns it.
+func (d *DAG) EnsureUDPProxy(port int) *UDPProxy {
+	if proxy, ok := d.UDPProxies[port]; ok {
+		return proxy
+	}
+
+	proxy := &UDPProxy{}
+	d.UDPProxies[port] = proxy
+	return proxy
+}
+
 func (d *DAG) GetClusters() []*Cluster {
 	var res []*Cluster
 
@@ -160,8 +207,8 @@ func (d *DAG) GetClusters() []*Cluster {
 			for _, route := range vhost.Routes {
 				res = append(res, route.Clusters...)
 
-				if route.MirrorPolicy != nil && route.MirrorPolicy.Cluster != nil {
-					res = append(res, route.MirrorPolicy.Cluster)
+				for _, mirror := range route.MirrorPolicies {
+					res = append(res, mirror.Cluster)
 				}
 			}
 		}
@@ -170,8 +217,8 @@ func (d *DAG) GetClusters() []*Cluster {
 			for _, route := range vhost.Routes {
 				res = append(res, route.Clusters...)
 
-				if route.MirrorPolicy != nil && route.MirrorPolicy.Cluster != nil {
-					res = append(res, route.MirrorPolicy.Cluster)
+				for _, mirror := range route.MirrorPolicies {
+					res = append(res, mirror.Cluster)
 				}
 			}
 
@@ -179,6 +226,14 @@ func (d *DAG) GetClusters() []*Cluster {
 				res = append(res, vhost.TCPProxy.Clusters...)
 			}
 		}
+
+		if listener.TCPProxy != nil {
+			res = append(res, listener.TCPProxy.Clusters...)
+		}
+
+		if listener.UDPProxy != nil && listener.UDPProxy.Cluster != nil {
+			res = append(res, listener.UDPProxy.Cluster)
+		}
 	}
 
 	return res
@@ -213,6 +268,23 @@ func (d *DAG) GetServiceClusters() []*ServiceCluster {
 	return res
 }
 
+// GetDNSNameClusters returns all DNS name clusters in the DAG.
+func (d *DAG) GetDNSNameClusters() []*DNSNameCluster {
+	var res []*DNSNameCluster
+
+	for _, listener := range d.Listeners {
+		for _, svhost := range listener.SecureVirtualHosts {
+			for _, provider := range svhost.JWTProviders {
+				if provider.RemoteJWKS != nil {
+					res = append(res, &provider.RemoteJWKS.Cluster)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
 // GetExtensionClusters returns all extension clusters in the DAG.
 func (d *DAG) GetExtensionClusters() map[string]*ExtensionCluster {
 	// TODO for DAG consumers, this should iterate
@@ -259,39 +331,3 @@ func (d *DAG) GetExtensionCluster(name string) *ExtensionCluster {
 
 	return nil
 }
-
-func (d *DAG) GetVirtualHostRoutes() map[*VirtualHost][]*Route {
-	res := map[*VirtualHost][]*Route{}
-
-	for _, l := range d.Listeners {
-		for _, vhost := range l.VirtualHosts {
-			var routes []*Route
-			for _, r := range vhost.Routes {
-				routes = append(routes, r)
-			}
-			if len(routes) > 0 {
-				res[vhost] = routes
-			}
-		}
-	}
-
-	return res
-}
-
-func (d *DAG) GetSecureVirtualHostRoutes() map[*SecureVirtualHost][]*Route {
-	res := map[*SecureVirtualHost][]*Route{}
-
-	for _, l := range d.Listeners {
-		for _, vhost := range l.SecureVirtualHosts {
-			var routes []*Route
-			for _, r := range vhost.Routes {
-				routes = append(routes, r)
-			}
-			if len(routes) > 0 {
-				res[vhost] = routes
-			}
-		}
-	}
-
-	return res
-}
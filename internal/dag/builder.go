@@ -14,9 +14,7 @@
 package dag
 
 import (
-	"github.com/projectcontour/contour/internal/k8s"
 	"github.com/projectcontour/contour/internal/status"
-	"k8s.io/apimachinery/pkg/types"
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
@@ -50,19 +48,17 @@ type Builder struct {
 // configured DAG processors, in order.
 func (b *Builder) Build() *DAG {
 
-	gatewayNSName := types.NamespacedName{}
-	if b.Source.gateway != nil {
-		gatewayNSName = k8s.NamespacedNameOf(b.Source.gateway)
-	}
 	var gatewayController gatewayapi_v1alpha2.GatewayController
 	if b.Source.gatewayclass != nil {
 		gatewayController = b.Source.gatewayclass.Spec.ControllerName
 	}
 
 	dag := &DAG{
-		VirtualHosts:       map[string]*VirtualHost{},
-		SecureVirtualHosts: map[string]*SecureVirtualHost{},
-		StatusCache:        status.NewCache(gatewayNSName, gatewayController),
+		VirtualHosts:       map[int]map[string]*VirtualHost{},
+		SecureVirtualHosts: map[int]map[string]*SecureVirtualHost{},
+		TCPProxies:         map[int]*TCPProxy{},
+		UDPProxies:         map[int]*UDPProxy{},
+		StatusCache:        status.NewCache(gatewayController),
 	}
 
 	for _, p := range b.Processors {
@@ -65,6 +65,21 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		},
 	}
 
+	kuardUDPService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kuard-udp",
+			Namespace: "projectcontour",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{
+				Name:       "dns",
+				Protocol:   "UDP",
+				Port:       5353,
+				TargetPort: intstr.FromInt(5353),
+			}},
+		},
+	}
+
 	kuardService3 := &v1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "kuard3",
@@ -274,7 +289,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		Spec: gatewayapi_v1alpha2.GatewaySpec{
 			GatewayClassName: gatewayapi_v1alpha2.ObjectName(validClass.Name),
 			Listeners: []gatewayapi_v1alpha2.Listener{{
-				Port:     80,
+				Port:     443,
 				Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 				TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 					Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModePassthrough),
@@ -288,6 +303,46 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		},
 	}
 
+	gatewayTCPAllNamespaces := &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: gatewayapi_v1alpha2.ObjectName(validClass.Name),
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Name:     "tcp",
+				Port:     5432,
+				Protocol: gatewayapi_v1alpha2.TCPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	}
+
+	gatewayUDPAllNamespaces := &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: gatewayapi_v1alpha2.ObjectName(validClass.Name),
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Name:     "udp",
+				Port:     5353,
+				Protocol: gatewayapi_v1alpha2.UDPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	}
+
 	gatewayTLSPassthroughSameNamespace := &gatewayapi_v1alpha2.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "contour",
@@ -296,7 +351,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		Spec: gatewayapi_v1alpha2.GatewaySpec{
 			GatewayClassName: gatewayapi_v1alpha2.ObjectName(validClass.Name),
 			Listeners: []gatewayapi_v1alpha2.Listener{{
-				Port:     80,
+				Port:     443,
 				Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 				TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 					Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModePassthrough),
@@ -318,7 +373,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		Spec: gatewayapi_v1alpha2.GatewaySpec{
 			GatewayClassName: gatewayapi_v1alpha2.ObjectName(validClass.Name),
 			Listeners: []gatewayapi_v1alpha2.Listener{{
-				Port:     80,
+				Port:     443,
 				Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 				TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 					Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModePassthrough),
@@ -352,11 +407,11 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		Spec: gatewayapi_v1alpha2.GatewaySpec{
 			GatewayClassName: gatewayapi_v1alpha2.ObjectName(validClass.Name),
 			Listeners: []gatewayapi_v1alpha2.Listener{{
-				Port:     80,
+				Port:     443,
 				Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 				TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 					Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-					CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+					CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 						gatewayapi.CertificateRef(sec1.Name, sec1.Namespace),
 					},
 				},
@@ -391,7 +446,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 				TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 					Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-					CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+					CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 						gatewayapi.CertificateRef(sec2.Name, sec2.Namespace),
 					},
 				},
@@ -415,7 +470,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				Port:     443,
 				Protocol: gatewayapi_v1alpha2.HTTPSProtocolType,
 				TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-					CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+					CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 						gatewayapi.CertificateRef(sec1.Name, sec1.Namespace),
 					},
 				},
@@ -451,7 +506,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					Port:     443,
 					Protocol: gatewayapi_v1alpha2.HTTPSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef(sec1.Name, sec1.Namespace),
 						},
 					},
@@ -472,7 +527,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		},
 		Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-				ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 			},
 			Hostnames: []gatewayapi_v1alpha2.Hostname{
 				"test.projectcontour.io",
@@ -491,7 +546,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 		},
 		Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-				ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 			},
 			Hostnames: []gatewayapi_v1alpha2.Hostname{"test.projectcontour.io"},
 			Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -523,6 +578,167 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 			),
 		},
+		"insert basic single route, single hostname, listeners on non-default ports": {
+			gatewayclass: validClass,
+			gateway: &gatewayapi_v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "contour",
+					Namespace: "projectcontour",
+				},
+				Spec: gatewayapi_v1alpha2.GatewaySpec{
+					Listeners: []gatewayapi_v1alpha2.Listener{{
+						Name:     "http-alt",
+						Port:     8081,
+						Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+						AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+							Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+								From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+							},
+						},
+					}, {
+						Name:     "https-alt",
+						Port:     8444,
+						Protocol: gatewayapi_v1alpha2.HTTPSProtocolType,
+						TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
+							CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
+								gatewayapi.CertificateRef(sec1.Name, sec1.Namespace),
+							},
+						},
+						AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+							Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+								From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+							},
+						},
+					}},
+				},
+			},
+			objs: []interface{}{
+				sec1,
+				kuardService,
+				basicHTTPRoute,
+			},
+			want: listeners(
+				&Listener{
+					Name: "ingress_http_8081",
+					Port: 8081,
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io", prefixrouteHTTPRoute("/", service(kuardService))),
+					),
+				},
+				&Listener{
+					Name: "ingress_https_8444",
+					Port: 8444,
+					SecureVirtualHosts: securevirtualhosts(
+						&SecureVirtualHost{
+							VirtualHost: VirtualHost{
+								Name:   "test.projectcontour.io",
+								Routes: routes(prefixrouteHTTPRoute("/", service(kuardService))),
+							},
+							Secret: secret(sec1),
+						},
+					),
+				},
+			),
+		},
+		"insert basic single route, single hostname, multiple HTTP listeners": {
+			gatewayclass: validClass,
+			gateway: &gatewayapi_v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "contour",
+					Namespace: "projectcontour",
+				},
+				Spec: gatewayapi_v1alpha2.GatewaySpec{
+					Listeners: []gatewayapi_v1alpha2.Listener{{
+						Name:     "http",
+						Port:     80,
+						Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+						AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+							Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+								From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+							},
+						},
+					}, {
+						Name:     "http-alt",
+						Port:     8081,
+						Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+						AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+							Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+								From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+							},
+						},
+					}},
+				},
+			},
+			objs: []interface{}{
+				kuardService,
+				basicHTTPRoute,
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io", prefixrouteHTTPRoute("/", service(kuardService))),
+					),
+				},
+				&Listener{
+					Name: "ingress_http_8081",
+					Port: 8081,
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io", prefixrouteHTTPRoute("/", service(kuardService))),
+					),
+				},
+			),
+		},
+		"insert basic single route, listener with a port conflict is not programmed": {
+			gatewayclass: validClass,
+			gateway: &gatewayapi_v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "contour",
+					Namespace: "projectcontour",
+				},
+				Spec: gatewayapi_v1alpha2.GatewaySpec{
+					Listeners: []gatewayapi_v1alpha2.Listener{{
+						Name:     "http",
+						Port:     80,
+						Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+						AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+							Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+								From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+							},
+						},
+					}, {
+						Name:     "https",
+						Port:     80,
+						Protocol: gatewayapi_v1alpha2.HTTPSProtocolType,
+						TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
+							CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
+								gatewayapi.CertificateRef(sec1.Name, sec1.Namespace),
+							},
+						},
+						AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+							Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+								From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+							},
+						},
+					}},
+				},
+			},
+			objs: []interface{}{
+				sec1,
+				kuardService,
+				basicHTTPRoute,
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(
+						virtualhost("test.projectcontour.io", prefixrouteHTTPRoute("/", service(kuardService))),
+					),
+				},
+			),
+		},
 		"gateway with addresses is unsupported": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPWithAddresses,
@@ -569,7 +785,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -604,7 +820,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -647,7 +863,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -674,7 +890,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef("projectcontour", "some-other-gateway"),
 								gatewayapi.GatewayParentRef("projectcontour", "some-other-gateway-2"),
 							},
@@ -705,7 +921,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"test.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -743,7 +959,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"test.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -781,7 +997,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"test.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -810,7 +1026,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"test.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -854,7 +1070,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"test.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -888,7 +1104,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("some-other-namespace", "some-other-gateway-name")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("some-other-namespace", "some-other-gateway-name")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"test.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -910,11 +1126,11 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.GatewaySpec{
 					Listeners: []gatewayapi_v1alpha2.Listener{{
-						Port:     80,
+						Port:     443,
 						Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 						TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 							Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModePassthrough),
-							CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+							CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 								gatewayapi.CertificateRef(sec1.Name, sec1.Namespace),
 							},
 						},
@@ -977,7 +1193,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.GatewaySpec{
 					Listeners: []gatewayapi_v1alpha2.Listener{{
-						Port:     80,
+						Port:     443,
 						Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 						AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
 							Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
@@ -1044,7 +1260,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"another.projectcontour.io",
@@ -1142,7 +1358,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -1180,7 +1396,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -1220,7 +1436,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1251,7 +1467,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"*.projectcontour.io",
@@ -1291,7 +1507,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"192.168.122.1",
@@ -1317,7 +1533,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io:80",
@@ -1343,7 +1559,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"*",
@@ -1370,7 +1586,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1401,7 +1617,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1439,7 +1655,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1478,7 +1694,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1501,13 +1717,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "HTTPRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 						}},
 					},
@@ -1531,7 +1747,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1554,13 +1770,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "HTTPRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 							Name: gatewayapi.ObjectNamePtr(kuardService.Name),
 						}},
@@ -1585,7 +1801,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1608,13 +1824,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "TLSRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 						}},
 					},
@@ -1640,7 +1856,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1663,13 +1879,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: "some-other-namespace", // would need to be "projectcontour" to be valid
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "HTTPRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 						}},
 					},
@@ -1695,7 +1911,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1718,13 +1934,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "HTTPRoute",
 							Namespace: "some-other-namespace", // would need to be "default" to be valid
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 						}},
 					},
@@ -1750,7 +1966,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -1773,13 +1989,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "HTTPRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 							Name: gatewayapi.ObjectNamePtr("some-other-service"), // would need to be "kuard" to be valid.
 						}},
@@ -1806,7 +2022,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -1842,7 +2058,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -1891,10 +2107,10 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.GatewaySpec{
 					Listeners: []gatewayapi_v1alpha2.Listener{{
-						Port:     443,
+						Port:     80,
 						Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
 						TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-							CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+							CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 								gatewayapi.CertificateRef(sec1.Name, sec1.Namespace),
 							},
 						},
@@ -2041,7 +2257,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Port:     443,
 						Protocol: gatewayapi_v1alpha2.HTTPSProtocolType,
 						TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-							CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+							CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 								{
 									Group: gatewayapi.GroupPtr("custom"),
 									Kind:  gatewayapi.KindPtr("shhhh"),
@@ -2103,13 +2319,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "tls-cert-reference-policy",
 						Namespace: sec2.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "Gateway",
 							Namespace: gatewayapi_v1alpha2.Namespace(gatewayTLSTerminateCertInDifferentNamespace.Namespace),
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Secret",
 						}},
 					},
@@ -2155,13 +2371,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "tls-cert-reference-policy",
 						Namespace: sec2.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "Gateway",
 							Namespace: gatewayapi_v1alpha2.Namespace(gatewayTLSTerminateCertInDifferentNamespace.Namespace),
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Secret",
 							Name: gatewayapi.ObjectNamePtr(sec2.Name),
 						}},
@@ -2198,13 +2414,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "tls-cert-reference-policy",
 						Namespace: "wrong-namespace",
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "Gateway",
 							Namespace: gatewayapi_v1alpha2.Namespace(gatewayTLSTerminateCertInDifferentNamespace.Namespace),
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Secret",
 						}},
 					},
@@ -2224,13 +2440,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "tls-cert-reference-policy",
 						Namespace: sec2.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "Gateway",
 							Namespace: gatewayapi_v1alpha2.Namespace("wrong-namespace"),
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Secret",
 						}},
 					},
@@ -2250,13 +2466,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "tls-cert-reference-policy",
 						Namespace: sec2.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "WrongKind",
 							Namespace: gatewayapi_v1alpha2.Namespace(gatewayTLSTerminateCertInDifferentNamespace.Namespace),
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Secret",
 						}},
 					},
@@ -2276,13 +2492,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "tls-cert-reference-policy",
 						Namespace: sec2.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "Gateway",
 							Namespace: gatewayapi_v1alpha2.Namespace(gatewayTLSTerminateCertInDifferentNamespace.Namespace),
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "WrongKind",
 						}},
 					},
@@ -2302,13 +2518,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "tls-cert-reference-policy",
 						Namespace: sec2.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "Gateway",
 							Namespace: gatewayapi_v1alpha2.Namespace(gatewayTLSTerminateCertInDifferentNamespace.Namespace),
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Secret",
 							Name: gatewayapi.ObjectNamePtr("wrong-name"),
 						}},
@@ -2333,7 +2549,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"*.*.projectcontour.io",
@@ -2427,7 +2643,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								{
 									Group:       gatewayapi.GroupPtr(gatewayapi_v1alpha2.GroupName),
 									Kind:        gatewayapi.KindPtr("Gateway"),
@@ -2454,7 +2670,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								{
 									Group:       gatewayapi.GroupPtr(gatewayapi_v1alpha2.GroupName),
 									Kind:        gatewayapi.KindPtr("Gateway"),
@@ -2509,7 +2725,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -2520,9 +2736,556 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 									Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
 									Value: pointer.StringPtr("/"),
 								},
-								Headers: gatewayapi.HTTPHeaderMatch(gatewayapi_v1alpha2.HeaderMatchExact, "foo", "bar"),
+								Headers: gatewayapi.HTTPHeaderMatch(gatewayapi_v1alpha2.HeaderMatchExact, "foo", "bar"),
+							}},
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: prefixString("/"),
+							HeaderMatchConditions: []HeaderMatchCondition{
+								{Name: "foo", Value: "bar", MatchType: "exact", Invert: false},
+							},
+							Clusters: clustersWeight(service(kuardService)),
+						}),
+					),
+				},
+			),
+		},
+		"insert basic single route with regex header match and regex path match": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
+								Path: &gatewayapi_v1alpha2.HTTPPathMatch{
+									Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchRegularExpression),
+									Value: pointer.StringPtr("/v[0-9]+/.*"),
+								},
+								Headers: gatewayapi.HTTPHeaderMatch(gatewayapi_v1alpha2.HeaderMatchRegularExpression, "foo", "ba[rz]"),
+							}},
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: regex("/v[0-9]+/.*"),
+							HeaderMatchConditions: []HeaderMatchCondition{
+								{Name: "foo", Value: "ba[rz]", MatchType: "regex", Invert: false},
+							},
+							Clusters: clustersWeight(service(kuardService)),
+						}),
+					),
+				},
+			),
+		},
+		"insert basic single route with invalid regex path match": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchRegularExpression, "/v[0-9+/.*"),
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						}},
+					},
+				},
+			},
+			want: listeners(),
+		},
+		"insert basic single route with query param matches and path match": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
+								Path: &gatewayapi_v1alpha2.HTTPPathMatch{
+									Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
+									Value: pointer.StringPtr("/"),
+								},
+								QueryParams: append(
+									gatewayapi.HTTPQueryParamMatch(gatewayapi_v1alpha2.QueryParamMatchExact, "foo", "bar"),
+									gatewayapi.HTTPQueryParamMatch(gatewayapi_v1alpha2.QueryParamMatchRegularExpression, "baz", "[0-9]+")...,
+								),
+							}},
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: prefixString("/"),
+							QueryParamMatchConditions: []QueryParamMatchCondition{
+								{Name: "foo", Value: "bar", MatchType: QueryParamMatchTypeExact},
+								{Name: "baz", Value: "[0-9]+", MatchType: QueryParamMatchTypeRegex},
+							},
+							Clusters: clustersWeight(service(kuardService)),
+						}),
+					),
+				},
+			),
+		},
+		"insert two routes with single header match, path match and header match": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{
+							{
+								Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{
+									{
+										Path: &gatewayapi_v1alpha2.HTTPPathMatch{
+											Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
+											Value: pointer.StringPtr("/blog"),
+										},
+									}, {
+										Path: &gatewayapi_v1alpha2.HTTPPathMatch{
+											Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
+											Value: pointer.StringPtr("/tech"),
+										},
+										Headers: gatewayapi.HTTPHeaderMatch(gatewayapi_v1alpha2.HeaderMatchExact, "foo", "bar"),
+									},
+								},
+								BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+							}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: prefixSegment("/blog"),
+							Clusters:           clustersWeight(service(kuardService)),
+						},
+						&Route{
+							PathMatchCondition: prefixSegment("/tech"),
+							HeaderMatchConditions: []HeaderMatchCondition{
+								{Name: "foo", Value: "bar", MatchType: "exact", Invert: false},
+							},
+							Clusters: clustersWeight(service(kuardService)),
+						},
+					)),
+				},
+			),
+		},
+		"insert two routes with single header match without explicit path match": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
+								Headers: gatewayapi.HTTPHeaderMatch(gatewayapi_v1alpha2.HeaderMatchExact, "foo", "bar"),
+							}},
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: prefixString("/"),
+							HeaderMatchConditions: []HeaderMatchCondition{
+								{Name: "foo", Value: "bar", MatchType: "exact", Invert: false},
+							},
+							Clusters: clustersWeight(service(kuardService)),
+						},
+					)),
+				},
+			),
+		},
+		"route with HTTP method match": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
+								Path: &gatewayapi_v1alpha2.HTTPPathMatch{
+									Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
+									Value: pointer.StringPtr("/"),
+								},
+								Method: gatewayapi.HTTPMethodPtr(gatewayapi_v1alpha2.HTTPMethodGet),
+							}},
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: prefixString("/"),
+							HeaderMatchConditions: []HeaderMatchCondition{
+								{Name: ":method", Value: "GET", MatchType: "exact"},
+							},
+							Clusters: clustersWeight(service(kuardService)),
+						}),
+					),
+				},
+			),
+		},
+		"Route rule with request header modifier": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+							Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+								Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestHeaderModifier,
+								RequestHeaderModifier: &gatewayapi_v1alpha2.HTTPRequestHeaderFilter{
+									Set: []gatewayapi_v1alpha2.HTTPHeader{
+										{Name: gatewayapi_v1alpha2.HTTPHeaderName("custom-header-set"), Value: "foo-bar"},
+										{Name: gatewayapi_v1alpha2.HTTPHeaderName("Host"), Value: "bar.com"},
+									},
+									Add: []gatewayapi_v1alpha2.HTTPHeader{
+										{Name: "custom-header-add", Value: "foo-bar"},
+									},
+								},
+							}},
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: prefixString("/"),
+							Clusters:           clustersWeight(service(kuardService)),
+							RequestHeadersPolicy: &HeadersPolicy{
+								Set: map[string]string{
+									"Custom-Header-Set": "foo-bar", // Verify the header key is canonicalized.
+								},
+								Add: map[string]string{
+									"Custom-Header-Add": "foo-bar", // Verify the header key is canonicalized.
+								},
+								HostRewrite: "bar.com",
+							},
+						},
+					)),
+				},
+			),
+		},
+		"Route rule with request mirror": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				kuardService2,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+							Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+								Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+								RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+									BackendRef: gatewayapi.ServiceBackendObjectRef("kuard2", 8080),
+								},
+							}},
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						withMirror(&Route{
+							PathMatchCondition: prefixString("/"),
+							Clusters:           clustersWeight(service(kuardService)),
+						}, service(kuardService2)),
+					)),
+				},
+			),
+		},
+		"Route rule with request mirror on its only backendRef": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				kuardService2,
+				kuardService3,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+							BackendRefs: []gatewayapi_v1alpha2.HTTPBackendRef{{
+								BackendRef: gatewayapi_v1alpha2.BackendRef{
+									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard", 8080),
+									Weight:                 pointer.Int32(1),
+								},
+								Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+									Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+									RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+										BackendRef: gatewayapi.ServiceBackendObjectRef("kuard2", 8080),
+									},
+								}},
+							}},
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: prefixString("/"),
+							Clusters:           clustersWeight(service(kuardService)),
+							MirrorPolicies: []*MirrorPolicy{{
+								Cluster:     &Cluster{Upstream: service(kuardService2)},
+								Weight:      1,
+								TotalWeight: 1,
+							}},
+						},
+					)),
+				},
+			),
+		},
+		"Route rule with request mirrors on the rule and on one of several backendRefs": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				kuardService2,
+				kuardService3,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+							Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+								Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+								RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+									BackendRef: gatewayapi.ServiceBackendObjectRef("kuard3", 8080),
+								},
+							}},
+							BackendRefs: []gatewayapi_v1alpha2.HTTPBackendRef{{
+								BackendRef: gatewayapi_v1alpha2.BackendRef{
+									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard", 8080),
+									Weight:                 pointer.Int32(1),
+								},
+								Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+									Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+									RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+										BackendRef: gatewayapi.ServiceBackendObjectRef("kuard2", 8080),
+									},
+								}},
+							}, {
+								BackendRef: gatewayapi_v1alpha2.BackendRef{
+									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard3", 8080),
+									Weight:                 pointer.Int32(3),
+								},
+							}},
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: HTTP_LISTENER_NAME,
+					Port: 80,
+					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
+						&Route{
+							PathMatchCondition: prefixString("/"),
+							Clusters: clustersWeight(
+								weightedService(kuardService, 1),
+								weightedService(kuardService3, 3),
+							),
+							MirrorPolicies: []*MirrorPolicy{{
+								Cluster: &Cluster{Upstream: service(kuardService3)},
+							}, {
+								Cluster:     &Cluster{Upstream: service(kuardService2)},
+								Weight:      1,
+								TotalWeight: 4,
+							}},
+						},
+					)),
+				},
+			),
+		},
+		"Route rule with URL rewrite of the hostname and path prefix": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				kuardService2,
+				&gatewayapi_v1alpha2.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Hostnames: []gatewayapi_v1alpha2.Hostname{
+							"test.projectcontour.io",
+						},
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/foo"),
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+							Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+								Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+								URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+									Hostname: gatewayapi.PreciseHostname("bar.com"),
+									Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+										Type:               gatewayapi_v1alpha2.PrefixMatchHTTPPathModifier,
+										ReplacePrefixMatch: pointer.String("/bar"),
+									},
+								},
 							}},
-							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
 						}},
 					},
 				},
@@ -2533,21 +3296,26 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					Port: 80,
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
 						&Route{
-							PathMatchCondition: prefixString("/"),
-							HeaderMatchConditions: []HeaderMatchCondition{
-								{Name: "foo", Value: "bar", MatchType: "exact", Invert: false},
+							PathMatchCondition: prefixSegment("/foo"),
+							Clusters:           clustersWeight(service(kuardService)),
+							RequestHeadersPolicy: &HeadersPolicy{
+								HostRewrite: "bar.com",
 							},
-							Clusters: clustersWeight(service(kuardService)),
-						}),
-					),
+							RegexRewrite: &RegexRewrite{
+								Pattern:      "^/foo",
+								Substitution: "/bar",
+							},
+						},
+					)),
 				},
 			),
 		},
-		"insert two routes with single header match, path match and header match": {
+		"Route rule with URL rewrite of the full path": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			objs: []interface{}{
 				kuardService,
+				kuardService2,
 				&gatewayapi_v1alpha2.HTTPRoute{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "basic",
@@ -2555,29 +3323,24 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
 						},
-						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{
-							{
-								Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{
-									{
-										Path: &gatewayapi_v1alpha2.HTTPPathMatch{
-											Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
-											Value: pointer.StringPtr("/blog"),
-										},
-									}, {
-										Path: &gatewayapi_v1alpha2.HTTPPathMatch{
-											Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
-											Value: pointer.StringPtr("/tech"),
-										},
-										Headers: gatewayapi.HTTPHeaderMatch(gatewayapi_v1alpha2.HeaderMatchExact, "foo", "bar"),
+						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchExact, "/foo"),
+							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+							Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+								Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+								URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+									Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+										Type:            gatewayapi_v1alpha2.FullPathHTTPPathModifier,
+										ReplaceFullPath: pointer.String("/bar"),
 									},
 								},
-								BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
 							}},
+						}},
 					},
 				},
 			},
@@ -2587,25 +3350,23 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					Port: 80,
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
 						&Route{
-							PathMatchCondition: prefixSegment("/blog"),
+							PathMatchCondition: exact("/foo"),
 							Clusters:           clustersWeight(service(kuardService)),
-						},
-						&Route{
-							PathMatchCondition: prefixSegment("/tech"),
-							HeaderMatchConditions: []HeaderMatchCondition{
-								{Name: "foo", Value: "bar", MatchType: "exact", Invert: false},
+							RegexRewrite: &RegexRewrite{
+								Pattern:      "^/.*$",
+								Substitution: "/bar",
 							},
-							Clusters: clustersWeight(service(kuardService)),
 						},
 					)),
 				},
 			),
 		},
-		"insert two routes with single header match without explicit path match": {
+		"Route rule with URL rewrite of the hostname on one of several backendRefs": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			objs: []interface{}{
 				kuardService,
+				kuardService2,
 				&gatewayapi_v1alpha2.HTTPRoute{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "basic",
@@ -2613,16 +3374,30 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
-							Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
-								Headers: gatewayapi.HTTPHeaderMatch(gatewayapi_v1alpha2.HeaderMatchExact, "foo", "bar"),
+							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+							BackendRefs: []gatewayapi_v1alpha2.HTTPBackendRef{{
+								BackendRef: gatewayapi_v1alpha2.BackendRef{
+									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard", 8080),
+									Weight:                 pointer.Int32(1),
+								},
+								Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+									Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+									URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+										Hostname: gatewayapi.PreciseHostname("bar.com"),
+									},
+								}},
+							}, {
+								BackendRef: gatewayapi_v1alpha2.BackendRef{
+									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard2", 8080),
+									Weight:                 pointer.Int32(1),
+								},
 							}},
-							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
 						}},
 					},
 				},
@@ -2634,20 +3409,27 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
 						&Route{
 							PathMatchCondition: prefixString("/"),
-							HeaderMatchConditions: []HeaderMatchCondition{
-								{Name: "foo", Value: "bar", MatchType: "exact", Invert: false},
-							},
-							Clusters: clustersWeight(service(kuardService)),
+							Clusters: []*Cluster{{
+								Upstream: service(kuardService),
+								Weight:   1,
+								RequestHeadersPolicy: &HeadersPolicy{
+									HostRewrite: "bar.com",
+								},
+							}, {
+								Upstream: service(kuardService2),
+								Weight:   1,
+							}},
 						},
 					)),
 				},
 			),
 		},
-		"route with HTTP method match": {
+		"Route rule with URL rewrite of the path on its only backendRef": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			objs: []interface{}{
 				kuardService,
+				kuardService2,
 				&gatewayapi_v1alpha2.HTTPRoute{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "basic",
@@ -2655,20 +3437,28 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
-							Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
-								Path: &gatewayapi_v1alpha2.HTTPPathMatch{
-									Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
-									Value: pointer.StringPtr("/"),
+							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/foo"),
+							BackendRefs: []gatewayapi_v1alpha2.HTTPBackendRef{{
+								BackendRef: gatewayapi_v1alpha2.BackendRef{
+									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard", 8080),
+									Weight:                 pointer.Int32(1),
 								},
-								Method: gatewayapi.HTTPMethodPtr(gatewayapi_v1alpha2.HTTPMethodGet),
+								Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+									Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+									URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+										Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+											Type:               gatewayapi_v1alpha2.PrefixMatchHTTPPathModifier,
+											ReplacePrefixMatch: pointer.String("/"),
+										},
+									},
+								}},
 							}},
-							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
 						}},
 					},
 				},
@@ -2679,17 +3469,18 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					Port: 80,
 					VirtualHosts: virtualhosts(virtualhost("test.projectcontour.io",
 						&Route{
-							PathMatchCondition: prefixString("/"),
-							HeaderMatchConditions: []HeaderMatchCondition{
-								{Name: ":method", Value: "GET", MatchType: "exact"},
+							PathMatchCondition: prefixSegment("/foo"),
+							Clusters:           clustersWeight(service(kuardService)),
+							RegexRewrite: &RegexRewrite{
+								Pattern:      "^/foo/*",
+								Substitution: "/",
 							},
-							Clusters: clustersWeight(service(kuardService)),
-						}),
-					),
+						},
+					)),
 				},
 			),
 		},
-		"Route rule with request header modifier": {
+		"Route rule with request mirror and an invalid mirror backend": {
 			gatewayclass: validClass,
 			gateway:      gatewayHTTPAllNamespaces,
 			objs: []interface{}{
@@ -2701,7 +3492,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -2710,15 +3501,9 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
 							BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
 							Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
-								Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestHeaderModifier,
-								RequestHeaderModifier: &gatewayapi_v1alpha2.HTTPRequestHeaderFilter{
-									Set: []gatewayapi_v1alpha2.HTTPHeader{
-										{Name: gatewayapi_v1alpha2.HTTPHeaderName("custom-header-set"), Value: "foo-bar"},
-										{Name: gatewayapi_v1alpha2.HTTPHeaderName("Host"), Value: "bar.com"},
-									},
-									Add: []gatewayapi_v1alpha2.HTTPHeader{
-										{Name: "custom-header-add", Value: "foo-bar"},
-									},
+								Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+								RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+									BackendRef: gatewayapi.ServiceBackendObjectRef("nonexistent", 8080),
 								},
 							}},
 						}},
@@ -2733,15 +3518,6 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						&Route{
 							PathMatchCondition: prefixString("/"),
 							Clusters:           clustersWeight(service(kuardService)),
-							RequestHeadersPolicy: &HeadersPolicy{
-								Set: map[string]string{
-									"Custom-Header-Set": "foo-bar", // Verify the header key is canonicalized.
-								},
-								Add: map[string]string{
-									"Custom-Header-Add": "foo-bar", // Verify the header key is canonicalized.
-								},
-								HostRewrite: "bar.com",
-							},
 						},
 					)),
 				},
@@ -2759,7 +3535,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -2815,7 +3591,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -2870,7 +3646,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -2927,7 +3703,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -2977,7 +3753,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"test.projectcontour.io",
@@ -3041,7 +3817,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -3103,7 +3879,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -3165,7 +3941,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
@@ -3191,6 +3967,226 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 			),
 		},
+		"basic TCPRoute": {
+			gatewayclass: validClass,
+			gateway:      gatewayTCPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.TCPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.TCPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.TCPRouteRule{{
+							BackendRefs: gatewayapi.TCPRouteBackendRef("kuard", 8080, nil),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: "ingress_tcp_5432",
+					Port: 5432,
+					TCPProxy: &TCPProxy{
+						Clusters: clustersWeight(service(kuardService)),
+					},
+				},
+			),
+		},
+		"only the first TCPRoute on a listener is attached": {
+			gatewayclass: validClass,
+			gateway:      gatewayTCPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				kuardService2,
+				&gatewayapi_v1alpha2.TCPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.TCPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.TCPRouteRule{{
+							BackendRefs: gatewayapi.TCPRouteBackendRef("kuard", 8080, nil),
+						}},
+					},
+				},
+				&gatewayapi_v1alpha2.TCPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.TCPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.TCPRouteRule{{
+							BackendRefs: gatewayapi.TCPRouteBackendRef("kuard2", 8080, nil),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: "ingress_tcp_5432",
+					Port: 5432,
+					TCPProxy: &TCPProxy{
+						Clusters: clustersWeight(service(kuardService)),
+					},
+				},
+			),
+		},
+		"TCPRoute with invalid listener protocol of HTTP": {
+			gatewayclass: validClass,
+			gateway:      gatewayHTTPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.TCPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.TCPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.TCPRouteRule{{
+							BackendRefs: gatewayapi.TCPRouteBackendRef("kuard", 8080, nil),
+						}},
+					},
+				},
+			},
+			want: listeners(),
+		},
+		"basic UDPRoute": {
+			gatewayclass: validClass,
+			gateway:      gatewayUDPAllNamespaces,
+			objs: []interface{}{
+				kuardUDPService,
+				&gatewayapi_v1alpha2.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+							BackendRefs: gatewayapi.UDPRouteBackendRef("kuard-udp", 5353, nil),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: "ingress_udp_5353",
+					Port: 5353,
+					UDPProxy: &UDPProxy{
+						Cluster: &Cluster{
+							Upstream: service(kuardUDPService),
+						},
+					},
+				},
+			),
+		},
+		"only the first UDPRoute on a listener is attached": {
+			gatewayclass: validClass,
+			gateway:      gatewayUDPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				kuardUDPService,
+				&gatewayapi_v1alpha2.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+							BackendRefs: gatewayapi.UDPRouteBackendRef("kuard-udp", 5353, nil),
+						}},
+					},
+				},
+				&gatewayapi_v1alpha2.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+							BackendRefs: gatewayapi.UDPRouteBackendRef("kuard", 8080, nil),
+						}},
+					},
+				},
+			},
+			want: listeners(
+				&Listener{
+					Name: "ingress_udp_5353",
+					Port: 5353,
+					UDPProxy: &UDPProxy{
+						Cluster: &Cluster{
+							Upstream: service(kuardUDPService),
+						},
+					},
+				},
+			),
+		},
+		"UDPRoute with a TCP service port": {
+			gatewayclass: validClass,
+			gateway:      gatewayUDPAllNamespaces,
+			objs: []interface{}{
+				kuardService,
+				&gatewayapi_v1alpha2.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+							BackendRefs: gatewayapi.UDPRouteBackendRef("kuard", 8080, nil),
+						}},
+					},
+				},
+			},
+			want: listeners(),
+		},
+		"UDPRoute with invalid listener protocol of TCP": {
+			gatewayclass: validClass,
+			gateway:      gatewayTCPAllNamespaces,
+			objs: []interface{}{
+				kuardUDPService,
+				&gatewayapi_v1alpha2.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic",
+						Namespace: "projectcontour",
+					},
+					Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						},
+						Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+							BackendRefs: gatewayapi.UDPRouteBackendRef("kuard-udp", 5353, nil),
+						}},
+					},
+				},
+			},
+			want: listeners(),
+		},
 		"basic TLSRoute": {
 			gatewayclass: validClass,
 			gateway:      gatewayTLSPassthroughAllNamespaces,
@@ -3203,7 +4199,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3241,7 +4237,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3264,7 +4260,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3287,13 +4283,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "TLSRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 						}},
 					},
@@ -3328,7 +4324,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3351,13 +4347,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "TLSRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 							Name: gatewayapi.ObjectNamePtr(kuardService.Name),
 						}},
@@ -3393,7 +4389,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3416,13 +4412,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "HTTPRoute", // would need to be TLSRoute to be valid
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 						}},
 					},
@@ -3442,7 +4438,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3465,13 +4461,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: "some-other-namespace", // would have to be "projectcontour" to be valid
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "TLSRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 						}},
 					},
@@ -3491,7 +4487,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3514,13 +4510,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "TLSRoute",
 							Namespace: "some-other-namespace", // would have to be "default" to be valid
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 						}},
 					},
@@ -3540,7 +4536,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3563,13 +4559,13 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 						Name:      "foo",
 						Namespace: kuardService.Namespace,
 					},
-					Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-						From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+					Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+						From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 							Group:     gatewayapi_v1alpha2.GroupName,
 							Kind:      "TLSRoute",
 							Namespace: "default",
 						}},
-						To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+						To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 							Kind: "Service",
 							Name: gatewayapi.ObjectNamePtr("some-other-service"), // would have to be "kuard" to be valid
 						}},
@@ -3590,7 +4586,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"tcp.projectcontour.io",
@@ -3648,7 +4644,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"tcp.projectcontour.io",
@@ -3698,7 +4694,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"tcp.*.projectcontour.io",
@@ -3725,7 +4721,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
 							BackendRefs: gatewayapi.TLSRouteBackendRef("kuard", 8080, nil),
@@ -3761,7 +4757,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3786,7 +4782,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3835,7 +4831,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3884,7 +4880,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{"tcp.projectcontour.io"},
 						Rules: []gatewayapi_v1alpha2.TLSRouteRule{{
@@ -3931,7 +4927,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
 							Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchExact, "/blog"),
@@ -3963,7 +4959,7 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 						},
 						Hostnames: []gatewayapi_v1alpha2.Hostname{
 							"http.projectcontour.io",
@@ -3994,7 +4990,6 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 			builder := Builder{
 				Source: KubernetesCache{
 					gatewayclass: tc.gatewayclass,
-					gateway:      tc.gateway,
 					FieldLogger:  fixture.NewTestLogger(t),
 				},
 				Processors: []Processor{
@@ -4005,6 +5000,10 @@ func TestDAGInsertGatewayAPI(t *testing.T) {
 				},
 			}
 
+			if tc.gateway != nil {
+				builder.Source.Insert(tc.gateway)
+			}
+
 			for _, o := range tc.objs {
 				builder.Source.Insert(o)
 			}
@@ -11597,7 +12596,7 @@ func TestDAGRootNamespaces(t *testing.T) {
 			}
 			dag := builder.Build()
 
-			if count := len(dag.VirtualHosts); tc.want != count {
+			if count := len(dag.VirtualHosts[HTTP_LISTENER_PORT]); tc.want != count {
 				t.Errorf("wanted %d vertices, but got %d", tc.want, count)
 			}
 		})
@@ -12511,10 +13510,10 @@ func exact(path string) MatchCondition  { return &ExactMatchCondition{Path: path
 func regex(regex string) MatchCondition { return &RegexMatchCondition{Regex: regex} }
 
 func withMirror(r *Route, mirror *Service) *Route {
-	r.MirrorPolicy = &MirrorPolicy{
+	r.MirrorPolicies = append(r.MirrorPolicies, &MirrorPolicy{
 		Cluster: &Cluster{
 			Upstream: mirror,
 		},
-	}
+	})
 	return r
 }
@@ -14,6 +14,7 @@
 package dag
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -57,9 +58,11 @@ type KubernetesCache struct {
 	services                  map[types.NamespacedName]*v1.Service
 	namespaces                map[string]*v1.Namespace
 	gatewayclass              *gatewayapi_v1alpha2.GatewayClass
-	gateway                   *gatewayapi_v1alpha2.Gateway
+	gateways                  map[types.NamespacedName]*gatewayapi_v1alpha2.Gateway
 	httproutes                map[types.NamespacedName]*gatewayapi_v1alpha2.HTTPRoute
 	tlsroutes                 map[types.NamespacedName]*gatewayapi_v1alpha2.TLSRoute
+	tcproutes                 map[types.NamespacedName]*gatewayapi_v1alpha2.TCPRoute
+	udproutes                 map[types.NamespacedName]*gatewayapi_v1alpha2.UDPRoute
 	referencepolicies         map[types.NamespacedName]*gatewayapi_v1alpha2.ReferencePolicy
 	extensions                map[types.NamespacedName]*contour_api_v1alpha1.ExtensionService
 
@@ -76,9 +79,12 @@ func (kc *KubernetesCache) init() {
 	kc.tlscertificatedelegations = make(map[types.NamespacedName]*contour_api_v1.TLSCertificateDelegation)
 	kc.services = make(map[types.NamespacedName]*v1.Service)
 	kc.namespaces = make(map[string]*v1.Namespace)
+	kc.gateways = make(map[types.NamespacedName]*gatewayapi_v1alpha2.Gateway)
 	kc.httproutes = make(map[types.NamespacedName]*gatewayapi_v1alpha2.HTTPRoute)
 	kc.referencepolicies = make(map[types.NamespacedName]*gatewayapi_v1alpha2.ReferencePolicy)
 	kc.tlsroutes = make(map[types.NamespacedName]*gatewayapi_v1alpha2.TLSRoute)
+	kc.tcproutes = make(map[types.NamespacedName]*gatewayapi_v1alpha2.TCPRoute)
+	kc.udproutes = make(map[types.NamespacedName]*gatewayapi_v1alpha2.UDPRoute)
 	kc.extensions = make(map[types.NamespacedName]*contour_api_v1alpha1.ExtensionService)
 }
 
@@ -170,7 +176,7 @@ func (kc *KubernetesCache) Insert(obj interface{}) bool {
 			kc.gatewayclass = obj
 			return true
 		case *gatewayapi_v1alpha2.Gateway:
-			kc.gateway = obj
+			kc.gateways[k8s.NamespacedNameOf(obj)] = obj
 			return true
 		case *gatewayapi_v1alpha2.HTTPRoute:
 			kc.httproutes[k8s.NamespacedNameOf(obj)] = obj
@@ -178,6 +184,12 @@ func (kc *KubernetesCache) Insert(obj interface{}) bool {
 		case *gatewayapi_v1alpha2.TLSRoute:
 			kc.tlsroutes[k8s.NamespacedNameOf(obj)] = obj
 			return true
+		case *gatewayapi_v1alpha2.TCPRoute:
+			kc.tcproutes[k8s.NamespacedNameOf(obj)] = obj
+			return true
+		case *gatewayapi_v1alpha2.UDPRoute:
+			kc.udproutes[k8s.NamespacedNameOf(obj)] = obj
+			return true
 		case *gatewayapi_v1alpha2.ReferencePolicy:
 			kc.referencepolicies[k8s.NamespacedNameOf(obj)] = obj
 			return true
@@ -280,8 +292,10 @@ func (kc *KubernetesCache) remove(obj interface{}) bool {
 		kc.gatewayclass = nil
 		return true
 	case *gatewayapi_v1alpha2.Gateway:
-		kc.gateway = nil
-		return true
+		m := k8s.NamespacedNameOf(obj)
+		_, ok := kc.gateways[m]
+		delete(kc.gateways, m)
+		return ok
 	case *gatewayapi_v1alpha2.HTTPRoute:
 		m := k8s.NamespacedNameOf(obj)
 		_, ok := kc.httproutes[m]
@@ -292,6 +306,16 @@ func (kc *KubernetesCache) remove(obj interface{}) bool {
 		_, ok := kc.tlsroutes[m]
 		delete(kc.tlsroutes, m)
 		return ok
+	case *gatewayapi_v1alpha2.TCPRoute:
+		m := k8s.NamespacedNameOf(obj)
+		_, ok := kc.tcproutes[m]
+		delete(kc.tcproutes, m)
+		return ok
+	case *gatewayapi_v1alpha2.UDPRoute:
+		m := k8s.NamespacedNameOf(obj)
+		_, ok := kc.udproutes[m]
+		delete(kc.udproutes, m)
+		return ok
 	case *gatewayapi_v1alpha2.ReferencePolicy:
 		m := k8s.NamespacedNameOf(obj)
 		_, ok := kc.referencepolicies[m]
@@ -390,6 +414,12 @@ func (kc *KubernetesCache) secretTriggersRebuild(secret *v1.Secret) bool {
 		return true
 	}
 
+	if _, isJWKS := secret.Data[JWKSKey]; isJWKS {
+		// Similarly, assume that any change to a JWKS secret
+		// will trigger a rebuild.
+		return true
+	}
+
 	delegations := make(map[string]bool) // targetnamespace/secretname to bool
 
 	// TODO(youngnick): Check if this is required.
@@ -460,14 +490,14 @@ func (kc *KubernetesCache) secretTriggersRebuild(secret *v1.Secret) bool {
 		}
 	}
 
-	if kc.gateway != nil {
-		for _, listener := range kc.gateway.Spec.Listeners {
+	for _, gateway := range kc.gateways {
+		for _, listener := range gateway.Spec.Listeners {
 			if listener.TLS == nil {
 				continue
 			}
 
 			for _, certificateRef := range listener.TLS.CertificateRefs {
-				if isRefToSecret(*certificateRef, secret, kc.gateway.Namespace) {
+				if isRefToSecret(certificateRef, secret, gateway.Namespace) {
 					return true
 				}
 			}
@@ -569,9 +599,32 @@ func validCA(s *v1.Secret) error {
 	return nil
 }
 
-// LookupService returns the Kubernetes service and port matching the provided parameters,
+func validJWKS(s *v1.Secret) error {
+	data := s.Data[JWKSKey]
+	if len(data) == 0 {
+		return fmt.Errorf("empty %q key", JWKSKey)
+	}
+
+	if !json.Valid(data) {
+		return fmt.Errorf("%q key is not valid JSON", JWKSKey)
+	}
+
+	return nil
+}
+
+// LookupService returns the Kubernetes service and TCP port matching the provided parameters,
 // or an error if a match can't be found.
 func (kc *KubernetesCache) LookupService(meta types.NamespacedName, port intstr.IntOrString) (*v1.Service, v1.ServicePort, error) {
+	return kc.lookupService(meta, port, v1.ProtocolTCP)
+}
+
+// LookupUDPService returns the Kubernetes service and UDP port matching the provided parameters,
+// or an error if a match can't be found.
+func (kc *KubernetesCache) LookupUDPService(meta types.NamespacedName, port intstr.IntOrString) (*v1.Service, v1.ServicePort, error) {
+	return kc.lookupService(meta, port, v1.ProtocolUDP)
+}
+
+func (kc *KubernetesCache) lookupService(meta types.NamespacedName, port intstr.IntOrString, protocol v1.Protocol) (*v1.Service, v1.ServicePort, error) {
 	svc, ok := kc.services[meta]
 	if !ok {
 		return nil, v1.ServicePort{}, fmt.Errorf("service %q not found", meta)
@@ -580,12 +633,15 @@ func (kc *KubernetesCache) LookupService(meta types.NamespacedName, port intstr.
 	for i := range svc.Spec.Ports {
 		p := svc.Spec.Ports[i]
 		if int(p.Port) == port.IntValue() || port.String() == p.Name {
-			switch p.Protocol {
-			case "", v1.ProtocolTCP:
-				return svc, p, nil
-			default:
-				return nil, v1.ServicePort{}, fmt.Errorf("unsupported service protocol %q", p.Protocol)
+			// An unset protocol defaults to TCP.
+			portProtocol := p.Protocol
+			if portProtocol == "" {
+				portProtocol = v1.ProtocolTCP
+			}
+			if portProtocol != protocol {
+				return nil, v1.ServicePort{}, fmt.Errorf("unsupported service protocol %q", portProtocol)
 			}
+			return svc, p, nil
 		}
 	}
 
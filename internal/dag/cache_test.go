@@ -886,6 +886,24 @@ func TestKubernetesCacheInsert(t *testing.T) {
 			},
 			want: true,
 		},
+		"insert gateway-api TCPRoute": {
+			obj: &gatewayapi_v1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tcproute",
+					Namespace: "default",
+				},
+			},
+			want: true,
+		},
+		"insert gateway-api UDPRoute": {
+			obj: &gatewayapi_v1alpha2.UDPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "udproute",
+					Namespace: "default",
+				},
+			},
+			want: true,
+		},
 		"insert gateway-api ReferencePolicy": {
 			obj: &gatewayapi_v1alpha2.ReferencePolicy{
 				ObjectMeta: metav1.ObjectMeta{
@@ -1168,6 +1186,36 @@ func TestKubernetesCacheRemove(t *testing.T) {
 			},
 			want: true,
 		},
+		"remove gateway-api TCPRoute": {
+			cache: cache(&gatewayapi_v1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tcproute",
+					Namespace: "default",
+				},
+			}),
+			obj: &gatewayapi_v1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tcproute",
+					Namespace: "default",
+				},
+			},
+			want: true,
+		},
+		"remove gateway-api UDPRoute": {
+			cache: cache(&gatewayapi_v1alpha2.UDPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "udproute",
+					Namespace: "default",
+				},
+			}),
+			obj: &gatewayapi_v1alpha2.UDPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "udproute",
+					Namespace: "default",
+				},
+			},
+			want: true,
+		},
 		"remove gateway-api ReferencePolicy": {
 			cache: cache(&gatewayapi_v1alpha2.ReferencePolicy{
 				ObjectMeta: metav1.ObjectMeta{
@@ -1304,6 +1352,83 @@ func TestLookupService(t *testing.T) {
 	}
 }
 
+func TestLookupUDPService(t *testing.T) {
+	cache := func(objs ...interface{}) *KubernetesCache {
+		cache := KubernetesCache{
+			FieldLogger: fixture.NewTestLogger(t),
+		}
+		for _, o := range objs {
+			cache.Insert(o)
+		}
+		return &cache
+	}
+
+	service := func(ns, name string, ports ...v1.ServicePort) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+			},
+			Spec: v1.ServiceSpec{
+				Ports: ports,
+			},
+		}
+	}
+
+	port := func(name string, port int32, protocol v1.Protocol) v1.ServicePort {
+		return v1.ServicePort{
+			Name:     name,
+			Port:     port,
+			Protocol: protocol,
+		}
+	}
+
+	tests := map[string]struct {
+		cache    *KubernetesCache
+		meta     types.NamespacedName
+		port     intstr.IntOrString
+		wantSvc  *v1.Service
+		wantPort v1.ServicePort
+		wantErr  error
+	}{
+		"service and port exist with valid service protocol": {
+			cache:    cache(service("default", "service-1", port("dns", 53, v1.ProtocolUDP))),
+			meta:     types.NamespacedName{Namespace: "default", Name: "service-1"},
+			port:     intstr.FromInt(53),
+			wantSvc:  service("default", "service-1", port("dns", 53, v1.ProtocolUDP)),
+			wantPort: port("dns", 53, v1.ProtocolUDP),
+		},
+		"service and port exist, TCP service protocol": {
+			cache:   cache(service("default", "service-1", port("dns", 53, v1.ProtocolTCP))),
+			meta:    types.NamespacedName{Namespace: "default", Name: "service-1"},
+			port:    intstr.FromString("dns"),
+			wantErr: errors.New(`unsupported service protocol "TCP"`),
+		},
+		"service and port exist, unset service protocol defaults to TCP": {
+			cache:   cache(service("default", "service-1", port("dns", 53, ""))),
+			meta:    types.NamespacedName{Namespace: "default", Name: "service-1"},
+			port:    intstr.FromInt(53),
+			wantErr: errors.New(`unsupported service protocol "TCP"`),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSvc, gotPort, gotErr := tc.cache.LookupUDPService(tc.meta, tc.port)
+
+			switch {
+			case tc.wantErr != nil:
+				require.Error(t, gotErr)
+				assert.EqualError(t, tc.wantErr, gotErr.Error())
+			default:
+				assert.Nil(t, gotErr)
+				assert.Equal(t, tc.wantSvc, gotSvc)
+				assert.Equal(t, tc.wantPort, gotPort)
+			}
+		})
+	}
+}
+
 func TestServiceTriggersRebuild(t *testing.T) {
 
 	cache := func(objs ...interface{}) *KubernetesCache {
@@ -1702,7 +1827,6 @@ func TestSecretTriggersRebuild(t *testing.T) {
 		"no defined gateway does not trigger rebuild": {
 			cache: &KubernetesCache{
 				FieldLogger: fixture.NewTestLogger(t),
-				gateway:     nil,
 			},
 			secret: secret("default", "tlscert"),
 			want:   false,
@@ -1753,7 +1877,7 @@ func TestSecretTriggersRebuild(t *testing.T) {
 					Spec: gatewayapi_v1alpha2.GatewaySpec{
 						Listeners: []gatewayapi_v1alpha2.Listener{{
 							TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-								CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+								CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 									gatewayapi.CertificateRef("tlscert", ""),
 								},
 							},
@@ -1774,7 +1898,7 @@ func TestSecretTriggersRebuild(t *testing.T) {
 					Spec: gatewayapi_v1alpha2.GatewaySpec{
 						Listeners: []gatewayapi_v1alpha2.Listener{{
 							TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-								CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+								CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 									gatewayapi.CertificateRef("tlscert", ""),
 								},
 							},
@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"regexp/syntax"
 	"strings"
 
 	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
@@ -26,14 +27,38 @@ import (
 // prefix Condition.
 // pathMatchConditionsValid guarantees that if a prefix is present, it will start with a
 // / character, so we can simply concatenate.
+// If the final condition in the slice is an exact or regex condition, the merged prefix
+// of the preceding (include) conditions is prepended to it and the result is an exact
+// or regex condition respectively. A regex is grouped so that alternations in it can
+// only match paths under the included prefix. A regex under the root prefix is used
+// as it is.
 func mergePathMatchConditions(conds []contour_api_v1.MatchCondition) MatchCondition {
 	prefix := ""
 	for _, cond := range conds {
-		prefix += cond.Prefix
+		switch {
+		case cond.Exact != "":
+			return &ExactMatchCondition{
+				Path: mergeSlashes(prefix + cond.Exact),
+			}
+		case cond.Regex != "":
+			prefix = mergeSlashes(prefix)
+			if prefix == "/" || strings.HasPrefix(cond.Regex, "/") {
+				prefix = strings.TrimSuffix(prefix, "/")
+			}
+			if prefix == "" {
+				return &RegexMatchCondition{
+					Regex: cond.Regex,
+				}
+			}
+			return &RegexMatchCondition{
+				Regex: regexp.QuoteMeta(prefix) + "(?:" + cond.Regex + ")",
+			}
+		default:
+			prefix += cond.Prefix
+		}
 	}
 
-	re := regexp.MustCompile(`//+`)
-	prefix = re.ReplaceAllString(prefix, `/`)
+	prefix = mergeSlashes(prefix)
 
 	// After the merge operation is done, if the string is still empty, then
 	// we need to set the prefix to /.
@@ -49,26 +74,175 @@ func mergePathMatchConditions(conds []contour_api_v1.MatchCondition) MatchCondit
 	}
 }
 
+// mergeSlashes collapses any runs of consecutive / characters in s.
+func mergeSlashes(s string) string {
+	re := regexp.MustCompile(`//+`)
+	return re.ReplaceAllString(s, `/`)
+}
+
 // pathMatchConditionsValid validates a slice of MatchConditions can be correctly merged.
-// It encodes the business rules about what is allowed for prefix MatchConditions.
+// It encodes the business rules about what is allowed for prefix, exact and regex
+// MatchConditions.
 func pathMatchConditionsValid(conds []contour_api_v1.MatchCondition) error {
 	prefixCount := 0
+	pathCount := 0
 
 	for _, cond := range conds {
 		if cond.Prefix != "" {
 			prefixCount++
+			pathCount++
 			if cond.Prefix[0] != '/' {
 				return fmt.Errorf("prefix conditions must start with /, %s was supplied", cond.Prefix)
 			}
 		}
+		if cond.Exact != "" {
+			pathCount++
+			if cond.Exact[0] != '/' {
+				return fmt.Errorf("exact conditions must start with /, %s was supplied", cond.Exact)
+			}
+		}
+		if cond.Regex != "" {
+			pathCount++
+			if err := ValidateRegex(cond.Regex); err != nil {
+				return fmt.Errorf("regex condition %q is not valid: %s", cond.Regex, err)
+			}
+		}
 		if prefixCount > 1 {
 			return errors.New("more than one prefix is not allowed in a condition block")
 		}
+		if pathCount > 1 {
+			return errors.New("more than one prefix, exact or regex condition is not allowed in a condition block")
+		}
+	}
+
+	return nil
+}
+
+// includedRegexMatchConditionsValid validates the regex path MatchConditions of the
+// routes of an included HTTPProxy. The regex is appended to the prefix of the include
+// conditions, so it may not contain ^ or $ anchors which would no longer anchor it to
+// the start or end of the path.
+func includedRegexMatchConditionsValid(includeConds, routeConds []contour_api_v1.MatchCondition) error {
+	prefix := ""
+	for _, cond := range includeConds {
+		prefix += cond.Prefix
+	}
+	if mergeSlashes(prefix) == "" || mergeSlashes(prefix) == "/" {
+		return nil
+	}
+
+	for _, cond := range routeConds {
+		if cond.Regex == "" {
+			continue
+		}
+		re, err := syntax.Parse(cond.Regex, syntax.Perl)
+		if err != nil {
+			return fmt.Errorf("regex condition %q is not valid: %s", cond.Regex, err)
+		}
+		if hasAnchor(re) {
+			return fmt.Errorf("regex condition %q may not contain ^ or $ anchors in an included HTTPProxy", cond.Regex)
+		}
+	}
+
+	return nil
+}
+
+// regexMatchConditionValid validates that a merged regex path MatchCondition
+// can match at least one request path. Since request paths always start with
+// a / character, a regex such as "api/.*" would silently never match.
+func regexMatchConditionValid(cond MatchCondition) error {
+	rc, ok := cond.(*RegexMatchCondition)
+	if !ok {
+		return nil
+	}
+
+	re, err := syntax.Parse(rc.Regex, syntax.Perl)
+	if err != nil {
+		return fmt.Errorf("regex condition %q is not valid: %s", rc.Regex, err)
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return fmt.Errorf("regex condition %q is not valid: %s", rc.Regex, err)
+	}
+	if !matchesPath(prog) {
+		return fmt.Errorf("regex condition %q cannot match any path, paths must start with /", rc.Regex)
 	}
 
 	return nil
 }
 
+// matchesPath returns true if prog can match a non-empty string that
+// starts with a / character. Only the first character is checked, so
+// the result may be true for some programs that cannot match a path.
+func matchesPath(prog *syntax.Prog) bool {
+	type state struct {
+		pc    uint32
+		start bool
+	}
+
+	seen := map[state]bool{}
+	var walk func(s state) bool
+	walk = func(s state) bool {
+		if seen[s] {
+			return false
+		}
+		seen[s] = true
+
+		inst := prog.Inst[s.pc]
+		switch inst.Op {
+		case syntax.InstMatch:
+			return !s.start
+		case syntax.InstFail:
+			return false
+		case syntax.InstAlt, syntax.InstAltMatch:
+			return walk(state{inst.Out, s.start}) || walk(state{inst.Arg, s.start})
+		case syntax.InstEmptyWidth:
+			empty := syntax.EmptyOp(inst.Arg)
+			if !s.start && empty&(syntax.EmptyBeginText|syntax.EmptyBeginLine) != 0 {
+				return false
+			}
+			return walk(state{inst.Out, s.start})
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			if s.start && !inst.MatchRune('/') {
+				return false
+			}
+			return walk(state{inst.Out, false})
+		default:
+			return walk(state{inst.Out, s.start})
+		}
+	}
+
+	return walk(state{uint32(prog.Start), true})
+}
+
+// hasAnchor returns true if re contains a begin or end of line or text anchor.
+func hasAnchor(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+		return true
+	}
+	for _, sub := range re.Sub {
+		if hasAnchor(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// includeMatchConditionsValid validates the path MatchConditions of an include.
+// In addition to the rules enforced by pathMatchConditionsValid, includes may
+// only specify prefix conditions since an exact or regex path cannot be
+// further extended by the routes of the included HTTPProxy.
+func includeMatchConditionsValid(conds []contour_api_v1.MatchCondition) error {
+	for _, cond := range conds {
+		if cond.Exact != "" || cond.Regex != "" {
+			return errors.New("exact and regex conditions are not allowed in an include condition block")
+		}
+	}
+
+	return pathMatchConditionsValid(conds)
+}
+
 func mergeHeaderMatchConditions(conds []contour_api_v1.MatchCondition) []HeaderMatchCondition {
 	var headerConditions []contour_api_v1.HeaderMatchCondition
 	for _, cond := range conds {
@@ -211,6 +385,88 @@ func headerMatchConditionsValid(conditions []contour_api_v1.MatchCondition) erro
 	return nil
 }
 
+func mergeQueryParamMatchConditions(conds []contour_api_v1.MatchCondition) []QueryParamMatchCondition {
+	var qpc []QueryParamMatchCondition
+
+	for _, cond := range conds {
+		if cond.QueryParameter == nil {
+			continue
+		}
+
+		switch {
+		case cond.QueryParameter.Present:
+			qpc = append(qpc, QueryParamMatchCondition{
+				Name:      cond.QueryParameter.Name,
+				MatchType: QueryParamMatchTypePresent,
+			})
+		case cond.QueryParameter.Exact != "":
+			qpc = append(qpc, QueryParamMatchCondition{
+				Name:      cond.QueryParameter.Name,
+				Value:     cond.QueryParameter.Exact,
+				MatchType: QueryParamMatchTypeExact,
+			})
+		case cond.QueryParameter.Regex != "":
+			qpc = append(qpc, QueryParamMatchCondition{
+				Name:      cond.QueryParameter.Name,
+				Value:     cond.QueryParameter.Regex,
+				MatchType: QueryParamMatchTypeRegex,
+			})
+		}
+	}
+	return qpc
+}
+
+// queryParamMatchConditionsValid validates that the query parameter conditions
+// within a slice of MatchConditions are valid. Specifically, it returns an error
+// for any of the following scenarios:
+//	- a condition without a name
+//	- a condition that does not specify exactly one of 'exact', 'regex' or 'present'
+//	- a 'regex' condition that is not a valid regular expression
+//	- more than 1 'exact' condition for the same query parameter
+func queryParamMatchConditionsValid(conditions []contour_api_v1.MatchCondition) error {
+	queryParamsWithExactMatch := map[string]bool{}
+
+	for _, v := range conditions {
+		if v.QueryParameter == nil {
+			continue
+		}
+
+		if v.QueryParameter.Name == "" {
+			return errors.New("query parameter conditions must specify a name")
+		}
+
+		matchTypes := 0
+		if v.QueryParameter.Exact != "" {
+			matchTypes++
+		}
+		if v.QueryParameter.Regex != "" {
+			matchTypes++
+		}
+		if v.QueryParameter.Present {
+			matchTypes++
+		}
+		if matchTypes != 1 {
+			return fmt.Errorf("query parameter condition for %q must specify exactly one of 'exact', 'regex' or 'present'", v.QueryParameter.Name)
+		}
+
+		switch {
+		case v.QueryParameter.Exact != "":
+			// Look for duplicate "exact match" query parameters on conditions.
+			// Query parameter names are case sensitive.
+			if queryParamsWithExactMatch[v.QueryParameter.Name] {
+				return errors.New("cannot specify duplicate query parameter 'exact match' conditions in the same route")
+			}
+			queryParamsWithExactMatch[v.QueryParameter.Name] = true
+		case v.QueryParameter.Regex != "":
+			if err := ValidateRegex(v.QueryParameter.Regex); err != nil {
+				return fmt.Errorf("query parameter condition for %q has an invalid regex: %s", v.QueryParameter.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // ValidateRegex returns an error if the supplied
 // RE2 regex syntax is invalid.
 func ValidateRegex(regex string) error {
@@ -82,6 +82,66 @@ func TestPathMatchCondition(t *testing.T) {
 			}},
 			want: &PrefixMatchCondition{Prefix: "/"},
 		},
+		"exact condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Exact: "/a",
+			}},
+			want: &ExactMatchCondition{Path: "/a"},
+		},
+		"exact condition with included prefix": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/a/",
+			}, {
+				Exact: "/b",
+			}},
+			want: &ExactMatchCondition{Path: "/a/b"},
+		},
+		"regex condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Regex: "/a/.*",
+			}},
+			want: &RegexMatchCondition{Regex: "/a/.*"},
+		},
+		"regex condition with included prefix": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/v1.0/",
+			}, {
+				Regex: "/b/[0-9]+",
+			}},
+			want: &RegexMatchCondition{Regex: `/v1\.0(?:/b/[0-9]+)`},
+		},
+		"regex condition without leading slash with included prefix": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/a",
+			}, {
+				Regex: ".*/b",
+			}},
+			want: &RegexMatchCondition{Regex: "/a(?:.*/b)"},
+		},
+		"regex condition with alternation and included prefix": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/team-a",
+			}, {
+				Regex: "/x|/admin.*",
+			}},
+			want: &RegexMatchCondition{Regex: "/team-a(?:/x|/admin.*)"},
+		},
+		"regex condition with root included prefix": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/",
+			}, {
+				Regex: "/x|/admin.*",
+			}},
+			want: &RegexMatchCondition{Regex: "/x|/admin.*"},
+		},
+		"anchored regex condition with root included prefix": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/",
+			}, {
+				Regex: "^/api/.*$",
+			}},
+			want: &RegexMatchCondition{Regex: "^/api/.*$"},
+		},
 	}
 
 	for name, tc := range tests {
@@ -256,6 +316,153 @@ func TestHeaderMatchConditions(t *testing.T) {
 	}
 }
 
+func TestQueryParamMatchConditions(t *testing.T) {
+	tests := map[string]struct {
+		matchconditions []contour_api_v1.MatchCondition
+		want            []QueryParamMatchCondition
+	}{
+		"empty condition list": {
+			matchconditions: nil,
+			want:            nil,
+		},
+		"prefix and header conditions only": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/",
+				Header: &contour_api_v1.HeaderMatchCondition{
+					Name:    "x-header",
+					Present: true,
+				},
+			}},
+			want: nil,
+		},
+		"exact, regex and present conditions": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/",
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:  "a",
+					Exact: "1",
+				},
+			}, {
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:  "b",
+					Regex: "[0-9]+",
+				},
+			}, {
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:    "c",
+					Present: true,
+				},
+			}},
+			want: []QueryParamMatchCondition{{
+				Name:      "a",
+				Value:     "1",
+				MatchType: QueryParamMatchTypeExact,
+			}, {
+				Name:      "b",
+				Value:     "[0-9]+",
+				MatchType: QueryParamMatchTypeRegex,
+			}, {
+				Name:      "c",
+				MatchType: QueryParamMatchTypePresent,
+			}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := mergeQueryParamMatchConditions(tc.matchconditions)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateQueryParamMatchConditions(t *testing.T) {
+	tests := map[string]struct {
+		matchconditions []contour_api_v1.MatchCondition
+		wantErr         bool
+	}{
+		"empty condition list": {
+			matchconditions: nil,
+			wantErr:         false,
+		},
+		"valid conditions": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:  "a",
+					Exact: "1",
+				},
+			}, {
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:  "A",
+					Exact: "1",
+				},
+			}, {
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:  "b",
+					Regex: "[0-9]+",
+				},
+			}},
+			wantErr: false,
+		},
+		"missing name": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Exact: "1",
+				},
+			}},
+			wantErr: true,
+		},
+		"no match type": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name: "a",
+				},
+			}},
+			wantErr: true,
+		},
+		"more than one match type": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:    "a",
+					Exact:   "1",
+					Present: true,
+				},
+			}},
+			wantErr: true,
+		},
+		"invalid regex": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:  "a",
+					Regex: "[0-9",
+				},
+			}},
+			wantErr: true,
+		},
+		"duplicate exact conditions": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:  "a",
+					Exact: "1",
+				},
+			}, {
+				QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+					Name:  "a",
+					Exact: "2",
+				},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := queryParamMatchConditionsValid(tc.matchconditions)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
 func TestPrefixMatchConditionsValid(t *testing.T) {
 	tests := map[string]struct {
 		matchconditions []contour_api_v1.MatchCondition
@@ -317,6 +524,45 @@ func TestPrefixMatchConditionsValid(t *testing.T) {
 			}},
 			want: false,
 		},
+		"valid exact condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Exact: "/api",
+			}},
+			want: true,
+		},
+		"invalid exact condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Exact: "api",
+			}},
+			want: false,
+		},
+		"valid regex condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Regex: "/api/[a-z]+",
+			}},
+			want: true,
+		},
+		"invalid regex condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Regex: "/api/[a-z",
+			}},
+			want: false,
+		},
+		"prefix and exact matchconditions": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/api",
+			}, {
+				Exact: "/v1",
+			}},
+			want: false,
+		},
+		"exact and regex in the same condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Exact: "/api",
+				Regex: "/v1",
+			}},
+			want: false,
+		},
 	}
 
 	for name, tc := range tests {
@@ -327,6 +573,146 @@ func TestPrefixMatchConditionsValid(t *testing.T) {
 	}
 }
 
+func TestIncludedRegexMatchConditionsValid(t *testing.T) {
+	tests := map[string]struct {
+		include []contour_api_v1.MatchCondition
+		route   []contour_api_v1.MatchCondition
+		want    bool
+	}{
+		"not included": {
+			route: []contour_api_v1.MatchCondition{{Regex: "^/api/.*$"}},
+			want:  true,
+		},
+		"included under root prefix": {
+			include: []contour_api_v1.MatchCondition{{Prefix: "/"}},
+			route:   []contour_api_v1.MatchCondition{{Regex: "^/api/.*$"}},
+			want:    true,
+		},
+		"included without anchors": {
+			include: []contour_api_v1.MatchCondition{{Prefix: "/team-a"}},
+			route:   []contour_api_v1.MatchCondition{{Regex: "/x|/admin.*"}},
+			want:    true,
+		},
+		"included with escaped anchors": {
+			include: []contour_api_v1.MatchCondition{{Prefix: "/team-a"}},
+			route:   []contour_api_v1.MatchCondition{{Regex: `/\^x\$`}},
+			want:    true,
+		},
+		"included with begin anchor": {
+			include: []contour_api_v1.MatchCondition{{Prefix: "/team-a"}},
+			route:   []contour_api_v1.MatchCondition{{Regex: "/x|^/admin.*"}},
+			want:    false,
+		},
+		"included with end anchor": {
+			include: []contour_api_v1.MatchCondition{{Prefix: "/team-a"}},
+			route:   []contour_api_v1.MatchCondition{{Regex: "/x$"}},
+			want:    false,
+		},
+		"included with text anchor": {
+			include: []contour_api_v1.MatchCondition{{Prefix: "/team-a"}},
+			route:   []contour_api_v1.MatchCondition{{Regex: `\A/x`}},
+			want:    false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := includedRegexMatchConditionsValid(tc.include, tc.route)
+			assert.Equal(t, tc.want, err == nil)
+		})
+	}
+}
+
+func TestRegexMatchConditionValid(t *testing.T) {
+	tests := map[string]struct {
+		regex string
+		want  bool
+	}{
+		"leading slash": {
+			regex: "/api/.*",
+			want:  true,
+		},
+		"anchored leading slash": {
+			regex: "^/api/.*$",
+			want:  true,
+		},
+		"leading wildcard": {
+			regex: ".*/api",
+			want:  true,
+		},
+		"optional leading slash": {
+			regex: "/?api/.*",
+			want:  true,
+		},
+		"alternation with leading slash": {
+			regex: "api|/admin",
+			want:  true,
+		},
+		"no leading slash": {
+			regex: "api/.*",
+			want:  false,
+		},
+		"anchor after prefix": {
+			regex: "/(?:^/api)",
+			want:  false,
+		},
+		"empty match only": {
+			regex: "^$",
+			want:  false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := regexMatchConditionValid(&RegexMatchCondition{Regex: tc.regex})
+			assert.Equal(t, tc.want, err == nil)
+		})
+	}
+}
+
+func TestIncludeMatchConditionsValid(t *testing.T) {
+	tests := map[string]struct {
+		matchconditions []contour_api_v1.MatchCondition
+		want            bool
+	}{
+		"empty condition list": {
+			matchconditions: nil,
+			want:            true,
+		},
+		"prefix condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "/api",
+			}},
+			want: true,
+		},
+		"exact condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Exact: "/api",
+			}},
+			want: false,
+		},
+		"regex condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Regex: "/api/.*",
+			}},
+			want: false,
+		},
+		"invalid prefix condition": {
+			matchconditions: []contour_api_v1.MatchCondition{{
+				Prefix: "api",
+			}},
+			want: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := includeMatchConditionsValid(tc.matchconditions)
+			assert.Equal(t, tc.want, err == nil)
+		})
+	}
+}
+
 func TestValidateHeaderMatchConditions(t *testing.T) {
 	tests := map[string]struct {
 		matchconditions []contour_api_v1.MatchCondition
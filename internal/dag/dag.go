@@ -18,6 +18,7 @@ package dag
 import (
 	"errors"
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -59,10 +60,24 @@ type DAG struct {
 	// StatusCache holds a cache of status updates to send.
 	StatusCache status.Cache
 
-	Listeners          []*Listener
-	VirtualHosts       map[string]*VirtualHost
-	SecureVirtualHosts map[string]*SecureVirtualHost
-	ExtensionClusters  []*ExtensionCluster
+	Listeners         []*Listener
+	ExtensionClusters []*ExtensionCluster
+
+	// VirtualHosts holds the virtual hosts for the HTTP
+	// listeners, keyed by listener port and then hostname.
+	VirtualHosts map[int]map[string]*VirtualHost
+
+	// SecureVirtualHosts holds the secure virtual hosts for the
+	// HTTPS listeners, keyed by listener port and then hostname.
+	SecureVirtualHosts map[int]map[string]*SecureVirtualHost
+
+	// TCPProxies holds the TCP proxies for Gateway API
+	// TCP listeners, keyed by listener port.
+	TCPProxies map[int]*TCPProxy
+
+	// UDPProxies holds the UDP proxies for Gateway API
+	// UDP listeners, keyed by listener port.
+	UDPProxies map[int]*UDPProxy
 }
 
 type MatchCondition interface {
@@ -153,6 +168,36 @@ func (hc *HeaderMatchCondition) String() string {
 	return "header: " + details
 }
 
+const (
+	// QueryParamMatchTypeExact matches a query parameter value exactly.
+	QueryParamMatchTypeExact = "exact"
+
+	// QueryParamMatchTypeRegex matches a query parameter if it matches
+	// the provided regular expression.
+	QueryParamMatchTypeRegex = "regex"
+
+	// QueryParamMatchTypePresent matches a query parameter if it is
+	// present in a request.
+	QueryParamMatchTypePresent = "present"
+)
+
+// QueryParamMatchCondition matches request query parameters by MatchType
+type QueryParamMatchCondition struct {
+	Name      string
+	Value     string
+	MatchType string
+}
+
+func (qc *QueryParamMatchCondition) String() string {
+	details := strings.Join([]string{
+		"name=" + qc.Name,
+		"value=" + qc.Value,
+		"matchtype=" + qc.MatchType,
+	}, "&")
+
+	return "queryparam: " + details
+}
+
 // DirectResponse allows for a specific HTTP status code
 // to be the response to a route request vs routing to
 // an envoy cluster.
@@ -199,6 +244,10 @@ type Route struct {
 	// match on the request headers.
 	HeaderMatchConditions []HeaderMatchCondition
 
+	// QueryParamMatchConditions specifies a set of additional Conditions to
+	// match on the request query parameters.
+	QueryParamMatchConditions []QueryParamMatchCondition
+
 	Clusters []*Cluster
 
 	// Should this route generate a 301 upgrade if accessed
@@ -213,6 +262,11 @@ type Route struct {
 	// AuthContext sets the authorization context (if authorization is enabled).
 	AuthContext map[string]string
 
+	// JWTProvider names a JWT provider defined on the virtual
+	// host to be used to validate JWTs on requests to this route.
+	// If empty, JWTs are not validated for this route.
+	JWTProvider string
+
 	// Is this a websocket route?
 	// TODO(dfc) this should go on the service
 	Websocket bool
@@ -226,8 +280,13 @@ type Route struct {
 	// Indicates that during forwarding, the matched prefix (or path) should be swapped with this value
 	PrefixRewrite string
 
-	// Mirror Policy defines the mirroring policy for this Route.
-	MirrorPolicy *MirrorPolicy
+	// RegexRewrite indicates that during forwarding, the part of the path
+	// matched by a regex should be swapped with a substitution. Must not
+	// be set together with PrefixRewrite.
+	RegexRewrite *RegexRewrite
+
+	// MirrorPolicies define the mirroring policies for this Route.
+	MirrorPolicies []*MirrorPolicy
 
 	// RequestHeadersPolicy defines how headers are managed during forwarding
 	RequestHeadersPolicy *HeadersPolicy
@@ -242,6 +301,16 @@ type Route struct {
 	// RateLimitPolicy defines if/how requests for the route are rate limited.
 	RateLimitPolicy *RateLimitPolicy
 
+	// IPFilterAllow determines how the IPFilterRules should be applied.
+	// If true, traffic is allowed only if it matches a rule.
+	// If false, traffic is allowed only if it doesn't match any rule.
+	IPFilterAllow bool
+
+	// IPFilterRules is a list of ipv4/6 filter rules for which matching
+	// requests should be filtered. The behavior of the filters is governed
+	// by IPFilterAllow. If set, these rules override the virtual host's.
+	IPFilterRules []IPFilterRule
+
 	// RequestHashPolicies is a list of policies for configuring hashes on
 	// request attributes.
 	RequestHashPolicies []RequestHashPolicy
@@ -254,6 +323,10 @@ type Route struct {
 	// Redirect allows for a 301 Redirect to be the response
 	// to a route request vs. routing to an envoy cluster.
 	Redirect *Redirect
+
+	// AccessLogPolicy overrides the access log configuration
+	// for requests matching this route.
+	AccessLogPolicy *AccessLogPolicy
 }
 
 // HasPathPrefix returns whether this route has a PrefixPathCondition.
@@ -296,9 +369,25 @@ type RetryPolicy struct {
 	PerTryTimeout timeout.Setting
 }
 
+// RegexRewrite defines how a request path is rewritten during
+// forwarding by substituting the part matched by a regex.
+type RegexRewrite struct {
+	// Pattern is the regex matched against the request path.
+	Pattern string
+
+	// Substitution replaces the part of the path matched by Pattern.
+	Substitution string
+}
+
 // MirrorPolicy defines the mirroring policy for a route.
 type MirrorPolicy struct {
 	Cluster *Cluster
+
+	// Weight is the number of requests, out of every TotalWeight
+	// requests, that are mirrored to the Cluster. If TotalWeight
+	// is zero, every request is mirrored.
+	Weight      uint32
+	TotalWeight uint32
 }
 
 // HeadersPolicy defines how headers are managed during forwarding
@@ -485,9 +574,65 @@ type VirtualHost struct {
 	// are rate limited.
 	RateLimitPolicy *RateLimitPolicy
 
+	// IPFilterAllow determines how the IPFilterRules should be applied.
+	// If true, traffic is allowed only if it matches a rule.
+	// If false, traffic is allowed only if it doesn't match any rule.
+	IPFilterAllow bool
+
+	// IPFilterRules is a list of ipv4/6 filter rules for which matching
+	// requests should be filtered. The behavior of the filters is governed
+	// by IPFilterAllow.
+	IPFilterRules []IPFilterRule
+
+	// AccessLogPolicy overrides the access log configuration
+	// for requests to this virtual host.
+	AccessLogPolicy *AccessLogPolicy
+
 	Routes map[string]*Route
 }
 
+// AccessLogPolicy defines how requests are access logged.
+type AccessLogPolicy struct {
+	// Name uniquely identifies the policy. Envoy marks the
+	// requests that the policy applies to with it.
+	Name string
+
+	// Disabled disables access logging.
+	Disabled bool
+
+	// Format is the access log format, either "envoy" or "json".
+	// If empty, the globally configured format is used.
+	Format string
+
+	// FormatString is the Envoy format string for the "envoy" format.
+	// If empty, the globally configured format string is used.
+	FormatString string
+
+	// Fields are the fields logged for the "json" format.
+	// If empty, the globally configured fields are used.
+	Fields []string
+
+	// MinStatusCode, if non-zero, restricts logging to responses
+	// with a status code greater than or equal to it.
+	MinStatusCode uint32
+
+	// SamplingPercent, if set, restricts logging to the given
+	// percentage of requests.
+	SamplingPercent *uint32
+}
+
+// IPFilterRule matches requests by the CIDR block of their source address.
+type IPFilterRule struct {
+	// Remote determines what ip to filter on.
+	// If true, filter on the remote address, which accounts for
+	// PROXY protocol and X-Forwarded-For.
+	// If false, filter on the address of the network peer.
+	Remote bool
+
+	// CIDR is the CIDR block to filter on.
+	CIDR net.IPNet
+}
+
 func (v *VirtualHost) addRoute(route *Route) {
 	if v.Routes == nil {
 		v.Routes = make(map[string]*Route)
@@ -500,6 +645,9 @@ func conditionsToString(r *Route) string {
 	for _, cond := range r.HeaderMatchConditions {
 		s = append(s, cond.String())
 	}
+	for _, cond := range r.QueryParamMatchConditions {
+		s = append(s, cond.String())
+	}
 	return strings.Join(s, ",")
 }
 
@@ -545,6 +693,73 @@ type SecureVirtualHost struct {
 	// AuthorizationServerWithRequestBody specifies configuration
 	// for buffering request data sent to AuthorizationServer
 	AuthorizationServerWithRequestBody *AuthorizationServerBufferSettings
+
+	// JWTProviders specify how to verify JWTs.
+	JWTProviders []JWTProvider
+}
+
+// JWTProvider defines how to verify JWTs on requests.
+type JWTProvider struct {
+	// Name is the unique name of the provider within
+	// the virtual host.
+	Name string
+
+	// Issuer that JWTs are required to have in the "iss"
+	// field. If empty, the issuer is not checked.
+	Issuer string
+
+	// Audiences that JWTs are allowed to have in the "aud"
+	// field. If empty, the audience is not checked.
+	Audiences []string
+
+	// RemoteJWKS is the remote JWKS used to verify JWT
+	// signatures. Exactly one of RemoteJWKS or LocalJWKS
+	// is set.
+	RemoteJWKS *RemoteJWKS
+
+	// LocalJWKS is the inline JWKS used to verify JWT
+	// signatures.
+	LocalJWKS string
+
+	// ForwardJWT is set if the JWT should be forwarded
+	// to the upstream after successful verification.
+	ForwardJWT bool
+}
+
+// RemoteJWKS defines how to fetch a JWKS from an HTTP endpoint.
+type RemoteJWKS struct {
+	// URI is the HTTP(S) URI of the JWKS.
+	URI string
+
+	// Timeout is how long to wait for a response from the URI.
+	Timeout time.Duration
+
+	// CacheDuration is how long to cache the JWKS. If zero,
+	// Envoy's default is used.
+	CacheDuration time.Duration
+
+	// Cluster is the upstream cluster used to fetch the JWKS.
+	Cluster DNSNameCluster
+}
+
+// DNSNameCluster is a cluster that routes directly to a DNS
+// name (i.e. not a Kubernetes service).
+type DNSNameCluster struct {
+	// Address is the DNS name (or IP address) of the upstream.
+	Address string
+
+	// Scheme is either "http" or "https".
+	Scheme string
+
+	// Port is the port to connect to.
+	Port int
+
+	// DNSLookupFamily defines how the address is resolved.
+	DNSLookupFamily string
+
+	// UpstreamValidation defines how to verify the upstream's
+	// certificate when Scheme is "https".
+	UpstreamValidation *PeerValidationContext
 }
 
 // AuthorizationServerBufferSettings enables ExtAuthz filter to buffer client
@@ -589,6 +804,14 @@ type Listener struct {
 
 	VirtualHosts       []*VirtualHost
 	SecureVirtualHosts []*SecureVirtualHost
+
+	// TCPProxy, if set, proxies all connections
+	// accepted by the listener to its clusters.
+	TCPProxy *TCPProxy
+
+	// UDPProxy, if set, proxies all datagrams
+	// received by the listener to its cluster.
+	UDPProxy *UDPProxy
 }
 
 // TCPProxy represents a cluster of TCP endpoints.
@@ -599,6 +822,13 @@ type TCPProxy struct {
 	Clusters []*Cluster
 }
 
+// UDPProxy represents a cluster of UDP endpoints.
+type UDPProxy struct {
+
+	// Cluster is the upstream service to forward datagrams to.
+	Cluster *Cluster
+}
+
 // Service represents a single Kubernetes' Service's Port.
 type Service struct {
 	Weighted WeightedService
@@ -655,6 +885,14 @@ type Cluster struct {
 	// Cluster tcp health check policy
 	*TCPHealthCheckPolicy
 
+	// OutlierDetectionPolicy defines the passive health
+	// checking of the cluster's endpoints.
+	OutlierDetectionPolicy *OutlierDetectionPolicy
+
+	// CircuitBreakers, if set, replaces the circuit breaking
+	// limits of the Upstream service.
+	CircuitBreakers *CircuitBreakers
+
 	// RequestHeadersPolicy defines how headers are managed during forwarding
 	RequestHeadersPolicy *HeadersPolicy
 
@@ -685,6 +923,19 @@ type Cluster struct {
 	// ClientCertificate is the optional identifier of the TLS secret containing client certificate and
 	// private key to be used when establishing TLS connection to upstream cluster.
 	ClientCertificate *Secret
+
+	// ZoneAwareRouting, if set, configures Envoy to prefer upstream
+	// endpoints in its own zone.
+	ZoneAwareRouting *ZoneAwareRouting
+}
+
+// ZoneAwareRouting holds the parameters of Envoy's zone aware routing.
+// See https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/load_balancing/zone_aware
+type ZoneAwareRouting struct {
+	// MinClusterSize is the minimum number of upstream endpoints
+	// required for zone aware routing to be applied. Zero selects
+	// Envoy's default.
+	MinClusterSize uint32
 }
 
 // WeightedService represents the load balancing weight of a
@@ -828,6 +1079,25 @@ type TCPHealthCheckPolicy struct {
 	HealthyThreshold   uint32
 }
 
+// CircuitBreakers holds the circuit breaking limits of a Cluster.
+// A nil limit uses the Envoy default.
+type CircuitBreakers struct {
+	MaxConnections     *uint32
+	MaxPendingRequests *uint32
+	MaxRequests        *uint32
+	MaxRetries         *uint32
+}
+
+// OutlierDetectionPolicy passive health check policy. A nil
+// threshold uses the Envoy default.
+type OutlierDetectionPolicy struct {
+	ConsecutiveServerErrors  *uint32
+	ConsecutiveGatewayErrors *uint32
+	Interval                 time.Duration
+	BaseEjectionTime         time.Duration
+	MaxEjectionPercent       *uint32
+}
+
 // ExtensionCluster generates an Envoy cluster (aka ClusterLoadAssignment)
 // for an ExtensionService resource.
 type ExtensionCluster struct {
@@ -17,6 +17,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/projectcontour/contour/internal/errors"
@@ -37,6 +39,8 @@ import (
 const (
 	KindHTTPRoute = "HTTPRoute"
 	KindTLSRoute  = "TLSRoute"
+	KindTCPRoute  = "TCPRoute"
+	KindUDPRoute  = "UDPRoute"
 	KindGateway   = "Gateway"
 )
 
@@ -48,24 +52,38 @@ type GatewayAPIProcessor struct {
 	dag    *DAG
 	source *KubernetesCache
 
+	// gateway is the Gateway being processed.
+	gateway *gatewayapi_v1alpha2.Gateway
+
 	// EnableExternalNameService allows processing of ExternalNameServices
 	// This is normally disabled for security reasons.
 	// See https://github.com/projectcontour/contour/security/advisories/GHSA-5ph6-qq5x-7jwc for details.
 	EnableExternalNameService bool
+
+	// ZoneAwareRouting, if set, configures Envoy to prefer upstream
+	// endpoints in its own zone (optional).
+	ZoneAwareRouting *ZoneAwareRouting
+
+	// CircuitBreakerDefaults holds the circuit breaking limits that
+	// apply to clusters that don't otherwise set them (optional).
+	CircuitBreakerDefaults *CircuitBreakers
+
+	// ReservedPorts holds the ports Envoy's default HTTP and HTTPS
+	// listeners are bound to. Gateway listeners on other ports are
+	// bound to their own port, so may not use these (optional).
+	ReservedPorts []int
 }
 
 // matchConditions holds match rules.
 type matchConditions struct {
-	path    MatchCondition
-	headers []HeaderMatchCondition
+	path        MatchCondition
+	headers     []HeaderMatchCondition
+	queryParams []QueryParamMatchCondition
 }
 
 // Run translates Service APIs into DAG objects and
 // adds them to the DAG.
 func (p *GatewayAPIProcessor) Run(dag *DAG, source *KubernetesCache) {
-	var gatewayErrors field.ErrorList
-	path := field.NewPath("spec")
-
 	p.dag = dag
 	p.source = source
 
@@ -73,10 +91,11 @@ func (p *GatewayAPIProcessor) Run(dag *DAG, source *KubernetesCache) {
 	defer func() {
 		p.dag = nil
 		p.source = nil
+		p.gateway = nil
 	}()
 
 	// Gateway and GatewayClass must be defined for resources to be processed.
-	if p.source.gateway == nil {
+	if len(p.source.gateways) == 0 {
 		p.Info("Gateway not found in cache.")
 		return
 	}
@@ -85,25 +104,118 @@ func (p *GatewayAPIProcessor) Run(dag *DAG, source *KubernetesCache) {
 		return
 	}
 
+	// Process the Gateways in a stable order, since the first
+	// valid listener on a port determines the protocols that
+	// listeners on other Gateways can use the port with.
+	var gateways []*gatewayapi_v1alpha2.Gateway
+	for _, gateway := range p.source.gateways {
+		gateways = append(gateways, gateway)
+	}
+	sort.Slice(gateways, func(i, j int) bool {
+		if gateways[i].Namespace != gateways[j].Namespace {
+			return gateways[i].Namespace < gateways[j].Namespace
+		}
+		return gateways[i].Name < gateways[j].Name
+	})
+
+	listenerSockets := map[listenerSocket]gatewayapi_v1alpha2.ProtocolType{}
+	listenerHostnames := map[listenerHostname]types.NamespacedName{}
+	for _, gateway := range gateways {
+		p.gateway = gateway
+		p.computeGateway(listenerSockets, listenerHostnames)
+	}
+}
+
+// computeGateway adds the listeners of the Gateway being processed, and the
+// routes attached to them, to the DAG and sets the Gateway's status. Listeners
+// of all Gateways share listenerSockets and listenerHostnames, since they are
+// all served by the same Envoy listeners and virtual hosts.
+func (p *GatewayAPIProcessor) computeGateway(listenerSockets map[listenerSocket]gatewayapi_v1alpha2.ProtocolType, listenerHostnames map[listenerHostname]types.NamespacedName) {
+	var gatewayErrors field.ErrorList
+	path := field.NewPath("spec")
+
 	gwAccessor, commit := p.dag.StatusCache.GatewayStatusAccessor(
-		k8s.NamespacedNameOf(p.source.gateway),
-		p.source.gateway.Generation,
-		&p.source.gateway.Status,
+		k8s.NamespacedNameOf(p.gateway),
+		p.gateway.Generation,
+		&p.gateway.Status,
 	)
 	defer commit()
 
-	if len(p.source.gateway.Spec.Addresses) > 0 {
-		gatewayErrors = append(gatewayErrors, &field.Error{Type: field.ErrorTypeNotSupported, Field: path.String(), BadValue: p.source.gateway.Spec.Addresses, Detail: "Spec.Addresses is not supported"})
+	if len(p.gateway.Spec.Addresses) > 0 {
+		gatewayErrors = append(gatewayErrors, &field.Error{Type: field.ErrorTypeNotSupported, Field: path.String(), BadValue: p.gateway.Spec.Addresses, Detail: "Spec.Addresses is not supported"})
 	}
 
-	for _, listener := range p.source.gateway.Spec.Listeners {
-		p.computeListener(listener, gwAccessor, len(gatewayErrors) == 0)
+	for _, listener := range p.gateway.Spec.Listeners {
+		p.computeListener(listener, listenerSockets, listenerHostnames, gwAccessor, len(gatewayErrors) == 0)
 	}
 
 	p.computeGatewayConditions(gwAccessor, gatewayErrors)
 }
 
-func (p *GatewayAPIProcessor) computeListener(listener gatewayapi_v1alpha2.Listener, gwAccessor *status.GatewayStatusUpdate, isGatewayValid bool) {
+// listenerSocket identifies the port and transport
+// protocol that a Gateway listener is served on.
+type listenerSocket struct {
+	port      gatewayapi_v1alpha2.PortNumber
+	transport string
+}
+
+// socketFor returns the listenerSocket for the given listener.
+func socketFor(listener gatewayapi_v1alpha2.Listener) listenerSocket {
+	if listener.Protocol == gatewayapi_v1alpha2.UDPProtocolType {
+		return listenerSocket{port: listener.Port, transport: "UDP"}
+	}
+	return listenerSocket{port: listener.Port, transport: "TCP"}
+}
+
+// listenerHostname identifies the socket and hostname
+// that a Gateway listener is served on.
+type listenerHostname struct {
+	socket   listenerSocket
+	hostname string
+}
+
+// hostnameFor returns the listenerHostname for the given listener.
+func hostnameFor(listener gatewayapi_v1alpha2.Listener) listenerHostname {
+	var hostname string
+	if listener.Hostname != nil {
+		hostname = string(*listener.Hostname)
+	}
+	return listenerHostname{socket: socketFor(listener), hostname: hostname}
+}
+
+// protocolsCompatible returns true if listeners with the given
+// protocols can share a port, i.e. be served by the same Envoy
+// listener. HTTPS and TLS listeners are both served by
+// matching on SNI so are compatible with each other.
+func protocolsCompatible(a, b gatewayapi_v1alpha2.ProtocolType) bool {
+	secure := func(protocol gatewayapi_v1alpha2.ProtocolType) bool {
+		return protocol == gatewayapi_v1alpha2.HTTPSProtocolType || protocol == gatewayapi_v1alpha2.TLSProtocolType
+	}
+
+	return a == b || (secure(a) && secure(b))
+}
+
+// reservedPort returns true if the given listener would be bound to
+// one of the ports of Envoy's default HTTP and HTTPS listeners.
+func (p *GatewayAPIProcessor) reservedPort(listener gatewayapi_v1alpha2.Listener) bool {
+	switch {
+	case listener.Protocol == gatewayapi_v1alpha2.UDPProtocolType:
+		return false
+	case listener.Protocol == gatewayapi_v1alpha2.HTTPProtocolType && listener.Port == HTTP_LISTENER_PORT:
+		return false
+	case protocolsCompatible(listener.Protocol, gatewayapi_v1alpha2.HTTPSProtocolType) && listener.Port == HTTPS_LISTENER_PORT:
+		return false
+	}
+
+	for _, port := range p.ReservedPorts {
+		if int(listener.Port) == port {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *GatewayAPIProcessor) computeListener(listener gatewayapi_v1alpha2.Listener, listenerSockets map[listenerSocket]gatewayapi_v1alpha2.ProtocolType, listenerHostnames map[listenerHostname]types.NamespacedName, gwAccessor *status.GatewayStatusUpdate, isGatewayValid bool) {
 	// set the listener's "Ready" condition based on whether we've
 	// added any other conditions for the listener. The assumption
 	// here is that if another condition is set, the listener is
@@ -202,8 +314,8 @@ func (p *GatewayAPIProcessor) computeListener(listener gatewayapi_v1alpha2.Liste
 				}
 			}
 		}
-	case gatewayapi_v1alpha2.HTTPProtocolType:
-		// no action required, this is a valid protocol type.
+	case gatewayapi_v1alpha2.HTTPProtocolType, gatewayapi_v1alpha2.TCPProtocolType, gatewayapi_v1alpha2.UDPProtocolType:
+		// no action required, these are valid protocol types.
 	default:
 		gwAccessor.AddListenerCondition(
 			string(listener.Name),
@@ -215,6 +327,56 @@ func (p *GatewayAPIProcessor) computeListener(listener gatewayapi_v1alpha2.Liste
 		return
 	}
 
+	// HTTP listeners on HTTP_LISTENER_PORT, and HTTPS and TLS listeners
+	// on HTTPS_LISTENER_PORT, are served by Envoy's default listeners.
+	// Every other listener binds its own port, which must not be one
+	// that the default listeners are bound to.
+	if p.reservedPort(listener) {
+		gwAccessor.AddListenerCondition(
+			string(listener.Name),
+			gatewayapi_v1alpha2.ListenerConditionDetached,
+			metav1.ConditionTrue,
+			gatewayapi_v1alpha2.ListenerReasonPortUnavailable,
+			fmt.Sprintf("Listener.Port %d is reserved for Envoy's default HTTP and HTTPS listeners.", listener.Port),
+		)
+		return
+	}
+
+	// Listeners that share a port are served by the same Envoy listener,
+	// so the first valid listener on a port determines which protocols
+	// the port can be used with.
+	socket := socketFor(listener)
+	if protocol, ok := listenerSockets[socket]; ok && !protocolsCompatible(protocol, listener.Protocol) {
+		gwAccessor.AddListenerCondition(
+			string(listener.Name),
+			gatewayapi_v1alpha2.ListenerConditionDetached,
+			metav1.ConditionTrue,
+			gatewayapi_v1alpha2.ListenerReasonPortUnavailable,
+			fmt.Sprintf("Listener.Port %d is already in use by a listener with protocol %q.", listener.Port, protocol),
+		)
+		return
+	}
+	if _, ok := listenerSockets[socket]; !ok {
+		listenerSockets[socket] = listener.Protocol
+	}
+
+	// Listeners of different Gateways with the same port and hostname
+	// would be served by the same virtual hosts, so only the first
+	// Gateway's listener is used.
+	gateway := k8s.NamespacedNameOf(p.gateway)
+	hostname := hostnameFor(listener)
+	if owner, ok := listenerHostnames[hostname]; ok && owner != gateway {
+		gwAccessor.AddListenerCondition(
+			string(listener.Name),
+			gatewayapi_v1alpha2.ListenerConditionConflicted,
+			metav1.ConditionTrue,
+			gatewayapi_v1alpha2.ListenerReasonHostnameConflict,
+			fmt.Sprintf("Listener.Port %d with Listener.Hostname %q is already in use by Gateway %s.", listener.Port, hostname.hostname, owner),
+		)
+		return
+	}
+	listenerHostnames[hostname] = gateway
+
 	// Get a list of the route kinds that the listener accepts.
 	listenerRouteKinds := p.getListenerRouteKinds(listener, gwAccessor)
 	gwAccessor.SetListenerSupportedKinds(string(listener.Name), listenerRouteKinds)
@@ -235,11 +397,11 @@ func (p *GatewayAPIProcessor) computeListener(listener gatewayapi_v1alpha2.Liste
 
 				// If the Gateway selects the HTTPRoute, check to see if the HTTPRoute selects
 				// the Gateway/listener.
-				if !routeSelectsGatewayListener(p.source.gateway, listener, route.Spec.ParentRefs, route.Namespace) {
+				if !routeSelectsGatewayListener(p.gateway, listener, route.Spec.ParentRefs, route.Namespace) {
 					continue
 				}
 
-				if p.computeHTTPRoute(route, listenerSecret, listener.Hostname, isGatewayValid) {
+				if p.computeHTTPRoute(route, listenerSecret, listener.Hostname, int(listener.Port), isGatewayValid) {
 					attachedRoutes++
 				}
 			}
@@ -256,20 +418,92 @@ func (p *GatewayAPIProcessor) computeListener(listener gatewayapi_v1alpha2.Liste
 
 				// If the Gateway selects the TLSRoute, check to see if the TLSRoute selects
 				// the Gateway/listener.
-				if !routeSelectsGatewayListener(p.source.gateway, listener, route.Spec.ParentRefs, route.Namespace) {
+				if !routeSelectsGatewayListener(p.gateway, listener, route.Spec.ParentRefs, route.Namespace) {
 					continue
 				}
 
-				if p.computeTLSRoute(route, listenerSecret, listener.Hostname, isGatewayValid) {
+				if p.computeTLSRoute(route, listenerSecret, listener.Hostname, int(listener.Port), isGatewayValid) {
 					attachedRoutes++
 				}
 			}
+		case KindTCPRoute, KindUDPRoute:
+			attachedRoutes += p.computeListenerProxyRoutes(routeKind, listener, isGatewayValid)
 		}
 	}
 
 	gwAccessor.SetListenerAttachedRoutes(string(listener.Name), attachedRoutes)
 }
 
+// computeListenerProxyRoutes attaches the routes of the given kind, which
+// is a kind of route that proxies all the traffic of a listener port, to
+// the listener and returns the number of routes attached. Only one such
+// route can be attached to a listener, so the routes are processed in a
+// stable order to always attach the same one.
+func (p *GatewayAPIProcessor) computeListenerProxyRoutes(kind gatewayapi_v1alpha2.Kind, listener gatewayapi_v1alpha2.Listener, isGatewayValid bool) int {
+	type proxyRoute struct {
+		name       types.NamespacedName
+		parentRefs []gatewayapi_v1alpha2.ParentReference
+		compute    func() bool
+	}
+
+	var routes []proxyRoute
+	switch kind {
+	case KindTCPRoute:
+		for _, route := range p.source.tcproutes {
+			route := route
+			routes = append(routes, proxyRoute{
+				name:       k8s.NamespacedNameOf(route),
+				parentRefs: route.Spec.ParentRefs,
+				compute: func() bool {
+					return p.computeTCPRoute(route, int(listener.Port), isGatewayValid)
+				},
+			})
+		}
+	case KindUDPRoute:
+		for _, route := range p.source.udproutes {
+			route := route
+			routes = append(routes, proxyRoute{
+				name:       k8s.NamespacedNameOf(route),
+				parentRefs: route.Spec.ParentRefs,
+				compute: func() bool {
+					return p.computeUDPRoute(route, int(listener.Port), isGatewayValid)
+				},
+			})
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].name.Namespace != routes[j].name.Namespace {
+			return routes[i].name.Namespace < routes[j].name.Namespace
+		}
+		return routes[i].name.Name < routes[j].name.Name
+	})
+
+	attachedRoutes := 0
+	for _, route := range routes {
+		// Check if the route is in a namespace that the listener allows.
+		nsMatches, err := p.namespaceMatches(listener.AllowedRoutes.Namespaces, route.name.Namespace)
+		if err != nil {
+			p.Errorf("error validating namespaces against Listener.Routes.Namespaces: %s", err)
+		}
+		if !nsMatches {
+			continue
+		}
+
+		// If the Gateway selects the route, check to see if the route selects
+		// the Gateway/listener.
+		if !routeSelectsGatewayListener(p.gateway, listener, route.parentRefs, route.name.Namespace) {
+			continue
+		}
+
+		if route.compute() {
+			attachedRoutes++
+		}
+	}
+
+	return attachedRoutes
+}
+
 // getListenerRouteKinds gets a list of the valid route kinds that
 // the listener accepts.
 func (p *GatewayAPIProcessor) getListenerRouteKinds(listener gatewayapi_v1alpha2.Listener, gwAccessor *status.GatewayStatusUpdate) []gatewayapi_v1alpha2.Kind {
@@ -283,6 +517,10 @@ func (p *GatewayAPIProcessor) getListenerRouteKinds(listener gatewayapi_v1alpha2
 			return []gatewayapi_v1alpha2.Kind{KindHTTPRoute}
 		case gatewayapi_v1alpha2.TLSProtocolType:
 			return []gatewayapi_v1alpha2.Kind{KindTLSRoute}
+		case gatewayapi_v1alpha2.TCPProtocolType:
+			return []gatewayapi_v1alpha2.Kind{KindTCPRoute}
+		case gatewayapi_v1alpha2.UDPProtocolType:
+			return []gatewayapi_v1alpha2.Kind{KindUDPRoute}
 		}
 	}
 
@@ -299,13 +537,13 @@ func (p *GatewayAPIProcessor) getListenerRouteKinds(listener gatewayapi_v1alpha2
 			)
 			continue
 		}
-		if routeKind.Kind != KindHTTPRoute && routeKind.Kind != KindTLSRoute {
+		if routeKind.Kind != KindHTTPRoute && routeKind.Kind != KindTLSRoute && routeKind.Kind != KindTCPRoute && routeKind.Kind != KindUDPRoute {
 			gwAccessor.AddListenerCondition(
 				string(listener.Name),
 				gatewayapi_v1alpha2.ListenerConditionResolvedRefs,
 				metav1.ConditionFalse,
 				gatewayapi_v1alpha2.ListenerReasonInvalidRouteKinds,
-				fmt.Sprintf("Kind %q is not supported, kind must be %q or %q", routeKind.Kind, KindHTTPRoute, KindTLSRoute),
+				fmt.Sprintf("Kind %q is not supported, kind must be %q, %q, %q or %q", routeKind.Kind, KindHTTPRoute, KindTLSRoute, KindTCPRoute, KindUDPRoute),
 			)
 			continue
 		}
@@ -319,6 +557,17 @@ func (p *GatewayAPIProcessor) getListenerRouteKinds(listener gatewayapi_v1alpha2
 			)
 			continue
 		}
+		if (routeKind.Kind == KindTCPRoute) != (listener.Protocol == gatewayapi_v1alpha2.TCPProtocolType) ||
+			(routeKind.Kind == KindUDPRoute) != (listener.Protocol == gatewayapi_v1alpha2.UDPProtocolType) {
+			gwAccessor.AddListenerCondition(
+				string(listener.Name),
+				gatewayapi_v1alpha2.ListenerConditionResolvedRefs,
+				metav1.ConditionFalse,
+				gatewayapi_v1alpha2.ListenerReasonInvalidRouteKinds,
+				fmt.Sprintf("%ss are incompatible with listener protocol %q", routeKind.Kind, listener.Protocol),
+			)
+			continue
+		}
 
 		routeKinds = append(routeKinds, routeKind.Kind)
 	}
@@ -342,7 +591,7 @@ func (p *GatewayAPIProcessor) validGatewayTLS(listenerTLS gatewayapi_v1alpha2.Ga
 
 	// Validate a v1.Secret is referenced which can be kind: secret & group: core.
 	// ref: https://github.com/kubernetes-sigs/gateway-api/pull/562
-	if !isSecretRef(*certificateRef) {
+	if !isSecretRef(certificateRef) {
 		gwAccessor.AddListenerCondition(
 			listenerName,
 			gatewayapi_v1alpha2.ListenerConditionResolvedRefs,
@@ -355,12 +604,12 @@ func (p *GatewayAPIProcessor) validGatewayTLS(listenerTLS gatewayapi_v1alpha2.Ga
 
 	// If the secret is in a different namespace than the gateway, then we need to
 	// check for a ReferencePolicy that allows the reference.
-	if certificateRef.Namespace != nil && string(*certificateRef.Namespace) != p.source.gateway.Namespace {
+	if certificateRef.Namespace != nil && string(*certificateRef.Namespace) != p.gateway.Namespace {
 		if !p.validCrossNamespaceRef(
 			crossNamespaceFrom{
 				group:     gatewayapi_v1alpha2.GroupName,
 				kind:      KindGateway,
-				namespace: p.source.gateway.Namespace,
+				namespace: p.gateway.Namespace,
 			},
 			crossNamespaceTo{
 				group:     "",
@@ -384,7 +633,7 @@ func (p *GatewayAPIProcessor) validGatewayTLS(listenerTLS gatewayapi_v1alpha2.Ga
 	if certificateRef.Namespace != nil {
 		meta = types.NamespacedName{Name: string(certificateRef.Name), Namespace: string(*certificateRef.Namespace)}
 	} else {
-		meta = types.NamespacedName{Name: string(certificateRef.Name), Namespace: p.source.gateway.Namespace}
+		meta = types.NamespacedName{Name: string(certificateRef.Name), Namespace: p.gateway.Namespace}
 	}
 
 	listenerSecret, err := p.source.LookupSecret(meta, validTLSSecret)
@@ -576,7 +825,7 @@ func (p *GatewayAPIProcessor) namespaceMatches(namespaces *gatewayapi_v1alpha2.R
 	case gatewayapi_v1alpha2.NamespacesFromAll:
 		return true, nil
 	case gatewayapi_v1alpha2.NamespacesFromSame:
-		return p.source.gateway.Namespace == routeNamespace, nil
+		return p.gateway.Namespace == routeNamespace, nil
 	case gatewayapi_v1alpha2.NamespacesFromSelector:
 		if namespaces.Selector == nil ||
 			(len(namespaces.Selector.MatchLabels) == 0 && len(namespaces.Selector.MatchExpressions) == 0) {
@@ -602,7 +851,7 @@ func (p *GatewayAPIProcessor) namespaceMatches(namespaces *gatewayapi_v1alpha2.R
 
 // routeSelectsGatewayListener determines whether a route selects
 // a given Gateway+Listener.
-func routeSelectsGatewayListener(gateway *gatewayapi_v1alpha2.Gateway, listener gatewayapi_v1alpha2.Listener, routeParentRefs []gatewayapi_v1alpha2.ParentRef, routeNamespace string) bool {
+func routeSelectsGatewayListener(gateway *gatewayapi_v1alpha2.Gateway, listener gatewayapi_v1alpha2.Listener, routeParentRefs []gatewayapi_v1alpha2.ParentReference, routeNamespace string) bool {
 	for _, ref := range routeParentRefs {
 		if ref.Group == nil || ref.Kind == nil {
 			continue
@@ -667,14 +916,14 @@ func (p *GatewayAPIProcessor) computeGatewayConditions(gwAccessor *status.Gatewa
 	}
 }
 
-func (p *GatewayAPIProcessor) computeTLSRoute(route *gatewayapi_v1alpha2.TLSRoute, listenerSecret *Secret, listenerHostname *gatewayapi_v1alpha2.Hostname, validGateway bool) bool {
+func (p *GatewayAPIProcessor) computeTLSRoute(route *gatewayapi_v1alpha2.TLSRoute, listenerSecret *Secret, listenerHostname *gatewayapi_v1alpha2.Hostname, listenerPort int, validGateway bool) bool {
 
-	routeAccessor, commit := p.dag.StatusCache.RouteConditionsAccessor(k8s.NamespacedNameOf(route), route.Generation, &gatewayapi_v1alpha2.TLSRoute{}, route.Status.Parents)
+	routeAccessor, commit := p.dag.StatusCache.RouteConditionsAccessor(k8s.NamespacedNameOf(route), route.Generation, &gatewayapi_v1alpha2.TLSRoute{}, k8s.NamespacedNameOf(p.gateway), route.Status.Parents)
 	defer commit()
 
 	// If the Gateway is invalid, set status on the route.
 	if !validGateway {
-		routeAccessor.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionFalse, status.ReasonInvalidGateway, "Invalid Gateway")
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonInvalidGateway, "Invalid Gateway")
 		return false
 	}
 
@@ -685,7 +934,7 @@ func (p *GatewayAPIProcessor) computeTLSRoute(route *gatewayapi_v1alpha2.TLSRout
 
 	// Check if all the hostnames are invalid.
 	if len(hosts) == 0 {
-		routeAccessor.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
 		return false
 	}
 
@@ -720,9 +969,11 @@ func (p *GatewayAPIProcessor) computeTLSRoute(route *gatewayapi_v1alpha2.TLSRout
 			// https://github.com/projectcontour/contour/issues/3593
 			service.Weighted.Weight = routeWeight
 			proxy.Clusters = append(proxy.Clusters, &Cluster{
-				Upstream: service,
-				SNI:      service.ExternalName,
-				Weight:   routeWeight,
+				Upstream:         service,
+				SNI:              service.ExternalName,
+				Weight:           routeWeight,
+				ZoneAwareRouting: p.ZoneAwareRouting,
+				CircuitBreakers:  circuitBreakers(service, p.CircuitBreakerDefaults),
 			})
 		}
 
@@ -740,7 +991,7 @@ func (p *GatewayAPIProcessor) computeTLSRoute(route *gatewayapi_v1alpha2.TLSRout
 		}
 
 		for host := range hosts {
-			secure := p.dag.EnsureSecureVirtualHost(host)
+			secure := p.dag.EnsureSecureVirtualHost(listenerPort, host)
 
 			if listenerSecret != nil {
 				secure.Secret = listenerSecret
@@ -756,21 +1007,188 @@ func (p *GatewayAPIProcessor) computeTLSRoute(route *gatewayapi_v1alpha2.TLSRout
 	// condition accordingly.
 	switch len(routeAccessor.Conditions) {
 	case 0:
-		routeAccessor.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionTrue, status.ReasonValid, "Valid TLSRoute")
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionTrue, status.ReasonValid, "Valid TLSRoute")
+	default:
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
+	}
+
+	return programmed
+}
+
+// computeTCPRoute adds the backends of the TCPRoute to the TCP
+// proxy for the listener port, and returns true if any were added.
+func (p *GatewayAPIProcessor) computeTCPRoute(route *gatewayapi_v1alpha2.TCPRoute, listenerPort int, validGateway bool) bool {
+	routeAccessor, commit := p.dag.StatusCache.RouteConditionsAccessor(k8s.NamespacedNameOf(route), route.Generation, &gatewayapi_v1alpha2.TCPRoute{}, k8s.NamespacedNameOf(p.gateway), route.Status.Parents)
+	defer commit()
+
+	// If the Gateway is invalid, set status on the route.
+	if !validGateway {
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonInvalidGateway, "Invalid Gateway")
+		return false
+	}
+
+	// The backends of all the route's rules share the listener's
+	// port, so they are combined into a single TCP proxy.
+	var routeClusters []*Cluster
+	for _, rule := range route.Spec.Rules {
+		if len(rule.BackendRefs) == 0 {
+			routeAccessor.AddCondition(status.ConditionResolvedRefs, metav1.ConditionFalse, status.ReasonDegraded, "At least one Spec.Rules.BackendRef must be specified.")
+			continue
+		}
+
+		var clusters []*Cluster
+		var totalWeight uint32
+
+		for _, backendRef := range rule.BackendRefs {
+
+			service, err := p.validateBackendRef(backendRef, KindTCPRoute, route.Namespace)
+			if err != nil {
+				routeAccessor.AddCondition(status.ConditionResolvedRefs, metav1.ConditionFalse, status.ReasonDegraded, err.Error())
+				continue
+			}
+
+			// Route defaults to a weight of "1" unless otherwise specified.
+			routeWeight := uint32(1)
+			if backendRef.Weight != nil {
+				routeWeight = uint32(*backendRef.Weight)
+			}
+
+			// Keep track of all the weights for this set of backendRefs. This will be
+			// used later to understand if all the weights are set to zero.
+			totalWeight += routeWeight
+
+			// https://github.com/projectcontour/contour/issues/3593
+			service.Weighted.Weight = routeWeight
+			clusters = append(clusters, &Cluster{
+				Upstream:         service,
+				SNI:              service.ExternalName,
+				Weight:           routeWeight,
+				ZoneAwareRouting: p.ZoneAwareRouting,
+				CircuitBreakers:  circuitBreakers(service, p.CircuitBreakerDefaults),
+			})
+		}
+
+		// No clusters added: they were all invalid, so reject
+		// the route (it already has a relevant condition set).
+		if len(clusters) == 0 {
+			continue
+		}
+
+		// If we have valid clusters but they all have a zero
+		// weight, reject the route.
+		if totalWeight == 0 {
+			routeAccessor.AddCondition(status.ConditionValidBackendRefs, metav1.ConditionFalse, status.ReasonAllBackendRefsHaveZeroWeights, "At least one Spec.Rules.BackendRef must have a non-zero weight.")
+			continue
+		}
+
+		routeClusters = append(routeClusters, clusters...)
+	}
+
+	var programmed bool
+	if len(routeClusters) > 0 {
+		// Only one TCPRoute can be attached to a listener, otherwise
+		// connections to the port would be balanced across the
+		// backends of unrelated routes.
+		proxy := p.dag.EnsureTCPProxy(listenerPort)
+		if len(proxy.Clusters) > 0 {
+			routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonNotAllowedByListeners, fmt.Sprintf("Another TCPRoute is already attached to listener port %d.", listenerPort))
+			return false
+		}
+		proxy.Clusters = routeClusters
+		programmed = true
+	}
+
+	// Determine if any errors exist in conditions and set the "Accepted"
+	// condition accordingly.
+	switch len(routeAccessor.Conditions) {
+	case 0:
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionTrue, status.ReasonValid, "Valid TCPRoute")
+	default:
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
+	}
+
+	return programmed
+}
+
+// computeUDPRoute sets the backend of the UDPRoute as the cluster of the
+// UDP proxy for the listener port, and returns true if it was set.
+func (p *GatewayAPIProcessor) computeUDPRoute(route *gatewayapi_v1alpha2.UDPRoute, listenerPort int, validGateway bool) bool {
+	routeAccessor, commit := p.dag.StatusCache.RouteConditionsAccessor(k8s.NamespacedNameOf(route), route.Generation, &gatewayapi_v1alpha2.UDPRoute{}, k8s.NamespacedNameOf(p.gateway), route.Status.Parents)
+	defer commit()
+
+	// If the Gateway is invalid, set status on the route.
+	if !validGateway {
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonInvalidGateway, "Invalid Gateway")
+		return false
+	}
+
+	// Envoy's UDP proxy forwards to a single cluster, so the
+	// route must have exactly one backendRef across its rules.
+	var backendRefs []gatewayapi_v1alpha2.BackendRef
+	for _, rule := range route.Spec.Rules {
+		backendRefs = append(backendRefs, rule.BackendRefs...)
+	}
+
+	var programmed bool
+	switch len(backendRefs) {
+	case 0:
+		routeAccessor.AddCondition(status.ConditionResolvedRefs, metav1.ConditionFalse, status.ReasonDegraded, "At least one Spec.Rules.BackendRef must be specified.")
+	case 1:
+		programmed = p.computeUDPRouteBackend(backendRefs[0], route.Namespace, listenerPort, routeAccessor)
+	default:
+		routeAccessor.AddCondition(status.ConditionValidBackendRefs, metav1.ConditionFalse, status.ReasonMultipleBackendRefs, "Only one Spec.Rules.BackendRef may be specified.")
+	}
+
+	// Determine if any errors exist in conditions and set the "Accepted"
+	// condition accordingly, unless the listener did not accept the route.
+	if _, ok := routeAccessor.Conditions[gatewayapi_v1alpha2.RouteConditionAccepted]; ok {
+		return programmed
+	}
+	switch len(routeAccessor.Conditions) {
+	case 0:
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionTrue, status.ReasonValid, "Valid UDPRoute")
 	default:
-		routeAccessor.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
 	}
 
 	return programmed
 }
 
-func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRoute, listenerSecret *Secret, listenerHostname *gatewayapi_v1alpha2.Hostname, validGateway bool) bool {
-	routeAccessor, commit := p.dag.StatusCache.RouteConditionsAccessor(k8s.NamespacedNameOf(route), route.Generation, &gatewayapi_v1alpha2.HTTPRoute{}, route.Status.Parents)
+func (p *GatewayAPIProcessor) computeUDPRouteBackend(backendRef gatewayapi_v1alpha2.BackendRef, routeNamespace string, listenerPort int, routeAccessor *status.RouteConditionsUpdate) bool {
+	service, err := p.validateBackendRef(backendRef, KindUDPRoute, routeNamespace)
+	if err != nil {
+		routeAccessor.AddCondition(status.ConditionResolvedRefs, metav1.ConditionFalse, status.ReasonDegraded, err.Error())
+		return false
+	}
+
+	if backendRef.Weight != nil && *backendRef.Weight == 0 {
+		routeAccessor.AddCondition(status.ConditionValidBackendRefs, metav1.ConditionFalse, status.ReasonAllBackendRefsHaveZeroWeights, "At least one Spec.Rules.BackendRef must have a non-zero weight.")
+		return false
+	}
+
+	proxy := p.dag.EnsureUDPProxy(listenerPort)
+	if proxy.Cluster != nil {
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonNotAllowedByListeners, fmt.Sprintf("Another UDPRoute is already attached to listener port %d.", listenerPort))
+		return false
+	}
+
+	proxy.Cluster = &Cluster{
+		Upstream:         service,
+		SNI:              service.ExternalName,
+		ZoneAwareRouting: p.ZoneAwareRouting,
+		CircuitBreakers:  circuitBreakers(service, p.CircuitBreakerDefaults),
+	}
+
+	return true
+}
+
+func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRoute, listenerSecret *Secret, listenerHostname *gatewayapi_v1alpha2.Hostname, listenerPort int, validGateway bool) bool {
+	routeAccessor, commit := p.dag.StatusCache.RouteConditionsAccessor(k8s.NamespacedNameOf(route), route.Generation, &gatewayapi_v1alpha2.HTTPRoute{}, k8s.NamespacedNameOf(p.gateway), route.Status.Parents)
 	defer commit()
 
 	// If the Gateway is invalid, set status on the route.
 	if !validGateway {
-		routeAccessor.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionFalse, status.ReasonInvalidGateway, "Invalid Gateway")
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonInvalidGateway, "Invalid Gateway")
 		return false
 	}
 
@@ -781,7 +1199,7 @@ func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRo
 
 	// Check if all the hostnames are invalid.
 	if len(hosts) == 0 {
-		routeAccessor.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
 		return false
 	}
 
@@ -795,9 +1213,8 @@ func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRo
 				continue
 			}
 
-			headerMatches, err := gatewayHeaderMatchConditions(match.Headers)
-			if err != nil {
-				routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonHeaderMatchType, "HTTPRoute.Spec.Rules.HeaderMatch: Only Exact match type is supported.")
+			headerMatches, ok := gatewayHeaderMatchConditions(match.Headers, routeAccessor)
+			if !ok {
 				continue
 			}
 
@@ -811,9 +1228,15 @@ func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRo
 				})
 			}
 
+			queryParamMatches, ok := gatewayQueryParamMatchConditions(match.QueryParams, routeAccessor)
+			if !ok {
+				continue
+			}
+
 			matchconditions = append(matchconditions, &matchConditions{
-				path:    pathMatch,
-				headers: headerMatches,
+				path:        pathMatch,
+				headers:     headerMatches,
+				queryParams: queryParamMatches,
 			})
 		}
 
@@ -822,6 +1245,8 @@ func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRo
 			headerPolicy       *HeadersPolicy
 			headerModifierSeen bool
 			redirect           *gatewayapi_v1alpha2.HTTPRequestRedirectFilter
+			urlRewrite         *gatewayapi_v1alpha2.HTTPURLRewriteFilter
+			mirrorPolicies     []*MirrorPolicy
 		)
 
 		for _, filter := range rule.Filters {
@@ -847,10 +1272,48 @@ func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRo
 				// custom conformance.", here we choose to just select the first one.
 				if redirect == nil && filter.RequestRedirect != nil {
 					redirect = filter.RequestRedirect
+					if redirect.Path != nil {
+						routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonUnsupportedValue,
+							"HTTPRoute.Spec.Rules.Filters: RequestRedirect.Path is not supported.")
+					}
+				}
+			case gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite:
+				// As with the other core filters, only the first one is processed.
+				if urlRewrite == nil && filter.URLRewrite != nil {
+					urlRewrite = filter.URLRewrite
+				}
+			case gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror:
+				// Envoy supports multiple mirror policies per route, so
+				// every RequestMirror filter is processed.
+				if filter.RequestMirror == nil {
+					continue
+				}
+
+				if mirrorPolicy := p.mirrorPolicy(filter.RequestMirror, route.Namespace, routeAccessor); mirrorPolicy != nil {
+					mirrorPolicies = append(mirrorPolicies, mirrorPolicy)
 				}
 			default:
 				routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonHTTPRouteFilterType,
-					fmt.Sprintf("HTTPRoute.Spec.Rules.Filters: invalid type %q: only RequestHeaderModifier and RequestRedirect are supported.", filter.Type))
+					fmt.Sprintf("HTTPRoute.Spec.Rules.Filters: invalid type %q: only RequestHeaderModifier, RequestRedirect, URLRewrite and RequestMirror are supported.", filter.Type))
+			}
+		}
+
+		// Per Gateway API, a URLRewrite filter must not be used on the
+		// same rule as a RequestRedirect filter.
+		if redirect != nil && urlRewrite != nil {
+			routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonHTTPRouteFilterType,
+				"HTTPRoute.Spec.Rules.Filters: URLRewrite cannot be used on the same rule as RequestRedirect.")
+			continue
+		}
+
+		var pathRewrite *gatewayapi_v1alpha2.HTTPPathModifier
+		if urlRewrite != nil {
+			pathRewrite = urlRewrite.Path
+			if urlRewrite.Hostname != nil {
+				if headerPolicy == nil {
+					headerPolicy = &HeadersPolicy{}
+				}
+				headerPolicy.HostRewrite = string(*urlRewrite.Hostname)
 			}
 		}
 
@@ -862,7 +1325,7 @@ func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRo
 		if redirect != nil {
 			routes = p.redirectRoutes(matchconditions, headerPolicy, redirect)
 		} else {
-			routes = p.clusterRoutes(route.Namespace, matchconditions, headerPolicy, rule.BackendRefs, routeAccessor)
+			routes = p.clusterRoutes(route.Namespace, matchconditions, headerPolicy, pathRewrite, mirrorPolicies, rule.BackendRefs, routeAccessor)
 		}
 
 		// Add each route to the relevant vhost(s)/svhosts(s).
@@ -878,11 +1341,11 @@ func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRo
 
 				switch {
 				case listenerSecret != nil:
-					svhost := p.dag.EnsureSecureVirtualHost(host)
+					svhost := p.dag.EnsureSecureVirtualHost(listenerPort, host)
 					svhost.Secret = listenerSecret
 					svhost.addRoute(route)
 				default:
-					vhost := p.dag.EnsureVirtualHost(host)
+					vhost := p.dag.EnsureVirtualHost(listenerPort, host)
 					vhost.addRoute(route)
 				}
 
@@ -895,9 +1358,9 @@ func (p *GatewayAPIProcessor) computeHTTPRoute(route *gatewayapi_v1alpha2.HTTPRo
 	// condition accordingly.
 	switch len(routeAccessor.Conditions) {
 	case 0:
-		routeAccessor.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionTrue, status.ReasonValid, "Valid HTTPRoute")
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionTrue, status.ReasonValid, "Valid HTTPRoute")
 	default:
-		routeAccessor.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
+		routeAccessor.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionFalse, status.ReasonErrorsExist, "Errors found, check other Conditions for details.")
 	}
 
 	return programmed
@@ -949,8 +1412,14 @@ func (p *GatewayAPIProcessor) validateBackendRef(backendRef gatewayapi_v1alpha2.
 		meta = types.NamespacedName{Name: string(backendRef.Name), Namespace: routeNamespace}
 	}
 
+	// UDPRoutes can only be backed by UDP service ports.
+	ensureService := p.dag.EnsureService
+	if routeKind == KindUDPRoute {
+		ensureService = p.dag.EnsureUDPService
+	}
+
 	// TODO: Refactor EnsureService to take an int32 so conversion to intstr is not needed.
-	service, err := p.dag.EnsureService(meta, intstr.FromInt(int(*backendRef.Port)), p.source, p.EnableExternalNameService)
+	service, err := ensureService(meta, intstr.FromInt(int(*backendRef.Port)), p.source, p.EnableExternalNameService)
 	if err != nil {
 		return nil, fmt.Errorf("service %q is invalid: %s", meta.Name, err)
 	}
@@ -998,11 +1467,20 @@ func gatewayPathMatchCondition(match *gatewayapi_v1alpha2.HTTPPathMatch, routeAc
 		return &ExactMatchCondition{Path: path}, true
 	}
 
-	routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonPathMatchType, "HTTPRoute.Spec.Rules.PathMatch: Only Prefix match type and Exact match type are supported.")
+	if *match.Type == gatewayapi_v1alpha2.PathMatchRegularExpression {
+		if err := ValidateRegex(path); err != nil {
+			routeAccessor.AddCondition(status.ConditionValidMatches, metav1.ConditionFalse, status.ReasonInvalidPathMatch, "Match.Path.Value must be a valid regular expression.")
+			return nil, false
+		}
+
+		return &RegexMatchCondition{Regex: path}, true
+	}
+
+	routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonPathMatchType, "HTTPRoute.Spec.Rules.PathMatch: Only Prefix, Exact and RegularExpression match types are supported.")
 	return nil, false
 }
 
-func gatewayHeaderMatchConditions(matches []gatewayapi_v1alpha2.HTTPHeaderMatch) ([]HeaderMatchCondition, error) {
+func gatewayHeaderMatchConditions(matches []gatewayapi_v1alpha2.HTTPHeaderMatch, routeAccessor *status.RouteConditionsUpdate) ([]HeaderMatchCondition, bool) {
 	var headerMatchConditions []HeaderMatchCondition
 
 	for _, match := range matches {
@@ -1012,25 +1490,63 @@ func gatewayHeaderMatchConditions(matches []gatewayapi_v1alpha2.HTTPHeaderMatch)
 			switch *match.Type {
 			case gatewayapi_v1alpha2.HeaderMatchExact:
 				headerMatchType = HeaderMatchTypeExact
+			case gatewayapi_v1alpha2.HeaderMatchRegularExpression:
+				if err := ValidateRegex(match.Value); err != nil {
+					routeAccessor.AddCondition(status.ConditionValidMatches, metav1.ConditionFalse, status.ReasonInvalidHeaderMatch, "Match.Headers.Value must be a valid regular expression.")
+					return nil, false
+				}
+				headerMatchType = HeaderMatchTypeRegex
 			default:
-				return nil, fmt.Errorf("HTTPRoute.Spec.Rules.HeaderMatch: Only Exact match type is supported")
+				routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonHeaderMatchType, "HTTPRoute.Spec.Rules.HeaderMatch: Only Exact and RegularExpression match types are supported.")
+				return nil, false
 			}
 		}
 
 		headerMatchConditions = append(headerMatchConditions, HeaderMatchCondition{MatchType: headerMatchType, Name: string(match.Name), Value: match.Value})
 	}
 
-	return headerMatchConditions, nil
+	return headerMatchConditions, true
 }
 
-// clusterRoutes builds a []*dag.Route for the supplied set of matchConditions, headerPolicy and backendRefs.
-func (p *GatewayAPIProcessor) clusterRoutes(routeNamespace string, matchConditions []*matchConditions, headerPolicy *HeadersPolicy, backendRefs []gatewayapi_v1alpha2.HTTPBackendRef, routeAccessor *status.RouteConditionsUpdate) []*Route {
+func gatewayQueryParamMatchConditions(matches []gatewayapi_v1alpha2.HTTPQueryParamMatch, routeAccessor *status.RouteConditionsUpdate) ([]QueryParamMatchCondition, bool) {
+	var queryParamMatchConditions []QueryParamMatchCondition
+
+	for _, match := range matches {
+		// QueryParamMatchTypeExact is the default if not defined in the object.
+		queryParamMatchType := QueryParamMatchTypeExact
+		if match.Type != nil {
+			switch *match.Type {
+			case gatewayapi_v1alpha2.QueryParamMatchExact:
+				queryParamMatchType = QueryParamMatchTypeExact
+			case gatewayapi_v1alpha2.QueryParamMatchRegularExpression:
+				if err := ValidateRegex(match.Value); err != nil {
+					routeAccessor.AddCondition(status.ConditionValidMatches, metav1.ConditionFalse, status.ReasonInvalidQueryParamMatch, "Match.QueryParams.Value must be a valid regular expression.")
+					return nil, false
+				}
+				queryParamMatchType = QueryParamMatchTypeRegex
+			default:
+				routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonQueryParamMatchType, "HTTPRoute.Spec.Rules.QueryParamMatch: Only Exact and RegularExpression match types are supported.")
+				return nil, false
+			}
+		}
+
+		queryParamMatchConditions = append(queryParamMatchConditions, QueryParamMatchCondition{MatchType: queryParamMatchType, Name: match.Name, Value: match.Value})
+	}
+
+	return queryParamMatchConditions, true
+}
+
+// clusterRoutes builds a []*dag.Route for the supplied set of matchConditions, headerPolicy, pathRewrite, mirrorPolicies and backendRefs.
+func (p *GatewayAPIProcessor) clusterRoutes(routeNamespace string, matchConditions []*matchConditions, headerPolicy *HeadersPolicy, pathRewrite *gatewayapi_v1alpha2.HTTPPathModifier, mirrorPolicies []*MirrorPolicy, backendRefs []gatewayapi_v1alpha2.HTTPBackendRef, routeAccessor *status.RouteConditionsUpdate) []*Route {
 	if len(backendRefs) == 0 {
 		routeAccessor.AddCondition(status.ConditionResolvedRefs, metav1.ConditionFalse, status.ReasonDegraded, "At least one Spec.Rules.BackendRef must be specified.")
 		return nil
 	}
 
-	var clusters []*Cluster
+	var (
+		clusters       []*Cluster
+		backendMirrors []*MirrorPolicy
+	)
 
 	// Validate the backend refs.
 	totalWeight := uint32(0)
@@ -1041,6 +1557,12 @@ func (p *GatewayAPIProcessor) clusterRoutes(routeNamespace string, matchConditio
 			continue
 		}
 
+		// Route defaults to a weight of "1" unless otherwise specified.
+		routeWeight := uint32(1)
+		if backendRef.Weight != nil {
+			routeWeight = uint32(*backendRef.Weight)
+		}
+
 		var headerPolicy *HeadersPolicy
 		for _, filter := range backendRef.Filters {
 			switch filter.Type {
@@ -1050,17 +1572,48 @@ func (p *GatewayAPIProcessor) clusterRoutes(routeNamespace string, matchConditio
 				if err != nil {
 					routeAccessor.AddCondition(status.ConditionResolvedRefs, metav1.ConditionFalse, status.ReasonDegraded, fmt.Sprintf("%s on request headers", err))
 				}
+			case gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite:
+				if filter.URLRewrite == nil {
+					continue
+				}
+
+				if filter.URLRewrite.Hostname != nil {
+					if headerPolicy == nil {
+						headerPolicy = &HeadersPolicy{}
+					}
+					headerPolicy.HostRewrite = string(*filter.URLRewrite.Hostname)
+				}
+
+				// Envoy rewrites paths per route rather than per weighted
+				// cluster, so a backendRef can only rewrite the path if it
+				// is the rule's only backendRef.
+				if filter.URLRewrite.Path != nil {
+					switch {
+					case len(backendRefs) > 1:
+						routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonHTTPRouteFilterType,
+							"HTTPRoute.Spec.Rules.BackendRef.Filters: URLRewrite.Path is only supported when the rule has a single backendRef.")
+					case pathRewrite == nil:
+						pathRewrite = filter.URLRewrite.Path
+					}
+				}
+			case gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror:
+				if filter.RequestMirror == nil {
+					continue
+				}
+
+				// Envoy mirrors requests per route rather than per weighted
+				// cluster, so a backendRef mirrors its share of the route's
+				// requests instead. The total weight is filled in once all
+				// the backendRefs are known.
+				if mirrorPolicy := p.mirrorPolicy(filter.RequestMirror, routeNamespace, routeAccessor); mirrorPolicy != nil {
+					mirrorPolicy.Weight = routeWeight
+					backendMirrors = append(backendMirrors, mirrorPolicy)
+				}
 			default:
-				routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonHTTPRouteFilterType, "HTTPRoute.Spec.Rules.BackendRef.Filters: Only RequestHeaderModifier type is supported.")
+				routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonHTTPRouteFilterType, "HTTPRoute.Spec.Rules.BackendRef.Filters: Only RequestHeaderModifier, URLRewrite and RequestMirror types are supported.")
 			}
 		}
 
-		// Route defaults to a weight of "1" unless otherwise specified.
-		routeWeight := uint32(1)
-		if backendRef.Weight != nil {
-			routeWeight = uint32(*backendRef.Weight)
-		}
-
 		// Keep track of all the weights for this set of backend refs. This will be
 		// used later to understand if all the weights are set to zero.
 		totalWeight += routeWeight
@@ -1072,9 +1625,16 @@ func (p *GatewayAPIProcessor) clusterRoutes(routeNamespace string, matchConditio
 			Weight:               routeWeight,
 			Protocol:             service.Protocol,
 			RequestHeadersPolicy: headerPolicy,
+			ZoneAwareRouting:     p.ZoneAwareRouting,
+			CircuitBreakers:      circuitBreakers(service, p.CircuitBreakerDefaults),
 		})
 	}
 
+	for _, mirrorPolicy := range backendMirrors {
+		mirrorPolicy.TotalWeight = totalWeight
+	}
+	mirrorPolicies = append(mirrorPolicies, backendMirrors...)
+
 	var routes []*Route
 
 	// Per Gateway API: "Each match is independent,
@@ -1082,11 +1642,20 @@ func (p *GatewayAPIProcessor) clusterRoutes(routeNamespace string, matchConditio
 	// the matches is satisfied." To implement this,
 	// we create a separate route per match.
 	for _, mc := range matchConditions {
+		regexRewrite, err := gatewayPathRewrite(pathRewrite, mc.path)
+		if err != nil {
+			routeAccessor.AddCondition(status.ConditionNotImplemented, metav1.ConditionTrue, status.ReasonUnsupportedValue, err.Error())
+			return nil
+		}
+
 		routes = append(routes, &Route{
-			Clusters:              clusters,
-			PathMatchCondition:    mc.path,
-			HeaderMatchConditions: mc.headers,
-			RequestHeadersPolicy:  headerPolicy,
+			Clusters:                  clusters,
+			PathMatchCondition:        mc.path,
+			HeaderMatchConditions:     mc.headers,
+			QueryParamMatchConditions: mc.queryParams,
+			RequestHeadersPolicy:      headerPolicy,
+			RegexRewrite:              regexRewrite,
+			MirrorPolicies:            mirrorPolicies,
 		})
 	}
 
@@ -1106,6 +1675,92 @@ func (p *GatewayAPIProcessor) clusterRoutes(routeNamespace string, matchConditio
 	return routes
 }
 
+// gatewayPathRewrite returns the RegexRewrite that applies the given URLRewrite
+// path modifier to requests matched by the given path condition, or nil if the
+// path is not rewritten.
+func gatewayPathRewrite(modifier *gatewayapi_v1alpha2.HTTPPathModifier, match MatchCondition) (*RegexRewrite, error) {
+	if modifier == nil {
+		return nil, nil
+	}
+
+	switch modifier.Type {
+	case gatewayapi_v1alpha2.FullPathHTTPPathModifier:
+		if modifier.ReplaceFullPath == nil || !strings.HasPrefix(*modifier.ReplaceFullPath, "/") {
+			return nil, fmt.Errorf("URLRewrite.Path.ReplaceFullPath must start with '/'")
+		}
+
+		return &RegexRewrite{
+			Pattern:      "^/.*$",
+			Substitution: regexSubstitution(*modifier.ReplaceFullPath),
+		}, nil
+	case gatewayapi_v1alpha2.PrefixMatchHTTPPathModifier:
+		if modifier.ReplacePrefixMatch == nil || !strings.HasPrefix(*modifier.ReplacePrefixMatch, "/") {
+			return nil, fmt.Errorf("URLRewrite.Path.ReplacePrefixMatch must start with '/'")
+		}
+
+		prefixMatch, ok := match.(*PrefixMatchCondition)
+		if !ok {
+			return nil, fmt.Errorf("URLRewrite.Path.ReplacePrefixMatch can only be used with PathPrefix matches")
+		}
+
+		// Prefix matches are on whole path elements, so a trailing
+		// '/' on either the prefix or its replacement is ignored.
+		prefix := strings.TrimSuffix(prefixMatch.Prefix, "/")
+		replacement := strings.TrimSuffix(*modifier.ReplacePrefixMatch, "/")
+
+		switch {
+		case prefix == "" && replacement == "":
+			return nil, nil
+		case prefix == "":
+			// Every path matches the "/" prefix, so the
+			// replacement is put in front of the path.
+			return &RegexRewrite{
+				Pattern:      "^/",
+				Substitution: regexSubstitution(replacement + "/"),
+			}, nil
+		case replacement == "":
+			// Removing the prefix must still leave a path
+			// that starts with a single '/'.
+			return &RegexRewrite{
+				Pattern:      "^" + regexp.QuoteMeta(prefix) + "/*",
+				Substitution: "/",
+			}, nil
+		default:
+			return &RegexRewrite{
+				Pattern:      "^" + regexp.QuoteMeta(prefix),
+				Substitution: regexSubstitution(replacement),
+			}, nil
+		}
+	default:
+		return nil, fmt.Errorf("URLRewrite.Path.Type %q is not supported", modifier.Type)
+	}
+}
+
+// regexSubstitution escapes the given value so it is used as a literal
+// regex substitution.
+func regexSubstitution(value string) string {
+	return strings.ReplaceAll(value, `\`, `\\`)
+}
+
+// mirrorPolicy returns the MirrorPolicy for the given RequestMirror filter, or nil
+// if its backend is not valid.
+func (p *GatewayAPIProcessor) mirrorPolicy(filter *gatewayapi_v1alpha2.HTTPRequestMirrorFilter, routeNamespace string, routeAccessor *status.RouteConditionsUpdate) *MirrorPolicy {
+	mirrorService, err := p.validateBackendRef(gatewayapi_v1alpha2.BackendRef{BackendObjectReference: filter.BackendRef}, KindHTTPRoute, routeNamespace)
+	if err != nil {
+		routeAccessor.AddCondition(status.ConditionResolvedRefs, metav1.ConditionFalse, status.ReasonDegraded, fmt.Sprintf("RequestMirror: %s", err))
+		return nil
+	}
+
+	return &MirrorPolicy{
+		Cluster: &Cluster{
+			Upstream:         mirrorService,
+			Protocol:         mirrorService.Protocol,
+			ZoneAwareRouting: p.ZoneAwareRouting,
+			CircuitBreakers:  circuitBreakers(mirrorService, p.CircuitBreakerDefaults),
+		},
+	}
+}
+
 // redirectRoutes builds a []*dag.Route for the supplied set of matchConditions, headerPolicy and redirect.
 func (p *GatewayAPIProcessor) redirectRoutes(matchConditions []*matchConditions, headerPolicy *HeadersPolicy, redirect *gatewayapi_v1alpha2.HTTPRequestRedirectFilter) []*Route {
 	var hostname string
@@ -1142,9 +1797,10 @@ func (p *GatewayAPIProcessor) redirectRoutes(matchConditions []*matchConditions,
 				PortNumber: portNumber,
 				StatusCode: statusCode,
 			},
-			PathMatchCondition:    mc.path,
-			HeaderMatchConditions: mc.headers,
-			RequestHeadersPolicy:  headerPolicy,
+			PathMatchCondition:        mc.path,
+			HeaderMatchConditions:     mc.headers,
+			QueryParamMatchConditions: mc.queryParams,
+			RequestHeadersPolicy:      headerPolicy,
 		})
 	}
 
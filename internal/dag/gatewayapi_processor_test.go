@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
@@ -525,13 +526,13 @@ func TestNamespaceMatches(t *testing.T) {
 
 			processor := &GatewayAPIProcessor{
 				FieldLogger: fixture.NewTestLogger(t),
-				source: &KubernetesCache{
-					gateway: &gatewayapi_v1alpha2.Gateway{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:      "contour",
-							Namespace: "projectcontour",
-						},
+				gateway: &gatewayapi_v1alpha2.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "contour",
+						Namespace: "projectcontour",
 					},
+				},
+				source: &KubernetesCache{
 					namespaces: map[string]*v1.Namespace{
 						"projectcontour": {
 							ObjectMeta: metav1.ObjectMeta{
@@ -572,53 +573,53 @@ func TestNamespaceMatches(t *testing.T) {
 
 func TestRouteSelectsGatewayListener(t *testing.T) {
 	tests := map[string]struct {
-		routeParentRefs []gatewayapi_v1alpha2.ParentRef
+		routeParentRefs []gatewayapi_v1alpha2.ParentReference
 		routeNamespace  string
 		listener        gatewayapi_v1alpha2.Listener
 		want            bool
 	}{
 		"gateway namespace specified, no listener specified, gateway in same namespace as route": {
-			routeParentRefs: []gatewayapi_v1alpha2.ParentRef{
+			routeParentRefs: []gatewayapi_v1alpha2.ParentReference{
 				gatewayapi.GatewayParentRef("projectcontour", "contour"),
 			},
 			want: true,
 		},
 		"gateway namespace specified, no listener specified, gateway in different namespace than route": {
-			routeParentRefs: []gatewayapi_v1alpha2.ParentRef{
+			routeParentRefs: []gatewayapi_v1alpha2.ParentReference{
 				gatewayapi.GatewayParentRef("different-ns-than-gateway", "contour"),
 			},
 			want: false,
 		},
 		"no gateway namespace specified, no listener specified, gateway in same namespace as route": {
-			routeParentRefs: []gatewayapi_v1alpha2.ParentRef{
+			routeParentRefs: []gatewayapi_v1alpha2.ParentReference{
 				gatewayapi.GatewayParentRef("", "contour"),
 			},
 			routeNamespace: "projectcontour",
 			want:           true,
 		},
 		"no gateway namespace specified, no listener specified, gateway in different namespace than route": {
-			routeParentRefs: []gatewayapi_v1alpha2.ParentRef{
+			routeParentRefs: []gatewayapi_v1alpha2.ParentReference{
 				gatewayapi.GatewayParentRef("", "contour"),
 			},
 			routeNamespace: "different-ns-than-gateway",
 			want:           false,
 		},
 		"parentRef name doesn't match gateway name": {
-			routeParentRefs: []gatewayapi_v1alpha2.ParentRef{
+			routeParentRefs: []gatewayapi_v1alpha2.ParentReference{
 				gatewayapi.GatewayParentRef("projectcontour", "different-name-than-gateway"),
 			},
 			want: false,
 		},
 
 		"section name specified, matches listener": {
-			routeParentRefs: []gatewayapi_v1alpha2.ParentRef{
+			routeParentRefs: []gatewayapi_v1alpha2.ParentReference{
 				gatewayapi.GatewayListenerParentRef("projectcontour", "contour", "http-listener"),
 			},
 			listener: gatewayapi_v1alpha2.Listener{Name: *gatewayapi.SectionNamePtr("http-listener")},
 			want:     true,
 		},
 		"section name specified, does not match listener": {
-			routeParentRefs: []gatewayapi_v1alpha2.ParentRef{
+			routeParentRefs: []gatewayapi_v1alpha2.ParentReference{
 				gatewayapi.GatewayListenerParentRef("projectcontour", "contour", "different-listener-name"),
 			},
 			listener: gatewayapi_v1alpha2.Listener{Name: *gatewayapi.SectionNamePtr("http-listener")},
@@ -629,19 +630,172 @@ func TestRouteSelectsGatewayListener(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 
-			processor := &GatewayAPIProcessor{
-				FieldLogger: fixture.NewTestLogger(t),
-				source: &KubernetesCache{
-					gateway: &gatewayapi_v1alpha2.Gateway{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:      "contour",
-							Namespace: "projectcontour",
-						},
-					},
+			gateway := &gatewayapi_v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "contour",
+					Namespace: "projectcontour",
 				},
 			}
 
-			got := routeSelectsGatewayListener(processor.source.gateway, tc.listener, tc.routeParentRefs, tc.routeNamespace)
+			got := routeSelectsGatewayListener(gateway, tc.listener, tc.routeParentRefs, tc.routeNamespace)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestReservedPort(t *testing.T) {
+	tests := map[string]struct {
+		port     gatewayapi_v1alpha2.PortNumber
+		protocol gatewayapi_v1alpha2.ProtocolType
+		want     bool
+	}{
+		"HTTP on the default HTTP port": {
+			port:     80,
+			protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+			want:     false,
+		},
+		"HTTPS on the default HTTPS port": {
+			port:     443,
+			protocol: gatewayapi_v1alpha2.HTTPSProtocolType,
+			want:     false,
+		},
+		"TLS on the default HTTPS port": {
+			port:     443,
+			protocol: gatewayapi_v1alpha2.TLSProtocolType,
+			want:     false,
+		},
+		"HTTP on the Envoy HTTP listener port": {
+			port:     8080,
+			protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+			want:     true,
+		},
+		"HTTPS on the Envoy HTTP listener port": {
+			port:     8080,
+			protocol: gatewayapi_v1alpha2.HTTPSProtocolType,
+			want:     true,
+		},
+		"TCP on the Envoy HTTPS listener port": {
+			port:     8443,
+			protocol: gatewayapi_v1alpha2.TCPProtocolType,
+			want:     true,
+		},
+		"UDP on the Envoy HTTP listener port": {
+			port:     8080,
+			protocol: gatewayapi_v1alpha2.UDPProtocolType,
+			want:     false,
+		},
+		"HTTP on another port": {
+			port:     8081,
+			protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+			want:     false,
+		},
+	}
+
+	p := &GatewayAPIProcessor{ReservedPorts: []int{8080, 8443}}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, p.reservedPort(gatewayapi_v1alpha2.Listener{
+				Port:     tc.port,
+				Protocol: tc.protocol,
+			}))
+		})
+	}
+}
+
+func TestGatewayPathRewrite(t *testing.T) {
+	fullPath := func(path string) *gatewayapi_v1alpha2.HTTPPathModifier {
+		return &gatewayapi_v1alpha2.HTTPPathModifier{
+			Type:            gatewayapi_v1alpha2.FullPathHTTPPathModifier,
+			ReplaceFullPath: pointer.String(path),
+		}
+	}
+	prefixMatch := func(prefix string) *gatewayapi_v1alpha2.HTTPPathModifier {
+		return &gatewayapi_v1alpha2.HTTPPathModifier{
+			Type:               gatewayapi_v1alpha2.PrefixMatchHTTPPathModifier,
+			ReplacePrefixMatch: pointer.String(prefix),
+		}
+	}
+
+	tests := map[string]struct {
+		modifier *gatewayapi_v1alpha2.HTTPPathModifier
+		match    MatchCondition
+		want     *RegexRewrite
+		wantErr  bool
+	}{
+		"no path modifier": {
+			match: prefixSegment("/foo"),
+			want:  nil,
+		},
+		"replace full path": {
+			modifier: fullPath("/bar"),
+			match:    exact("/foo"),
+			want:     &RegexRewrite{Pattern: "^/.*$", Substitution: "/bar"},
+		},
+		"replace full path escapes backslashes": {
+			modifier: fullPath(`/bar\1`),
+			match:    prefixSegment("/foo"),
+			want:     &RegexRewrite{Pattern: "^/.*$", Substitution: `/bar\\1`},
+		},
+		"replace full path must start with a slash": {
+			modifier: fullPath("bar"),
+			match:    prefixSegment("/foo"),
+			wantErr:  true,
+		},
+		"replace prefix match": {
+			modifier: prefixMatch("/bar"),
+			match:    prefixSegment("/foo"),
+			want:     &RegexRewrite{Pattern: "^/foo", Substitution: "/bar"},
+		},
+		"replace prefix match ignores trailing slashes": {
+			modifier: prefixMatch("/bar/"),
+			match:    prefixSegment("/foo/"),
+			want:     &RegexRewrite{Pattern: "^/foo", Substitution: "/bar"},
+		},
+		"replace prefix match quotes the prefix": {
+			modifier: prefixMatch("/bar"),
+			match:    prefixSegment("/foo.v1"),
+			want:     &RegexRewrite{Pattern: `^/foo\.v1`, Substitution: "/bar"},
+		},
+		"replace prefix match with a slash removes the prefix": {
+			modifier: prefixMatch("/"),
+			match:    prefixSegment("/foo"),
+			want:     &RegexRewrite{Pattern: "^/foo/*", Substitution: "/"},
+		},
+		"replace root prefix match": {
+			modifier: prefixMatch("/bar"),
+			match:    prefixString("/"),
+			want:     &RegexRewrite{Pattern: "^/", Substitution: "/bar/"},
+		},
+		"replace root prefix match with a slash": {
+			modifier: prefixMatch("/"),
+			match:    prefixString("/"),
+			want:     nil,
+		},
+		"replace prefix match on an exact match": {
+			modifier: prefixMatch("/bar"),
+			match:    exact("/foo"),
+			wantErr:  true,
+		},
+		"replace prefix match must start with a slash": {
+			modifier: prefixMatch("bar"),
+			match:    prefixSegment("/foo"),
+			wantErr:  true,
+		},
+		"unknown path modifier type": {
+			modifier: &gatewayapi_v1alpha2.HTTPPathModifier{Type: "ReplaceSomething"},
+			match:    prefixSegment("/foo"),
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := gatewayPathRewrite(tc.modifier, tc.match)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
 			assert.Equal(t, tc.want, got)
 		})
 	}
@@ -14,11 +14,14 @@
 package dag
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
@@ -29,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // defaultMaxRequestBytes specifies default value maxRequestBytes for AuthorizationServer
@@ -84,6 +88,14 @@ type HTTPProxyProcessor struct {
 
 	// Response headers that will be set on all routes (optional).
 	ResponseHeadersPolicy *HeadersPolicy
+
+	// ZoneAwareRouting, if set, configures Envoy to prefer upstream
+	// endpoints in its own zone (optional).
+	ZoneAwareRouting *ZoneAwareRouting
+
+	// CircuitBreakerDefaults holds the circuit breaking limits that
+	// apply to clusters that don't otherwise set them (optional).
+	CircuitBreakerDefaults *CircuitBreakers
 }
 
 // Run translates HTTPProxies into DAG objects and
@@ -193,7 +205,7 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_api_v1.HTTPProxy) {
 				return
 			}
 
-			svhost := p.dag.EnsureSecureVirtualHost(host)
+			svhost := p.dag.EnsureSecureVirtualHost(HTTPS_LISTENER_PORT, host)
 			svhost.Secret = sec
 			// default to a minimum TLS version of 1.2 if it's not specified
 			svhost.MinTLSVersion = annotation.MinTLSVersion(tls.MinimumProtocolVersion, "1.2")
@@ -216,6 +228,14 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_api_v1.HTTPProxy) {
 				return
 			}
 
+			// Fallback certificates and JWT verification are
+			// incompatible for the same reason as authorization.
+			if tls.EnableFallbackCertificate && len(proxy.Spec.VirtualHost.JWTProviders) > 0 {
+				validCond.AddError(contour_api_v1.ConditionTypeTLSError, "TLSIncompatibleFeatures",
+					"Spec.Virtualhost.TLS fallback & JWT verification are incompatible")
+				return
+			}
+
 			// If FallbackCertificate is enabled, but no cert passed, set error
 			if tls.EnableFallbackCertificate {
 				if p.FallbackCertificate == nil {
@@ -313,9 +333,23 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_api_v1.HTTPProxy) {
 					}
 				}
 			}
+
+			if len(proxy.Spec.VirtualHost.JWTProviders) > 0 {
+				providers, ok := p.computeJWTProviders(validCond, proxy)
+				if !ok {
+					return
+				}
+				svhost.JWTProviders = providers
+			}
 		}
 	}
 
+	if len(proxy.Spec.VirtualHost.JWTProviders) > 0 && (!tlsEnabled || proxy.Spec.VirtualHost.TLS.Passthrough) {
+		validCond.AddError(contour_api_v1.ConditionTypeJWTVerificationError, "JWTVerificationNotPermitted",
+			"Spec.VirtualHost.JWTProviders can only be defined for root HTTPProxies that terminate TLS")
+		return
+	}
+
 	if proxy.Spec.TCPProxy != nil {
 		if !tlsEnabled {
 			validCond.AddError(contour_api_v1.ConditionTypeTCPProxyError, "TLSMustBeConfigured",
@@ -328,7 +362,7 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_api_v1.HTTPProxy) {
 	}
 
 	routes := p.computeRoutes(validCond, proxy, proxy, nil, nil, tlsEnabled)
-	insecure := p.dag.EnsureVirtualHost(host)
+	insecure := p.dag.EnsureVirtualHost(HTTP_LISTENER_PORT, host)
 	cp, err := toCORSPolicy(proxy.Spec.VirtualHost.CORSPolicy)
 	if err != nil {
 		validCond.AddErrorf(contour_api_v1.ConditionTypeCORSError, "PolicyDidNotParse",
@@ -345,12 +379,29 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_api_v1.HTTPProxy) {
 	}
 	insecure.RateLimitPolicy = rlp
 
+	ipFilterAllow, ipFilterRules, err := toIPFilterRules(proxy.Spec.VirtualHost.IPAllowFilterPolicy, proxy.Spec.VirtualHost.IPDenyFilterPolicy)
+	if err != nil {
+		validCond.AddErrorf(contour_api_v1.ConditionTypeVirtualHostError, "IPFilterPolicyNotValid",
+			"Spec.VirtualHost.IPFilterPolicy is invalid: %s", err)
+		return
+	}
+	insecure.IPFilterAllow = ipFilterAllow
+	insecure.IPFilterRules = ipFilterRules
+
+	alp, err := accessLogPolicy(proxy.Spec.VirtualHost.AccessLogPolicy, k8s.NamespacedNameOf(proxy).String())
+	if err != nil {
+		validCond.AddErrorf(contour_api_v1.ConditionTypeVirtualHostError, "AccessLogPolicyNotValid",
+			"Spec.VirtualHost.AccessLogPolicy is invalid: %s", err)
+		return
+	}
+	insecure.AccessLogPolicy = alp
+
 	addRoutes(insecure, routes)
 
 	// if TLS is enabled for this virtual host and there is no tcp proxy defined,
 	// then add routes to the secure virtualhost definition.
 	if tlsEnabled && proxy.Spec.TCPProxy == nil {
-		secure := p.dag.EnsureSecureVirtualHost(host)
+		secure := p.dag.EnsureSecureVirtualHost(HTTPS_LISTENER_PORT, host)
 		secure.CORSPolicy = cp
 
 		rlp, err := rateLimitPolicy(proxy.Spec.VirtualHost.RateLimitPolicy)
@@ -360,6 +411,9 @@ func (p *HTTPProxyProcessor) computeHTTPProxy(proxy *contour_api_v1.HTTPProxy) {
 			return
 		}
 		secure.RateLimitPolicy = rlp
+		secure.IPFilterAllow = ipFilterAllow
+		secure.IPFilterRules = ipFilterRules
+		secure.AccessLogPolicy = alp
 
 		addRoutes(secure, routes)
 	}
@@ -415,7 +469,7 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			namespace = proxy.Namespace
 		}
 
-		if err := pathMatchConditionsValid(include.Conditions); err != nil {
+		if err := includeMatchConditionsValid(include.Conditions); err != nil {
 			validCond.AddErrorf(contour_api_v1.ConditionTypeIncludeError, "PathMatchConditionsNotValid",
 				"include: %s", err)
 			continue
@@ -427,6 +481,12 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			continue
 		}
 
+		if err := queryParamMatchConditionsValid(include.Conditions); err != nil {
+			validCond.AddError(contour_api_v1.ConditionTypeRouteError, "QueryParameterMatchConditionsNotValid",
+				err.Error())
+			continue
+		}
+
 		includedProxy, ok := p.source.httpproxies[types.NamespacedName{Name: include.Name, Namespace: namespace}]
 		if !ok {
 			validCond.AddErrorf(contour_api_v1.ConditionTypeIncludeError, "IncludeNotFound",
@@ -435,9 +495,10 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			// Set 502 response when include was not found but include condition was valid.
 			if len(include.Conditions) > 0 {
 				routes = append(routes, &Route{
-					PathMatchCondition:    mergePathMatchConditions(include.Conditions),
-					HeaderMatchConditions: mergeHeaderMatchConditions(include.Conditions),
-					DirectResponse:        directResponse(http.StatusBadGateway),
+					PathMatchCondition:        mergePathMatchConditions(include.Conditions),
+					HeaderMatchConditions:     mergeHeaderMatchConditions(include.Conditions),
+					QueryParamMatchConditions: mergeQueryParamMatchConditions(include.Conditions),
+					DirectResponse:            directResponse(http.StatusBadGateway),
 				})
 			}
 
@@ -463,16 +524,27 @@ func (p *HTTPProxyProcessor) computeRoutes(
 		"CONTOUR_NAMESPACE": proxy.Namespace,
 	}
 
-	for _, route := range proxy.Spec.Routes {
+	for i, route := range proxy.Spec.Routes {
 		if err := pathMatchConditionsValid(route.Conditions); err != nil {
 			validCond.AddErrorf(contour_api_v1.ConditionTypeRouteError, "PathMatchConditionsNotValid",
 				"route: %s", err)
 			return nil
 		}
+		if err := includedRegexMatchConditionsValid(conditions, route.Conditions); err != nil {
+			validCond.AddErrorf(contour_api_v1.ConditionTypeRouteError, "PathMatchConditionsNotValid",
+				"route: %s", err)
+			return nil
+		}
 
 		routeConditions := conditions
 		routeConditions = append(routeConditions, route.Conditions...)
 
+		if err := regexMatchConditionValid(mergePathMatchConditions(routeConditions)); err != nil {
+			validCond.AddErrorf(contour_api_v1.ConditionTypeRouteError, "PathMatchConditionsNotValid",
+				"route: %s", err)
+			return nil
+		}
+
 		// Look for invalid header conditions on this route
 		if err := headerMatchConditionsValid(routeConditions); err != nil {
 			validCond.AddError(contour_api_v1.ConditionTypeRouteError, "HeaderMatchConditionsNotValid",
@@ -480,6 +552,13 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			return nil
 		}
 
+		// Look for invalid query parameter conditions on this route
+		if err := queryParamMatchConditionsValid(routeConditions); err != nil {
+			validCond.AddError(contour_api_v1.ConditionTypeRouteError, "QueryParameterMatchConditionsNotValid",
+				err.Error())
+			return nil
+		}
+
 		reqHP, err := headersPolicyRoute(route.RequestHeadersPolicy, true /* allow Host */, dynamicHeaders)
 		if err != nil {
 			validCond.AddErrorf(contour_api_v1.ConditionTypeRouteError, "RequestHeadersPolicyInvalid",
@@ -522,6 +601,20 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			return nil
 		}
 
+		ipFilterAllow, ipFilterRules, err := toIPFilterRules(route.IPAllowFilterPolicy, route.IPDenyFilterPolicy)
+		if err != nil {
+			validCond.AddErrorf(contour_api_v1.ConditionTypeRouteError, "IPFilterPolicyNotValid",
+				"route.ipFilterPolicy is invalid: %s", err)
+			return nil
+		}
+
+		alp, err := accessLogPolicy(route.AccessLogPolicy, fmt.Sprintf("%s/%s/routes/%d", proxy.Namespace, proxy.Name, i))
+		if err != nil {
+			validCond.AddErrorf(contour_api_v1.ConditionTypeRouteError, "AccessLogPolicyNotValid",
+				"route.accessLogPolicy is invalid: %s", err)
+			return nil
+		}
+
 		requestHashPolicies, lbPolicy := loadBalancerRequestHashPolicies(route.LoadBalancerPolicy, validCond)
 
 		redirectPolicy, err := redirectRoutePolicy(route.RequestRedirectPolicy)
@@ -532,18 +625,22 @@ func (p *HTTPProxyProcessor) computeRoutes(
 		}
 
 		r := &Route{
-			PathMatchCondition:    mergePathMatchConditions(routeConditions),
-			HeaderMatchConditions: mergeHeaderMatchConditions(routeConditions),
-			Websocket:             route.EnableWebsockets,
-			HTTPSUpgrade:          routeEnforceTLS(enforceTLS, route.PermitInsecure && !p.DisablePermitInsecure),
-			TimeoutPolicy:         tp,
-			RetryPolicy:           retryPolicy(route.RetryPolicy),
-			RequestHeadersPolicy:  reqHP,
-			ResponseHeadersPolicy: respHP,
-			CookieRewritePolicies: cookieRP,
-			RateLimitPolicy:       rlp,
-			RequestHashPolicies:   requestHashPolicies,
-			Redirect:              redirectPolicy,
+			PathMatchCondition:        mergePathMatchConditions(routeConditions),
+			HeaderMatchConditions:     mergeHeaderMatchConditions(routeConditions),
+			QueryParamMatchConditions: mergeQueryParamMatchConditions(routeConditions),
+			Websocket:                 route.EnableWebsockets,
+			HTTPSUpgrade:              routeEnforceTLS(enforceTLS, route.PermitInsecure && !p.DisablePermitInsecure),
+			TimeoutPolicy:             tp,
+			RetryPolicy:               retryPolicy(route.RetryPolicy),
+			RequestHeadersPolicy:      reqHP,
+			ResponseHeadersPolicy:     respHP,
+			CookieRewritePolicies:     cookieRP,
+			RateLimitPolicy:           rlp,
+			RequestHashPolicies:       requestHashPolicies,
+			Redirect:                  redirectPolicy,
+			IPFilterAllow:             ipFilterAllow,
+			IPFilterRules:             ipFilterRules,
+			AccessLogPolicy:           alp,
 		}
 
 		// If the enclosing root proxy enabled authorization,
@@ -568,6 +665,23 @@ func (p *HTTPProxyProcessor) computeRoutes(
 			r.AuthContext = route.AuthorizationContext(rootProxy.Spec.VirtualHost.AuthorizationContext())
 		}
 
+		jwtProvider, err := jwtVerificationProvider(rootProxy.Spec.VirtualHost.JWTProviders, route.JWTVerificationPolicy)
+		if err != nil {
+			validCond.AddErrorf(contour_api_v1.ConditionTypeJWTVerificationError, "JWTVerificationPolicyNotValid",
+				"route.jwtVerificationPolicy is invalid: %s", err)
+			return nil
+		}
+
+		// JWT verification is only configured on the secure
+		// listener, so an insecure route would be served
+		// without any verification.
+		if len(jwtProvider) > 0 && route.PermitInsecure && !p.DisablePermitInsecure {
+			validCond.AddError(contour_api_v1.ConditionTypeJWTVerificationError, "JWTVerificationNotPermitted",
+				"route.permitInsecure cannot be used on a route that requires JWT verification")
+			return nil
+		}
+		r.JWTProvider = jwtProvider
+
 		if len(route.GetPrefixReplacements()) > 0 {
 			if !r.HasPathPrefix() {
 				validCond.AddError(contour_api_v1.ConditionTypePrefixReplaceError, "MustHavePrefix",
@@ -681,29 +795,39 @@ func (p *HTTPProxyProcessor) computeRoutes(
 				}
 			}
 
+			odp, err := outlierDetectionPolicy(route.OutlierDetection, service.OutlierDetection)
+			if err != nil {
+				validCond.AddErrorf(contour_api_v1.ConditionTypeServiceError, "OutlierDetectionInvalid",
+					"Service [%s:%d] outlier detection policy error: %s", service.Name, service.Port, err)
+				return nil
+			}
+
 			c := &Cluster{
-				Upstream:              s,
-				LoadBalancerPolicy:    lbPolicy,
-				Weight:                uint32(service.Weight),
-				HTTPHealthCheckPolicy: httpHealthCheckPolicy(route.HealthCheckPolicy),
-				UpstreamValidation:    uv,
-				RequestHeadersPolicy:  reqHP,
-				ResponseHeadersPolicy: respHP,
-				CookieRewritePolicies: cookieRP,
-				Protocol:              protocol,
-				SNI:                   determineSNI(r.RequestHeadersPolicy, reqHP, s),
-				DNSLookupFamily:       string(p.DNSLookupFamily),
-				ClientCertificate:     clientCertSecret,
+				Upstream:               s,
+				LoadBalancerPolicy:     lbPolicy,
+				Weight:                 uint32(service.Weight),
+				HTTPHealthCheckPolicy:  httpHealthCheckPolicy(route.HealthCheckPolicy),
+				OutlierDetectionPolicy: odp,
+				UpstreamValidation:     uv,
+				RequestHeadersPolicy:   reqHP,
+				ResponseHeadersPolicy:  respHP,
+				CookieRewritePolicies:  cookieRP,
+				Protocol:               protocol,
+				SNI:                    determineSNI(r.RequestHeadersPolicy, reqHP, s),
+				DNSLookupFamily:        string(p.DNSLookupFamily),
+				ClientCertificate:      clientCertSecret,
+				ZoneAwareRouting:       p.ZoneAwareRouting,
+				CircuitBreakers:        circuitBreakers(s, p.CircuitBreakerDefaults, service.CircuitBreakerPolicy, route.CircuitBreakerPolicy),
 			}
-			if service.Mirror && r.MirrorPolicy != nil {
+			if service.Mirror && len(r.MirrorPolicies) > 0 {
 				validCond.AddError(contour_api_v1.ConditionTypeServiceError, "OnlyOneMirror",
 					"only one service per route may be nominated as mirror")
 				return nil
 			}
 			if service.Mirror {
-				r.MirrorPolicy = &MirrorPolicy{
+				r.MirrorPolicies = []*MirrorPolicy{{
 					Cluster: c,
-				}
+				}}
 			} else {
 				r.Clusters = append(r.Clusters, c)
 			}
@@ -789,9 +913,11 @@ func (p *HTTPProxyProcessor) processHTTPProxyTCPProxy(validCond *contour_api_v1.
 				LoadBalancerPolicy:   lbPolicy,
 				TCPHealthCheckPolicy: tcpHealthCheckPolicy(tcpproxy.HealthCheckPolicy),
 				SNI:                  s.ExternalName,
+				ZoneAwareRouting:     p.ZoneAwareRouting,
+				CircuitBreakers:      circuitBreakers(s, p.CircuitBreakerDefaults, service.CircuitBreakerPolicy),
 			})
 		}
-		secure := p.dag.EnsureSecureVirtualHost(host)
+		secure := p.dag.EnsureSecureVirtualHost(HTTPS_LISTENER_PORT, host)
 		secure.TCPProxy = &proxy
 
 		return true
@@ -850,6 +976,146 @@ func (p *HTTPProxyProcessor) processHTTPProxyTCPProxy(validCond *contour_api_v1.
 	return ok
 }
 
+// computeJWTProviders validates the JWT providers defined on the
+// root HTTPProxy's virtual host and converts them to DAG objects.
+// It returns false if any of the providers is invalid.
+func (p *HTTPProxyProcessor) computeJWTProviders(validCond *contour_api_v1.DetailedCondition, proxy *contour_api_v1.HTTPProxy) ([]JWTProvider, bool) {
+	var (
+		providers       []JWTProvider
+		providerNames   = sets.NewString()
+		defaultProvider string
+	)
+
+	for _, provider := range proxy.Spec.VirtualHost.JWTProviders {
+		if providerNames.Has(provider.Name) {
+			validCond.AddErrorf(contour_api_v1.ConditionTypeJWTVerificationError, "DuplicateProviderName",
+				"Spec.VirtualHost.JWTProviders is invalid: duplicate name %s", provider.Name)
+			return nil, false
+		}
+		providerNames.Insert(provider.Name)
+
+		if provider.Default {
+			if len(defaultProvider) > 0 {
+				validCond.AddError(contour_api_v1.ConditionTypeJWTVerificationError, "MultipleDefaultProvidersSpecified",
+					"Spec.VirtualHost.JWTProviders is invalid: at most one provider can be set as the default")
+				return nil, false
+			}
+			defaultProvider = provider.Name
+		}
+
+		jwtProvider := JWTProvider{
+			Name:       provider.Name,
+			Issuer:     provider.Issuer,
+			Audiences:  provider.Audiences,
+			ForwardJWT: provider.ForwardJWT,
+		}
+
+		switch {
+		case (provider.RemoteJWKS == nil) == (provider.LocalJWKS == nil):
+			validCond.AddErrorf(contour_api_v1.ConditionTypeJWTVerificationError, "JWKSNotValid",
+				"Spec.VirtualHost.JWTProviders is invalid: provider %s must specify exactly one of remoteJWKS or localJWKS", provider.Name)
+			return nil, false
+		case provider.RemoteJWKS != nil:
+			remoteJWKS, err := p.remoteJWKS(provider.RemoteJWKS, proxy.Namespace)
+			if err != nil {
+				validCond.AddErrorf(contour_api_v1.ConditionTypeJWTVerificationError, "RemoteJWKSNotValid",
+					"Spec.VirtualHost.JWTProviders.RemoteJWKS is invalid: %s", err)
+				return nil, false
+			}
+			jwtProvider.RemoteJWKS = remoteJWKS
+		default:
+			secretName := types.NamespacedName{Name: provider.LocalJWKS.SecretName, Namespace: proxy.Namespace}
+			sec, err := p.source.LookupSecret(secretName, validJWKS)
+			if err != nil {
+				validCond.AddErrorf(contour_api_v1.ConditionTypeJWTVerificationError, "LocalJWKSNotValid",
+					"Spec.VirtualHost.JWTProviders.LocalJWKS Secret %q is invalid: %s", provider.LocalJWKS.SecretName, err)
+				return nil, false
+			}
+			jwtProvider.LocalJWKS = string(sec.Object.Data[JWKSKey])
+		}
+
+		providers = append(providers, jwtProvider)
+	}
+
+	return providers, true
+}
+
+// remoteJWKS validates a remote JWKS specification and builds the
+// DNS name cluster that Envoy will use to fetch it.
+func (p *HTTPProxyProcessor) remoteJWKS(remote *contour_api_v1.RemoteJWKS, namespace string) (*RemoteJWKS, error) {
+	uri, err := url.Parse(remote.URI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URI %q: %w", remote.URI, err)
+	}
+
+	var port int
+	switch uri.Scheme {
+	case "http":
+		port = 80
+	case "https":
+		port = 443
+	default:
+		return nil, fmt.Errorf("unsupported URI scheme %q", uri.Scheme)
+	}
+
+	if len(uri.Hostname()) == 0 {
+		return nil, fmt.Errorf("URI %q is missing a host", remote.URI)
+	}
+
+	if uri.Port() != "" {
+		port, err = strconv.Atoi(uri.Port())
+		if err != nil {
+			return nil, fmt.Errorf("invalid port in URI %q: %w", remote.URI, err)
+		}
+	}
+
+	jwksTimeout := time.Second
+	if len(remote.Timeout) > 0 {
+		jwksTimeout, err = time.ParseDuration(remote.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+
+	var cacheDuration time.Duration
+	if len(remote.CacheDuration) > 0 {
+		cacheDuration, err = time.ParseDuration(remote.CacheDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache duration: %w", err)
+		}
+	}
+
+	var uv *PeerValidationContext
+	if remote.UpstreamValidation != nil {
+		if uri.Scheme != "https" {
+			return nil, errors.New("validation can only be specified for https URIs")
+		}
+
+		caCertNamespacedName := k8s.NamespacedNameFrom(remote.UpstreamValidation.CACertificate, k8s.DefaultNamespace(namespace))
+		if !p.source.DelegationPermitted(caCertNamespacedName, namespace) {
+			return nil, fmt.Errorf("CA Secret %q is not configured for certificate delegation", caCertNamespacedName)
+		}
+
+		uv, err = p.source.LookupUpstreamValidation(remote.UpstreamValidation, caCertNamespacedName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &RemoteJWKS{
+		URI:           remote.URI,
+		Timeout:       jwksTimeout,
+		CacheDuration: cacheDuration,
+		Cluster: DNSNameCluster{
+			Address:            uri.Hostname(),
+			Scheme:             uri.Scheme,
+			Port:               port,
+			DNSLookupFamily:    string(p.DNSLookupFamily),
+			UpstreamValidation: uv,
+		},
+	}, nil
+}
+
 // validHTTPProxies returns a slice of *contour_api_v1.HTTPProxy objects.
 // invalid HTTPProxy objects are excluded from the slice and their status
 // updated accordingly.
@@ -929,6 +1195,7 @@ func expandPrefixMatches(routes []*Route) []*Route {
 		// If there is no path prefix, we won't do any expansion, so skip it.
 		if !r.HasPathPrefix() {
 			expandedRoutes = append(expandedRoutes, r)
+			continue
 		}
 
 		routingPrefix := r.PathMatchCondition.(*PrefixMatchCondition).Prefix
@@ -1069,7 +1336,9 @@ func includeMatchConditionsIdentical(includes []contour_api_v1.Include) bool {
 		// Now compare each include's set of conditions
 		for _, cA := range includes[i].Conditions {
 			for _, cB := range includes[j].Conditions {
-				if (cA.Prefix == cB.Prefix) && equality.Semantic.DeepEqual(cA.Header, cB.Header) {
+				if (cA.Prefix == cB.Prefix) && (cA.Exact == cB.Exact) && (cA.Regex == cB.Regex) &&
+					equality.Semantic.DeepEqual(cA.Header, cB.Header) &&
+					equality.Semantic.DeepEqual(cA.QueryParameter, cB.QueryParameter) {
 					return true
 				}
 			}
@@ -49,6 +49,14 @@ type IngressProcessor struct {
 
 	// Response headers that will be set on all routes (optional).
 	ResponseHeadersPolicy *HeadersPolicy
+
+	// ZoneAwareRouting, if set, configures Envoy to prefer upstream
+	// endpoints in its own zone (optional).
+	ZoneAwareRouting *ZoneAwareRouting
+
+	// CircuitBreakerDefaults holds the circuit breaking limits that
+	// apply to clusters that don't otherwise set them (optional).
+	CircuitBreakerDefaults *CircuitBreakers
 }
 
 // Run translates Ingresses into DAG objects and
@@ -99,7 +107,7 @@ func (p *IngressProcessor) computeSecureVirtualhosts() {
 			// ahead and create the SecureVirtualHost for this
 			// Ingress.
 			for _, host := range tls.Hosts {
-				svhost := p.dag.EnsureSecureVirtualHost(host)
+				svhost := p.dag.EnsureSecureVirtualHost(HTTPS_LISTENER_PORT, host)
 				svhost.Secret = sec
 				// default to a minimum TLS version of 1.2 if it's not specified
 				svhost.MinTLSVersion = annotation.MinTLSVersion(annotation.ContourAnnotation(ing, "tls-minimum-protocol-version"), "1.2")
@@ -178,14 +186,14 @@ func (p *IngressProcessor) computeIngressRule(ing *networking_v1.Ingress, rule n
 
 		// should we create port 80 routes for this ingress
 		if annotation.TLSRequired(ing) || annotation.HTTPAllowed(ing) {
-			vhost := p.dag.EnsureVirtualHost(host)
+			vhost := p.dag.EnsureVirtualHost(HTTP_LISTENER_PORT, host)
 			vhost.addRoute(r)
 		}
 
 		// computeSecureVirtualhosts will have populated b.securevirtualhosts
 		// with the names of tls enabled ingress objects. If host exists then
 		// it is correctly configured for TLS.
-		if svh := p.dag.GetSecureVirtualHost(host); svh != nil && host != "*" {
+		if svh := p.dag.GetSecureVirtualHost(HTTPS_LISTENER_PORT, host); svh != nil && host != "*" {
 			svh.addRoute(r)
 		}
 	}
@@ -229,6 +237,8 @@ func (p *IngressProcessor) route(ingress *networking_v1.Ingress, host string, pa
 			ClientCertificate:     clientCertSecret,
 			RequestHeadersPolicy:  reqHP,
 			ResponseHeadersPolicy: respHP,
+			ZoneAwareRouting:      p.ZoneAwareRouting,
+			CircuitBreakers:       circuitBreakers(service, p.CircuitBreakerDefaults),
 		}},
 	}
 
@@ -13,77 +13,171 @@
 
 package dag
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+)
 
 // nolint:revive
 const (
 	HTTP_LISTENER_NAME  = "ingress_http"
 	HTTPS_LISTENER_NAME = "ingress_https"
+	HTTP_LISTENER_PORT  = 80
+	HTTPS_LISTENER_PORT = 443
 )
 
 // ListenerProcessor adds an HTTP and an HTTPS listener to
-// the DAG if there are virtual hosts and secure virtual
-// hosts already defined as roots in the DAG.
+// the DAG for each port that has virtual hosts and secure
+// virtual hosts already defined as roots in the DAG.
 type ListenerProcessor struct{}
 
-// Run adds HTTP and HTTPS listeners to the DAG if there are
-// virtual hosts and secure virtual hosts already defined as
-// roots in the DAG.
+// Run adds HTTP and HTTPS listeners to the DAG for each port
+// that has virtual hosts and secure virtual hosts already
+// defined as roots in the DAG, and a TCP or UDP listener for
+// each TCP or UDP proxy.
 func (p *ListenerProcessor) Run(dag *DAG, _ *KubernetesCache) {
-	p.buildHTTPListener(dag)
-	p.buildHTTPSListener(dag)
+	p.buildHTTPListeners(dag)
+	p.buildHTTPSListeners(dag)
+	p.buildTCPListeners(dag)
+	p.buildUDPListeners(dag)
 }
 
-// buildHTTPListener builds a *dag.Listener for the vhosts bound to port 80.
-// The list of virtual hosts attached to the listener will be sorted by hostname.
-func (p *ListenerProcessor) buildHTTPListener(dag *DAG) {
-	var vhosts []*VirtualHost
-	for _, vh := range dag.VirtualHosts {
-		if vh.Valid() {
-			vhosts = append(vhosts, vh)
+// buildHTTPListeners builds a *dag.Listener for the vhosts bound to each port.
+// The listeners will be sorted by port, and the list of virtual hosts attached
+// to each listener will be sorted by hostname.
+func (p *ListenerProcessor) buildHTTPListeners(dag *DAG) {
+	var ports []int
+	for port := range dag.VirtualHosts {
+		ports = append(ports, port)
+	}
+
+	sort.Ints(ports)
+
+	for _, port := range ports {
+		var vhosts []*VirtualHost
+		for _, vh := range dag.VirtualHosts[port] {
+			if vh.Valid() {
+				vhosts = append(vhosts, vh)
+			}
 		}
+
+		if len(vhosts) == 0 {
+			continue
+		}
+
+		sort.SliceStable(vhosts, func(i, j int) bool {
+			return vhosts[i].Name < vhosts[j].Name
+		})
+
+		dag.Listeners = append(dag.Listeners, &Listener{
+			Name:         HTTPListenerName(port),
+			Port:         port,
+			VirtualHosts: vhosts,
+		})
 	}
+}
 
-	if len(vhosts) == 0 {
-		return
+// buildHTTPSListeners builds a *dag.Listener for the vhosts bound to each port.
+// The listeners will be sorted by port, and the list of virtual hosts attached
+// to each listener will be sorted by hostname.
+func (p *ListenerProcessor) buildHTTPSListeners(dag *DAG) {
+	var ports []int
+	for port := range dag.SecureVirtualHosts {
+		ports = append(ports, port)
 	}
 
-	sort.SliceStable(vhosts, func(i, j int) bool {
-		return vhosts[i].Name < vhosts[j].Name
-	})
+	sort.Ints(ports)
+
+	for _, port := range ports {
+		var vhosts []*SecureVirtualHost
+		for _, svh := range dag.SecureVirtualHosts[port] {
+			if svh.Valid() {
+				vhosts = append(vhosts, svh)
+			}
+		}
+
+		if len(vhosts) == 0 {
+			continue
+		}
+
+		sort.SliceStable(vhosts, func(i, j int) bool {
+			return vhosts[i].Name < vhosts[j].Name
+		})
 
-	http := &Listener{
-		Name:         HTTP_LISTENER_NAME,
-		Port:         80,
-		VirtualHosts: vhosts,
+		dag.Listeners = append(dag.Listeners, &Listener{
+			Name:               HTTPSListenerName(port),
+			Port:               port,
+			SecureVirtualHosts: vhosts,
+		})
 	}
+}
 
-	dag.Listeners = append(dag.Listeners, http)
+// HTTPListenerName returns the name of the HTTP listener for the given
+// port. The listener for HTTP_LISTENER_PORT is always HTTP_LISTENER_NAME.
+func HTTPListenerName(port int) string {
+	if port == HTTP_LISTENER_PORT {
+		return HTTP_LISTENER_NAME
+	}
+	return fmt.Sprintf("%s_%d", HTTP_LISTENER_NAME, port)
 }
 
-// buildHTTPSListener builds a *dag.Listener for the vhosts bound to port 443.
-// The list of virtual hosts attached to the listener will be sorted by hostname.
-func (p *ListenerProcessor) buildHTTPSListener(dag *DAG) {
-	var vhosts []*SecureVirtualHost
-	for _, svh := range dag.SecureVirtualHosts {
-		if svh.Valid() {
-			vhosts = append(vhosts, svh)
+// HTTPSListenerName returns the name of the HTTPS listener for the given
+// port. The listener for HTTPS_LISTENER_PORT is always HTTPS_LISTENER_NAME.
+func HTTPSListenerName(port int) string {
+	if port == HTTPS_LISTENER_PORT {
+		return HTTPS_LISTENER_NAME
+	}
+	return fmt.Sprintf("%s_%d", HTTPS_LISTENER_NAME, port)
+}
+
+// buildTCPListeners builds a *dag.Listener for each TCP proxy
+// that has clusters. The listeners will be sorted by port.
+func (p *ListenerProcessor) buildTCPListeners(dag *DAG) {
+	var ports []int
+	for port, proxy := range dag.TCPProxies {
+		if len(proxy.Clusters) > 0 {
+			ports = append(ports, port)
 		}
 	}
 
-	if len(vhosts) == 0 {
-		return
+	sort.Ints(ports)
+
+	for _, port := range ports {
+		dag.Listeners = append(dag.Listeners, &Listener{
+			Name:     TCPListenerName(port),
+			Port:     port,
+			TCPProxy: dag.TCPProxies[port],
+		})
 	}
+}
 
-	sort.SliceStable(vhosts, func(i, j int) bool {
-		return vhosts[i].Name < vhosts[j].Name
-	})
+// TCPListenerName returns the name of the TCP listener for the given port.
+func TCPListenerName(port int) string {
+	return fmt.Sprintf("ingress_tcp_%d", port)
+}
+
+// buildUDPListeners builds a *dag.Listener for each UDP proxy
+// that has a cluster. The listeners will be sorted by port.
+func (p *ListenerProcessor) buildUDPListeners(dag *DAG) {
+	var ports []int
+	for port, proxy := range dag.UDPProxies {
+		if proxy.Cluster != nil {
+			ports = append(ports, port)
+		}
+	}
+
+	sort.Ints(ports)
 
-	https := &Listener{
-		Name:               HTTPS_LISTENER_NAME,
-		Port:               443,
-		SecureVirtualHosts: vhosts,
+	for _, port := range ports {
+		dag.Listeners = append(dag.Listeners, &Listener{
+			Name:     UDPListenerName(port),
+			Port:     port,
+			UDPProxy: dag.UDPProxies[port],
+		})
 	}
+}
 
-	dag.Listeners = append(dag.Listeners, https)
+// UDPListenerName returns the name of the UDP listener for the given port.
+func UDPListenerName(port int) string {
+	return fmt.Sprintf("ingress_udp_%d", port)
 }
@@ -16,6 +16,7 @@ package dag
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"regexp"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 	"github.com/projectcontour/contour/internal/annotation"
 	"github.com/projectcontour/contour/internal/timeout"
 	"github.com/sirupsen/logrus"
@@ -473,6 +475,129 @@ func tcpHealthCheckPolicy(hc *contour_api_v1.TCPHealthCheckPolicy) *TCPHealthChe
 	}
 }
 
+// circuitBreakers returns the circuit breaking limits for a Cluster
+// of service. Each limit is taken from the first of policies that sets
+// it, then from the projectcontour.io/max-* annotations on the Service,
+// and then from defaults. If there are no policies or defaults, nil is
+// returned so that the Service's own limits apply.
+func circuitBreakers(service *Service, defaults *CircuitBreakers, policies ...*contour_api_v1.CircuitBreakerPolicy) *CircuitBreakers {
+	// Candidates in order of increasing precedence.
+	var candidates []CircuitBreakers
+
+	if defaults != nil {
+		candidates = append(candidates, *defaults)
+	}
+
+	candidates = append(candidates, serviceCircuitBreakers(service))
+
+	overridden := defaults != nil
+	for i := len(policies) - 1; i >= 0; i-- {
+		if p := policies[i]; p != nil {
+			overridden = true
+			candidates = append(candidates, CircuitBreakers{
+				MaxConnections:     p.MaxConnections,
+				MaxPendingRequests: p.MaxPendingRequests,
+				MaxRequests:        p.MaxRequests,
+				MaxRetries:         p.MaxRetries,
+			})
+		}
+	}
+
+	if !overridden {
+		return nil
+	}
+
+	cb := &CircuitBreakers{}
+	for _, c := range candidates {
+		if c.MaxConnections != nil {
+			cb.MaxConnections = c.MaxConnections
+		}
+		if c.MaxPendingRequests != nil {
+			cb.MaxPendingRequests = c.MaxPendingRequests
+		}
+		if c.MaxRequests != nil {
+			cb.MaxRequests = c.MaxRequests
+		}
+		if c.MaxRetries != nil {
+			cb.MaxRetries = c.MaxRetries
+		}
+	}
+
+	return cb
+}
+
+// serviceCircuitBreakers returns the circuit breaker thresholds
+// set by the `projectcontour.io/max-*` annotations on a Service.
+// An annotation value of zero is not set.
+func serviceCircuitBreakers(service *Service) CircuitBreakers {
+	positive := func(v uint32) *uint32 {
+		if v == 0 {
+			return nil
+		}
+		return &v
+	}
+
+	return CircuitBreakers{
+		MaxConnections:     positive(service.MaxConnections),
+		MaxPendingRequests: positive(service.MaxPendingRequests),
+		MaxRequests:        positive(service.MaxRequests),
+		MaxRetries:         positive(service.MaxRetries),
+	}
+}
+
+// outlierDetectionPolicy returns the outlier detection policy for
+// a Service, which is taken from the Service if it has one and
+// otherwise from its Route.
+func outlierDetectionPolicy(route, service *contour_api_v1.OutlierDetection) (*OutlierDetectionPolicy, error) {
+	od := route
+	if service != nil {
+		od = service
+	}
+	if od == nil {
+		return nil, nil
+	}
+
+	if od.MaxEjectionPercent != nil && *od.MaxEjectionPercent > 100 {
+		return nil, fmt.Errorf("invalid max ejection percent %d, must be between 0 and 100", *od.MaxEjectionPercent)
+	}
+
+	interval, err := outlierDetectionDuration(od.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval: %w", err)
+	}
+
+	baseEjectionTime, err := outlierDetectionDuration(od.BaseEjectionTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base ejection time: %w", err)
+	}
+
+	return &OutlierDetectionPolicy{
+		ConsecutiveServerErrors:  od.ConsecutiveServerErrors,
+		ConsecutiveGatewayErrors: od.ConsecutiveGatewayErrors,
+		Interval:                 interval,
+		BaseEjectionTime:         baseEjectionTime,
+		MaxEjectionPercent:       od.MaxEjectionPercent,
+	}, nil
+}
+
+// outlierDetectionDuration parses an optional, positive duration.
+// An empty string is parsed as zero, meaning the Envoy default.
+func outlierDetectionDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration %q must not be negative", s)
+	}
+
+	return d, nil
+}
+
 // loadBalancerPolicy returns the load balancer strategy or
 // blank if no valid strategy is supplied.
 func loadBalancerPolicy(lbp *contour_api_v1.LoadBalancerPolicy) string {
@@ -720,3 +845,152 @@ func loadBalancerRequestHashPolicies(lbp *contour_api_v1.LoadBalancerPolicy, val
 	}
 
 }
+
+// jwtVerificationProvider returns the name of the JWT provider to require
+// for a route, given the providers defined on the root virtual host and the
+// route's verification policy. An empty name means that JWTs are not
+// verified for the route.
+func jwtVerificationProvider(providers []contour_api_v1.JWTProvider, policy *contour_api_v1.JWTVerificationPolicy) (string, error) {
+	if policy != nil {
+		switch {
+		case len(policy.Require) > 0 && policy.Disabled:
+			return "", errors.New("require and disabled cannot both be specified")
+		case policy.Disabled:
+			return "", nil
+		case len(policy.Require) > 0:
+			for _, provider := range providers {
+				if provider.Name == policy.Require {
+					return provider.Name, nil
+				}
+			}
+			return "", fmt.Errorf("provider %q is not defined on the virtual host", policy.Require)
+		}
+	}
+
+	for _, provider := range providers {
+		if provider.Default {
+			return provider.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// toIPFilterRules converts the allow and deny IP filter policies into
+// a list of DAG IP filter rules. The returned bool reports whether
+// the rules form an allow list. At most one of allow and deny may be
+// non-empty.
+func toIPFilterRules(allow, deny []contour_api_v1.IPFilterPolicy) (bool, []IPFilterRule, error) {
+	if len(allow) > 0 && len(deny) > 0 {
+		return false, nil, errors.New("cannot specify both ipAllowPolicy and ipDenyPolicy")
+	}
+
+	policies := deny
+	if len(allow) > 0 {
+		policies = allow
+	}
+
+	var rules []IPFilterRule
+	for _, policy := range policies {
+		cidr, err := parseCIDR(policy.CIDR)
+		if err != nil {
+			return false, nil, err
+		}
+
+		switch policy.Source {
+		case contour_api_v1.IPFilterSourceRemote, contour_api_v1.IPFilterSourcePeer:
+		default:
+			return false, nil, fmt.Errorf("invalid source %q", policy.Source)
+		}
+
+		rules = append(rules, IPFilterRule{
+			Remote: policy.Source == contour_api_v1.IPFilterSourceRemote,
+			CIDR:   *cidr,
+		})
+	}
+
+	return len(allow) > 0, rules, nil
+}
+
+// parseCIDR parses a CIDR block, or a bare IP address which is treated
+// as a block containing exactly that address.
+func parseCIDR(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip address %q", cidr)
+		}
+
+		bits := net.IPv6len * 8
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = net.IPv4len * 8
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q", cidr)
+	}
+
+	return ipnet, nil
+}
+
+// accessLogPolicy converts the given access log policy into a DAG
+// access log policy with the given name, validating its format and
+// fields.
+func accessLogPolicy(in *contour_api_v1.AccessLogPolicy, name string) (*AccessLogPolicy, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	format := contour_api_v1alpha1.AccessLogType(in.Format)
+	if format != "" {
+		if err := format.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.FormatString != "" {
+		if format == contour_api_v1alpha1.JSONAccessLog {
+			return nil, errors.New("formatString cannot be used with the json format")
+		}
+		if err := contour_api_v1alpha1.ValidateAccessLogFormatString(in.FormatString); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(in.Fields) > 0 {
+		if format == contour_api_v1alpha1.EnvoyAccessLog {
+			return nil, errors.New("fields cannot be used with the envoy format")
+		}
+		if err := contour_api_v1alpha1.AccessLogFields(in.Fields).Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.MinStatusCode != 0 && (in.MinStatusCode < 100 || in.MinStatusCode > 599) {
+		return nil, fmt.Errorf("invalid minimum status code %d", in.MinStatusCode)
+	}
+
+	alp := &AccessLogPolicy{
+		Name:          name,
+		Disabled:      in.Disabled,
+		Format:        in.Format,
+		FormatString:  in.FormatString,
+		Fields:        in.Fields,
+		MinStatusCode: uint32(in.MinStatusCode),
+	}
+
+	if in.SamplingPercent != nil {
+		if *in.SamplingPercent < 0 || *in.SamplingPercent > 100 {
+			return nil, fmt.Errorf("invalid sampling percent %d", *in.SamplingPercent)
+		}
+		percent := uint32(*in.SamplingPercent)
+		alp.SamplingPercent = &percent
+	}
+
+	return alp, nil
+}
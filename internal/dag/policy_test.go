@@ -16,6 +16,7 @@ package dag
 import (
 	"errors"
 	"io/ioutil"
+	"net"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	networking_v1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func TestRetryPolicyIngress(t *testing.T) {
@@ -304,6 +306,179 @@ func TestLoadBalancerPolicy(t *testing.T) {
 	}
 }
 
+func TestCircuitBreakers(t *testing.T) {
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+
+	annotated := &Service{
+		MaxConnections:     1,
+		MaxPendingRequests: 2,
+	}
+
+	tests := map[string]struct {
+		service  *Service
+		defaults *CircuitBreakers
+		policies []*contour_api_v1.CircuitBreakerPolicy
+		want     *CircuitBreakers
+	}{
+		"no policies or defaults": {
+			service: annotated,
+			want:    nil,
+		},
+		"nil policies": {
+			service:  annotated,
+			policies: []*contour_api_v1.CircuitBreakerPolicy{nil, nil},
+			want:     nil,
+		},
+		"defaults fill in unset annotations": {
+			service: annotated,
+			defaults: &CircuitBreakers{
+				MaxConnections: uint32Ptr(10),
+				MaxRequests:    uint32Ptr(30),
+			},
+			want: &CircuitBreakers{
+				MaxConnections:     uint32Ptr(1),
+				MaxPendingRequests: uint32Ptr(2),
+				MaxRequests:        uint32Ptr(30),
+			},
+		},
+		"policy takes precedence over annotations and defaults": {
+			service: annotated,
+			defaults: &CircuitBreakers{
+				MaxRetries: uint32Ptr(40),
+			},
+			policies: []*contour_api_v1.CircuitBreakerPolicy{{
+				MaxConnections: uint32Ptr(100),
+			}},
+			want: &CircuitBreakers{
+				MaxConnections:     uint32Ptr(100),
+				MaxPendingRequests: uint32Ptr(2),
+				MaxRetries:         uint32Ptr(40),
+			},
+		},
+		"explicit zero policy overrides annotations": {
+			service: annotated,
+			policies: []*contour_api_v1.CircuitBreakerPolicy{{
+				MaxConnections: uint32Ptr(0),
+				MaxRetries:     uint32Ptr(0),
+			}},
+			want: &CircuitBreakers{
+				MaxConnections:     uint32Ptr(0),
+				MaxPendingRequests: uint32Ptr(2),
+				MaxRetries:         uint32Ptr(0),
+			},
+		},
+		"earlier policies take precedence": {
+			service: &Service{},
+			policies: []*contour_api_v1.CircuitBreakerPolicy{{
+				MaxConnections: uint32Ptr(100),
+			}, {
+				MaxConnections: uint32Ptr(200),
+				MaxRequests:    uint32Ptr(300),
+			}},
+			want: &CircuitBreakers{
+				MaxConnections: uint32Ptr(100),
+				MaxRequests:    uint32Ptr(300),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := circuitBreakers(tc.service, tc.defaults, tc.policies...)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestOutlierDetectionPolicy(t *testing.T) {
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+
+	tests := map[string]struct {
+		route   *contour_api_v1.OutlierDetection
+		service *contour_api_v1.OutlierDetection
+		want    *OutlierDetectionPolicy
+		wantErr bool
+	}{
+		"nil": {
+			want: nil,
+		},
+		"empty": {
+			route: &contour_api_v1.OutlierDetection{},
+			want:  &OutlierDetectionPolicy{},
+		},
+		"route policy": {
+			route: &contour_api_v1.OutlierDetection{
+				ConsecutiveServerErrors:  uint32Ptr(3),
+				ConsecutiveGatewayErrors: uint32Ptr(2),
+				Interval:                 "5s",
+				BaseEjectionTime:         "1m",
+				MaxEjectionPercent:       uint32Ptr(50),
+			},
+			want: &OutlierDetectionPolicy{
+				ConsecutiveServerErrors:  uint32Ptr(3),
+				ConsecutiveGatewayErrors: uint32Ptr(2),
+				Interval:                 5 * time.Second,
+				BaseEjectionTime:         time.Minute,
+				MaxEjectionPercent:       uint32Ptr(50),
+			},
+		},
+		"service policy replaces route policy": {
+			route: &contour_api_v1.OutlierDetection{
+				ConsecutiveServerErrors: uint32Ptr(3),
+				Interval:                "5s",
+			},
+			service: &contour_api_v1.OutlierDetection{
+				ConsecutiveGatewayErrors: uint32Ptr(7),
+			},
+			want: &OutlierDetectionPolicy{
+				ConsecutiveGatewayErrors: uint32Ptr(7),
+			},
+		},
+		"explicit zero thresholds": {
+			route: &contour_api_v1.OutlierDetection{
+				ConsecutiveServerErrors:  uint32Ptr(0),
+				ConsecutiveGatewayErrors: uint32Ptr(0),
+				MaxEjectionPercent:       uint32Ptr(0),
+			},
+			want: &OutlierDetectionPolicy{
+				ConsecutiveServerErrors:  uint32Ptr(0),
+				ConsecutiveGatewayErrors: uint32Ptr(0),
+				MaxEjectionPercent:       uint32Ptr(0),
+			},
+		},
+		"invalid interval": {
+			route: &contour_api_v1.OutlierDetection{
+				Interval: "10",
+			},
+			wantErr: true,
+		},
+		"negative base ejection time": {
+			route: &contour_api_v1.OutlierDetection{
+				BaseEjectionTime: "-1s",
+			},
+			wantErr: true,
+		},
+		"max ejection percent too large": {
+			service: &contour_api_v1.OutlierDetection{
+				MaxEjectionPercent: uint32Ptr(101),
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := outlierDetectionPolicy(tc.route, tc.service)
+			if tc.wantErr {
+				assert.Error(t, gotErr)
+			} else {
+				assert.Equal(t, tc.want, got)
+				assert.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
 func TestHeadersPolicy(t *testing.T) {
 	tests := map[string]struct {
 		hp      *contour_api_v1.HeadersPolicy
@@ -1106,3 +1281,227 @@ func TestValidateHeaderAlteration(t *testing.T) {
 		})
 	}
 }
+
+func TestJWTVerificationProvider(t *testing.T) {
+	providers := []contour_api_v1.JWTProvider{
+		{Name: "provider-1"},
+		{Name: "provider-2", Default: true},
+	}
+
+	tests := map[string]struct {
+		providers []contour_api_v1.JWTProvider
+		policy    *contour_api_v1.JWTVerificationPolicy
+		want      string
+		wantErr   bool
+	}{
+		"no providers": {
+			want: "",
+		},
+		"no policy uses the default provider": {
+			providers: providers,
+			want:      "provider-2",
+		},
+		"no policy and no default provider": {
+			providers: []contour_api_v1.JWTProvider{{Name: "provider-1"}},
+			want:      "",
+		},
+		"require overrides the default provider": {
+			providers: providers,
+			policy:    &contour_api_v1.JWTVerificationPolicy{Require: "provider-1"},
+			want:      "provider-1",
+		},
+		"disabled": {
+			providers: providers,
+			policy:    &contour_api_v1.JWTVerificationPolicy{Disabled: true},
+			want:      "",
+		},
+		"require and disabled": {
+			providers: providers,
+			policy:    &contour_api_v1.JWTVerificationPolicy{Require: "provider-1", Disabled: true},
+			wantErr:   true,
+		},
+		"require undefined provider": {
+			providers: providers,
+			policy:    &contour_api_v1.JWTVerificationPolicy{Require: "provider-3"},
+			wantErr:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := jwtVerificationProvider(tc.providers, tc.policy)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestToIPFilterRules(t *testing.T) {
+	tests := map[string]struct {
+		allow     []contour_api_v1.IPFilterPolicy
+		deny      []contour_api_v1.IPFilterPolicy
+		wantAllow bool
+		want      []IPFilterRule
+		wantErr   bool
+	}{
+		"no policies": {
+			want: nil,
+		},
+		"allow list": {
+			allow: []contour_api_v1.IPFilterPolicy{{
+				Source: contour_api_v1.IPFilterSourceRemote,
+				CIDR:   "10.0.0.0/8",
+			}, {
+				Source: contour_api_v1.IPFilterSourcePeer,
+				CIDR:   "192.168.1.1",
+			}},
+			wantAllow: true,
+			want: []IPFilterRule{{
+				Remote: true,
+				CIDR: net.IPNet{
+					IP:   net.IPv4(10, 0, 0, 0).To4(),
+					Mask: net.CIDRMask(8, 32),
+				},
+			}, {
+				Remote: false,
+				CIDR: net.IPNet{
+					IP:   net.IPv4(192, 168, 1, 1).To4(),
+					Mask: net.CIDRMask(32, 32),
+				},
+			}},
+		},
+		"deny list with ipv6": {
+			deny: []contour_api_v1.IPFilterPolicy{{
+				Source: contour_api_v1.IPFilterSourceRemote,
+				CIDR:   "2001:db8::1",
+			}},
+			wantAllow: false,
+			want: []IPFilterRule{{
+				Remote: true,
+				CIDR: net.IPNet{
+					IP:   net.ParseIP("2001:db8::1"),
+					Mask: net.CIDRMask(128, 128),
+				},
+			}},
+		},
+		"allow and deny": {
+			allow:   []contour_api_v1.IPFilterPolicy{{Source: contour_api_v1.IPFilterSourcePeer, CIDR: "10.0.0.1"}},
+			deny:    []contour_api_v1.IPFilterPolicy{{Source: contour_api_v1.IPFilterSourcePeer, CIDR: "10.0.0.2"}},
+			wantErr: true,
+		},
+		"invalid CIDR": {
+			deny:    []contour_api_v1.IPFilterPolicy{{Source: contour_api_v1.IPFilterSourcePeer, CIDR: "10.0.0.0/33"}},
+			wantErr: true,
+		},
+		"invalid address": {
+			deny:    []contour_api_v1.IPFilterPolicy{{Source: contour_api_v1.IPFilterSourcePeer, CIDR: "invalid"}},
+			wantErr: true,
+		},
+		"invalid source": {
+			deny:    []contour_api_v1.IPFilterPolicy{{Source: "Client", CIDR: "10.0.0.1"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotAllow, got, err := toIPFilterRules(tc.allow, tc.deny)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantAllow, gotAllow)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAccessLogPolicy(t *testing.T) {
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+
+	tests := map[string]struct {
+		in      *contour_api_v1.AccessLogPolicy
+		want    *AccessLogPolicy
+		wantErr bool
+	}{
+		"nil policy": {
+			in:   nil,
+			want: nil,
+		},
+		"disabled": {
+			in:   &contour_api_v1.AccessLogPolicy{Disabled: true},
+			want: &AccessLogPolicy{Name: "default/example", Disabled: true},
+		},
+		"envoy format string": {
+			in: &contour_api_v1.AccessLogPolicy{
+				Format:       "envoy",
+				FormatString: "%REQ(:METHOD)% %RESPONSE_CODE%\n",
+			},
+			want: &AccessLogPolicy{
+				Name:         "default/example",
+				Format:       "envoy",
+				FormatString: "%REQ(:METHOD)% %RESPONSE_CODE%\n",
+			},
+		},
+		"json fields with filters": {
+			in: &contour_api_v1.AccessLogPolicy{
+				Format:          "json",
+				Fields:          []string{"method", "response_code"},
+				MinStatusCode:   500,
+				SamplingPercent: pointer.Int(10),
+			},
+			want: &AccessLogPolicy{
+				Name:            "default/example",
+				Format:          "json",
+				Fields:          []string{"method", "response_code"},
+				MinStatusCode:   500,
+				SamplingPercent: uint32Ptr(10),
+			},
+		},
+		"invalid format": {
+			in:      &contour_api_v1.AccessLogPolicy{Format: "xml"},
+			wantErr: true,
+		},
+		"format string without newline": {
+			in:      &contour_api_v1.AccessLogPolicy{FormatString: "%RESPONSE_CODE%"},
+			wantErr: true,
+		},
+		"format string with json format": {
+			in:      &contour_api_v1.AccessLogPolicy{Format: "json", FormatString: "%RESPONSE_CODE%\n"},
+			wantErr: true,
+		},
+		"invalid field": {
+			in:      &contour_api_v1.AccessLogPolicy{Fields: []string{"nope"}},
+			wantErr: true,
+		},
+		"fields with envoy format": {
+			in:      &contour_api_v1.AccessLogPolicy{Format: "envoy", Fields: []string{"method"}},
+			wantErr: true,
+		},
+		"invalid status code": {
+			in:      &contour_api_v1.AccessLogPolicy{MinStatusCode: 700},
+			wantErr: true,
+		},
+		"invalid sampling percent": {
+			in:      &contour_api_v1.AccessLogPolicy{SamplingPercent: pointer.Int(101)},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := accessLogPolicy(tc.in, "default/example")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
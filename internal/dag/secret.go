@@ -27,6 +27,9 @@ import (
 // CACertificateKey is the key name for accessing TLS CA certificate bundles in Kubernetes Secrets.
 const CACertificateKey = "ca.crt"
 
+// JWKSKey is the key name for accessing a JSON Web Key Set in Kubernetes Secrets.
+const JWKSKey = "jwks"
+
 // validTLSSecret returns an error if the Secret is not of type TLS or if it doesn't contain certificate and private key material.
 func validTLSSecret(s *v1.Secret) error {
 	if s.Type != v1.SecretTypeTLS {
@@ -74,6 +77,17 @@ func isValidSecret(secret *v1.Secret) (bool, error) {
 			return false, nil
 		}
 
+		// An Opaque Secret with a `jwks` key and no `ca.crt` key
+		// holds a JWKS for verifying JWTs.
+		if _, ok := secret.Data[CACertificateKey]; !ok {
+			if data, ok := secret.Data[JWKSKey]; ok {
+				if len(data) == 0 {
+					return false, errors.New("can't use zero-length jwks value")
+				}
+				return true, nil
+			}
+		}
+
 		// If there's an Opaque Secret with a `ca.crt` key, and it's zero
 		// length, Contour can't use it, so return an error.
 		if data := secret.Data[CACertificateKey]; len(data) == 0 {
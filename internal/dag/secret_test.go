@@ -393,6 +393,26 @@ func TestIsValidSecret(t *testing.T) {
 			valid: false,
 			err:   nil,
 		},
+		"Opaque Secret, JWKS": {
+			secret: &v1.Secret{
+				Type: v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					JWKSKey: []byte(`{"keys":[]}`),
+				},
+			},
+			valid: true,
+			err:   nil,
+		},
+		"Opaque Secret, zero length JWKS": {
+			secret: &v1.Secret{
+				Type: v1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					JWKSKey: []byte(""),
+				},
+			},
+			valid: false,
+			err:   errors.New("can't use zero-length jwks value"),
+		},
 	}
 
 	for name, tc := range tests {
@@ -779,6 +779,39 @@ func TestDAGStatus(t *testing.T) {
 		},
 	})
 
+	// proxyInvalidOutlierDetection is invalid because its outlier detection policy has an invalid interval
+	proxyInvalidOutlierDetection := &contour_api_v1.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "roots",
+			Name:      "invalidod",
+		},
+		Spec: contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_api_v1.Route{{
+				Conditions: []contour_api_v1.MatchCondition{{
+					Prefix: "/foo",
+				}},
+				OutlierDetection: &contour_api_v1.OutlierDetection{
+					Interval: "10", // 10 what?
+				},
+				Services: []contour_api_v1.Service{{
+					Name: "home",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+
+	run(t, "proxy with invalid outlier detection policy is invalid", testcase{
+		objs: []interface{}{proxyInvalidOutlierDetection, fixture.ServiceRootsHome},
+		want: map[types.NamespacedName]contour_api_v1.DetailedCondition{
+			{Name: proxyInvalidOutlierDetection.Name, Namespace: proxyInvalidOutlierDetection.Namespace}: fixture.NewValidCondition().
+				WithError(contour_api_v1.ConditionTypeServiceError, "OutlierDetectionInvalid", `Service [home:8080] outlier detection policy error: invalid interval: time: missing unit in duration "10"`),
+		},
+	})
+
 	proxyValidExampleCom := &contour_api_v1.HTTPProxy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "example-com",
@@ -2623,6 +2656,78 @@ func TestDAGStatus(t *testing.T) {
 				Valid(),
 		},
 	})
+
+	proxyAccessLogPolicyQueryParam := &contour_api_v1.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "roots",
+			Name:       "example",
+			Generation: 24,
+		},
+		Spec: contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{
+				Fqdn: "example.com",
+			},
+			Routes: []contour_api_v1.Route{{
+				Conditions: []contour_api_v1.MatchCondition{{
+					Prefix: "/foo",
+				}, {
+					QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+						Name:    "debug",
+						Present: true,
+					},
+				}},
+				Services: []contour_api_v1.Service{{
+					Name: "home",
+					Port: 8080,
+				}},
+				AccessLogPolicy: &contour_api_v1.AccessLogPolicy{
+					Disabled: true,
+				},
+			}},
+		},
+	}
+
+	run(t, "access log policy on a route with query parameter conditions is valid", testcase{
+		objs: []interface{}{proxyAccessLogPolicyQueryParam, fixture.ServiceRootsHome},
+		want: map[types.NamespacedName]contour_api_v1.DetailedCondition{
+			{Name: proxyAccessLogPolicyQueryParam.Name, Namespace: proxyAccessLogPolicyQueryParam.Namespace}: fixture.NewValidCondition().
+				WithGeneration(proxyAccessLogPolicyQueryParam.Generation).
+				Valid(),
+		},
+	})
+
+	proxyInvalidAccessLogPolicy := &contour_api_v1.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "roots",
+			Name:       "example",
+			Generation: 24,
+		},
+		Spec: contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{
+				Fqdn: "example.com",
+				AccessLogPolicy: &contour_api_v1.AccessLogPolicy{
+					Format: "json",
+					Fields: []string{"nope"},
+				},
+			},
+			Routes: []contour_api_v1.Route{{
+				Services: []contour_api_v1.Service{{
+					Name: "home",
+					Port: 8080,
+				}},
+			}},
+		},
+	}
+
+	run(t, "invalid virtual host access log policy", testcase{
+		objs: []interface{}{proxyInvalidAccessLogPolicy, fixture.ServiceRootsHome},
+		want: map[types.NamespacedName]contour_api_v1.DetailedCondition{
+			{Name: proxyInvalidAccessLogPolicy.Name, Namespace: proxyInvalidAccessLogPolicy.Namespace}: fixture.NewValidCondition().
+				WithGeneration(proxyInvalidAccessLogPolicy.Generation).
+				WithError(contour_api_v1.ConditionTypeVirtualHostError, "AccessLogPolicyNotValid",
+					"Spec.VirtualHost.AccessLogPolicy is invalid: invalid JSON log field name nope"),
+		},
+	})
 }
 
 func validGatewayStatusUpdate(listenerName string, kind gatewayapi_v1alpha2.Kind, attachedRoutes int) []*status.GatewayStatusUpdate {
@@ -2694,7 +2799,6 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 							},
 						},
 					},
-					gateway: tc.gateway,
 				},
 				Processors: []Processor{
 					&IngressProcessor{
@@ -2710,7 +2814,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 
 			// Set a default gateway if not defined by a test
 			if tc.gateway == nil {
-				builder.Source.gateway = &gatewayapi_v1alpha2.Gateway{
+				tc.gateway = &gatewayapi_v1alpha2.Gateway{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "contour",
 						Namespace: "projectcontour",
@@ -2730,6 +2834,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				}
 			}
 
+			builder.Source.Insert(tc.gateway)
+
 			for _, o := range tc.objs {
 				builder.Source.Insert(o)
 			}
@@ -2801,7 +2907,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -2815,8 +2921,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionTrue,
 					Reason:  string(status.ValidCondition),
 					Message: "Valid HTTPRoute",
@@ -2839,7 +2945,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -2865,8 +2971,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionTrue,
 					Reason:  string(status.ValidCondition),
 					Message: "Valid HTTPRoute",
@@ -2886,7 +2992,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -2904,7 +3010,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -2919,8 +3025,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 			{
 				FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 				Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionTrue,
 						Reason:  string(status.ValidCondition),
 						Message: "Valid HTTPRoute",
@@ -2930,8 +3036,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 			{
 				FullName: types.NamespacedName{Namespace: "default", Name: "basic-2"},
 				Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionTrue,
 						Reason:  string(status.ValidCondition),
 						Message: "Valid HTTPRoute",
@@ -2955,7 +3061,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -2974,8 +3080,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3004,7 +3110,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3023,8 +3129,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3053,7 +3159,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3072,8 +3178,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3102,7 +3208,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3121,8 +3227,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3151,7 +3257,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3170,8 +3276,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3180,14 +3286,52 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Type:    string(status.ConditionNotImplemented),
 					Status:  contour_api_v1.ConditionTrue,
 					Reason:  string(status.ReasonPathMatchType),
-					Message: "HTTPRoute.Spec.Rules.PathMatch: Only Prefix match type and Exact match type are supported.",
+					Message: "HTTPRoute.Spec.Rules.PathMatch: Only Prefix, Exact and RegularExpression match types are supported.",
 				},
 			},
 		}},
 		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 0),
 	})
 
-	run(t, "regular expression match not yet supported for httproute", testcase{
+	run(t, "regular expression path match for httproute", testcase{
+		objs: []interface{}{
+			kuardService,
+			&gatewayapi_v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "contour",
+					},
+				},
+				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1alpha2.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchRegularExpression, "/foo/.*"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					}},
+				},
+			}},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ValidCondition),
+					Message: "Valid HTTPRoute",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 1),
+	})
+
+	run(t, "invalid regular expression path match for httproute", testcase{
 		objs: []interface{}{
 			kuardService,
 			&gatewayapi_v1alpha2.HTTPRoute{
@@ -3200,13 +3344,13 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
 					},
 					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
-						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchRegularExpression, "/"),
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchRegularExpression, "/foo/[a-z"),
 						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
 					}},
 				},
@@ -3214,24 +3358,74 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
 				},
-				status.ConditionNotImplemented: {
-					Type:    string(status.ConditionNotImplemented),
-					Status:  contour_api_v1.ConditionTrue,
-					Reason:  string(status.ReasonPathMatchType),
-					Message: "HTTPRoute.Spec.Rules.PathMatch: Only Prefix match type and Exact match type are supported.",
+				status.ConditionValidMatches: {
+					Type:    string(status.ConditionValidMatches),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonInvalidPathMatch),
+					Message: "Match.Path.Value must be a valid regular expression.",
 				},
 			},
 		}},
 		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 0),
 	})
 
-	run(t, "RegularExpression header match not yet supported for httproute", testcase{
+	run(t, "RegularExpression header match for httproute", testcase{
+		objs: []interface{}{
+			kuardService,
+			&gatewayapi_v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "contour",
+					},
+				},
+				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1alpha2.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+						Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
+							Path: &gatewayapi_v1alpha2.HTTPPathMatch{
+								Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
+								Value: pointer.StringPtr("/"),
+							},
+							Headers: []gatewayapi_v1alpha2.HTTPHeaderMatch{
+								{
+									Type:  gatewayapi.HeaderMatchTypePtr(gatewayapi_v1alpha2.HeaderMatchRegularExpression),
+									Name:  gatewayapi_v1alpha2.HTTPHeaderName("foo"),
+									Value: "ba[rz]",
+								},
+							},
+						}},
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					}},
+				},
+			}},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ValidCondition),
+					Message: "Valid HTTPRoute",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 1),
+	})
+
+	run(t, "invalid RegularExpression header match for httproute", testcase{
 		objs: []interface{}{
 			kuardService,
 			&gatewayapi_v1alpha2.HTTPRoute{
@@ -3244,7 +3438,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3257,9 +3451,9 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 							},
 							Headers: []gatewayapi_v1alpha2.HTTPHeaderMatch{
 								{
-									Type:  gatewayapi.HeaderMatchTypePtr(gatewayapi_v1alpha2.HeaderMatchRegularExpression), // <---- RegularExpression type not yet supported
+									Type:  gatewayapi.HeaderMatchTypePtr(gatewayapi_v1alpha2.HeaderMatchRegularExpression),
 									Name:  gatewayapi_v1alpha2.HTTPHeaderName("foo"),
-									Value: "bar",
+									Value: "ba[rz",
 								},
 							},
 						}},
@@ -3270,17 +3464,67 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
 				},
-				status.ConditionNotImplemented: {
-					Type:    string(status.ConditionNotImplemented),
-					Status:  contour_api_v1.ConditionTrue,
-					Reason:  string(status.ReasonHeaderMatchType),
-					Message: "HTTPRoute.Spec.Rules.HeaderMatch: Only Exact match type is supported.",
+				status.ConditionValidMatches: {
+					Type:    string(status.ConditionValidMatches),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonInvalidHeaderMatch),
+					Message: "Match.Headers.Value must be a valid regular expression.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 0),
+	})
+
+	run(t, "invalid RegularExpression query param match for httproute", testcase{
+		objs: []interface{}{
+			kuardService,
+			&gatewayapi_v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "contour",
+					},
+				},
+				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1alpha2.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+						Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
+							Path: &gatewayapi_v1alpha2.HTTPPathMatch{
+								Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchPathPrefix),
+								Value: pointer.StringPtr("/"),
+							},
+							QueryParams: gatewayapi.HTTPQueryParamMatch(gatewayapi_v1alpha2.QueryParamMatchRegularExpression, "foo", "[0-9"),
+						}},
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+					}},
+				},
+			}},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonErrorsExist),
+					Message: "Errors found, check other Conditions for details.",
+				},
+				status.ConditionValidMatches: {
+					Type:    string(status.ConditionValidMatches),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonInvalidQueryParamMatch),
+					Message: "Match.QueryParams.Value must be a valid regular expression.",
 				},
 			},
 		}},
@@ -3300,7 +3544,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3329,8 +3573,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "Spec.Rules.BackendRef.Name must be specified",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3353,7 +3597,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3386,8 +3630,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "service \"invalid-one\" is invalid: service \"default/invalid-one\" not found, service \"invalid-two\" is invalid: service \"default/invalid-two\" not found",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3411,7 +3655,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3440,8 +3684,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "Spec.Rules.BackendRef.Port must be specified",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3465,7 +3709,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3484,8 +3728,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "At least one Spec.Rules.BackendRef must be specified.",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3508,7 +3752,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -3539,8 +3783,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "Spec.Rules.BackendRef.Namespace must match the route's namespace or be covered by a ReferencePolicy",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -3566,7 +3810,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("secret", "tls-cert-namespace"),
 						},
 					},
@@ -3592,13 +3836,13 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Name:      "tls-cert-reference-policy",
 					Namespace: "tls-cert-namespace",
 				},
-				Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-					From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+				Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+					From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 						Group:     gatewayapi_v1alpha2.GroupName,
 						Kind:      "Gateway",
 						Namespace: gatewayapi_v1alpha2.Namespace("projectcontour"),
 					}},
-					To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+					To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 						Kind: "Secret",
 					}},
 				},
@@ -3621,7 +3865,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("secret", "tls-cert-namespace"),
 						},
 					},
@@ -3690,7 +3934,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("secret", "tls-cert-namespace"),
 						},
 					},
@@ -3716,13 +3960,13 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Name:      "tls-cert-reference-policy",
 					Namespace: "tls-cert-namespace",
 				},
-				Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-					From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+				Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+					From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 						Group:     gatewayapi_v1alpha2.GroupName,
 						Kind:      "Gateway",
 						Namespace: gatewayapi_v1alpha2.Namespace("projectcontour"),
 					}},
-					To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+					To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 						Kind: "Secret",
 						Name: gatewayapi.ObjectNamePtr("secret"),
 					}},
@@ -3746,7 +3990,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("secret", "tls-cert-namespace"),
 						},
 					},
@@ -3772,13 +4016,13 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Name:      "tls-cert-reference-policy",
 					Namespace: "wrong-namespace",
 				},
-				Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-					From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+				Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+					From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 						Group:     gatewayapi_v1alpha2.GroupName,
 						Kind:      "Gateway",
 						Namespace: gatewayapi_v1alpha2.Namespace("projectcontour"),
 					}},
-					To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+					To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 						Kind: "Secret",
 					}},
 				},
@@ -3831,7 +4075,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("secret", "tls-cert-namespace"),
 						},
 					},
@@ -3857,13 +4101,13 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Name:      "tls-cert-reference-policy",
 					Namespace: "tls-cert-namespace",
 				},
-				Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-					From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+				Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+					From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 						Group:     gatewayapi_v1alpha2.GroupName,
 						Kind:      "Gateway",
 						Namespace: gatewayapi_v1alpha2.Namespace("wrong-namespace"),
 					}},
-					To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+					To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 						Kind: "Secret",
 					}},
 				},
@@ -3916,7 +4160,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("secret", "tls-cert-namespace"),
 						},
 					},
@@ -3942,13 +4186,13 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Name:      "tls-cert-reference-policy",
 					Namespace: "tls-cert-namespace",
 				},
-				Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-					From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+				Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+					From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 						Group:     gatewayapi_v1alpha2.GroupName,
 						Kind:      "WrongKind",
 						Namespace: gatewayapi_v1alpha2.Namespace("projectontour"),
 					}},
-					To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+					To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 						Kind: "Secret",
 					}},
 				},
@@ -4001,7 +4245,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("secret", "tls-cert-namespace"),
 						},
 					},
@@ -4027,13 +4271,13 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Name:      "tls-cert-reference-policy",
 					Namespace: "tls-cert-namespace",
 				},
-				Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-					From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+				Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+					From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 						Group:     gatewayapi_v1alpha2.GroupName,
 						Kind:      "Gateway",
 						Namespace: gatewayapi_v1alpha2.Namespace("projectcontour"),
 					}},
-					To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+					To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 						Kind: "WrongKind",
 					}},
 				},
@@ -4086,7 +4330,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("secret", "tls-cert-namespace"),
 						},
 					},
@@ -4112,13 +4356,13 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Name:      "tls-cert-reference-policy",
 					Namespace: "tls-cert-namespace",
 				},
-				Spec: gatewayapi_v1alpha2.ReferencePolicySpec{
-					From: []gatewayapi_v1alpha2.ReferencePolicyFrom{{
+				Spec: gatewayapi_v1alpha2.ReferenceGrantSpec{
+					From: []gatewayapi_v1alpha2.ReferenceGrantFrom{{
 						Group:     gatewayapi_v1alpha2.GroupName,
 						Kind:      "Gateway",
 						Namespace: gatewayapi_v1alpha2.Namespace("projectcontour"),
 					}},
-					To: []gatewayapi_v1alpha2.ReferencePolicyTo{{
+					To: []gatewayapi_v1alpha2.ReferenceGrantTo{{
 						Kind: "Secret",
 						Name: gatewayapi.ObjectNamePtr("wrong-name"),
 					}},
@@ -4173,7 +4417,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"*.*.projectcontour.io",
@@ -4192,8 +4436,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "invalid hostname \"*.*.projectcontour.io\": [a wildcard DNS-1123 subdomain must start with '*.', followed by a valid DNS subdomain, which must consist of lower case alphanumeric characters, '-' or '.' and end with an alphanumeric character (e.g. '*.example.com', regex used for validation is '\\*\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')]",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -4216,7 +4460,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"#projectcontour.io",
@@ -4235,8 +4479,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "invalid hostname \"#projectcontour.io\": [a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')]",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -4259,7 +4503,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"1.2.3.4",
@@ -4278,8 +4522,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "hostname \"1.2.3.4\" must be a DNS name, not an IP address",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -4289,7 +4533,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 0),
 	})
 
-	run(t, "HTTPRouteFilterRequestMirror not yet supported for httproute rule", testcase{
+	run(t, "HTTPRouteFilterRequestMirror with a valid backend for httproute rule", testcase{
 		objs: []interface{}{
 			kuardService,
 			&gatewayapi_v1alpha2.HTTPRoute{
@@ -4302,7 +4546,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -4311,7 +4555,10 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
 						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
 						Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
-							Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror, // HTTPRouteFilterRequestMirror is not supported yet.
+							Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+							RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+								BackendRef: gatewayapi.ServiceBackendObjectRef("kuard", 8080),
+							},
 						}},
 					}},
 				},
@@ -4319,27 +4566,19 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				status.ConditionNotImplemented: {
-					Type:    string(status.ConditionNotImplemented),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionTrue,
-					Reason:  string(status.ReasonHTTPRouteFilterType),
-					Message: "HTTPRoute.Spec.Rules.Filters: invalid type \"RequestMirror\": only RequestHeaderModifier and RequestRedirect are supported.",
-				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
-					Status:  contour_api_v1.ConditionFalse,
-					Reason:  string(status.ReasonErrorsExist),
-					Message: "Errors found, check other Conditions for details.",
+					Reason:  string(status.ValidCondition),
+					Message: "Valid HTTPRoute",
 				},
 			},
 		}},
-		// Invalid filters still result in an attached route.
 		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 1),
 	})
 
-	run(t, "HTTPRouteFilterRequestMirror not yet supported for httproute backendref", testcase{
+	run(t, "HTTPRouteFilterRequestMirror with a missing backend for httproute rule", testcase{
 		objs: []interface{}{
-
 			kuardService,
 			&gatewayapi_v1alpha2.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
@@ -4351,37 +4590,34 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
 					},
 					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
-						Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
-						BackendRefs: []gatewayapi_v1alpha2.HTTPBackendRef{
-							{
-								BackendRef: gatewayapi_v1alpha2.BackendRef{
-									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard", 8080),
-								},
-								Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
-									Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror, // HTTPRouteFilterRequestMirror is not supported yet.
-								}},
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+							Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+							RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+								BackendRef: gatewayapi.ServiceBackendObjectRef("nonexistent", 8080),
 							},
-						},
+						}},
 					}},
 				},
 			}},
 		wantRouteConditions: []*status.RouteConditionsUpdate{{
 			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
 			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
-				status.ConditionNotImplemented: {
-					Type:    string(status.ConditionNotImplemented),
-					Status:  contour_api_v1.ConditionTrue,
-					Reason:  string(status.ReasonHTTPRouteFilterType),
-					Message: "HTTPRoute.Spec.Rules.BackendRef.Filters: Only RequestHeaderModifier type is supported.",
+				status.ConditionResolvedRefs: {
+					Type:    string(status.ConditionResolvedRefs),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonDegraded),
+					Message: "RequestMirror: service \"nonexistent\" is invalid: service \"default/nonexistent\" not found",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -4392,7 +4628,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 1),
 	})
 
-	run(t, "Invalid RequestHeaderModifier due to duplicated headers", testcase{
+	run(t, "HTTPRouteFilterURLRewrite path not supported for one of several httproute backendrefs", testcase{
 		objs: []interface{}{
 			kuardService,
 			&gatewayapi_v1alpha2.HTTPRoute{
@@ -4405,23 +4641,305 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
 					},
 					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
-						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
-						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
-						Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
-							Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestHeaderModifier,
-							RequestHeaderModifier: &gatewayapi_v1alpha2.HTTPRequestHeaderFilter{
-								Set: []gatewayapi_v1alpha2.HTTPHeader{
-									{Name: "custom", Value: "duplicated"},
-									{Name: "Custom", Value: "duplicated"},
-								},
-							},
-						}},
+						Matches: gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+						BackendRefs: []gatewayapi_v1alpha2.HTTPBackendRef{
+							{
+								BackendRef: gatewayapi_v1alpha2.BackendRef{
+									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard", 8080),
+								},
+								Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+									Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+									URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+										Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+											Type:               gatewayapi_v1alpha2.PrefixMatchHTTPPathModifier,
+											ReplacePrefixMatch: pointer.String("/bar"),
+										},
+									},
+								}},
+							},
+							{
+								BackendRef: gatewayapi_v1alpha2.BackendRef{
+									BackendObjectReference: gatewayapi.ServiceBackendObjectRef("kuard", 8080),
+								},
+							},
+						},
+					}},
+				},
+			}},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionNotImplemented: {
+					Type:    string(status.ConditionNotImplemented),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonHTTPRouteFilterType),
+					Message: "HTTPRoute.Spec.Rules.BackendRef.Filters: URLRewrite.Path is only supported when the rule has a single backendRef.",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonErrorsExist),
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		// Invalid filters still result in an attached route.
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 1),
+	})
+
+	run(t, "HTTPRouteFilterURLRewrite on the same httproute rule as HTTPRouteFilterRequestRedirect", testcase{
+		objs: []interface{}{
+			kuardService,
+			&gatewayapi_v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "contour",
+					},
+				},
+				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1alpha2.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/foo"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+							Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestRedirect,
+							RequestRedirect: &gatewayapi_v1alpha2.HTTPRequestRedirectFilter{
+								Hostname: gatewayapi.PreciseHostname("bar.com"),
+							},
+						}, {
+							Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+							URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+								Hostname: gatewayapi.PreciseHostname("bar.com"),
+							},
+						}},
+					}},
+				},
+			}},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionNotImplemented: {
+					Type:    string(status.ConditionNotImplemented),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonHTTPRouteFilterType),
+					Message: "HTTPRoute.Spec.Rules.Filters: URLRewrite cannot be used on the same rule as RequestRedirect.",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonErrorsExist),
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		// The rule is not programmed, so the route is not attached.
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 0),
+	})
+
+	run(t, "HTTPRouteFilterURLRewrite ReplacePrefixMatch not supported for an exact httproute path match", testcase{
+		objs: []interface{}{
+			kuardService,
+			&gatewayapi_v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "contour",
+					},
+				},
+				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1alpha2.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchExact, "/foo"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+							Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+							URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+								Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+									Type:               gatewayapi_v1alpha2.PrefixMatchHTTPPathModifier,
+									ReplacePrefixMatch: pointer.String("/bar"),
+								},
+							},
+						}},
+					}},
+				},
+			}},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionNotImplemented: {
+					Type:    string(status.ConditionNotImplemented),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonUnsupportedValue),
+					Message: "URLRewrite.Path.ReplacePrefixMatch can only be used with PathPrefix matches",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonErrorsExist),
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		// The rule is not programmed, so the route is not attached.
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 0),
+	})
+
+	run(t, "HTTPRouteFilterURLRewrite unknown path modifier type", testcase{
+		objs: []interface{}{
+			kuardService,
+			&gatewayapi_v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "contour",
+					},
+				},
+				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1alpha2.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/foo"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+							Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+							URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+								Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+									Type: "ReplaceSomething",
+								},
+							},
+						}},
+					}},
+				},
+			}},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionNotImplemented: {
+					Type:    string(status.ConditionNotImplemented),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonUnsupportedValue),
+					Message: "URLRewrite.Path.Type \"ReplaceSomething\" is not supported",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonErrorsExist),
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		// The rule is not programmed, so the route is not attached.
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 0),
+	})
+
+	run(t, "HTTPRouteFilterRequestRedirect path not supported", testcase{
+		objs: []interface{}{
+			kuardService,
+			&gatewayapi_v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "contour",
+					},
+				},
+				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1alpha2.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/foo"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+							Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestRedirect,
+							RequestRedirect: &gatewayapi_v1alpha2.HTTPRequestRedirectFilter{
+								Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+									Type:            gatewayapi_v1alpha2.FullPathHTTPPathModifier,
+									ReplaceFullPath: pointer.String("/bar"),
+								},
+							},
+						}},
+					}},
+				},
+			}},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionNotImplemented: {
+					Type:    string(status.ConditionNotImplemented),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonUnsupportedValue),
+					Message: "HTTPRoute.Spec.Rules.Filters: RequestRedirect.Path is not supported.",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonErrorsExist),
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		// Invalid filters still result in an attached route.
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("http", "HTTPRoute", 1),
+	})
+
+	run(t, "Invalid RequestHeaderModifier due to duplicated headers", testcase{
+		objs: []interface{}{
+			kuardService,
+			&gatewayapi_v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "basic",
+					Namespace: "default",
+					Labels: map[string]string{
+						"app": "contour",
+					},
+				},
+				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+					},
+					Hostnames: []gatewayapi_v1alpha2.Hostname{
+						"test.projectcontour.io",
+					},
+					Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+						Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+						BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+						Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+							Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestHeaderModifier,
+							RequestHeaderModifier: &gatewayapi_v1alpha2.HTTPRequestHeaderFilter{
+								Set: []gatewayapi_v1alpha2.HTTPHeader{
+									{Name: "custom", Value: "duplicated"},
+									{Name: "Custom", Value: "duplicated"},
+								},
+							},
+						}},
 					}},
 				},
 			}},
@@ -4434,8 +4952,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "duplicate header addition: \"Custom\" on request headers",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -4459,7 +4977,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 				},
 				Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 					CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-						ParentRefs: []gatewayapi_v1alpha2.ParentRef{gatewayapi.GatewayParentRef("projectcontour", "contour")},
+						ParentRefs: []gatewayapi_v1alpha2.ParentReference{gatewayapi.GatewayParentRef("projectcontour", "contour")},
 					},
 					Hostnames: []gatewayapi_v1alpha2.Hostname{
 						"test.projectcontour.io",
@@ -4493,8 +5011,8 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Reason:  string(status.ReasonDegraded),
 					Message: "invalid set header \"!invalid-Header\": [a valid HTTP header must consist of alphanumeric characters or '-' (e.g. 'X-Header-Name', regex used for validation is '[-A-Za-z0-9]+')] on request headers",
 				},
-				gatewayapi_v1alpha2.ConditionRouteAccepted: {
-					Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 					Status:  contour_api_v1.ConditionFalse,
 					Reason:  string(status.ReasonErrorsExist),
 					Message: "Errors found, check other Conditions for details.",
@@ -4664,7 +5182,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 							Type:    string(gatewayapi_v1alpha2.ListenerConditionResolvedRefs),
 							Status:  metav1.ConditionFalse,
 							Reason:  string(gatewayapi_v1alpha2.ListenerReasonInvalidRouteKinds),
-							Message: "Kind \"FooRoute\" is not supported, kind must be \"HTTPRoute\" or \"TLSRoute\"",
+							Message: "Kind \"FooRoute\" is not supported, kind must be \"HTTPRoute\", \"TLSRoute\", \"TCPRoute\" or \"UDPRoute\"",
 						},
 					},
 				},
@@ -4672,7 +5190,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 		}},
 	})
 
-	run(t, "allowedroute of TLSRoute on a non-TLS listener results in a listener condition", testcase{
+	run(t, "listeners with incompatible protocols on the same port results in a listener condition", testcase{
 		objs: []interface{}{},
 		gateway: &gatewayapi_v1alpha2.Gateway{
 			ObjectMeta: metav1.ObjectMeta{
@@ -4685,9 +5203,24 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					Port:     80,
 					Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
 					AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
-						Kinds: []gatewayapi_v1alpha2.RouteGroupKind{
-							{Kind: "TLSRoute"},
+						Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+							From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+						},
+					},
+				}, {
+					Name:     "tcp",
+					Port:     80,
+					Protocol: gatewayapi_v1alpha2.TCPProtocolType,
+					AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+						Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+							From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
 						},
+					},
+				}, {
+					Name:     "udp",
+					Port:     80,
+					Protocol: gatewayapi_v1alpha2.UDPProtocolType,
+					AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
 						Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
 							From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
 						},
@@ -4707,12 +5240,101 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 			},
 			ListenerStatus: map[string]*gatewayapi_v1alpha2.ListenerStatus{
 				"http": {
-					Name:           "http",
-					SupportedKinds: nil,
-					Conditions: []metav1.Condition{
+					Name: "http",
+					SupportedKinds: []gatewayapi_v1alpha2.RouteGroupKind{
 						{
-							Type:    "Ready",
-							Status:  metav1.ConditionFalse,
+							Group: gatewayapi.GroupPtr(gatewayapi_v1alpha2.GroupName),
+							Kind:  "HTTPRoute",
+						},
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:    "Ready",
+							Status:  metav1.ConditionTrue,
+							Reason:  "Ready",
+							Message: "Valid listener",
+						},
+					},
+				},
+				"tcp": {
+					Name: "tcp",
+					Conditions: []metav1.Condition{
+						{
+							Type:    "Ready",
+							Status:  metav1.ConditionFalse,
+							Reason:  "Invalid",
+							Message: "Invalid listener, see other listener conditions for details",
+						},
+						{
+							Type:    string(gatewayapi_v1alpha2.ListenerConditionDetached),
+							Status:  metav1.ConditionTrue,
+							Reason:  string(gatewayapi_v1alpha2.ListenerReasonPortUnavailable),
+							Message: "Listener.Port 80 is already in use by a listener with protocol \"HTTP\".",
+						},
+					},
+				},
+				"udp": {
+					Name: "udp",
+					SupportedKinds: []gatewayapi_v1alpha2.RouteGroupKind{
+						{
+							Group: gatewayapi.GroupPtr(gatewayapi_v1alpha2.GroupName),
+							Kind:  "UDPRoute",
+						},
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:    "Ready",
+							Status:  metav1.ConditionTrue,
+							Reason:  "Ready",
+							Message: "Valid listener",
+						},
+					},
+				},
+			},
+		}},
+	})
+
+	run(t, "allowedroute of TLSRoute on a non-TLS listener results in a listener condition", testcase{
+		objs: []interface{}{},
+		gateway: &gatewayapi_v1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "contour",
+				Namespace: "projectcontour",
+			},
+			Spec: gatewayapi_v1alpha2.GatewaySpec{
+				Listeners: []gatewayapi_v1alpha2.Listener{{
+					Name:     "http",
+					Port:     80,
+					Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+					AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+						Kinds: []gatewayapi_v1alpha2.RouteGroupKind{
+							{Kind: "TLSRoute"},
+						},
+						Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+							From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+						},
+					},
+				}},
+			},
+		},
+		wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{{
+			FullName: types.NamespacedName{Namespace: "projectcontour", Name: "contour"},
+			Conditions: map[gatewayapi_v1alpha2.GatewayConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.GatewayConditionReady: {
+					Type:    string(gatewayapi_v1alpha2.GatewayConditionReady),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(gatewayapi_v1alpha2.GatewayReasonListenersNotValid),
+					Message: "Listeners are not valid",
+				},
+			},
+			ListenerStatus: map[string]*gatewayapi_v1alpha2.ListenerStatus{
+				"http": {
+					Name:           "http",
+					SupportedKinds: nil,
+					Conditions: []metav1.Condition{
+						{
+							Type:    "Ready",
+							Status:  metav1.ConditionFalse,
 							Reason:  "Invalid",
 							Message: "Invalid listener, see other listener conditions for details",
 						},
@@ -4746,7 +5368,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 						},
 					},
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							{
 								Group: gatewayapi.GroupPtr("invalid-group"),
 								Kind:  gatewayapi.KindPtr("NotASecret"),
@@ -4808,7 +5430,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 						},
 					},
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("nonexistent-secret", "projectcontour"),
 						},
 					},
@@ -4866,7 +5488,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					},
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							{
 								Group: gatewayapi.GroupPtr("invalid-group"),
 								Kind:  gatewayapi.KindPtr("NotASecret"),
@@ -4929,7 +5551,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					},
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("nonexistent-secret", "projectcontour"),
 						},
 					},
@@ -5135,7 +5757,7 @@ func TestGatewayAPIHTTPRouteDAGStatus(t *testing.T) {
 					},
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 						Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModePassthrough),
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("tlscert", "projectcontour"),
 						},
 					},
@@ -5187,7 +5809,6 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 				Source: KubernetesCache{
 					RootNamespaces: []string{"roots", "marketing"},
 					FieldLogger:    fixture.NewTestLogger(t),
-					gateway:        tc.gateway,
 					gatewayclass: &gatewayapi_v1alpha2.GatewayClass{
 						TypeMeta: metav1.TypeMeta{},
 						ObjectMeta: metav1.ObjectMeta{
@@ -5218,6 +5839,10 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 				},
 			}
 
+			if tc.gateway != nil {
+				builder.Source.Insert(tc.gateway)
+			}
+
 			// Add a default cert to be used in tests with TLS.
 			builder.Source.Insert(fixture.SecretProjectContourCert)
 
@@ -5273,7 +5898,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 				Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 				TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 					// Mode is not defined and should default to "Terminate".
-					CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+					CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 						gatewayapi.CertificateRef(fixture.SecretProjectContourCert.Name, fixture.SecretProjectContourCert.Namespace),
 					},
 				},
@@ -5296,7 +5921,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 				Protocol: gatewayapi_v1alpha2.TLSProtocolType,
 				TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
 					Mode: gatewayapi.TLSModeTypePtr(gatewayapi_v1alpha2.TLSModeTerminate),
-					CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+					CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 						gatewayapi.CertificateRef(fixture.SecretProjectContourCert.Name, fixture.SecretProjectContourCert.Namespace),
 					},
 				},
@@ -5363,7 +5988,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef("projectcontour", "contour"),
 							},
 						},
@@ -5389,8 +6014,8 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 						Reason:  string(status.ReasonDegraded),
 						Message: "Spec.Rules.BackendRef.Name must be specified",
 					},
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionFalse,
 						Reason:  "ErrorsExist",
 						Message: "Errors found, check other Conditions for details.",
@@ -5413,7 +6038,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef("projectcontour", "contour"),
 							},
 						},
@@ -5433,8 +6058,8 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 						Reason:  string(status.ReasonDegraded),
 						Message: "service \"invalid-one\" is invalid: service \"default/invalid-one\" not found, service \"invalid-two\" is invalid: service \"default/invalid-two\" not found",
 					},
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionFalse,
 						Reason:  "ErrorsExist",
 						Message: "Errors found, check other Conditions for details.",
@@ -5458,7 +6083,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef("projectcontour", "contour"),
 							},
 						},
@@ -5484,8 +6109,8 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 						Reason:  string(status.ReasonDegraded),
 						Message: "Spec.Rules.BackendRef.Port must be specified",
 					},
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionFalse,
 						Reason:  "ErrorsExist",
 						Message: "Errors found, check other Conditions for details.",
@@ -5509,7 +6134,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef("projectcontour", "contour"),
 							},
 						},
@@ -5528,8 +6153,8 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 						Reason:  string(status.ReasonDegraded),
 						Message: "At least one Spec.Rules.BackendRef must be specified.",
 					},
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionFalse,
 						Reason:  "ErrorsExist",
 						Message: "Errors found, check other Conditions for details.",
@@ -5553,7 +6178,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef("projectcontour", "contour"),
 							},
 						},
@@ -5572,8 +6197,8 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 						Reason:  string(status.ReasonDegraded),
 						Message: "invalid hostname \"*.*.projectcontour.io\": [a wildcard DNS-1123 subdomain must start with '*.', followed by a valid DNS subdomain, which must consist of lower case alphanumeric characters, '-' or '.' and end with an alphanumeric character (e.g. '*.example.com', regex used for validation is '\\*\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')]",
 					},
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionFalse,
 						Reason:  "ErrorsExist",
 						Message: "Errors found, check other Conditions for details.",
@@ -5597,7 +6222,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef("projectcontour", "contour"),
 							},
 						},
@@ -5616,8 +6241,8 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 						Reason:  string(status.ReasonDegraded),
 						Message: "invalid hostname \"#projectcontour.io\": [a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')]",
 					},
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionFalse,
 						Reason:  "ErrorsExist",
 						Message: "Errors found, check other Conditions for details.",
@@ -5641,7 +6266,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef("projectcontour", "contour"),
 							},
 						},
@@ -5660,8 +6285,8 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 						Reason:  string(status.ReasonDegraded),
 						Message: "hostname \"1.2.3.4\" must be a DNS name, not an IP address",
 					},
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionFalse,
 						Reason:  "ErrorsExist",
 						Message: "Errors found, check other Conditions for details.",
@@ -5682,7 +6307,7 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 					},
 					Spec: gatewayapi_v1alpha2.TLSRouteSpec{
 						CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-							ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+							ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 								gatewayapi.GatewayParentRef(gw.Namespace, gw.Name),
 							},
 						},
@@ -5701,8 +6326,8 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 						Reason:  string(status.ReasonAllBackendRefsHaveZeroWeights),
 						Message: "At least one Spec.Rules.BackendRef must have a non-zero weight.",
 					},
-					gatewayapi_v1alpha2.ConditionRouteAccepted: {
-						Type:    string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+					gatewayapi_v1alpha2.RouteConditionAccepted: {
+						Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
 						Status:  contour_api_v1.ConditionFalse,
 						Reason:  "ErrorsExist",
 						Message: "Errors found, check other Conditions for details.",
@@ -5713,3 +6338,887 @@ func TestGatewayAPITLSRouteDAGStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGatewayAPITCPRouteDAGStatus(t *testing.T) {
+
+	type testcase struct {
+		objs                    []interface{}
+		wantRouteConditions     []*status.RouteConditionsUpdate
+		wantGatewayStatusUpdate []*status.GatewayStatusUpdate
+	}
+
+	gateway := &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Name:     "tcp",
+				Port:     5432,
+				Protocol: gatewayapi_v1alpha2.TCPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	}
+
+	run := func(t *testing.T, desc string, tc testcase) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			t.Helper()
+			builder := Builder{
+				Source: KubernetesCache{
+					FieldLogger: fixture.NewTestLogger(t),
+					gatewayclass: &gatewayapi_v1alpha2.GatewayClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-gc",
+						},
+						Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+							ControllerName: "projectcontour.io/contour",
+						},
+					},
+				},
+				Processors: []Processor{
+					&GatewayAPIProcessor{
+						FieldLogger: fixture.NewTestLogger(t),
+					},
+					&ListenerProcessor{},
+				},
+			}
+
+			builder.Source.Insert(gateway)
+
+			for _, o := range tc.objs {
+				builder.Source.Insert(o)
+			}
+			dag := builder.Build()
+			gotRouteUpdates := dag.StatusCache.GetRouteUpdates()
+			gotGatewayUpdates := dag.StatusCache.GetGatewayUpdates()
+
+			ops := []cmp.Option{
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "ExistingConditions"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "GatewayRef"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "TransitionTime"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "Resource"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "ExistingConditions"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "TransitionTime"),
+				cmpopts.SortSlices(func(i, j metav1.Condition) bool {
+					return i.Message < j.Message
+				}),
+				cmpopts.SortSlices(func(i, j *status.RouteConditionsUpdate) bool {
+					return i.FullName.String() < j.FullName.String()
+				}),
+			}
+
+			for _, u := range tc.wantRouteConditions {
+				u.GatewayController = builder.Source.gatewayclass.Spec.ControllerName
+			}
+
+			if diff := cmp.Diff(tc.wantRouteConditions, gotRouteUpdates, ops...); diff != "" {
+				t.Fatalf("expected route status: %v, got %v", tc.wantRouteConditions, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantGatewayStatusUpdate, gotGatewayUpdates, ops...); diff != "" {
+				t.Fatalf("expected gateway status: %v, got %v", tc.wantGatewayStatusUpdate, diff)
+			}
+		})
+	}
+
+	kuardService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "default",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{
+				Name:       "http",
+				Protocol:   "TCP",
+				Port:       8080,
+				TargetPort: intstr.FromInt(8080),
+			}},
+		},
+	}
+
+	tcpRoute := func(name string, backendRefs []gatewayapi_v1alpha2.BackendRef) *gatewayapi_v1alpha2.TCPRoute {
+		return &gatewayapi_v1alpha2.TCPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: gatewayapi_v1alpha2.TCPRouteSpec{
+				CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+					ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+						gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					},
+				},
+				Rules: []gatewayapi_v1alpha2.TCPRouteRule{{
+					BackendRefs: backendRefs,
+				}},
+			},
+		}
+	}
+
+	run(t, "TCPRoute: valid route", testcase{
+		objs: []interface{}{
+			kuardService,
+			tcpRoute("basic", gatewayapi.TCPRouteBackendRef("kuard", 8080, nil)),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonValid),
+					Message: "Valid TCPRoute",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("tcp", "TCPRoute", 1),
+	})
+
+	run(t, "TCPRoute: spec.rules.backendRef.name not found", testcase{
+		objs: []interface{}{
+			tcpRoute("basic", gatewayapi.TCPRouteBackendRef("kuard", 8080, nil)),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionResolvedRefs: {
+					Type:    string(status.ConditionResolvedRefs),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonDegraded),
+					Message: "service \"kuard\" is invalid: service \"default/kuard\" not found",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  "ErrorsExist",
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("tcp", "TCPRoute", 0),
+	})
+
+	run(t, "TCPRoute: spec.rules.backendRefs has 0 weight", testcase{
+		objs: []interface{}{
+			kuardService,
+			tcpRoute("basic", gatewayapi.TCPRouteBackendRef("kuard", 8080, pointer.Int32(0))),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionValidBackendRefs: {
+					Type:    string(status.ConditionValidBackendRefs),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonAllBackendRefsHaveZeroWeights),
+					Message: "At least one Spec.Rules.BackendRef must have a non-zero weight.",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  "ErrorsExist",
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("tcp", "TCPRoute", 0),
+	})
+
+	run(t, "TCPRoute: another TCPRoute is already attached to the listener", testcase{
+		objs: []interface{}{
+			kuardService,
+			tcpRoute("basic", gatewayapi.TCPRouteBackendRef("kuard", 8080, nil)),
+			tcpRoute("other", gatewayapi.TCPRouteBackendRef("kuard", 8080, nil)),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonValid),
+					Message: "Valid TCPRoute",
+				},
+			},
+		}, {
+			FullName: types.NamespacedName{Namespace: "default", Name: "other"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonNotAllowedByListeners),
+					Message: "Another TCPRoute is already attached to listener port 5432.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("tcp", "TCPRoute", 1),
+	})
+}
+
+func TestGatewayAPIUDPRouteDAGStatus(t *testing.T) {
+
+	type testcase struct {
+		objs                    []interface{}
+		wantRouteConditions     []*status.RouteConditionsUpdate
+		wantGatewayStatusUpdate []*status.GatewayStatusUpdate
+	}
+
+	gateway := &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Name:     "udp",
+				Port:     5353,
+				Protocol: gatewayapi_v1alpha2.UDPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	}
+
+	run := func(t *testing.T, desc string, tc testcase) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			t.Helper()
+			builder := Builder{
+				Source: KubernetesCache{
+					FieldLogger: fixture.NewTestLogger(t),
+					gatewayclass: &gatewayapi_v1alpha2.GatewayClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-gc",
+						},
+						Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+							ControllerName: "projectcontour.io/contour",
+						},
+					},
+				},
+				Processors: []Processor{
+					&GatewayAPIProcessor{
+						FieldLogger: fixture.NewTestLogger(t),
+					},
+					&ListenerProcessor{},
+				},
+			}
+
+			builder.Source.Insert(gateway)
+
+			for _, o := range tc.objs {
+				builder.Source.Insert(o)
+			}
+			dag := builder.Build()
+			gotRouteUpdates := dag.StatusCache.GetRouteUpdates()
+			gotGatewayUpdates := dag.StatusCache.GetGatewayUpdates()
+
+			ops := []cmp.Option{
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "ExistingConditions"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "GatewayRef"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "TransitionTime"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "Resource"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "ExistingConditions"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "TransitionTime"),
+				cmpopts.SortSlices(func(i, j metav1.Condition) bool {
+					return i.Message < j.Message
+				}),
+				cmpopts.SortSlices(func(i, j *status.RouteConditionsUpdate) bool {
+					return i.FullName.String() < j.FullName.String()
+				}),
+			}
+
+			for _, u := range tc.wantRouteConditions {
+				u.GatewayController = builder.Source.gatewayclass.Spec.ControllerName
+			}
+
+			if diff := cmp.Diff(tc.wantRouteConditions, gotRouteUpdates, ops...); diff != "" {
+				t.Fatalf("expected route status: %v, got %v", tc.wantRouteConditions, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantGatewayStatusUpdate, gotGatewayUpdates, ops...); diff != "" {
+				t.Fatalf("expected gateway status: %v, got %v", tc.wantGatewayStatusUpdate, diff)
+			}
+		})
+	}
+
+	kuardService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "default",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{
+				Name:       "dns",
+				Protocol:   "UDP",
+				Port:       5353,
+				TargetPort: intstr.FromInt(5353),
+			}},
+		},
+	}
+
+	udpRoute := func(name string, backendRefs []gatewayapi_v1alpha2.BackendRef) *gatewayapi_v1alpha2.UDPRoute {
+		return &gatewayapi_v1alpha2.UDPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+				CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+					ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+						gatewayapi.GatewayParentRef("projectcontour", "contour"),
+					},
+				},
+				Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+					BackendRefs: backendRefs,
+				}},
+			},
+		}
+	}
+
+	run(t, "UDPRoute: valid route", testcase{
+		objs: []interface{}{
+			kuardService,
+			udpRoute("basic", gatewayapi.UDPRouteBackendRef("kuard", 5353, nil)),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonValid),
+					Message: "Valid UDPRoute",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", "UDPRoute", 1),
+	})
+
+	run(t, "UDPRoute: spec.rules.backendRef.name not found", testcase{
+		objs: []interface{}{
+			udpRoute("basic", gatewayapi.UDPRouteBackendRef("kuard", 5353, nil)),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionResolvedRefs: {
+					Type:    string(status.ConditionResolvedRefs),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonDegraded),
+					Message: "service \"kuard\" is invalid: service \"default/kuard\" not found",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  "ErrorsExist",
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", "UDPRoute", 0),
+	})
+
+	run(t, "UDPRoute: spec.rules.backendRefs has 0 weight", testcase{
+		objs: []interface{}{
+			kuardService,
+			udpRoute("basic", gatewayapi.UDPRouteBackendRef("kuard", 5353, pointer.Int32(0))),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionValidBackendRefs: {
+					Type:    string(status.ConditionValidBackendRefs),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonAllBackendRefsHaveZeroWeights),
+					Message: "At least one Spec.Rules.BackendRef must have a non-zero weight.",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  "ErrorsExist",
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", "UDPRoute", 0),
+	})
+
+	run(t, "UDPRoute: spec.rules.backendRef references a TCP service port", testcase{
+		objs: []interface{}{
+			&v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kuard-tcp",
+					Namespace: "default",
+				},
+				Spec: v1.ServiceSpec{
+					Ports: []v1.ServicePort{{
+						Name:       "http",
+						Protocol:   "TCP",
+						Port:       8080,
+						TargetPort: intstr.FromInt(8080),
+					}},
+				},
+			},
+			udpRoute("basic", gatewayapi.UDPRouteBackendRef("kuard-tcp", 8080, nil)),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionResolvedRefs: {
+					Type:    string(status.ConditionResolvedRefs),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonDegraded),
+					Message: "service \"kuard-tcp\" is invalid: unsupported service protocol \"TCP\"",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  "ErrorsExist",
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", "UDPRoute", 0),
+	})
+
+	run(t, "UDPRoute: more than one spec.rules.backendRef", testcase{
+		objs: []interface{}{
+			kuardService,
+			udpRoute("basic", append(
+				gatewayapi.UDPRouteBackendRef("kuard", 5353, nil),
+				gatewayapi.UDPRouteBackendRef("kuard", 5353, nil)...,
+			)),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				status.ConditionValidBackendRefs: {
+					Type:    string(status.ConditionValidBackendRefs),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonMultipleBackendRefs),
+					Message: "Only one Spec.Rules.BackendRef may be specified.",
+				},
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  "ErrorsExist",
+					Message: "Errors found, check other Conditions for details.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", "UDPRoute", 0),
+	})
+
+	run(t, "UDPRoute: another UDPRoute is already attached to the listener", testcase{
+		objs: []interface{}{
+			kuardService,
+			udpRoute("basic", gatewayapi.UDPRouteBackendRef("kuard", 5353, nil)),
+			udpRoute("other", gatewayapi.UDPRouteBackendRef("kuard", 5353, nil)),
+		},
+		wantRouteConditions: []*status.RouteConditionsUpdate{{
+			FullName: types.NamespacedName{Namespace: "default", Name: "basic"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonValid),
+					Message: "Valid UDPRoute",
+				},
+			},
+		}, {
+			FullName: types.NamespacedName{Namespace: "default", Name: "other"},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionFalse,
+					Reason:  string(status.ReasonNotAllowedByListeners),
+					Message: "Another UDPRoute is already attached to listener port 5353.",
+				},
+			},
+		}},
+		wantGatewayStatusUpdate: validGatewayStatusUpdate("udp", "UDPRoute", 1),
+	})
+}
+
+func TestGatewayAPIMultipleGatewaysDAGStatus(t *testing.T) {
+	gateway := func(name string, listeners ...gatewayapi_v1alpha2.Listener) *gatewayapi_v1alpha2.Gateway {
+		return &gatewayapi_v1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "projectcontour",
+			},
+			Spec: gatewayapi_v1alpha2.GatewaySpec{
+				Listeners: listeners,
+			},
+		}
+	}
+	listener := func(name string, port int, protocol gatewayapi_v1alpha2.ProtocolType) gatewayapi_v1alpha2.Listener {
+		return gatewayapi_v1alpha2.Listener{
+			Name:     gatewayapi_v1alpha2.SectionName(name),
+			Port:     gatewayapi_v1alpha2.PortNumber(port),
+			Protocol: protocol,
+			AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+				Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+					From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+				},
+			},
+		}
+	}
+	listenerWithHostname := func(name string, port int, protocol gatewayapi_v1alpha2.ProtocolType, hostname string) gatewayapi_v1alpha2.Listener {
+		l := listener(name, port, protocol)
+		l.Hostname = gatewayapi.ListenerHostname(hostname)
+		return l
+	}
+
+	kuardService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kuard",
+			Namespace: "default",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{
+				Name:       "http",
+				Protocol:   "TCP",
+				Port:       8080,
+				TargetPort: intstr.FromInt(8080),
+			}},
+		},
+	}
+	httpRoute := &gatewayapi_v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "gateway-a"),
+					gatewayapi.GatewayParentRef("projectcontour", "gateway-b"),
+				},
+			},
+			Hostnames: []gatewayapi_v1alpha2.Hostname{"test.projectcontour.io"},
+			Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+				Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+				BackendRefs: gatewayapi.HTTPBackendRef("kuard", 8080, 1),
+			}},
+		},
+	}
+
+	validRoute := func(gateway string) *status.RouteConditionsUpdate {
+		return &status.RouteConditionsUpdate{
+			FullName:   types.NamespacedName{Namespace: "default", Name: "basic"},
+			GatewayRef: types.NamespacedName{Namespace: "projectcontour", Name: gateway},
+			Conditions: map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition{
+				gatewayapi_v1alpha2.RouteConditionAccepted: {
+					Type:    string(gatewayapi_v1alpha2.RouteConditionAccepted),
+					Status:  contour_api_v1.ConditionTrue,
+					Reason:  string(status.ReasonValid),
+					Message: "Valid HTTPRoute",
+				},
+			},
+		}
+	}
+	validGateway := func(name string, listener string) *status.GatewayStatusUpdate {
+		update := validGatewayStatusUpdate(listener, "HTTPRoute", 1)[0]
+		update.FullName = types.NamespacedName{Namespace: "projectcontour", Name: name}
+		return update
+	}
+
+	tests := map[string]struct {
+		gateways                []*gatewayapi_v1alpha2.Gateway
+		reservedPorts           []int
+		wantRouteConditions     []*status.RouteConditionsUpdate
+		wantGatewayStatusUpdate []*status.GatewayStatusUpdate
+	}{
+		"route attached to gateways on different ports": {
+			gateways: []*gatewayapi_v1alpha2.Gateway{
+				gateway("gateway-a", listener("http", 80, gatewayapi_v1alpha2.HTTPProtocolType)),
+				gateway("gateway-b", listener("http-alt", 81, gatewayapi_v1alpha2.HTTPProtocolType)),
+			},
+			wantRouteConditions: []*status.RouteConditionsUpdate{
+				validRoute("gateway-a"),
+				validRoute("gateway-b"),
+			},
+			wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{
+				validGateway("gateway-a", "http"),
+				validGateway("gateway-b", "http-alt"),
+			},
+		},
+		"route attached to gateways sharing a port": {
+			gateways: []*gatewayapi_v1alpha2.Gateway{
+				gateway("gateway-a", listenerWithHostname("http", 80, gatewayapi_v1alpha2.HTTPProtocolType, "test.projectcontour.io")),
+				gateway("gateway-b", listenerWithHostname("http", 80, gatewayapi_v1alpha2.HTTPProtocolType, "*.projectcontour.io")),
+			},
+			wantRouteConditions: []*status.RouteConditionsUpdate{
+				validRoute("gateway-a"),
+				validRoute("gateway-b"),
+			},
+			wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{
+				validGateway("gateway-a", "http"),
+				validGateway("gateway-b", "http"),
+			},
+		},
+		"listener hostname conflicts with another gateway's listener": {
+			gateways: []*gatewayapi_v1alpha2.Gateway{
+				gateway("gateway-a", listener("http", 80, gatewayapi_v1alpha2.HTTPProtocolType)),
+				gateway("gateway-b", listener("http", 80, gatewayapi_v1alpha2.HTTPProtocolType)),
+			},
+			wantRouteConditions: []*status.RouteConditionsUpdate{
+				validRoute("gateway-a"),
+			},
+			wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{
+				validGateway("gateway-a", "http"),
+				{
+					FullName: types.NamespacedName{Namespace: "projectcontour", Name: "gateway-b"},
+					Conditions: map[gatewayapi_v1alpha2.GatewayConditionType]metav1.Condition{
+						gatewayapi_v1alpha2.GatewayConditionReady: {
+							Type:    string(gatewayapi_v1alpha2.GatewayConditionReady),
+							Status:  contour_api_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1alpha2.GatewayReasonListenersNotValid),
+							Message: "Listeners are not valid",
+						},
+					},
+					ListenerStatus: map[string]*gatewayapi_v1alpha2.ListenerStatus{
+						"http": {
+							Name: "http",
+							Conditions: []metav1.Condition{
+								{
+									Type:    string(gatewayapi_v1alpha2.ListenerConditionConflicted),
+									Status:  metav1.ConditionTrue,
+									Reason:  string(gatewayapi_v1alpha2.ListenerReasonHostnameConflict),
+									Message: "Listener.Port 80 with Listener.Hostname \"\" is already in use by Gateway projectcontour/gateway-a.",
+								},
+								{
+									Type:    string(gatewayapi_v1alpha2.ListenerConditionReady),
+									Status:  metav1.ConditionFalse,
+									Reason:  string(gatewayapi_v1alpha2.ListenerReasonInvalid),
+									Message: "Invalid listener, see other listener conditions for details",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"listener protocol conflicts with another gateway's listener": {
+			gateways: []*gatewayapi_v1alpha2.Gateway{
+				gateway("gateway-a", listener("http", 80, gatewayapi_v1alpha2.HTTPProtocolType)),
+				gateway("gateway-b", listener("tcp", 80, gatewayapi_v1alpha2.TCPProtocolType)),
+			},
+			wantRouteConditions: []*status.RouteConditionsUpdate{
+				validRoute("gateway-a"),
+			},
+			wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{
+				validGateway("gateway-a", "http"),
+				{
+					FullName: types.NamespacedName{Namespace: "projectcontour", Name: "gateway-b"},
+					Conditions: map[gatewayapi_v1alpha2.GatewayConditionType]metav1.Condition{
+						gatewayapi_v1alpha2.GatewayConditionReady: {
+							Type:    string(gatewayapi_v1alpha2.GatewayConditionReady),
+							Status:  contour_api_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1alpha2.GatewayReasonListenersNotValid),
+							Message: "Listeners are not valid",
+						},
+					},
+					ListenerStatus: map[string]*gatewayapi_v1alpha2.ListenerStatus{
+						"tcp": {
+							Name: "tcp",
+							Conditions: []metav1.Condition{
+								{
+									Type:    string(gatewayapi_v1alpha2.ListenerConditionDetached),
+									Status:  metav1.ConditionTrue,
+									Reason:  string(gatewayapi_v1alpha2.ListenerReasonPortUnavailable),
+									Message: "Listener.Port 80 is already in use by a listener with protocol \"HTTP\".",
+								},
+								{
+									Type:    string(gatewayapi_v1alpha2.ListenerConditionReady),
+									Status:  metav1.ConditionFalse,
+									Reason:  string(gatewayapi_v1alpha2.ListenerReasonInvalid),
+									Message: "Invalid listener, see other listener conditions for details",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"listener port conflicts with Envoy's default HTTPS listener": {
+			gateways: []*gatewayapi_v1alpha2.Gateway{
+				gateway("gateway-a", listener("http", 80, gatewayapi_v1alpha2.HTTPProtocolType)),
+				gateway("gateway-b", listener("tcp", 8443, gatewayapi_v1alpha2.TCPProtocolType)),
+			},
+			wantRouteConditions: []*status.RouteConditionsUpdate{
+				validRoute("gateway-a"),
+			},
+			wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{
+				validGateway("gateway-a", "http"),
+				{
+					FullName: types.NamespacedName{Namespace: "projectcontour", Name: "gateway-b"},
+					Conditions: map[gatewayapi_v1alpha2.GatewayConditionType]metav1.Condition{
+						gatewayapi_v1alpha2.GatewayConditionReady: {
+							Type:    string(gatewayapi_v1alpha2.GatewayConditionReady),
+							Status:  contour_api_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1alpha2.GatewayReasonListenersNotValid),
+							Message: "Listeners are not valid",
+						},
+					},
+					ListenerStatus: map[string]*gatewayapi_v1alpha2.ListenerStatus{
+						"tcp": {
+							Name: "tcp",
+							Conditions: []metav1.Condition{
+								{
+									Type:    string(gatewayapi_v1alpha2.ListenerConditionDetached),
+									Status:  metav1.ConditionTrue,
+									Reason:  string(gatewayapi_v1alpha2.ListenerReasonPortUnavailable),
+									Message: "Listener.Port 8443 is reserved for Envoy's default HTTP and HTTPS listeners.",
+								},
+								{
+									Type:    string(gatewayapi_v1alpha2.ListenerConditionReady),
+									Status:  metav1.ConditionFalse,
+									Reason:  string(gatewayapi_v1alpha2.ListenerReasonInvalid),
+									Message: "Invalid listener, see other listener conditions for details",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"listener on a reserved port does not claim the port": {
+			gateways: []*gatewayapi_v1alpha2.Gateway{
+				gateway("gateway-a", listener("tcp", 80, gatewayapi_v1alpha2.TCPProtocolType)),
+				gateway("gateway-b", listener("http", 80, gatewayapi_v1alpha2.HTTPProtocolType)),
+			},
+			reservedPorts: []int{80, 443},
+			wantRouteConditions: []*status.RouteConditionsUpdate{
+				validRoute("gateway-b"),
+			},
+			wantGatewayStatusUpdate: []*status.GatewayStatusUpdate{
+				{
+					FullName: types.NamespacedName{Namespace: "projectcontour", Name: "gateway-a"},
+					Conditions: map[gatewayapi_v1alpha2.GatewayConditionType]metav1.Condition{
+						gatewayapi_v1alpha2.GatewayConditionReady: {
+							Type:    string(gatewayapi_v1alpha2.GatewayConditionReady),
+							Status:  contour_api_v1.ConditionFalse,
+							Reason:  string(gatewayapi_v1alpha2.GatewayReasonListenersNotValid),
+							Message: "Listeners are not valid",
+						},
+					},
+					ListenerStatus: map[string]*gatewayapi_v1alpha2.ListenerStatus{
+						"tcp": {
+							Name: "tcp",
+							Conditions: []metav1.Condition{
+								{
+									Type:    string(gatewayapi_v1alpha2.ListenerConditionDetached),
+									Status:  metav1.ConditionTrue,
+									Reason:  string(gatewayapi_v1alpha2.ListenerReasonPortUnavailable),
+									Message: "Listener.Port 80 is reserved for Envoy's default HTTP and HTTPS listeners.",
+								},
+								{
+									Type:    string(gatewayapi_v1alpha2.ListenerConditionReady),
+									Status:  metav1.ConditionFalse,
+									Reason:  string(gatewayapi_v1alpha2.ListenerReasonInvalid),
+									Message: "Invalid listener, see other listener conditions for details",
+								},
+							},
+						},
+					},
+				},
+				validGateway("gateway-b", "http"),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			reservedPorts := tc.reservedPorts
+			if reservedPorts == nil {
+				reservedPorts = []int{8080, 8443}
+			}
+
+			builder := Builder{
+				Source: KubernetesCache{
+					FieldLogger: fixture.NewTestLogger(t),
+					gatewayclass: &gatewayapi_v1alpha2.GatewayClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-gc",
+						},
+						Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+							ControllerName: "projectcontour.io/contour",
+						},
+					},
+				},
+				Processors: []Processor{
+					&GatewayAPIProcessor{
+						FieldLogger:   fixture.NewTestLogger(t),
+						ReservedPorts: reservedPorts,
+					},
+					&ListenerProcessor{},
+				},
+			}
+
+			for _, gw := range tc.gateways {
+				builder.Source.Insert(gw)
+			}
+			builder.Source.Insert(kuardService)
+			builder.Source.Insert(httpRoute)
+
+			dag := builder.Build()
+			gotRouteUpdates := dag.StatusCache.GetRouteUpdates()
+			gotGatewayUpdates := dag.StatusCache.GetGatewayUpdates()
+
+			ops := []cmp.Option{
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "ExistingConditions"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "TransitionTime"),
+				cmpopts.IgnoreFields(status.RouteConditionsUpdate{}, "Resource"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "ExistingConditions"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "Generation"),
+				cmpopts.IgnoreFields(status.GatewayStatusUpdate{}, "TransitionTime"),
+				cmpopts.SortSlices(func(i, j *status.RouteConditionsUpdate) bool {
+					return i.GatewayRef.String() < j.GatewayRef.String()
+				}),
+				cmpopts.SortSlices(func(i, j *status.GatewayStatusUpdate) bool {
+					return i.FullName.String() < j.FullName.String()
+				}),
+			}
+
+			for _, u := range tc.wantRouteConditions {
+				u.GatewayController = builder.Source.gatewayclass.Spec.ControllerName
+			}
+
+			if diff := cmp.Diff(tc.wantRouteConditions, gotRouteUpdates, ops...); diff != "" {
+				t.Fatalf("expected route status: %v, got %v", tc.wantRouteConditions, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantGatewayStatusUpdate, gotGatewayUpdates, ops...); diff != "" {
+				t.Fatalf("expected gateway status: %v, got %v", tc.wantGatewayStatusUpdate, diff)
+			}
+
+			// Both Gateways' HTTP listeners serve the route's virtual host.
+			for _, gw := range tc.gateways {
+				for _, l := range gw.Spec.Listeners {
+					if l.Protocol == gatewayapi_v1alpha2.HTTPProtocolType {
+						assert.NotNil(t, dag.VirtualHosts[int(l.Port)]["test.projectcontour.io"])
+					}
+				}
+			}
+		})
+	}
+}
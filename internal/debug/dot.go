@@ -49,9 +49,9 @@ func (dw *dotWriter) writeDot(w io.Writer) {
 				edges[pair{vhost, route}] = true
 				nodes[route] = true
 
-				clusters := route.Clusters
-				if route.MirrorPolicy != nil && route.MirrorPolicy.Cluster != nil {
-					clusters = append(clusters, route.MirrorPolicy.Cluster)
+				clusters := append([]*dag.Cluster{}, route.Clusters...)
+				for _, mirror := range route.MirrorPolicies {
+					clusters = append(clusters, mirror.Cluster)
 				}
 				for _, cluster := range clusters {
 					edges[pair{route, cluster}] = true
@@ -73,9 +73,9 @@ func (dw *dotWriter) writeDot(w io.Writer) {
 				edges[pair{vhost, route}] = true
 				nodes[route] = true
 
-				clusters := route.Clusters
-				if route.MirrorPolicy != nil && route.MirrorPolicy.Cluster != nil {
-					clusters = append(clusters, route.MirrorPolicy.Cluster)
+				clusters := append([]*dag.Cluster{}, route.Clusters...)
+				for _, mirror := range route.MirrorPolicies {
+					clusters = append(clusters, mirror.Cluster)
 				}
 				for _, cluster := range clusters {
 					edges[pair{route, cluster}] = true
@@ -36,6 +36,11 @@ const SDSTLSCertificateFile = "xds-tls-certificate.json"
 // CA certificates for Envoy to use for the XDS gRPC connection.
 const SDSValidationContextFile = "xds-validation-context.json"
 
+// LocalClusterName is the name of the cluster holding the Envoy fleet,
+// which Envoy uses as its local cluster when zone aware routing is
+// configured. Its endpoints are discovered from Contour over EDS.
+const LocalClusterName = "envoy-local"
+
 // BootstrapConfig holds configuration values for a Bootstrap configuration.
 type BootstrapConfig struct {
 	// AdminAccessLogPath is the path to write the access log for the administration server.
@@ -90,6 +95,11 @@ type BootstrapConfig struct {
 	// DNSLookupFamily specifies DNS Resolution Policy to use for Envoy -> Contour cluster name lookup.
 	// Either v4, v6 or auto.
 	DNSLookupFamily string
+
+	// LocalZone is the topology zone that Envoy is running in. If set,
+	// Envoy is configured with its node locality and local cluster so
+	// that zone aware routing can be used.
+	LocalZone string
 }
 
 // GetXdsAddress returns the address configured or defaults to "127.0.0.1"
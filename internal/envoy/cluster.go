@@ -42,6 +42,14 @@ func Clustername(cluster *dag.Cluster) string {
 		}
 		buf += hc.Path
 	}
+	if od := cluster.OutlierDetectionPolicy; od != nil {
+		buf += fmt.Sprintf("%s/%s/%s/%s/%s", optionalUint32(od.ConsecutiveServerErrors), optionalUint32(od.ConsecutiveGatewayErrors),
+			od.Interval, od.BaseEjectionTime, optionalUint32(od.MaxEjectionPercent))
+	}
+	if cb := cluster.CircuitBreakers; cb != nil {
+		buf += fmt.Sprintf("%s/%s/%s/%s", optionalUint32(cb.MaxConnections), optionalUint32(cb.MaxPendingRequests),
+			optionalUint32(cb.MaxRequests), optionalUint32(cb.MaxRetries))
+	}
 	if uv := cluster.UpstreamValidation; uv != nil {
 		buf += uv.CACertificate.Object.ObjectMeta.Name
 		buf += uv.SubjectName
@@ -55,6 +63,20 @@ func Clustername(cluster *dag.Cluster) string {
 	return Hashname(60, ns, name, strconv.Itoa(int(service.Weighted.ServicePort.Port)), fmt.Sprintf("%x", hash[:5]))
 }
 
+// DNSNameClusterName returns the name of the CDS cluster for this DNS name cluster.
+func DNSNameClusterName(cluster *dag.DNSNameCluster) string {
+	parts := []string{"dnsname", cluster.Scheme, cluster.Address, strconv.Itoa(cluster.Port)}
+	if uv := cluster.UpstreamValidation; uv != nil {
+		buf := uv.CACertificate.Object.ObjectMeta.Namespace + "/" + uv.CACertificate.Object.ObjectMeta.Name + uv.SubjectName
+
+		// This isn't a crypto hash, we just want a unique name.
+		hash := sha1.Sum([]byte(buf)) // nolint:gosec
+		parts = append(parts, fmt.Sprintf("%x", hash[:5]))
+	}
+
+	return strings.Join(parts, "/")
+}
+
 // AltStatName generates an alternative stat name for the service
 // using format ns_name_port
 func AltStatName(service *dag.Service) string {
@@ -110,6 +132,15 @@ func min(a, b int) int {
 	return a
 }
 
+// optionalUint32 formats an optional value, so that
+// an unset value is distinct from an explicit zero.
+func optionalUint32(v *uint32) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatUint(uint64(*v), 10)
+}
+
 // AnyPositive indicates if any of the values provided are greater than zero.
 func AnyPositive(first uint32, rest ...uint32) bool {
 	if first > 0 {
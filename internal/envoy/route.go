@@ -69,7 +69,8 @@ func SingleSimpleCluster(route *dag.Route) bool {
 		// no request headers policy
 	} else if len(cluster.RequestHeadersPolicy.Set) != 0 ||
 		len(cluster.RequestHeadersPolicy.Add) != 0 ||
-		len(cluster.RequestHeadersPolicy.Remove) != 0 {
+		len(cluster.RequestHeadersPolicy.Remove) != 0 ||
+		cluster.RequestHeadersPolicy.HostRewrite != "" {
 		return false
 	}
 	if cluster.ResponseHeadersPolicy == nil {
@@ -14,16 +14,89 @@
 package v3
 
 import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	envoy_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_file_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
+	envoy_grpc_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
+	envoy_open_telemetry_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/open_telemetry/v3"
+	envoy_header_to_metadata_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_to_metadata/v3"
 	envoy_req_without_query_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/formatter/req_without_query/v3"
+	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/any"
 	_struct "github.com/golang/protobuf/ptypes/struct"
 	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/envoy"
 	"github.com/projectcontour/contour/internal/protobuf"
+	"github.com/projectcontour/contour/internal/timeout"
+	otlp_common_v1 "go.opentelemetry.io/proto/otlp/common/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
+const (
+	// tcpGRPCAccessLogName is the name of the TCP gRPC access log service sink.
+	tcpGRPCAccessLogName = "envoy.access_loggers.tcp_grpc"
+
+	// openTelemetryAccessLogName is the name of the OpenTelemetry access log sink.
+	openTelemetryAccessLogName = "envoy.access_loggers.open_telemetry"
+
+	// defaultAccessLogFormat is Envoy's default access log format, used
+	// as the body of OpenTelemetry log records when no format string is set.
+	defaultAccessLogFormat = `[%START_TIME%] "%REQ(:METHOD)% %REQ(X-ENVOY-ORIGINAL-PATH?:PATH)% %PROTOCOL%" ` +
+		`%RESPONSE_CODE% %RESPONSE_FLAGS% %BYTES_RECEIVED% %BYTES_SENT% %DURATION% ` +
+		`%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)% "%REQ(X-FORWARDED-FOR)%" "%REQ(USER-AGENT)%" ` +
+		`"%REQ(X-REQUEST-ID)%" "%REQ(:AUTHORITY)%" "%UPSTREAM_HOST%"`
+
+	// accessLogRuntimeKeyPrefix prefixes the runtime keys that may
+	// override the rules of access log filters. Each rule has a key
+	// of its own, so that overriding one rule leaves the others be.
+	accessLogRuntimeKeyPrefix = "contour.access_log"
+
+	// accessLogPolicyMetadataNamespace is the dynamic metadata namespace
+	// in which requests are marked with the access log policy that
+	// applies to them.
+	accessLogPolicyMetadataNamespace = "contour.access_log"
+
+	// accessLogPolicyMetadataKey is the dynamic metadata key that holds
+	// the name of the access log policy that applies to a request.
+	accessLogPolicyMetadataKey = "policy"
+)
+
+// AccessLogFilterConfig stores the rules that select the HTTP
+// requests that are access logged. A request is logged only if
+// it matches all of the rules that are set.
+type AccessLogFilterConfig struct {
+	StatusCodes     []StatusCodeRange
+	MinDuration     time.Duration
+	ResponseFlags   []string
+	RequestHeaders  []string
+	SamplingPercent *uint32
+}
+
+// StatusCodeRange is an inclusive range of HTTP status codes.
+type StatusCodeRange struct {
+	Min uint32
+	Max uint32
+}
+
+// AccessLogServiceConfig stores configuration for streaming
+// access logs to an access log service over gRPC.
+type AccessLogServiceConfig struct {
+	ExtensionService    types.NamespacedName
+	LogName             string
+	BufferFlushInterval timeout.Setting
+	BufferSizeBytes     uint32
+}
+
 // FileAccessLogEnvoy returns a new file based access log filter
 func FileAccessLogEnvoy(path string, format string, extensions []string) []*envoy_accesslog_v3.AccessLog {
 	// Nil by default to defer to Envoy's default log format.
@@ -64,7 +137,7 @@ func FileAccessLogJSON(path string, fields contour_api_v1alpha1.AccessLogFields,
 	}
 
 	for k, v := range fields.AsFieldMap() {
-		jsonformat.Fields[k] = sv(v)
+		jsonformat.Fields[k] = jsonValue(v)
 	}
 
 	return []*envoy_accesslog_v3.AccessLog{{
@@ -85,6 +158,351 @@ func FileAccessLogJSON(path string, fields contour_api_v1alpha1.AccessLogFields,
 	}}
 }
 
+// HTTPGRPCAccessLog returns a new access log that streams HTTP
+// access log entries to the configured access log service.
+func HTTPGRPCAccessLog(config *AccessLogServiceConfig) []*envoy_accesslog_v3.AccessLog {
+	return []*envoy_accesslog_v3.AccessLog{{
+		Name: wellknown.HTTPGRPCAccessLog,
+		ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_grpc_v3.HttpGrpcAccessLogConfig{
+				CommonConfig: commonGRPCAccessLogConfig(config),
+			}),
+		},
+	}}
+}
+
+// TCPGRPCAccessLog returns a new access log that streams TCP
+// access log entries to the configured access log service.
+func TCPGRPCAccessLog(config *AccessLogServiceConfig) []*envoy_accesslog_v3.AccessLog {
+	return []*envoy_accesslog_v3.AccessLog{{
+		Name: tcpGRPCAccessLogName,
+		ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_grpc_v3.TcpGrpcAccessLogConfig{
+				CommonConfig: commonGRPCAccessLogConfig(config),
+			}),
+		},
+	}}
+}
+
+// OpenTelemetryAccessLogEnvoy returns a new access log that streams
+// OpenTelemetry log records to the configured access log service. The
+// body of each record is formatted with format, or with Envoy's default
+// format if format is empty.
+func OpenTelemetryAccessLogEnvoy(config *AccessLogServiceConfig, format string) []*envoy_accesslog_v3.AccessLog {
+	if format == "" {
+		format = defaultAccessLogFormat
+	}
+
+	return openTelemetryAccessLog(&envoy_open_telemetry_v3.OpenTelemetryAccessLogConfig{
+		CommonConfig: commonGRPCAccessLogConfig(config),
+		Body: &otlp_common_v1.AnyValue{
+			Value: &otlp_common_v1.AnyValue_StringValue{
+				StringValue: format,
+			},
+		},
+	})
+}
+
+// OpenTelemetryAccessLogJSON returns a new access log that streams
+// OpenTelemetry log records to the configured access log service, with
+// an attribute for each of the JSON fields.
+func OpenTelemetryAccessLogJSON(config *AccessLogServiceConfig, fields contour_api_v1alpha1.AccessLogFields) []*envoy_accesslog_v3.AccessLog {
+	fieldMap := fields.AsFieldMap()
+
+	keys := make([]string, 0, len(fieldMap))
+	for k := range fieldMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attributes := &otlp_common_v1.KeyValueList{}
+	for _, k := range keys {
+		attributes.Values = append(attributes.Values, &otlp_common_v1.KeyValue{
+			Key:   k,
+			Value: anyValue(fieldMap[k]),
+		})
+	}
+
+	return openTelemetryAccessLog(&envoy_open_telemetry_v3.OpenTelemetryAccessLogConfig{
+		CommonConfig: commonGRPCAccessLogConfig(config),
+		Attributes:   attributes,
+	})
+}
+
+func openTelemetryAccessLog(config *envoy_open_telemetry_v3.OpenTelemetryAccessLogConfig) []*envoy_accesslog_v3.AccessLog {
+	return []*envoy_accesslog_v3.AccessLog{{
+		Name: openTelemetryAccessLogName,
+		ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(config),
+		},
+	}}
+}
+
+func commonGRPCAccessLogConfig(config *AccessLogServiceConfig) *envoy_grpc_v3.CommonGrpcAccessLogConfig {
+	return &envoy_grpc_v3.CommonGrpcAccessLogConfig{
+		LogName: config.LogName,
+		GrpcService: &envoy_config_core_v3.GrpcService{
+			TargetSpecifier: &envoy_config_core_v3.GrpcService_EnvoyGrpc_{
+				EnvoyGrpc: &envoy_config_core_v3.GrpcService_EnvoyGrpc{
+					ClusterName: dag.ExtensionClusterName(config.ExtensionService),
+				},
+			},
+		},
+		TransportApiVersion: envoy_config_core_v3.ApiVersion_V3,
+		BufferFlushInterval: envoy.Timeout(config.BufferFlushInterval),
+		BufferSizeBytes:     protobuf.UInt32OrNil(config.BufferSizeBytes),
+	}
+}
+
+// AccessLogPolicyMark returns the per-vhost or per-route configuration
+// of the header_to_metadata filter that marks requests with the name of
+// the given access log policy, so that the access log filters returned by
+// MarkedAccessLogFilter and UnmarkedAccessLogFilter can select them.
+func AccessLogPolicyMark(policy *dag.AccessLogPolicy) *any.Any {
+	// The :path header is always present, so every request is marked.
+	mark := &envoy_header_to_metadata_v3.Config_KeyValuePair{
+		MetadataNamespace: accessLogPolicyMetadataNamespace,
+		Key:               accessLogPolicyMetadataKey,
+		Value:             policy.Name,
+	}
+
+	return protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{
+		RequestRules: []*envoy_header_to_metadata_v3.Config_Rule{{
+			Header:          ":path",
+			OnHeaderPresent: mark,
+			OnHeaderMissing: mark,
+		}},
+	})
+}
+
+// MarkedAccessLogFilter returns an access log filter that selects
+// the requests marked with the name of the given access log policy.
+func MarkedAccessLogFilter(name string) *envoy_accesslog_v3.AccessLogFilter {
+	return metadataFilter(&envoy_matcher_v3.ValueMatcher{
+		MatchPattern: &envoy_matcher_v3.ValueMatcher_StringMatch{
+			StringMatch: &envoy_matcher_v3.StringMatcher{
+				MatchPattern: &envoy_matcher_v3.StringMatcher_Exact{
+					Exact: name,
+				},
+			},
+		},
+	}, false)
+}
+
+// UnmarkedAccessLogFilter returns an access log filter that selects
+// the requests not marked with the name of any access log policy.
+func UnmarkedAccessLogFilter() *envoy_accesslog_v3.AccessLogFilter {
+	// A marked request never has a null value, so only the requests
+	// without a mark are selected.
+	return metadataFilter(&envoy_matcher_v3.ValueMatcher{
+		MatchPattern: &envoy_matcher_v3.ValueMatcher_NullMatch_{
+			NullMatch: &envoy_matcher_v3.ValueMatcher_NullMatch{},
+		},
+	}, true)
+}
+
+// AccessLogFilter returns an access log filter that selects requests
+// matching the status code and sampling rules of the given policy. A
+// nil filter logs every request.
+func AccessLogFilter(policy *dag.AccessLogPolicy) *envoy_accesslog_v3.AccessLogFilter {
+	var filters []*envoy_accesslog_v3.AccessLogFilter
+
+	if policy != nil && policy.MinStatusCode > 0 {
+		filters = append(filters, statusCodeFilter(envoy_accesslog_v3.ComparisonFilter_GE, policy.MinStatusCode,
+			accessLogRuntimeKey("policy", policy.Name, "min_status_code")))
+	}
+
+	if policy != nil && policy.SamplingPercent != nil {
+		filters = append(filters, samplingFilter(*policy.SamplingPercent,
+			accessLogRuntimeKey("policy", policy.Name, "sampling")))
+	}
+
+	return andFilter(filters)
+}
+
+// GlobalAccessLogFilter returns an access log filter that selects
+// the requests matching all of the rules of the given configuration.
+// A nil filter logs every request.
+func GlobalAccessLogFilter(config *AccessLogFilterConfig) *envoy_accesslog_v3.AccessLogFilter {
+	if config == nil {
+		return nil
+	}
+
+	var filters []*envoy_accesslog_v3.AccessLogFilter
+
+	var statusCodes []*envoy_accesslog_v3.AccessLogFilter
+	for i, r := range config.StatusCodes {
+		statusCodes = append(statusCodes, andFilter([]*envoy_accesslog_v3.AccessLogFilter{
+			statusCodeFilter(envoy_accesslog_v3.ComparisonFilter_GE, r.Min,
+				accessLogRuntimeKey("status_codes", strconv.Itoa(i), "min")),
+			statusCodeFilter(envoy_accesslog_v3.ComparisonFilter_LE, r.Max,
+				accessLogRuntimeKey("status_codes", strconv.Itoa(i), "max")),
+		}))
+	}
+	if f := orFilter(statusCodes); f != nil {
+		filters = append(filters, f)
+	}
+
+	if config.MinDuration > 0 {
+		filters = append(filters, &envoy_accesslog_v3.AccessLogFilter{
+			FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_DurationFilter{
+				DurationFilter: &envoy_accesslog_v3.DurationFilter{
+					Comparison: &envoy_accesslog_v3.ComparisonFilter{
+						Op: envoy_accesslog_v3.ComparisonFilter_GE,
+						Value: &envoy_config_core_v3.RuntimeUInt32{
+							DefaultValue: uint32(config.MinDuration.Milliseconds()),
+							RuntimeKey:   accessLogRuntimeKey("min_duration"),
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if len(config.ResponseFlags) > 0 {
+		filters = append(filters, &envoy_accesslog_v3.AccessLogFilter{
+			FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_ResponseFlagFilter{
+				ResponseFlagFilter: &envoy_accesslog_v3.ResponseFlagFilter{
+					Flags: config.ResponseFlags,
+				},
+			},
+		})
+	}
+
+	for _, h := range config.RequestHeaders {
+		filters = append(filters, headerFilter(&envoy_route_v3.HeaderMatcher{
+			Name:                 h,
+			HeaderMatchSpecifier: &envoy_route_v3.HeaderMatcher_PresentMatch{PresentMatch: true},
+		}))
+	}
+
+	if config.SamplingPercent != nil {
+		filters = append(filters, samplingFilter(*config.SamplingPercent, accessLogRuntimeKey("sampling")))
+	}
+
+	return andFilter(filters)
+}
+
+// AndAccessLogFilter returns an access log filter that selects
+// requests matching all of the given filters. Nil filters are
+// ignored.
+func AndAccessLogFilter(filters ...*envoy_accesslog_v3.AccessLogFilter) *envoy_accesslog_v3.AccessLogFilter {
+	var nonNil []*envoy_accesslog_v3.AccessLogFilter
+	for _, f := range filters {
+		if f != nil {
+			nonNil = append(nonNil, f)
+		}
+	}
+	return andFilter(nonNil)
+}
+
+// FilterAccessLogs sets the filter of each of the given access logs.
+func FilterAccessLogs(accessLogs []*envoy_accesslog_v3.AccessLog, filter *envoy_accesslog_v3.AccessLogFilter) []*envoy_accesslog_v3.AccessLog {
+	for _, a := range accessLogs {
+		a.Filter = filter
+	}
+	return accessLogs
+}
+
+func headerFilter(h *envoy_route_v3.HeaderMatcher) *envoy_accesslog_v3.AccessLogFilter {
+	return &envoy_accesslog_v3.AccessLogFilter{
+		FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_HeaderFilter{
+			HeaderFilter: &envoy_accesslog_v3.HeaderFilter{
+				Header: h,
+			},
+		},
+	}
+}
+
+// accessLogRuntimeKey returns the runtime key of the access log
+// filter rule named by the given path.
+func accessLogRuntimeKey(path ...string) string {
+	return accessLogRuntimeKeyPrefix + "." + strings.Join(path, ".")
+}
+
+func metadataFilter(value *envoy_matcher_v3.ValueMatcher, matchIfKeyNotFound bool) *envoy_accesslog_v3.AccessLogFilter {
+	return &envoy_accesslog_v3.AccessLogFilter{
+		FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_MetadataFilter{
+			MetadataFilter: &envoy_accesslog_v3.MetadataFilter{
+				Matcher: &envoy_matcher_v3.MetadataMatcher{
+					Filter: accessLogPolicyMetadataNamespace,
+					Path: []*envoy_matcher_v3.MetadataMatcher_PathSegment{{
+						Segment: &envoy_matcher_v3.MetadataMatcher_PathSegment_Key{
+							Key: accessLogPolicyMetadataKey,
+						},
+					}},
+					Value: value,
+				},
+				MatchIfKeyNotFound: protobuf.Bool(matchIfKeyNotFound),
+			},
+		},
+	}
+}
+
+func statusCodeFilter(op envoy_accesslog_v3.ComparisonFilter_Op, code uint32, runtimeKey string) *envoy_accesslog_v3.AccessLogFilter {
+	return &envoy_accesslog_v3.AccessLogFilter{
+		FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_StatusCodeFilter{
+			StatusCodeFilter: &envoy_accesslog_v3.StatusCodeFilter{
+				Comparison: &envoy_accesslog_v3.ComparisonFilter{
+					Op: op,
+					Value: &envoy_config_core_v3.RuntimeUInt32{
+						DefaultValue: code,
+						RuntimeKey:   runtimeKey,
+					},
+				},
+			},
+		},
+	}
+}
+
+func samplingFilter(percent uint32, runtimeKey string) *envoy_accesslog_v3.AccessLogFilter {
+	return &envoy_accesslog_v3.AccessLogFilter{
+		FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_RuntimeFilter{
+			RuntimeFilter: &envoy_accesslog_v3.RuntimeFilter{
+				RuntimeKey: runtimeKey,
+				PercentSampled: &envoy_type_v3.FractionalPercent{
+					Numerator:   percent,
+					Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+				},
+			},
+		},
+	}
+}
+
+func andFilter(filters []*envoy_accesslog_v3.AccessLogFilter) *envoy_accesslog_v3.AccessLogFilter {
+	switch len(filters) {
+	case 0:
+		return nil
+	case 1:
+		return filters[0]
+	default:
+		return &envoy_accesslog_v3.AccessLogFilter{
+			FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_AndFilter{
+				AndFilter: &envoy_accesslog_v3.AndFilter{
+					Filters: filters,
+				},
+			},
+		}
+	}
+}
+
+func orFilter(filters []*envoy_accesslog_v3.AccessLogFilter) *envoy_accesslog_v3.AccessLogFilter {
+	switch len(filters) {
+	case 0:
+		return nil
+	case 1:
+		return filters[0]
+	default:
+		return &envoy_accesslog_v3.AccessLogFilter{
+			FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_OrFilter{
+				OrFilter: &envoy_accesslog_v3.OrFilter{
+					Filters: filters,
+				},
+			},
+		}
+	}
+}
+
 func sv(s string) *_struct.Value {
 	return &_struct.Value{
 		Kind: &_struct.Value_StringValue{
@@ -93,6 +511,68 @@ func sv(s string) *_struct.Value {
 	}
 }
 
+// jsonNumber matches a JSON number literal.
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// jsonValue returns the JSON value for an access log field. Literal
+// booleans and numbers keep their JSON type, everything else is a string.
+// A field that is a single command operator is typed by Envoy itself.
+func jsonValue(s string) *_struct.Value {
+	switch {
+	case s == "true" || s == "false":
+		return &_struct.Value{
+			Kind: &_struct.Value_BoolValue{
+				BoolValue: s == "true",
+			},
+		}
+	case jsonNumber.MatchString(s):
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return &_struct.Value{
+				Kind: &_struct.Value_NumberValue{
+					NumberValue: n,
+				},
+			}
+		}
+	}
+
+	return sv(s)
+}
+
+// anyValue returns the OpenTelemetry attribute value for an access log
+// field, keeping the type of literal booleans and numbers the same way
+// as jsonValue.
+func anyValue(s string) *otlp_common_v1.AnyValue {
+	switch {
+	case s == "true" || s == "false":
+		return &otlp_common_v1.AnyValue{
+			Value: &otlp_common_v1.AnyValue_BoolValue{
+				BoolValue: s == "true",
+			},
+		}
+	case jsonNumber.MatchString(s):
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return &otlp_common_v1.AnyValue{
+				Value: &otlp_common_v1.AnyValue_IntValue{
+					IntValue: n,
+				},
+			}
+		}
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return &otlp_common_v1.AnyValue{
+				Value: &otlp_common_v1.AnyValue_DoubleValue{
+					DoubleValue: n,
+				},
+			}
+		}
+	}
+
+	return &otlp_common_v1.AnyValue{
+		Value: &otlp_common_v1.AnyValue_StringValue{
+			StringValue: s,
+		},
+	}
+}
+
 // extensionConfig returns a list of extension configs required by the access log format.
 //
 // Note: When adding support for new formatter, update the list of extensions here and
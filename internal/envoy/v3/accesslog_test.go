@@ -14,16 +14,29 @@
 package v3
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	envoy_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_file_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
+	envoy_grpc_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
+	envoy_open_telemetry_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/open_telemetry/v3"
+	envoy_header_to_metadata_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_to_metadata/v3"
 	envoy_req_without_query_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/formatter/req_without_query/v3"
+	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	_struct "github.com/golang/protobuf/ptypes/struct"
 	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/k8s"
 	"github.com/projectcontour/contour/internal/protobuf"
+	"github.com/projectcontour/contour/internal/timeout"
+	"github.com/stretchr/testify/assert"
+	otlp_common_v1 "go.opentelemetry.io/proto/otlp/common/v1"
 )
 
 func TestFileAccessLog(t *testing.T) {
@@ -168,6 +181,40 @@ func TestJSONFileAccessLog(t *testing.T) {
 			},
 			},
 		},
+		"typed literal fields": {
+			path: "/dev/stdout",
+			headers: contour_api_v1alpha1.AccessLogFields([]string{
+				"authz=%DYNAMIC_METADATA(envoy.filters.http.ext_authz:user)%",
+				"sampled=true",
+				"version=2",
+				"ratio=0.5",
+				"code=007",
+			}),
+			want: []*envoy_accesslog_v3.AccessLog{{
+				Name: wellknown.FileAccessLog,
+				ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+					TypedConfig: protobuf.MustMarshalAny(&envoy_file_v3.FileAccessLog{
+						Path: "/dev/stdout",
+						AccessLogFormat: &envoy_file_v3.FileAccessLog_LogFormat{
+							LogFormat: &envoy_config_core_v3.SubstitutionFormatString{
+								Format: &envoy_config_core_v3.SubstitutionFormatString_JsonFormat{
+									JsonFormat: &_struct.Struct{
+										Fields: map[string]*_struct.Value{
+											"authz":   sv("%DYNAMIC_METADATA(envoy.filters.http.ext_authz:user)%"),
+											"sampled": {Kind: &_struct.Value_BoolValue{BoolValue: true}},
+											"version": {Kind: &_struct.Value_NumberValue{NumberValue: 2}},
+											"ratio":   {Kind: &_struct.Value_NumberValue{NumberValue: 0.5}},
+											"code":    sv("007"),
+										},
+									},
+								},
+							},
+						},
+					}),
+				},
+			},
+			},
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -176,3 +223,386 @@ func TestJSONFileAccessLog(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessLogService(t *testing.T) {
+	config := &AccessLogServiceConfig{
+		ExtensionService:    k8s.NamespacedNameFrom("projectcontour/als"),
+		LogName:             "contour",
+		BufferFlushInterval: timeout.DurationSetting(500 * time.Millisecond),
+		BufferSizeBytes:     1024,
+	}
+
+	commonConfig := &envoy_grpc_v3.CommonGrpcAccessLogConfig{
+		LogName: "contour",
+		GrpcService: &envoy_config_core_v3.GrpcService{
+			TargetSpecifier: &envoy_config_core_v3.GrpcService_EnvoyGrpc_{
+				EnvoyGrpc: &envoy_config_core_v3.GrpcService_EnvoyGrpc{
+					ClusterName: "extension/projectcontour/als",
+				},
+			},
+		},
+		TransportApiVersion: envoy_config_core_v3.ApiVersion_V3,
+		BufferFlushInterval: protobuf.Duration(500 * time.Millisecond),
+		BufferSizeBytes:     protobuf.UInt32(1024),
+	}
+
+	tests := map[string]struct {
+		got  []*envoy_accesslog_v3.AccessLog
+		want []*envoy_accesslog_v3.AccessLog
+	}{
+		"http grpc": {
+			got: HTTPGRPCAccessLog(config),
+			want: []*envoy_accesslog_v3.AccessLog{{
+				Name: wellknown.HTTPGRPCAccessLog,
+				ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+					TypedConfig: protobuf.MustMarshalAny(&envoy_grpc_v3.HttpGrpcAccessLogConfig{
+						CommonConfig: commonConfig,
+					}),
+				},
+			}},
+		},
+		"tcp grpc": {
+			got: TCPGRPCAccessLog(config),
+			want: []*envoy_accesslog_v3.AccessLog{{
+				Name: "envoy.access_loggers.tcp_grpc",
+				ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+					TypedConfig: protobuf.MustMarshalAny(&envoy_grpc_v3.TcpGrpcAccessLogConfig{
+						CommonConfig: commonConfig,
+					}),
+				},
+			}},
+		},
+		"opentelemetry with format string": {
+			got: OpenTelemetryAccessLogEnvoy(config, "%REQ(:METHOD)% %RESPONSE_CODE%"),
+			want: []*envoy_accesslog_v3.AccessLog{{
+				Name: "envoy.access_loggers.open_telemetry",
+				ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+					TypedConfig: protobuf.MustMarshalAny(&envoy_open_telemetry_v3.OpenTelemetryAccessLogConfig{
+						CommonConfig: commonConfig,
+						Body: &otlp_common_v1.AnyValue{
+							Value: &otlp_common_v1.AnyValue_StringValue{StringValue: "%REQ(:METHOD)% %RESPONSE_CODE%"},
+						},
+					}),
+				},
+			}},
+		},
+		"opentelemetry with default format": {
+			got: OpenTelemetryAccessLogEnvoy(config, ""),
+			want: []*envoy_accesslog_v3.AccessLog{{
+				Name: "envoy.access_loggers.open_telemetry",
+				ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+					TypedConfig: protobuf.MustMarshalAny(&envoy_open_telemetry_v3.OpenTelemetryAccessLogConfig{
+						CommonConfig: commonConfig,
+						Body: &otlp_common_v1.AnyValue{
+							Value: &otlp_common_v1.AnyValue_StringValue{StringValue: defaultAccessLogFormat},
+						},
+					}),
+				},
+			}},
+		},
+		"opentelemetry with json fields": {
+			got: OpenTelemetryAccessLogJSON(config, contour_api_v1alpha1.AccessLogFields{"method", "status=%RESPONSE_CODE%"}),
+			want: []*envoy_accesslog_v3.AccessLog{{
+				Name: "envoy.access_loggers.open_telemetry",
+				ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+					TypedConfig: protobuf.MustMarshalAny(&envoy_open_telemetry_v3.OpenTelemetryAccessLogConfig{
+						CommonConfig: commonConfig,
+						Attributes: &otlp_common_v1.KeyValueList{
+							Values: []*otlp_common_v1.KeyValue{{
+								Key:   "method",
+								Value: &otlp_common_v1.AnyValue{Value: &otlp_common_v1.AnyValue_StringValue{StringValue: "%REQ(:METHOD)%"}},
+							}, {
+								Key:   "status",
+								Value: &otlp_common_v1.AnyValue{Value: &otlp_common_v1.AnyValue_StringValue{StringValue: "%RESPONSE_CODE%"}},
+							}},
+						},
+					}),
+				},
+			}},
+		},
+		"opentelemetry with typed json fields": {
+			got: OpenTelemetryAccessLogJSON(config, contour_api_v1alpha1.AccessLogFields{"sampled=false", "version=2", "ratio=0.5"}),
+			want: []*envoy_accesslog_v3.AccessLog{{
+				Name: "envoy.access_loggers.open_telemetry",
+				ConfigType: &envoy_accesslog_v3.AccessLog_TypedConfig{
+					TypedConfig: protobuf.MustMarshalAny(&envoy_open_telemetry_v3.OpenTelemetryAccessLogConfig{
+						CommonConfig: commonConfig,
+						Attributes: &otlp_common_v1.KeyValueList{
+							Values: []*otlp_common_v1.KeyValue{{
+								Key:   "ratio",
+								Value: &otlp_common_v1.AnyValue{Value: &otlp_common_v1.AnyValue_DoubleValue{DoubleValue: 0.5}},
+							}, {
+								Key:   "sampled",
+								Value: &otlp_common_v1.AnyValue{Value: &otlp_common_v1.AnyValue_BoolValue{BoolValue: false}},
+							}, {
+								Key:   "version",
+								Value: &otlp_common_v1.AnyValue{Value: &otlp_common_v1.AnyValue_IntValue{IntValue: 2}},
+							}},
+						},
+					}),
+				},
+			}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			protobuf.ExpectEqual(t, tc.want, tc.got)
+		})
+	}
+}
+
+func TestAccessLogPolicyMark(t *testing.T) {
+	mark := &envoy_header_to_metadata_v3.Config_KeyValuePair{
+		MetadataNamespace: "contour.access_log",
+		Key:               "policy",
+		Value:             "default/example/routes/0",
+	}
+
+	want := protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{
+		RequestRules: []*envoy_header_to_metadata_v3.Config_Rule{{
+			Header:          ":path",
+			OnHeaderPresent: mark,
+			OnHeaderMissing: mark,
+		}},
+	})
+
+	protobuf.ExpectEqual(t, want, AccessLogPolicyMark(&dag.AccessLogPolicy{Name: "default/example/routes/0"}))
+}
+
+func TestMarkedAccessLogFilter(t *testing.T) {
+	tests := map[string]struct {
+		got  *envoy_accesslog_v3.AccessLogFilter
+		want *envoy_accesslog_v3.AccessLogFilter
+	}{
+		"marked": {
+			got: MarkedAccessLogFilter("default/example"),
+			want: &envoy_accesslog_v3.AccessLogFilter{
+				FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_MetadataFilter{
+					MetadataFilter: &envoy_accesslog_v3.MetadataFilter{
+						Matcher: &envoy_matcher_v3.MetadataMatcher{
+							Filter: "contour.access_log",
+							Path: []*envoy_matcher_v3.MetadataMatcher_PathSegment{{
+								Segment: &envoy_matcher_v3.MetadataMatcher_PathSegment_Key{Key: "policy"},
+							}},
+							Value: &envoy_matcher_v3.ValueMatcher{
+								MatchPattern: &envoy_matcher_v3.ValueMatcher_StringMatch{
+									StringMatch: &envoy_matcher_v3.StringMatcher{
+										MatchPattern: &envoy_matcher_v3.StringMatcher_Exact{Exact: "default/example"},
+									},
+								},
+							},
+						},
+						MatchIfKeyNotFound: protobuf.Bool(false),
+					},
+				},
+			},
+		},
+		"unmarked": {
+			got: UnmarkedAccessLogFilter(),
+			want: &envoy_accesslog_v3.AccessLogFilter{
+				FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_MetadataFilter{
+					MetadataFilter: &envoy_accesslog_v3.MetadataFilter{
+						Matcher: &envoy_matcher_v3.MetadataMatcher{
+							Filter: "contour.access_log",
+							Path: []*envoy_matcher_v3.MetadataMatcher_PathSegment{{
+								Segment: &envoy_matcher_v3.MetadataMatcher_PathSegment_Key{Key: "policy"},
+							}},
+							Value: &envoy_matcher_v3.ValueMatcher{
+								MatchPattern: &envoy_matcher_v3.ValueMatcher_NullMatch_{
+									NullMatch: &envoy_matcher_v3.ValueMatcher_NullMatch{},
+								},
+							},
+						},
+						MatchIfKeyNotFound: protobuf.Bool(true),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			protobuf.ExpectEqual(t, tc.want, tc.got)
+		})
+	}
+}
+
+func TestAccessLogFilter(t *testing.T) {
+	percent := uint32(10)
+
+	tests := map[string]struct {
+		policy *dag.AccessLogPolicy
+		want   *envoy_accesslog_v3.AccessLogFilter
+	}{
+		"no filter": {
+			policy: &dag.AccessLogPolicy{Format: "json"},
+			want:   nil,
+		},
+		"no policy": {
+			policy: nil,
+			want:   nil,
+		},
+		"status code and sampling": {
+			policy: &dag.AccessLogPolicy{Name: "default/example", MinStatusCode: 500, SamplingPercent: &percent},
+			want: &envoy_accesslog_v3.AccessLogFilter{
+				FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_AndFilter{
+					AndFilter: &envoy_accesslog_v3.AndFilter{
+						Filters: []*envoy_accesslog_v3.AccessLogFilter{{
+							FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_StatusCodeFilter{
+								StatusCodeFilter: &envoy_accesslog_v3.StatusCodeFilter{
+									Comparison: &envoy_accesslog_v3.ComparisonFilter{
+										Op: envoy_accesslog_v3.ComparisonFilter_GE,
+										Value: &envoy_config_core_v3.RuntimeUInt32{
+											DefaultValue: 500,
+											RuntimeKey:   "contour.access_log.policy.default/example.min_status_code",
+										},
+									},
+								},
+							},
+						}, {
+							FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_RuntimeFilter{
+								RuntimeFilter: &envoy_accesslog_v3.RuntimeFilter{
+									RuntimeKey: "contour.access_log.policy.default/example.sampling",
+									PercentSampled: &envoy_type_v3.FractionalPercent{
+										Numerator:   10,
+										Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+									},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			protobuf.ExpectEqual(t, tc.want, AccessLogFilter(tc.policy))
+		})
+	}
+}
+
+func TestGlobalAccessLogFilter(t *testing.T) {
+	percent := uint32(50)
+	statusCode := func(op envoy_accesslog_v3.ComparisonFilter_Op, code uint32, key string) *envoy_accesslog_v3.AccessLogFilter {
+		return &envoy_accesslog_v3.AccessLogFilter{
+			FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_StatusCodeFilter{
+				StatusCodeFilter: &envoy_accesslog_v3.StatusCodeFilter{
+					Comparison: &envoy_accesslog_v3.ComparisonFilter{
+						Op: op,
+						Value: &envoy_config_core_v3.RuntimeUInt32{
+							DefaultValue: code,
+							RuntimeKey:   key,
+						},
+					},
+				},
+			},
+		}
+	}
+	statusCodeRange := func(i int, min, max uint32) *envoy_accesslog_v3.AccessLogFilter {
+		key := fmt.Sprintf("contour.access_log.status_codes.%d", i)
+		return &envoy_accesslog_v3.AccessLogFilter{
+			FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_AndFilter{
+				AndFilter: &envoy_accesslog_v3.AndFilter{
+					Filters: []*envoy_accesslog_v3.AccessLogFilter{
+						statusCode(envoy_accesslog_v3.ComparisonFilter_GE, min, key+".min"),
+						statusCode(envoy_accesslog_v3.ComparisonFilter_LE, max, key+".max"),
+					},
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		config *AccessLogFilterConfig
+		want   *envoy_accesslog_v3.AccessLogFilter
+	}{
+		"nil config": {
+			config: nil,
+			want:   nil,
+		},
+		"empty config": {
+			config: &AccessLogFilterConfig{},
+			want:   nil,
+		},
+		"single status code range": {
+			config: &AccessLogFilterConfig{
+				StatusCodes: []StatusCodeRange{{Min: 500, Max: 599}},
+			},
+			want: statusCodeRange(0, 500, 599),
+		},
+		"all rules": {
+			config: &AccessLogFilterConfig{
+				StatusCodes:     []StatusCodeRange{{Min: 400, Max: 499}, {Min: 500, Max: 599}},
+				MinDuration:     time.Second,
+				ResponseFlags:   []string{"UH"},
+				RequestHeaders:  []string{"x-debug"},
+				SamplingPercent: &percent,
+			},
+			want: &envoy_accesslog_v3.AccessLogFilter{
+				FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_AndFilter{
+					AndFilter: &envoy_accesslog_v3.AndFilter{
+						Filters: []*envoy_accesslog_v3.AccessLogFilter{{
+							FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_OrFilter{
+								OrFilter: &envoy_accesslog_v3.OrFilter{
+									Filters: []*envoy_accesslog_v3.AccessLogFilter{
+										statusCodeRange(0, 400, 499),
+										statusCodeRange(1, 500, 599),
+									},
+								},
+							},
+						}, {
+							FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_DurationFilter{
+								DurationFilter: &envoy_accesslog_v3.DurationFilter{
+									Comparison: &envoy_accesslog_v3.ComparisonFilter{
+										Op: envoy_accesslog_v3.ComparisonFilter_GE,
+										Value: &envoy_config_core_v3.RuntimeUInt32{
+											DefaultValue: 1000,
+											RuntimeKey:   "contour.access_log.min_duration",
+										},
+									},
+								},
+							},
+						}, {
+							FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_ResponseFlagFilter{
+								ResponseFlagFilter: &envoy_accesslog_v3.ResponseFlagFilter{
+									Flags: []string{"UH"},
+								},
+							},
+						}, {
+							FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_HeaderFilter{
+								HeaderFilter: &envoy_accesslog_v3.HeaderFilter{
+									Header: &envoy_route_v3.HeaderMatcher{
+										Name:                 "x-debug",
+										HeaderMatchSpecifier: &envoy_route_v3.HeaderMatcher_PresentMatch{PresentMatch: true},
+									},
+								},
+							},
+						}, {
+							FilterSpecifier: &envoy_accesslog_v3.AccessLogFilter_RuntimeFilter{
+								RuntimeFilter: &envoy_accesslog_v3.RuntimeFilter{
+									RuntimeKey: "contour.access_log.sampling",
+									PercentSampled: &envoy_type_v3.FractionalPercent{
+										Numerator:   50,
+										Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+									},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := GlobalAccessLogFilter(tc.config)
+			protobuf.ExpectEqual(t, tc.want, got)
+			if got != nil {
+				assert.NoError(t, got.Validate())
+			}
+		})
+	}
+}
@@ -154,7 +154,7 @@ func bootstrap(c *envoy.BootstrapConfig) ([]bootstrapf, error) {
 }
 
 func bootstrapConfig(c *envoy.BootstrapConfig) *envoy_bootstrap_v3.Bootstrap {
-	return &envoy_bootstrap_v3.Bootstrap{
+	b := &envoy_bootstrap_v3.Bootstrap{
 		DynamicResources: &envoy_bootstrap_v3.Bootstrap_DynamicResources{
 			LdsConfig: ConfigSource("contour"),
 			CdsConfig: ConfigSource("contour"),
@@ -215,6 +215,33 @@ func bootstrapConfig(c *envoy.BootstrapConfig) *envoy_bootstrap_v3.Bootstrap {
 			Address:   UnixSocketAddress(c.GetAdminAddress(), c.GetAdminPort()),
 		},
 	}
+
+	if c.LocalZone != "" {
+		// Zone aware routing needs to know which zone this Envoy
+		// is in, and how the Envoy fleet is spread across zones.
+		// The latter is the local cluster, whose endpoints are
+		// served by Contour.
+		b.Node = &envoy_core_v3.Node{
+			Locality: &envoy_core_v3.Locality{
+				Zone: c.LocalZone,
+			},
+		}
+		b.ClusterManager = &envoy_bootstrap_v3.ClusterManager{
+			LocalClusterName: envoy.LocalClusterName,
+		}
+		b.StaticResources.Clusters = append(b.StaticResources.Clusters, &envoy_cluster_v3.Cluster{
+			Name:                 envoy.LocalClusterName,
+			ConnectTimeout:       protobuf.Duration(2 * time.Second),
+			ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS),
+			EdsClusterConfig: &envoy_cluster_v3.Cluster_EdsClusterConfig{
+				EdsConfig:   ConfigSource("contour"),
+				ServiceName: envoy.LocalClusterName,
+			},
+			LbPolicy: envoy_cluster_v3.Cluster_ROUND_ROBIN,
+		})
+	}
+
+	return b
 }
 
 func adminAccessLog(logPath string) []*envoy_config_accesslog_v3.AccessLog {
@@ -22,6 +22,7 @@ import (
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/projectcontour/contour/internal/dag"
 	"github.com/projectcontour/contour/internal/envoy"
 	"github.com/projectcontour/contour/internal/protobuf"
@@ -46,6 +47,7 @@ func Cluster(c *dag.Cluster) *envoy_cluster_v3.Cluster {
 	cluster.AltStatName = envoy.AltStatName(service)
 	cluster.LbPolicy = lbPolicy(c.LoadBalancerPolicy)
 	cluster.HealthChecks = edshealthcheck(c)
+	cluster.OutlierDetection = outlierDetection(c.OutlierDetectionPolicy)
 	cluster.DnsLookupFamily = parseDNSLookupFamily(c.DNSLookupFamily)
 
 	switch len(service.ExternalName) {
@@ -53,6 +55,9 @@ func Cluster(c *dag.Cluster) *envoy_cluster_v3.Cluster {
 		// external name not set, cluster will be discovered via EDS
 		cluster.ClusterDiscoveryType = ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS)
 		cluster.EdsClusterConfig = edsconfig("contour", service)
+		if c.ZoneAwareRouting != nil {
+			cluster.CommonLbConfig.LocalityConfigSpecifier = zoneAwareLbConfig(c.ZoneAwareRouting)
+		}
 	default:
 		// external name set, use hard coded DNS name
 		cluster.ClusterDiscoveryType = ClusterDiscoveryType(envoy_cluster_v3.Cluster_STRICT_DNS)
@@ -64,14 +69,28 @@ func Cluster(c *dag.Cluster) *envoy_cluster_v3.Cluster {
 		cluster.IgnoreHealthOnHostRemoval = true
 	}
 
-	if envoy.AnyPositive(service.MaxConnections, service.MaxPendingRequests, service.MaxRequests, service.MaxRetries) {
+	var thresholds *envoy_cluster_v3.CircuitBreakers_Thresholds
+	if cb := c.CircuitBreakers; cb != nil {
+		if cb.MaxConnections != nil || cb.MaxPendingRequests != nil || cb.MaxRequests != nil || cb.MaxRetries != nil {
+			thresholds = &envoy_cluster_v3.CircuitBreakers_Thresholds{
+				MaxConnections:     protobuf.UInt32OrUnset(cb.MaxConnections),
+				MaxPendingRequests: protobuf.UInt32OrUnset(cb.MaxPendingRequests),
+				MaxRequests:        protobuf.UInt32OrUnset(cb.MaxRequests),
+				MaxRetries:         protobuf.UInt32OrUnset(cb.MaxRetries),
+			}
+		}
+	} else if envoy.AnyPositive(service.MaxConnections, service.MaxPendingRequests, service.MaxRequests, service.MaxRetries) {
+		thresholds = &envoy_cluster_v3.CircuitBreakers_Thresholds{
+			MaxConnections:     protobuf.UInt32OrNil(service.MaxConnections),
+			MaxPendingRequests: protobuf.UInt32OrNil(service.MaxPendingRequests),
+			MaxRequests:        protobuf.UInt32OrNil(service.MaxRequests),
+			MaxRetries:         protobuf.UInt32OrNil(service.MaxRetries),
+		}
+	}
+
+	if thresholds != nil {
 		cluster.CircuitBreakers = &envoy_cluster_v3.CircuitBreakers{
-			Thresholds: []*envoy_cluster_v3.CircuitBreakers_Thresholds{{
-				MaxConnections:     protobuf.UInt32OrNil(service.MaxConnections),
-				MaxPendingRequests: protobuf.UInt32OrNil(service.MaxPendingRequests),
-				MaxRequests:        protobuf.UInt32OrNil(service.MaxRequests),
-				MaxRetries:         protobuf.UInt32OrNil(service.MaxRetries),
-			}},
+			Thresholds: []*envoy_cluster_v3.CircuitBreakers_Thresholds{thresholds},
 		}
 	}
 
@@ -146,6 +165,24 @@ func ExtensionCluster(ext *dag.ExtensionCluster) *envoy_cluster_v3.Cluster {
 	return cluster
 }
 
+// DNSNameCluster builds a envoy_cluster_v3.Cluster for the given *dag.DNSNameCluster.
+func DNSNameCluster(c *dag.DNSNameCluster) *envoy_cluster_v3.Cluster {
+	cluster := clusterDefaults()
+
+	cluster.Name = envoy.DNSNameClusterName(c)
+	cluster.DnsLookupFamily = parseDNSLookupFamily(c.DNSLookupFamily)
+	cluster.ClusterDiscoveryType = ClusterDiscoveryTypeForAddress(c.Address, envoy_cluster_v3.Cluster_STRICT_DNS)
+	cluster.LoadAssignment = ClusterLoadAssignment(cluster.Name, SocketAddress(c.Address, c.Port))
+
+	if c.Scheme == "https" {
+		cluster.TransportSocket = UpstreamTLSTransportSocket(
+			UpstreamTLSContext(c.UpstreamValidation, c.Address, nil),
+		)
+	}
+
+	return cluster
+}
+
 // StaticClusterLoadAssignment creates a *envoy_endpoint_v3.ClusterLoadAssignment pointing to the external DNS address of the service
 func StaticClusterLoadAssignment(service *dag.Service) *envoy_endpoint_v3.ClusterLoadAssignment {
 	addr := SocketAddress(service.ExternalName, int(service.Weighted.ServicePort.Port))
@@ -206,6 +243,59 @@ func ClusterCommonLBConfig() *envoy_cluster_v3.Cluster_CommonLbConfig {
 	}
 }
 
+// outlierDetection returns the Envoy outlier detection for the
+// given policy, leaving any unset fields to the Envoy defaults.
+func outlierDetection(od *dag.OutlierDetectionPolicy) *envoy_cluster_v3.OutlierDetection {
+	if od == nil {
+		return nil
+	}
+
+	config := &envoy_cluster_v3.OutlierDetection{}
+	if od.Interval > 0 {
+		config.Interval = protobuf.Duration(od.Interval)
+	}
+	if od.BaseEjectionTime > 0 {
+		config.BaseEjectionTime = protobuf.Duration(od.BaseEjectionTime)
+	}
+	if od.MaxEjectionPercent != nil {
+		config.MaxEjectionPercent = protobuf.UInt32(*od.MaxEjectionPercent)
+	}
+
+	// A threshold of zero disables ejection for consecutive
+	// 5xx responses, which Envoy otherwise always enforces.
+	if n := od.ConsecutiveServerErrors; n != nil {
+		if *n > 0 {
+			config.Consecutive_5Xx = protobuf.UInt32(*n)
+		} else {
+			config.EnforcingConsecutive_5Xx = protobuf.UInt32(0)
+		}
+	}
+
+	// Envoy counts gateway errors by default, but never
+	// ejects for them unless enforcement is enabled.
+	if n := od.ConsecutiveGatewayErrors; n != nil && *n > 0 {
+		config.ConsecutiveGatewayFailure = protobuf.UInt32(*n)
+		config.EnforcingConsecutiveGatewayFailure = protobuf.UInt32(100)
+	}
+
+	return config
+}
+
+// zoneAwareLbConfig returns the CommonLbConfig locality settings that
+// enable zone aware routing. Envoy compares the zones of the upstream
+// endpoints with the zones of the local cluster, so this only has
+// effect if the Envoy was bootstrapped with a local zone.
+func zoneAwareLbConfig(z *dag.ZoneAwareRouting) *envoy_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig_ {
+	config := &envoy_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig{}
+	if z.MinClusterSize > 0 {
+		config.MinClusterSize = &wrappers.UInt64Value{Value: uint64(z.MinClusterSize)}
+	}
+
+	return &envoy_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig_{
+		ZoneAwareLbConfig: config,
+	}
+}
+
 // ConfigSource returns a *envoy_core_v3.ConfigSource for cluster.
 func ConfigSource(cluster string) *envoy_core_v3.ConfigSource {
 	return &envoy_core_v3.ConfigSource{
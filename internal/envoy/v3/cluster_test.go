@@ -23,6 +23,7 @@ import (
 	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/projectcontour/contour/internal/dag"
 	"github.com/projectcontour/contour/internal/envoy"
 	"github.com/projectcontour/contour/internal/protobuf"
@@ -35,6 +36,8 @@ import (
 )
 
 func TestCluster(t *testing.T) {
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+
 	s1 := &v1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "kuard",
@@ -412,6 +415,68 @@ func TestCluster(t *testing.T) {
 				},
 			},
 		},
+		"cluster circuit breakers replace service annotations": {
+			cluster: &dag.Cluster{
+				Upstream: &dag.Service{
+					MaxConnections: 9000,
+					Weighted: dag.WeightedService{
+						Weight:           1,
+						ServiceName:      s1.Name,
+						ServiceNamespace: s1.Namespace,
+						ServicePort:      s1.Spec.Ports[0],
+					},
+				},
+				CircuitBreakers: &dag.CircuitBreakers{
+					MaxConnections: uint32Ptr(100),
+					MaxRetries:     uint32Ptr(3),
+				},
+			},
+			want: &envoy_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/17ab7b5e9b",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				CircuitBreakers: &envoy_cluster_v3.CircuitBreakers{
+					Thresholds: []*envoy_cluster_v3.CircuitBreakers_Thresholds{{
+						MaxConnections: protobuf.UInt32(100),
+						MaxRetries:     protobuf.UInt32(3),
+					}},
+				},
+			},
+		},
+		"circuit breaker explicit zero": {
+			cluster: &dag.Cluster{
+				Upstream: &dag.Service{
+					MaxRetries: 7,
+					Weighted: dag.WeightedService{
+						Weight:           1,
+						ServiceName:      s1.Name,
+						ServiceNamespace: s1.Namespace,
+						ServicePort:      s1.Spec.Ports[0],
+					},
+				},
+				CircuitBreakers: &dag.CircuitBreakers{
+					MaxRetries: uint32Ptr(0),
+				},
+			},
+			want: &envoy_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/0854132147",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				CircuitBreakers: &envoy_cluster_v3.CircuitBreakers{
+					Thresholds: []*envoy_cluster_v3.CircuitBreakers_Thresholds{{
+						MaxRetries: protobuf.UInt32(0),
+					}},
+				},
+			},
+		},
 		"cluster with random load balancer policy": {
 			cluster: &dag.Cluster{
 				Upstream:           service(s1),
@@ -489,6 +554,130 @@ func TestCluster(t *testing.T) {
 				}},
 			},
 		},
+		"outlier detection": {
+			cluster: &dag.Cluster{
+				Upstream: service(s1),
+				OutlierDetectionPolicy: &dag.OutlierDetectionPolicy{
+					ConsecutiveServerErrors:  uint32Ptr(3),
+					ConsecutiveGatewayErrors: uint32Ptr(2),
+					Interval:                 5 * time.Second,
+					BaseEjectionTime:         time.Minute,
+					MaxEjectionPercent:       uint32Ptr(50),
+				},
+			},
+			want: &envoy_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/108939b182",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				OutlierDetection: &envoy_cluster_v3.OutlierDetection{
+					Consecutive_5Xx:                    protobuf.UInt32(3),
+					Interval:                           protobuf.Duration(5 * time.Second),
+					BaseEjectionTime:                   protobuf.Duration(time.Minute),
+					MaxEjectionPercent:                 protobuf.UInt32(50),
+					ConsecutiveGatewayFailure:          protobuf.UInt32(2),
+					EnforcingConsecutiveGatewayFailure: protobuf.UInt32(100),
+				},
+			},
+		},
+		"outlier detection defaults": {
+			cluster: &dag.Cluster{
+				Upstream:               service(s1),
+				OutlierDetectionPolicy: &dag.OutlierDetectionPolicy{},
+			},
+			want: &envoy_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/90c870be27",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				OutlierDetection: &envoy_cluster_v3.OutlierDetection{},
+			},
+		},
+		"outlier detection explicit zero": {
+			cluster: &dag.Cluster{
+				Upstream: service(s1),
+				OutlierDetectionPolicy: &dag.OutlierDetectionPolicy{
+					ConsecutiveServerErrors:  uint32Ptr(0),
+					ConsecutiveGatewayErrors: uint32Ptr(0),
+					MaxEjectionPercent:       uint32Ptr(0),
+				},
+			},
+			want: &envoy_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/5d3414d305",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				OutlierDetection: &envoy_cluster_v3.OutlierDetection{
+					EnforcingConsecutive_5Xx: protobuf.UInt32(0),
+					MaxEjectionPercent:       protobuf.UInt32(0),
+				},
+			},
+		},
+		"zone aware routing": {
+			cluster: &dag.Cluster{
+				Upstream:         service(s1),
+				ZoneAwareRouting: &dag.ZoneAwareRouting{},
+			},
+			want: &envoy_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/da39a3ee5e",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				CommonLbConfig: &envoy_cluster_v3.Cluster_CommonLbConfig{
+					LocalityConfigSpecifier: &envoy_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig_{
+						ZoneAwareLbConfig: &envoy_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig{},
+					},
+				},
+			},
+		},
+		"zone aware routing with min cluster size": {
+			cluster: &dag.Cluster{
+				Upstream: service(s1),
+				ZoneAwareRouting: &dag.ZoneAwareRouting{
+					MinClusterSize: 3,
+				},
+			},
+			want: &envoy_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/da39a3ee5e",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_EDS),
+				EdsClusterConfig: &envoy_cluster_v3.Cluster_EdsClusterConfig{
+					EdsConfig:   ConfigSource("contour"),
+					ServiceName: "default/kuard/http",
+				},
+				CommonLbConfig: &envoy_cluster_v3.Cluster_CommonLbConfig{
+					LocalityConfigSpecifier: &envoy_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig_{
+						ZoneAwareLbConfig: &envoy_cluster_v3.Cluster_CommonLbConfig_ZoneAwareLbConfig{
+							MinClusterSize: &wrappers.UInt64Value{Value: 3},
+						},
+					},
+				},
+			},
+		},
+		"zone aware routing - externalName service": {
+			cluster: &dag.Cluster{
+				Upstream:         service(s2),
+				ZoneAwareRouting: &dag.ZoneAwareRouting{},
+			},
+			want: &envoy_cluster_v3.Cluster{
+				Name:                 "default/kuard/443/da39a3ee5e",
+				AltStatName:          "default_kuard_443",
+				ClusterDiscoveryType: ClusterDiscoveryType(envoy_cluster_v3.Cluster_STRICT_DNS),
+				LoadAssignment:       StaticClusterLoadAssignment(service(s2)),
+			},
+		},
 		"use client certificate to authentication towards backend": {
 			cluster: &dag.Cluster{
 				Upstream:          service(s1, "tls"),
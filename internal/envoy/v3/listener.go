@@ -25,16 +25,21 @@ import (
 	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	envoy_config_filter_http_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_header_to_metadata_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_to_metadata/v3"
+	envoy_jwt_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	envoy_config_filter_http_local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoy_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	envoy_extensions_filters_http_router_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	tcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	udp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
 	envoy_extensions_http_original_ip_detection_xff_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/original_ip_detection/xff/v3"
 	envoy_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/projectcontour/contour/internal/dag"
 	"github.com/projectcontour/contour/internal/envoy"
 	"github.com/projectcontour/contour/internal/protobuf"
@@ -54,6 +59,9 @@ const (
 	HTTPFilterCORS    = "type.googleapis.com/envoy.extensions.filters.http.cors.v3.Cors"
 	HTTPFilterGrpcWeb = "type.googleapis.com/envoy.extensions.filters.http.grpc_web.v3.GrpcWeb"
 	HTTPFilterGzip    = "type.googleapis.com/envoy.extensions.compression.gzip.compressor.v3.Gzip"
+
+	// UDPProxyFilter is the name of the Envoy UDP proxy listener filter.
+	UDPProxyFilter = "envoy.filters.udp_listener.udp_proxy"
 )
 
 // ProtoNamesForVersions returns the slice of ALPN protocol names for the give HTTP versions.
@@ -138,6 +146,17 @@ func Listener(name, address string, port int, lf []*envoy_listener_v3.ListenerFi
 	return l
 }
 
+// UDPListener returns a new envoy_listener_v3.Listener that proxies
+// the datagrams it receives on the supplied address and port using
+// the supplied UDP proxy listener filter.
+func UDPListener(name, address string, port int, proxy *envoy_listener_v3.ListenerFilter) *envoy_listener_v3.Listener {
+	return &envoy_listener_v3.Listener{
+		Name:            name,
+		Address:         UDPSocketAddress(address, port),
+		ListenerFilters: ListenerFilters(proxy),
+	}
+}
+
 type httpConnectionManagerBuilder struct {
 	routeConfigName               string
 	metricsPrefix                 string
@@ -151,6 +170,7 @@ type httpConnectionManagerBuilder struct {
 	filters                       []*http.HttpFilter
 	codec                         HTTPVersionType // Note the zero value is AUTO, which is the default we want.
 	allowChunkedLength            bool
+	tracing                       *http.HttpConnectionManager_Tracing
 }
 
 // RouteConfigName sets the name of the RDS element that contains
@@ -223,12 +243,31 @@ func (b *httpConnectionManagerBuilder) AllowChunkedLength(enabled bool) *httpCon
 	return b
 }
 
+// Tracing sets the tracing configuration of the manager. Tracing
+// is disabled if tracing is nil.
+func (b *httpConnectionManagerBuilder) Tracing(tracing *http.HttpConnectionManager_Tracing) *httpConnectionManagerBuilder {
+	b.tracing = tracing
+	return b
+}
+
 func (b *httpConnectionManagerBuilder) DefaultFilters() *httpConnectionManagerBuilder {
 
 	// Add a default set of ordered http filters.
 	// The names are not required to match anything and are
 	// identified by the TypeURL of each filter.
 	b.filters = append(b.filters,
+		&http.HttpFilter{
+			Name: "envoy.filters.http.header_to_metadata",
+			ConfigType: &http.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(
+					// since no rules are defined here, the filter
+					// does nothing but is configured on a
+					// per-vhost/route basis to mark the requests
+					// that an access log policy applies to.
+					&envoy_header_to_metadata_v3.Config{},
+				),
+			},
+		},
 		&http.HttpFilter{
 			Name: "compressor",
 			ConfigType: &http.HttpFilter_TypedConfig{
@@ -270,6 +309,17 @@ func (b *httpConnectionManagerBuilder) DefaultFilters() *httpConnectionManagerBu
 				),
 			},
 		},
+		&http.HttpFilter{
+			Name: "envoy.filters.http.rbac",
+			ConfigType: &http.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(
+					// since no rules are defined here, the filter allows
+					// all requests but can be configured on a
+					// per-vhost/route basis.
+					&envoy_rbac_v3.RBAC{},
+				),
+			},
+		},
 		&http.HttpFilter{
 			Name: "envoy.filters.http.lua",
 			ConfigType: &http.HttpFilter_TypedConfig{
@@ -405,6 +455,8 @@ func (b *httpConnectionManagerBuilder) Get() *envoy_listener_v3.Filter {
 		StreamIdleTimeout:   envoy.Timeout(b.streamIdleTimeout),
 		DrainTimeout:        envoy.Timeout(b.connectionShutdownGracePeriod),
 		DelayedCloseTimeout: envoy.Timeout(b.delayedCloseTimeout),
+
+		Tracing: b.tracing,
 	}
 
 	// Max connection duration is infinite/disabled by default in Envoy, so if the timeout setting
@@ -528,6 +580,23 @@ func TCPProxy(statPrefix string, proxy *dag.TCPProxy, accesslogger []*accesslog.
 	}
 }
 
+// UDPProxy creates a new UDPProxy listener filter.
+func UDPProxy(statPrefix string, proxy *dag.UDPProxy) *envoy_listener_v3.ListenerFilter {
+	udpProxy := &udp.UdpProxyConfig{
+		StatPrefix: statPrefix,
+		RouteSpecifier: &udp.UdpProxyConfig_Cluster{
+			Cluster: envoy.Clustername(proxy.Cluster),
+		},
+	}
+
+	return &envoy_listener_v3.ListenerFilter{
+		Name: UDPProxyFilter,
+		ConfigType: &envoy_listener_v3.ListenerFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(udpProxy),
+		},
+	}
+}
+
 // UnixSocketAddress creates a new Unix Socket envoy_core_v3.Address.
 func UnixSocketAddress(address string, port int) *envoy_core_v3.Address {
 	return &envoy_core_v3.Address{
@@ -569,6 +638,13 @@ func SocketAddress(address string, port int) *envoy_core_v3.Address {
 	}
 }
 
+// UDPSocketAddress creates a new UDP envoy_core_v3.Address.
+func UDPSocketAddress(address string, port int) *envoy_core_v3.Address {
+	addr := SocketAddress(address, port)
+	addr.GetSocketAddress().Protocol = envoy_core_v3.SocketAddress_UDP
+	return addr
+}
+
 // Filters returns a []*envoy_listener_v3.Filter for the supplied filters.
 func Filters(filters ...*envoy_listener_v3.Filter) []*envoy_listener_v3.Filter {
 	if len(filters) == 0 {
@@ -684,6 +760,73 @@ func FilterExternalAuthz(authzClusterName string, failOpen bool, timeout timeout
 	}
 }
 
+// FilterJWTAuthN returns a `jwt_authn` filter configured with the
+// requested parameters. It returns nil if no providers are given.
+func FilterJWTAuthN(jwtProviders []dag.JWTProvider) *http.HttpFilter {
+	if len(jwtProviders) == 0 {
+		return nil
+	}
+
+	jwtConfig := envoy_jwt_v3.JwtAuthentication{
+		Providers:      map[string]*envoy_jwt_v3.JwtProvider{},
+		RequirementMap: map[string]*envoy_jwt_v3.JwtRequirement{},
+	}
+
+	for _, provider := range jwtProviders {
+		jwtProvider := &envoy_jwt_v3.JwtProvider{
+			Issuer:    provider.Issuer,
+			Audiences: provider.Audiences,
+			Forward:   provider.ForwardJWT,
+		}
+
+		if provider.RemoteJWKS != nil {
+			var cacheDuration *duration.Duration
+			if provider.RemoteJWKS.CacheDuration > 0 {
+				cacheDuration = protobuf.Duration(provider.RemoteJWKS.CacheDuration)
+			}
+
+			jwtProvider.JwksSourceSpecifier = &envoy_jwt_v3.JwtProvider_RemoteJwks{
+				RemoteJwks: &envoy_jwt_v3.RemoteJwks{
+					HttpUri: &envoy_core_v3.HttpUri{
+						Uri: provider.RemoteJWKS.URI,
+						HttpUpstreamType: &envoy_core_v3.HttpUri_Cluster{
+							Cluster: envoy.DNSNameClusterName(&provider.RemoteJWKS.Cluster),
+						},
+						Timeout: protobuf.Duration(provider.RemoteJWKS.Timeout),
+					},
+					CacheDuration: cacheDuration,
+				},
+			}
+		} else {
+			jwtProvider.JwksSourceSpecifier = &envoy_jwt_v3.JwtProvider_LocalJwks{
+				LocalJwks: &envoy_core_v3.DataSource{
+					Specifier: &envoy_core_v3.DataSource_InlineString{
+						InlineString: provider.LocalJWKS,
+					},
+				},
+			}
+		}
+
+		jwtConfig.Providers[provider.Name] = jwtProvider
+
+		// Routes select a provider by naming its requirement
+		// in their per-route config, so each provider gets a
+		// requirement of the same name.
+		jwtConfig.RequirementMap[provider.Name] = &envoy_jwt_v3.JwtRequirement{
+			RequiresType: &envoy_jwt_v3.JwtRequirement_ProviderName{
+				ProviderName: provider.Name,
+			},
+		}
+	}
+
+	return &http.HttpFilter{
+		Name: "envoy.filters.http.jwt_authn",
+		ConfigType: &http.HttpFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&jwtConfig),
+		},
+	}
+}
+
 func OriginalIPDetectionFilter(xffNumTrustedHops uint32) *http.HttpFilter {
 	if xffNumTrustedHops == 0 {
 		return nil
@@ -22,10 +22,13 @@ import (
 	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_compressor_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	envoy_config_filter_http_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_header_to_metadata_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_to_metadata/v3"
 	envoy_config_filter_http_local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoy_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoy_tcp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	envoy_udp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
 	envoy_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
@@ -183,6 +186,53 @@ func TestSocketAddress(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestUDPListener(t *testing.T) {
+	proxy := &dag.UDPProxy{
+		Cluster: &dag.Cluster{
+			Upstream: &dag.Service{
+				Weighted: dag.WeightedService{
+					Weight:           1,
+					ServiceName:      "dns",
+					ServiceNamespace: "default",
+					ServicePort: v1.ServicePort{
+						Protocol:   "UDP",
+						Port:       53,
+						TargetPort: intstr.FromInt(5353),
+					},
+				},
+			},
+		},
+	}
+
+	got := UDPListener("ingress_udp_53", "0.0.0.0", 53, UDPProxy("ingress_udp_53", proxy))
+	want := &envoy_listener_v3.Listener{
+		Name: "ingress_udp_53",
+		Address: &envoy_core_v3.Address{
+			Address: &envoy_core_v3.Address_SocketAddress{
+				SocketAddress: &envoy_core_v3.SocketAddress{
+					Protocol: envoy_core_v3.SocketAddress_UDP,
+					Address:  "0.0.0.0",
+					PortSpecifier: &envoy_core_v3.SocketAddress_PortValue{
+						PortValue: 53,
+					},
+				},
+			},
+		},
+		ListenerFilters: []*envoy_listener_v3.ListenerFilter{{
+			Name: UDPProxyFilter,
+			ConfigType: &envoy_listener_v3.ListenerFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(&envoy_udp_proxy_v3.UdpProxyConfig{
+					StatPrefix: "ingress_udp_53",
+					RouteSpecifier: &envoy_udp_proxy_v3.UdpProxyConfig_Cluster{
+						Cluster: "default/dns/53/da39a3ee5e",
+					},
+				}),
+			},
+		}},
+	}
+	protobuf.ExpectEqual(t, want, got)
+}
+
 func TestDownstreamTLSContext(t *testing.T) {
 	const subjectName = "client-subject-name"
 	ca := []byte("client-ca-cert")
@@ -419,6 +469,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -453,6 +508,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -517,6 +577,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -551,6 +616,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -616,6 +686,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -650,6 +725,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -716,6 +796,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -750,6 +835,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -815,6 +905,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -849,6 +944,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -915,6 +1015,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -949,6 +1054,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -1013,6 +1123,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -1047,6 +1162,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -1112,6 +1232,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -1146,6 +1271,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -1212,6 +1342,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 							},
 						},
 						HttpFilters: []*http.HttpFilter{{
+							Name: "envoy.filters.http.header_to_metadata",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+							},
+						}, {
 							Name: "compressor",
 							ConfigType: &http.HttpFilter_TypedConfig{
 								TypedConfig: protobuf.MustMarshalAny(&envoy_compressor_v3.Compressor{
@@ -1246,6 +1381,11 @@ func TestHTTPConnectionManager(t *testing.T) {
 									},
 								),
 							},
+						}, {
+							Name: "envoy.filters.http.rbac",
+							ConfigType: &http.HttpFilter_TypedConfig{
+								TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+							},
 						}, {
 							Name: "envoy.filters.http.lua",
 							ConfigType: &http.HttpFilter_TypedConfig{
@@ -1661,6 +1801,12 @@ func TestAddFilter(t *testing.T) {
 			builder: HTTPConnectionManagerBuilder().DefaultFilters(),
 			add:     FilterExternalAuthz("test", false, timeout.Setting{}, nil),
 			want: []*http.HttpFilter{
+				{
+					Name: "envoy.filters.http.header_to_metadata",
+					ConfigType: &http.HttpFilter_TypedConfig{
+						TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+					},
+				},
 				{
 					Name: "compressor",
 					ConfigType: &http.HttpFilter_TypedConfig{
@@ -1700,6 +1846,12 @@ func TestAddFilter(t *testing.T) {
 						),
 					},
 				},
+				{
+					Name: "envoy.filters.http.rbac",
+					ConfigType: &http.HttpFilter_TypedConfig{
+						TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+					},
+				},
 				{
 					Name: "envoy.filters.http.lua",
 					ConfigType: &http.HttpFilter_TypedConfig{
@@ -1728,6 +1880,12 @@ func TestAddFilter(t *testing.T) {
 					PackAsBytes:         true,
 				}),
 			want: []*http.HttpFilter{
+				{
+					Name: "envoy.filters.http.header_to_metadata",
+					ConfigType: &http.HttpFilter_TypedConfig{
+						TypedConfig: protobuf.MustMarshalAny(&envoy_header_to_metadata_v3.Config{}),
+					},
+				},
 				{
 					Name: "compressor",
 					ConfigType: &http.HttpFilter_TypedConfig{
@@ -1767,6 +1925,12 @@ func TestAddFilter(t *testing.T) {
 						),
 					},
 				},
+				{
+					Name: "envoy.filters.http.rbac",
+					ConfigType: &http.HttpFilter_TypedConfig{
+						TypedConfig: protobuf.MustMarshalAny(&envoy_rbac_v3.RBAC{}),
+					},
+				},
 				{
 					Name: "envoy.filters.http.lua",
 					ConfigType: &http.HttpFilter_TypedConfig{
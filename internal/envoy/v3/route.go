@@ -22,10 +22,14 @@ import (
 	"text/template"
 
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_config_filter_http_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_jwt_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoy_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/golang/protobuf/ptypes/any"
 	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/projectcontour/contour/internal/dag"
@@ -38,11 +42,33 @@ import (
 func VirtualHostAndRoutes(vh *dag.VirtualHost, dagRoutes []*dag.Route, secure bool, authService *dag.ExtensionCluster) *envoy_route_v3.VirtualHost {
 	var envoyRoutes []*envoy_route_v3.Route
 	for _, route := range dagRoutes {
-		envoyRoutes = append(envoyRoutes, buildRoute(route, vh.Name, secure, authService))
+		rt := buildRoute(route, vh.Name, secure, authService)
+
+		// Mark the requests for routes with an access log policy,
+		// whatever the route's action, so that their access logs
+		// can be selected.
+		if route.AccessLogPolicy != nil {
+			if rt.TypedPerFilterConfig == nil {
+				rt.TypedPerFilterConfig = map[string]*any.Any{}
+			}
+			rt.TypedPerFilterConfig["envoy.filters.http.header_to_metadata"] = AccessLogPolicyMark(route.AccessLogPolicy)
+		}
+
+		envoyRoutes = append(envoyRoutes, rt)
 	}
 
 	evh := VirtualHost(vh.Name, envoyRoutes...)
 
+	// Secure virtual hosts have a listener of their own, whose
+	// access log applies the virtual host's policy, so only the
+	// requests for insecure virtual hosts need to be marked.
+	if !secure && vh.AccessLogPolicy != nil {
+		if evh.TypedPerFilterConfig == nil {
+			evh.TypedPerFilterConfig = map[string]*any.Any{}
+		}
+		evh.TypedPerFilterConfig["envoy.filters.http.header_to_metadata"] = AccessLogPolicyMark(vh.AccessLogPolicy)
+	}
+
 	if vh.CORSPolicy != nil {
 		evh.Cors = corsPolicy(vh.CORSPolicy)
 	}
@@ -57,6 +83,13 @@ func VirtualHostAndRoutes(vh *dag.VirtualHost, dagRoutes []*dag.Route, secure bo
 		evh.RateLimits = GlobalRateLimits(vh.RateLimitPolicy.Global.Descriptors)
 	}
 
+	if len(vh.IPFilterRules) > 0 {
+		if evh.TypedPerFilterConfig == nil {
+			evh.TypedPerFilterConfig = map[string]*any.Any{}
+		}
+		evh.TypedPerFilterConfig["envoy.filters.http.rbac"] = ipFilterConfig(vh.IPFilterAllow, vh.IPFilterRules)
+	}
+
 	return evh
 }
 
@@ -104,6 +137,13 @@ func buildRoute(dagRoute *dag.Route, vhostName string, secure bool, authService
 			rt.TypedPerFilterConfig["envoy.filters.http.local_ratelimit"] = LocalRateLimitConfig(dagRoute.RateLimitPolicy.Local, "vhost."+vhostName)
 		}
 
+		if len(dagRoute.IPFilterRules) > 0 {
+			if rt.TypedPerFilterConfig == nil {
+				rt.TypedPerFilterConfig = map[string]*any.Any{}
+			}
+			rt.TypedPerFilterConfig["envoy.filters.http.rbac"] = ipFilterConfig(dagRoute.IPFilterAllow, dagRoute.IPFilterRules)
+		}
+
 		// If authorization is enabled on this host, we may need to set per-route filter overrides.
 		if authService != nil {
 			// Apply per-route authorization policy modifications.
@@ -120,6 +160,16 @@ func buildRoute(dagRoute *dag.Route, vhostName string, secure bool, authService
 			}
 		}
 
+		// If JWT verification is enabled, add per-route filtering
+		// to require the route's provider. The jwt_authn filter is
+		// only installed on secure virtual hosts.
+		if secure && len(dagRoute.JWTProvider) > 0 {
+			if rt.TypedPerFilterConfig == nil {
+				rt.TypedPerFilterConfig = map[string]*any.Any{}
+			}
+			rt.TypedPerFilterConfig["envoy.filters.http.jwt_authn"] = routeJWTAuthn(dagRoute.JWTProvider)
+		}
+
 		return rt
 	}
 }
@@ -149,6 +199,63 @@ func routeAuthzContext(settings map[string]string) *any.Any {
 	)
 }
 
+// ipFilterConfig returns a per-vhost/route config that allows or denies
+// requests whose source address matches one of the given rules.
+func ipFilterConfig(allow bool, rules []dag.IPFilterRule) *any.Any {
+	action := envoy_config_rbac_v3.RBAC_DENY
+	if allow {
+		action = envoy_config_rbac_v3.RBAC_ALLOW
+	}
+
+	var principals []*envoy_config_rbac_v3.Principal
+	for _, rule := range rules {
+		prefixLen, _ := rule.CIDR.Mask.Size()
+		cidr := &envoy_core_v3.CidrRange{
+			AddressPrefix: rule.CIDR.IP.String(),
+			PrefixLen:     protobuf.UInt32(uint32(prefixLen)),
+		}
+
+		if rule.Remote {
+			principals = append(principals, &envoy_config_rbac_v3.Principal{
+				Identifier: &envoy_config_rbac_v3.Principal_RemoteIp{RemoteIp: cidr},
+			})
+		} else {
+			principals = append(principals, &envoy_config_rbac_v3.Principal{
+				Identifier: &envoy_config_rbac_v3.Principal_DirectRemoteIp{DirectRemoteIp: cidr},
+			})
+		}
+	}
+
+	return protobuf.MustMarshalAny(
+		&envoy_rbac_v3.RBACPerRoute{
+			Rbac: &envoy_rbac_v3.RBAC{
+				Rules: &envoy_config_rbac_v3.RBAC{
+					Action: action,
+					Policies: map[string]*envoy_config_rbac_v3.Policy{
+						"ip-rules": {
+							Permissions: []*envoy_config_rbac_v3.Permission{{
+								Rule: &envoy_config_rbac_v3.Permission_Any{Any: true},
+							}},
+							Principals: principals,
+						},
+					},
+				},
+			},
+		},
+	)
+}
+
+// routeJWTAuthn returns a per-route config to require the named JWT provider.
+func routeJWTAuthn(requirementName string) *any.Any {
+	return protobuf.MustMarshalAny(
+		&envoy_jwt_v3.PerRouteConfig{
+			RequirementSpecifier: &envoy_jwt_v3.PerRouteConfig_RequirementName{
+				RequirementName: requirementName,
+			},
+		},
+	)
+}
+
 const prefixPathMatchSegmentRegex = `(?:[\/].*)*`
 
 var _ = regexp.MustCompile(prefixPathMatchSegmentRegex)
@@ -163,7 +270,8 @@ func RouteMatch(route *dag.Route) *envoy_route_v3.RouteMatch {
 				// Reduces regex program size so Envoy doesn't reject long prefix matches.
 				SafeRegex: SafeRegexMatch("^" + c.Regex),
 			},
-			Headers: headerMatcher(route.HeaderMatchConditions),
+			Headers:         headerMatcher(route.HeaderMatchConditions),
+			QueryParameters: queryParamMatcher(route.QueryParamMatchConditions),
 		}
 	case *dag.PrefixMatchCondition:
 		switch c.PrefixMatchType {
@@ -174,7 +282,8 @@ func RouteMatch(route *dag.Route) *envoy_route_v3.RouteMatch {
 					// Reduces regex program size so Envoy doesn't reject long prefix matches.
 					SafeRegex: SafeRegexMatch("^" + regexp.QuoteMeta(c.Prefix) + prefixPathMatchSegmentRegex),
 				},
-				Headers: headerMatcher(route.HeaderMatchConditions),
+				Headers:         headerMatcher(route.HeaderMatchConditions),
+				QueryParameters: queryParamMatcher(route.QueryParamMatchConditions),
 			}
 		case dag.PrefixMatchString:
 			fallthrough
@@ -183,7 +292,8 @@ func RouteMatch(route *dag.Route) *envoy_route_v3.RouteMatch {
 				PathSpecifier: &envoy_route_v3.RouteMatch_Prefix{
 					Prefix: c.Prefix,
 				},
-				Headers: headerMatcher(route.HeaderMatchConditions),
+				Headers:         headerMatcher(route.HeaderMatchConditions),
+				QueryParameters: queryParamMatcher(route.QueryParamMatchConditions),
 			}
 		}
 	case *dag.ExactMatchCondition:
@@ -191,11 +301,13 @@ func RouteMatch(route *dag.Route) *envoy_route_v3.RouteMatch {
 			PathSpecifier: &envoy_route_v3.RouteMatch_Path{
 				Path: c.Path,
 			},
-			Headers: headerMatcher(route.HeaderMatchConditions),
+			Headers:         headerMatcher(route.HeaderMatchConditions),
+			QueryParameters: queryParamMatcher(route.QueryParamMatchConditions),
 		}
 	default:
 		return &envoy_route_v3.RouteMatch{
-			Headers: headerMatcher(route.HeaderMatchConditions),
+			Headers:         headerMatcher(route.HeaderMatchConditions),
+			QueryParameters: queryParamMatcher(route.QueryParamMatchConditions),
 		}
 	}
 }
@@ -273,6 +385,13 @@ func routeRoute(r *dag.Route) *envoy_route_v3.Route_Route {
 		ra.RateLimits = GlobalRateLimits(r.RateLimitPolicy.Global.Descriptors)
 	}
 
+	if r.RegexRewrite != nil {
+		ra.RegexRewrite = &matcher.RegexMatchAndSubstitute{
+			Pattern:      SafeRegexMatch(r.RegexRewrite.Pattern),
+			Substitution: r.RegexRewrite.Substitution,
+		}
+	}
+
 	// Check for host header policy and set if found
 	if val := envoy.HostReplaceHeader(r.RequestHeadersPolicy); val != "" {
 		ra.HostRewriteSpecifier = &envoy_route_v3.RouteAction_HostRewriteLiteral{
@@ -343,13 +462,28 @@ func hashPolicy(requestHashPolicies []dag.RequestHashPolicy) []*envoy_route_v3.R
 }
 
 func mirrorPolicy(r *dag.Route) []*envoy_route_v3.RouteAction_RequestMirrorPolicy {
-	if r.MirrorPolicy == nil {
-		return nil
+	var mirrors []*envoy_route_v3.RouteAction_RequestMirrorPolicy
+
+	for _, mp := range r.MirrorPolicies {
+		mirror := &envoy_route_v3.RouteAction_RequestMirrorPolicy{
+			Cluster: envoy.Clustername(mp.Cluster),
+		}
+
+		// Only mirror a fraction of requests if the policy
+		// does not cover all of them.
+		if mp.TotalWeight > 0 && mp.Weight < mp.TotalWeight {
+			mirror.RuntimeFraction = &envoy_core_v3.RuntimeFractionalPercent{
+				DefaultValue: &envoy_type_v3.FractionalPercent{
+					Numerator:   uint32(uint64(mp.Weight) * 1000000 / uint64(mp.TotalWeight)),
+					Denominator: envoy_type_v3.FractionalPercent_MILLION,
+				},
+			}
+		}
+
+		mirrors = append(mirrors, mirror)
 	}
 
-	return []*envoy_route_v3.RouteAction_RequestMirrorPolicy{{
-		Cluster: envoy.Clustername(r.MirrorPolicy.Cluster),
-	}}
+	return mirrors
 }
 
 func retryPolicy(r *dag.Route) *envoy_route_v3.RetryPolicy {
@@ -420,6 +554,11 @@ func weightedClusters(route *dag.Route) *envoy_route_v3.WeightedCluster {
 		if cluster.RequestHeadersPolicy != nil {
 			c.RequestHeadersToAdd = append(headerValueList(cluster.RequestHeadersPolicy.Set, false), headerValueList(cluster.RequestHeadersPolicy.Add, true)...)
 			c.RequestHeadersToRemove = cluster.RequestHeadersPolicy.Remove
+			if val := envoy.HostReplaceHeader(cluster.RequestHeadersPolicy); val != "" {
+				c.HostRewriteSpecifier = &envoy_route_v3.WeightedCluster_ClusterWeight_HostRewriteLiteral{
+					HostRewriteLiteral: val,
+				}
+			}
 		}
 		if cluster.ResponseHeadersPolicy != nil {
 			c.ResponseHeadersToAdd = headerValueList(cluster.ResponseHeadersPolicy.Set, false)
@@ -545,6 +684,35 @@ func headerMatcher(headers []dag.HeaderMatchCondition) []*envoy_route_v3.HeaderM
 	return envoyHeaders
 }
 
+func queryParamMatcher(queryParams []dag.QueryParamMatchCondition) []*envoy_route_v3.QueryParameterMatcher {
+	var envoyQueryParamMatchers []*envoy_route_v3.QueryParameterMatcher
+
+	for _, q := range queryParams {
+		queryParam := &envoy_route_v3.QueryParameterMatcher{
+			Name: q.Name,
+		}
+
+		switch q.MatchType {
+		case dag.QueryParamMatchTypeExact:
+			queryParam.QueryParameterMatchSpecifier = &envoy_route_v3.QueryParameterMatcher_StringMatch{
+				StringMatch: &matcher.StringMatcher{
+					MatchPattern: &matcher.StringMatcher_Exact{Exact: q.Value},
+				},
+			}
+		case dag.QueryParamMatchTypeRegex:
+			queryParam.QueryParameterMatchSpecifier = &envoy_route_v3.QueryParameterMatcher_StringMatch{
+				StringMatch: &matcher.StringMatcher{
+					MatchPattern: &matcher.StringMatcher_SafeRegex{SafeRegex: SafeRegexMatch(q.Value)},
+				},
+			}
+		case dag.QueryParamMatchTypePresent:
+			queryParam.QueryParameterMatchSpecifier = &envoy_route_v3.QueryParameterMatcher_PresentMatch{PresentMatch: true}
+		}
+		envoyQueryParamMatchers = append(envoyQueryParamMatchers, queryParam)
+	}
+	return envoyQueryParamMatchers
+}
+
 // containsMatch returns a HeaderMatchSpecifier which will match the
 // supplied substring
 func containsMatch(s string) *envoy_route_v3.HeaderMatcher_SafeRegexMatch {
@@ -20,6 +20,7 @@ import (
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/projectcontour/contour/internal/dag"
 	"github.com/projectcontour/contour/internal/fixture"
@@ -558,7 +559,7 @@ func TestRouteRoute(t *testing.T) {
 					},
 					Weight: 90,
 				}},
-				MirrorPolicy: &dag.MirrorPolicy{
+				MirrorPolicies: []*dag.MirrorPolicy{{
 					Cluster: &dag.Cluster{
 						Upstream: &dag.Service{
 							Weighted: dag.WeightedService{
@@ -569,8 +570,30 @@ func TestRouteRoute(t *testing.T) {
 							},
 						},
 					},
+				}},
+			},
+			want: &envoy_route_v3.Route_Route{
+				Route: &envoy_route_v3.RouteAction{
+					ClusterSpecifier: &envoy_route_v3.RouteAction_Cluster{
+						Cluster: "default/kuard/8080/da39a3ee5e",
+					},
+					RequestMirrorPolicies: []*envoy_route_v3.RouteAction_RequestMirrorPolicy{{
+						Cluster: "default/kuard/8080/da39a3ee5e",
+					}},
 				},
 			},
+		},
+		"weighted mirror": {
+			route: &dag.Route{
+				Clusters: []*dag.Cluster{c1},
+				MirrorPolicies: []*dag.MirrorPolicy{{
+					Cluster: c1,
+				}, {
+					Cluster:     c1,
+					Weight:      1,
+					TotalWeight: 3,
+				}},
+			},
 			want: &envoy_route_v3.Route_Route{
 				Route: &envoy_route_v3.RouteAction{
 					ClusterSpecifier: &envoy_route_v3.RouteAction_Cluster{
@@ -578,10 +601,64 @@ func TestRouteRoute(t *testing.T) {
 					},
 					RequestMirrorPolicies: []*envoy_route_v3.RouteAction_RequestMirrorPolicy{{
 						Cluster: "default/kuard/8080/da39a3ee5e",
+					}, {
+						Cluster: "default/kuard/8080/da39a3ee5e",
+						RuntimeFraction: &envoy_core_v3.RuntimeFractionalPercent{
+							DefaultValue: &envoy_type_v3.FractionalPercent{
+								Numerator:   333333,
+								Denominator: envoy_type_v3.FractionalPercent_MILLION,
+							},
+						},
 					}},
 				},
 			},
 		},
+		"regex rewrite": {
+			route: &dag.Route{
+				Clusters: []*dag.Cluster{c1},
+				RegexRewrite: &dag.RegexRewrite{
+					Pattern:      "^/foo",
+					Substitution: "/bar",
+				},
+			},
+			want: &envoy_route_v3.Route_Route{
+				Route: &envoy_route_v3.RouteAction{
+					ClusterSpecifier: &envoy_route_v3.RouteAction_Cluster{
+						Cluster: "default/kuard/8080/da39a3ee5e",
+					},
+					RegexRewrite: &matcher.RegexMatchAndSubstitute{
+						Pattern:      SafeRegexMatch("^/foo"),
+						Substitution: "/bar",
+					},
+				},
+			},
+		},
+		"cluster host header rewrite": {
+			route: &dag.Route{
+				Clusters: []*dag.Cluster{{
+					Upstream: c1.Upstream,
+					RequestHeadersPolicy: &dag.HeadersPolicy{
+						HostRewrite: "bar.com",
+					},
+				}},
+			},
+			want: &envoy_route_v3.Route_Route{
+				Route: &envoy_route_v3.RouteAction{
+					ClusterSpecifier: &envoy_route_v3.RouteAction_WeightedClusters{
+						WeightedClusters: &envoy_route_v3.WeightedCluster{
+							Clusters: []*envoy_route_v3.WeightedCluster_ClusterWeight{{
+								Name:   "default/kuard/8080/da39a3ee5e",
+								Weight: protobuf.UInt32(1),
+								HostRewriteSpecifier: &envoy_route_v3.WeightedCluster_ClusterWeight_HostRewriteLiteral{
+									HostRewriteLiteral: "bar.com",
+								},
+							}},
+							TotalWeight: protobuf.UInt32(1),
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -1215,6 +1292,78 @@ func TestRouteMatch(t *testing.T) {
 				}},
 			},
 		},
+		"query param exact match": {
+			route: &dag.Route{
+				PathMatchCondition: &dag.PrefixMatchCondition{
+					Prefix: "/",
+				},
+				QueryParamMatchConditions: []dag.QueryParamMatchCondition{{
+					Name:      "param",
+					Value:     "value",
+					MatchType: dag.QueryParamMatchTypeExact,
+				}},
+			},
+			want: &envoy_route_v3.RouteMatch{
+				PathSpecifier: &envoy_route_v3.RouteMatch_Prefix{
+					Prefix: "/",
+				},
+				QueryParameters: []*envoy_route_v3.QueryParameterMatcher{{
+					Name: "param",
+					QueryParameterMatchSpecifier: &envoy_route_v3.QueryParameterMatcher_StringMatch{
+						StringMatch: &matcher.StringMatcher{
+							MatchPattern: &matcher.StringMatcher_Exact{Exact: "value"},
+						},
+					},
+				}},
+			},
+		},
+		"query param regex match": {
+			route: &dag.Route{
+				PathMatchCondition: &dag.PrefixMatchCondition{
+					Prefix: "/",
+				},
+				QueryParamMatchConditions: []dag.QueryParamMatchCondition{{
+					Name:      "param",
+					Value:     "v[0-9]+",
+					MatchType: dag.QueryParamMatchTypeRegex,
+				}},
+			},
+			want: &envoy_route_v3.RouteMatch{
+				PathSpecifier: &envoy_route_v3.RouteMatch_Prefix{
+					Prefix: "/",
+				},
+				QueryParameters: []*envoy_route_v3.QueryParameterMatcher{{
+					Name: "param",
+					QueryParameterMatchSpecifier: &envoy_route_v3.QueryParameterMatcher_StringMatch{
+						StringMatch: &matcher.StringMatcher{
+							MatchPattern: &matcher.StringMatcher_SafeRegex{SafeRegex: SafeRegexMatch("v[0-9]+")},
+						},
+					},
+				}},
+			},
+		},
+		"query param present match": {
+			route: &dag.Route{
+				PathMatchCondition: &dag.ExactMatchCondition{
+					Path: "/foo",
+				},
+				QueryParamMatchConditions: []dag.QueryParamMatchCondition{{
+					Name:      "param",
+					MatchType: dag.QueryParamMatchTypePresent,
+				}},
+			},
+			want: &envoy_route_v3.RouteMatch{
+				PathSpecifier: &envoy_route_v3.RouteMatch_Path{
+					Path: "/foo",
+				},
+				QueryParameters: []*envoy_route_v3.QueryParameterMatcher{{
+					Name: "param",
+					QueryParameterMatchSpecifier: &envoy_route_v3.QueryParameterMatcher_PresentMatch{
+						PresentMatch: true,
+					},
+				}},
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -0,0 +1,93 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	envoy_config_trace_v3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_type_tracing_v3 "github.com/envoyproxy/go-control-plane/envoy/type/tracing/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/projectcontour/contour/internal/dag"
+	"github.com/projectcontour/contour/internal/protobuf"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TracingConfig stores configuration for exporting the spans of
+// an HTTP connection manager to a Zipkin collector.
+type TracingConfig struct {
+	ExtensionService  types.NamespacedName
+	CollectorEndpoint string
+	OverallSampling   float64
+	MaxPathTagLength  uint32
+	CustomTags        []*CustomTag
+}
+
+// CustomTag is a span tag set to either a literal
+// or the value of a request header.
+type CustomTag struct {
+	TagName           string
+	Literal           string
+	RequestHeaderName string
+}
+
+// TracingConfigFor returns the HTTP connection manager tracing
+// configuration, or nil if config is nil.
+func TracingConfigFor(config *TracingConfig) *http.HttpConnectionManager_Tracing {
+	if config == nil {
+		return nil
+	}
+
+	var customTags []*envoy_type_tracing_v3.CustomTag
+	for _, tag := range config.CustomTags {
+		switch {
+		case tag.Literal != "":
+			customTags = append(customTags, &envoy_type_tracing_v3.CustomTag{
+				Tag: tag.TagName,
+				Type: &envoy_type_tracing_v3.CustomTag_Literal_{
+					Literal: &envoy_type_tracing_v3.CustomTag_Literal{
+						Value: tag.Literal,
+					},
+				},
+			})
+		case tag.RequestHeaderName != "":
+			customTags = append(customTags, &envoy_type_tracing_v3.CustomTag{
+				Tag: tag.TagName,
+				Type: &envoy_type_tracing_v3.CustomTag_RequestHeader{
+					RequestHeader: &envoy_type_tracing_v3.CustomTag_Header{
+						Name: tag.RequestHeaderName,
+					},
+				},
+			})
+		}
+	}
+
+	return &http.HttpConnectionManager_Tracing{
+		OverallSampling: &envoy_type_v3.Percent{
+			Value: config.OverallSampling,
+		},
+		MaxPathTagLength: protobuf.UInt32OrNil(config.MaxPathTagLength),
+		CustomTags:       customTags,
+		Provider: &envoy_config_trace_v3.Tracing_Http{
+			Name: wellknown.Zipkin,
+			ConfigType: &envoy_config_trace_v3.Tracing_Http_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(&envoy_config_trace_v3.ZipkinConfig{
+					CollectorCluster:         dag.ExtensionClusterName(config.ExtensionService),
+					CollectorEndpoint:        config.CollectorEndpoint,
+					CollectorEndpointVersion: envoy_config_trace_v3.ZipkinConfig_HTTP_JSON,
+				}),
+			},
+		},
+	}
+}
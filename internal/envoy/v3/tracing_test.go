@@ -0,0 +1,85 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_config_trace_v3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_type_tracing_v3 "github.com/envoyproxy/go-control-plane/envoy/type/tracing/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/projectcontour/contour/internal/k8s"
+	"github.com/projectcontour/contour/internal/protobuf"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestTracingConfigFor(t *testing.T) {
+	tests := map[string]struct {
+		cfg  *TracingConfig
+		want *http.HttpConnectionManager_Tracing
+	}{
+		"nil config produces nil tracing": {
+			cfg:  nil,
+			want: nil,
+		},
+		"all fields configured": {
+			cfg: &TracingConfig{
+				ExtensionService:  k8s.NamespacedNameFrom("projectcontour/zipkin"),
+				CollectorEndpoint: "/api/v2/spans",
+				OverallSampling:   12.5,
+				MaxPathTagLength:  128,
+				CustomTags: []*CustomTag{
+					{TagName: "cluster", Literal: "production"},
+					{TagName: "user-agent", RequestHeaderName: "User-Agent"},
+				},
+			},
+			want: &http.HttpConnectionManager_Tracing{
+				OverallSampling:  &envoy_type_v3.Percent{Value: 12.5},
+				MaxPathTagLength: wrapperspb.UInt32(128),
+				CustomTags: []*envoy_type_tracing_v3.CustomTag{
+					{
+						Tag: "cluster",
+						Type: &envoy_type_tracing_v3.CustomTag_Literal_{
+							Literal: &envoy_type_tracing_v3.CustomTag_Literal{Value: "production"},
+						},
+					},
+					{
+						Tag: "user-agent",
+						Type: &envoy_type_tracing_v3.CustomTag_RequestHeader{
+							RequestHeader: &envoy_type_tracing_v3.CustomTag_Header{Name: "User-Agent"},
+						},
+					},
+				},
+				Provider: &envoy_config_trace_v3.Tracing_Http{
+					Name: wellknown.Zipkin,
+					ConfigType: &envoy_config_trace_v3.Tracing_Http_TypedConfig{
+						TypedConfig: protobuf.MustMarshalAny(&envoy_config_trace_v3.ZipkinConfig{
+							CollectorCluster:         "extension/projectcontour/zipkin",
+							CollectorEndpoint:        "/api/v2/spans",
+							CollectorEndpointVersion: envoy_config_trace_v3.ZipkinConfig_HTTP_JSON,
+						}),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			protobuf.ExpectEqual(t, tc.want, TracingConfigFor(tc.cfg))
+		})
+	}
+}
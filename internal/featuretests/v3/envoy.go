@@ -26,8 +26,10 @@ import (
 	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_config_filter_http_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_jwt_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoy_tcp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	envoy_udp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
 	envoy_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_extensions_upstream_http_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
@@ -118,6 +120,15 @@ func routePrefix(prefix string, headers ...dag.HeaderMatchCondition) *envoy_rout
 	})
 }
 
+func routeExact(path string, headers ...dag.HeaderMatchCondition) *envoy_route_v3.RouteMatch {
+	return envoy_v3.RouteMatch(&dag.Route{
+		PathMatchCondition: &dag.ExactMatchCondition{
+			Path: path,
+		},
+		HeaderMatchConditions: headers,
+	})
+}
+
 func routeSegmentPrefix(prefix string) *envoy_route_v3.RouteMatch {
 	return &envoy_route_v3.RouteMatch{
 		PathSpecifier: &envoy_route_v3.RouteMatch_SafeRegex{
@@ -419,6 +430,28 @@ func authzFilterFor(
 		Get()
 }
 
+// jwtAuthnFilterFor does the same as httpsFilterFor but inserts a
+// `jwt_authn` filter with the specified configuration into the
+// filter chain.
+func jwtAuthnFilterFor(
+	vhost string,
+	jwt *envoy_jwt_v3.JwtAuthentication,
+) *envoy_listener_v3.Filter {
+	return envoy_v3.HTTPConnectionManagerBuilder().
+		AddFilter(envoy_v3.FilterMisdirectedRequests(vhost)).
+		DefaultFilters().
+		AddFilter(&http.HttpFilter{
+			Name: "envoy.filters.http.jwt_authn",
+			ConfigType: &http.HttpFilter_TypedConfig{
+				TypedConfig: protobuf.MustMarshalAny(jwt),
+			},
+		}).
+		RouteConfigName(path.Join("https", vhost)).
+		MetricsPrefix(xdscache_v3.ENVOY_HTTPS_LISTENER).
+		AccessLoggers(envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil)).
+		Get()
+}
+
 func tcpproxy(statPrefix, cluster string) *envoy_listener_v3.Filter {
 	return &envoy_listener_v3.Filter{
 		Name: wellknown.TCPProxy,
@@ -464,6 +497,20 @@ func tcpproxyWeighted(statPrefix string, clusters ...clusterWeight) *envoy_liste
 	}
 }
 
+func udpproxy(statPrefix, cluster string) *envoy_listener_v3.ListenerFilter {
+	return &envoy_listener_v3.ListenerFilter{
+		Name: envoy_v3.UDPProxyFilter,
+		ConfigType: &envoy_listener_v3.ListenerFilter_TypedConfig{
+			TypedConfig: protobuf.MustMarshalAny(&envoy_udp_proxy_v3.UdpProxyConfig{
+				StatPrefix: statPrefix,
+				RouteSpecifier: &envoy_udp_proxy_v3.UdpProxyConfig_Cluster{
+					Cluster: cluster,
+				},
+			}),
+		},
+	}
+}
+
 func statsListener() *envoy_listener_v3.Listener {
 	// Single listener with metrics and health endpoints.
 	listeners := envoy_v3.StatsListeners(
@@ -29,6 +29,36 @@ func prefixMatchCondition(prefix string) contour_api_v1.MatchCondition {
 	}
 }
 
+func exactMatchCondition(path string) contour_api_v1.MatchCondition {
+	return contour_api_v1.MatchCondition{
+		Exact: path,
+	}
+}
+
+func regexMatchCondition(regex string) contour_api_v1.MatchCondition {
+	return contour_api_v1.MatchCondition{
+		Regex: regex,
+	}
+}
+
+func queryParamExactMatchCondition(name, value string) contour_api_v1.MatchCondition {
+	return contour_api_v1.MatchCondition{
+		QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+			Name:  name,
+			Exact: value,
+		},
+	}
+}
+
+func queryParamPresentMatchCondition(name string) contour_api_v1.MatchCondition {
+	return contour_api_v1.MatchCondition{
+		QueryParameter: &contour_api_v1.QueryParameterMatchCondition{
+			Name:    name,
+			Present: true,
+		},
+	}
+}
+
 func headerContainsMatchCondition(name, value string) contour_api_v1.MatchCondition {
 	return contour_api_v1.MatchCondition{
 		Header: &contour_api_v1.HeaderMatchCondition{
@@ -26,6 +26,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
@@ -68,7 +69,7 @@ var (
 					Port:     443,
 					Protocol: gatewayapi_v1alpha2.HTTPSProtocolType,
 					TLS: &gatewayapi_v1alpha2.GatewayTLSConfig{
-						CertificateRefs: []*gatewayapi_v1alpha2.SecretObjectReference{
+						CertificateRefs: []gatewayapi_v1alpha2.SecretObjectReference{
 							gatewayapi.CertificateRef("tlscert", ""),
 						},
 					},
@@ -121,7 +122,7 @@ func TestGateway_TLS(t *testing.T) {
 		},
 		Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-				ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 					gatewayapi.GatewayParentRef("projectcontour", "contour"),
 				},
 			},
@@ -185,3 +186,182 @@ func TestGateway_TLS(t *testing.T) {
 		),
 	})
 }
+
+func TestGateway_ListenerPorts(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	rh.OnAdd(fixture.NewService("svc1").
+		WithPorts(v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}),
+	)
+
+	rh.OnAdd(gc)
+
+	rh.OnAdd(&gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: gatewayapi_v1alpha2.ObjectName(gc.Name),
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Name:     "http-alt",
+				Port:     8081,
+				Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	})
+
+	rh.OnAdd(&gatewayapi_v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Hostnames: []gatewayapi_v1alpha2.Hostname{
+				"test.projectcontour.io",
+			},
+			Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+				Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+				BackendRefs: gatewayapi.HTTPBackendRef("svc1", 80, 1),
+			}},
+		},
+	})
+
+	// The routes are served from a route configuration
+	// for the listener rather than the default one.
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+			envoy_v3.RouteConfiguration("ingress_http_8081",
+				envoy_v3.VirtualHost("test.projectcontour.io",
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: routeCluster("default/svc1/80/da39a3ee5e"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	})
+
+	// The listener is bound to the Gateway listener's port.
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			&envoy_listener_v3.Listener{
+				Name:    "ingress_http_8081",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8081),
+				FilterChains: envoy_v3.FilterChains(
+					envoy_v3.HTTPConnectionManager("ingress_http_8081", envoy_v3.FileAccessLogEnvoy("/dev/stdout", "", nil), 0),
+				),
+				SocketOptions: envoy_v3.TCPKeepaliveSocketOptions(),
+			},
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+}
+
+func TestGateway_RegexMatches(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	rh.OnAdd(fixture.NewService("svc1").
+		WithPorts(v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}),
+	)
+
+	rh.OnAdd(gc)
+
+	rh.OnAdd(&gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: gatewayapi_v1alpha2.ObjectName(gc.Name),
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Port:     80,
+				Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	})
+
+	route := &gatewayapi_v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Hostnames: []gatewayapi_v1alpha2.Hostname{
+				"test.projectcontour.io",
+			},
+			Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+				Matches: []gatewayapi_v1alpha2.HTTPRouteMatch{{
+					Path: &gatewayapi_v1alpha2.HTTPPathMatch{
+						Type:  gatewayapi.PathMatchTypePtr(gatewayapi_v1alpha2.PathMatchRegularExpression),
+						Value: pointer.StringPtr("/v[0-9]+/.*"),
+					},
+					Headers: gatewayapi.HTTPHeaderMatch(gatewayapi_v1alpha2.HeaderMatchRegularExpression, "x-version", "v[12]"),
+				}},
+				BackendRefs: gatewayapi.HTTPBackendRef("svc1", 80, 1),
+			}},
+		},
+	}
+	rh.OnAdd(route)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("test.projectcontour.io",
+					&envoy_route_v3.Route{
+						Match: &envoy_route_v3.RouteMatch{
+							PathSpecifier: &envoy_route_v3.RouteMatch_SafeRegex{
+								SafeRegex: envoy_v3.SafeRegexMatch("^/v[0-9]+/.*"),
+							},
+							Headers: []*envoy_route_v3.HeaderMatcher{{
+								Name: "x-version",
+								HeaderMatchSpecifier: &envoy_route_v3.HeaderMatcher_SafeRegexMatch{
+									SafeRegexMatch: envoy_v3.SafeRegexMatch("v[12]"),
+								},
+							}},
+						},
+						Action: routeCluster("default/svc1/80/da39a3ee5e"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	})
+
+	// An invalid regular expression must not be programmed into Envoy.
+	invalid := route.DeepCopy()
+	invalid.Spec.Rules[0].Matches[0].Path.Value = pointer.StringPtr("/v[0-9+/.*")
+	rh.OnUpdate(route, invalid)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+		),
+		TypeUrl: routeType,
+	})
+}
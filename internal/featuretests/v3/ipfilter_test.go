@@ -0,0 +1,152 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_rbac_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	envoy_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/protobuf"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func ipFilterConfig(action envoy_config_rbac_v3.RBAC_Action, principals ...*envoy_config_rbac_v3.Principal) *envoy_rbac_v3.RBACPerRoute {
+	return &envoy_rbac_v3.RBACPerRoute{
+		Rbac: &envoy_rbac_v3.RBAC{
+			Rules: &envoy_config_rbac_v3.RBAC{
+				Action: action,
+				Policies: map[string]*envoy_config_rbac_v3.Policy{
+					"ip-rules": {
+						Permissions: []*envoy_config_rbac_v3.Permission{{
+							Rule: &envoy_config_rbac_v3.Permission_Any{Any: true},
+						}},
+						Principals: principals,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestIPFilterPolicy(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	rh.OnAdd(fixture.NewService("backend").
+		WithPorts(v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}))
+
+	proxy := fixture.NewProxy("simple").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{
+				Fqdn: "test1.test.com",
+				IPAllowFilterPolicy: []contour_api_v1.IPFilterPolicy{{
+					Source: contour_api_v1.IPFilterSourceRemote,
+					CIDR:   "10.8.8.0/24",
+				}},
+			},
+			Routes: []contour_api_v1.Route{{
+				Conditions: matchconditions(prefixMatchCondition("/admin")),
+				Services: []contour_api_v1.Service{{
+					Name: "backend",
+					Port: 80,
+				}},
+				IPDenyFilterPolicy: []contour_api_v1.IPFilterPolicy{{
+					Source: contour_api_v1.IPFilterSourcePeer,
+					CIDR:   "192.168.1.1",
+				}},
+			}, {
+				Conditions: matchconditions(prefixMatchCondition("/")),
+				Services: []contour_api_v1.Service{{
+					Name: "backend",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnAdd(proxy)
+
+	vhost := envoy_v3.VirtualHost("test1.test.com",
+		&envoy_route_v3.Route{
+			Match:  routePrefix("/admin"),
+			Action: routeCluster("default/backend/80/da39a3ee5e"),
+			TypedPerFilterConfig: withFilterConfig("envoy.filters.http.rbac",
+				ipFilterConfig(envoy_config_rbac_v3.RBAC_DENY, &envoy_config_rbac_v3.Principal{
+					Identifier: &envoy_config_rbac_v3.Principal_DirectRemoteIp{
+						DirectRemoteIp: &envoy_core_v3.CidrRange{
+							AddressPrefix: "192.168.1.1",
+							PrefixLen:     protobuf.UInt32(32),
+						},
+					},
+				})),
+		},
+		&envoy_route_v3.Route{
+			Match:  routePrefix("/"),
+			Action: routeCluster("default/backend/80/da39a3ee5e"),
+		},
+	)
+	vhost.TypedPerFilterConfig = withFilterConfig("envoy.filters.http.rbac",
+		ipFilterConfig(envoy_config_rbac_v3.RBAC_ALLOW, &envoy_config_rbac_v3.Principal{
+			Identifier: &envoy_config_rbac_v3.Principal_RemoteIp{
+				RemoteIp: &envoy_core_v3.CidrRange{
+					AddressPrefix: "10.8.8.0",
+					PrefixLen:     protobuf.UInt32(24),
+				},
+			},
+		}))
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http", vhost),
+		),
+		TypeUrl: routeType,
+	}).Status(proxy).IsValid()
+
+	// Specifying both an allow and a deny policy is invalid.
+	invalid := fixture.NewProxy("simple").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{
+				Fqdn: "test1.test.com",
+				IPAllowFilterPolicy: []contour_api_v1.IPFilterPolicy{{
+					Source: contour_api_v1.IPFilterSourceRemote,
+					CIDR:   "10.8.8.0/24",
+				}},
+				IPDenyFilterPolicy: []contour_api_v1.IPFilterPolicy{{
+					Source: contour_api_v1.IPFilterSourceRemote,
+					CIDR:   "10.8.8.8",
+				}},
+			},
+			Routes: []contour_api_v1.Route{{
+				Services: []contour_api_v1.Service{{
+					Name: "backend",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnUpdate(proxy, invalid)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+		),
+		TypeUrl: routeType,
+	}).Status(invalid).HasError(contour_api_v1.ConditionTypeVirtualHostError, "IPFilterPolicyNotValid",
+		"Spec.VirtualHost.IPFilterPolicy is invalid: cannot specify both ipAllowPolicy and ipDenyPolicy")
+}
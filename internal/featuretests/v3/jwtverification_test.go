@@ -0,0 +1,423 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	envoy_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_jwt_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	envoy_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/featuretests"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/protobuf"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const jwks = `{"keys":[{"kty":"oct","kid":"key","k":"c2VjcmV0"}]}`
+
+func requireProvider(name string) *envoy_jwt_v3.JwtRequirement {
+	return &envoy_jwt_v3.JwtRequirement{
+		RequiresType: &envoy_jwt_v3.JwtRequirement_ProviderName{
+			ProviderName: name,
+		},
+	}
+}
+
+func jwtRemoteJWKS(t *testing.T, rh cache.ResourceEventHandler, c *Contour) {
+	const fqdn = "jwt.projectcontour.io"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithSpec(contour_api_v1.HTTPProxySpec{
+			Routes: []contour_api_v1.Route{{
+				Conditions: matchconditions(prefixMatchCondition("/disabled")),
+				Services:   []contour_api_v1.Service{{Name: "app-server", Port: 80}},
+				JWTVerificationPolicy: &contour_api_v1.JWTVerificationPolicy{
+					Disabled: true,
+				},
+			}, {
+				Conditions: matchconditions(prefixMatchCondition("/")),
+				Services:   []contour_api_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	p.Spec.VirtualHost.JWTProviders = []contour_api_v1.JWTProvider{{
+		Name:      "provider-1",
+		Default:   true,
+		Issuer:    "issuer.jwt.example.com",
+		Audiences: []string{"aud1", "aud2"},
+		RemoteJWKS: &contour_api_v1.RemoteJWKS{
+			URI:           "https://jwt.example.com/jwks.json",
+			Timeout:       "5s",
+			CacheDuration: "1h",
+		},
+		ForwardJWT: true,
+	}}
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			defaultHTTPListener(),
+			&envoy_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_listener_v3.FilterChain{
+					filterchaintls(fqdn,
+						&corev1.Secret{
+							ObjectMeta: fixture.ObjectMeta("certificate"),
+							Type:       "kubernetes.io/tls",
+							Data:       featuretests.Secretdata(featuretests.CERTIFICATE, featuretests.RSA_PRIVATE_KEY),
+						},
+						jwtAuthnFilterFor(fqdn, &envoy_jwt_v3.JwtAuthentication{
+							Providers: map[string]*envoy_jwt_v3.JwtProvider{
+								"provider-1": {
+									Issuer:    "issuer.jwt.example.com",
+									Audiences: []string{"aud1", "aud2"},
+									JwksSourceSpecifier: &envoy_jwt_v3.JwtProvider_RemoteJwks{
+										RemoteJwks: &envoy_jwt_v3.RemoteJwks{
+											HttpUri: &envoy_core_v3.HttpUri{
+												Uri: "https://jwt.example.com/jwks.json",
+												HttpUpstreamType: &envoy_core_v3.HttpUri_Cluster{
+													Cluster: "dnsname/https/jwt.example.com/443",
+												},
+												Timeout: protobuf.Duration(5 * time.Second),
+											},
+											CacheDuration: protobuf.Duration(time.Hour),
+										},
+									},
+									Forward: true,
+								},
+							},
+							RequirementMap: map[string]*envoy_jwt_v3.JwtRequirement{
+								"provider-1": requireProvider("provider-1"),
+							},
+						}),
+						nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.TCPKeepaliveSocketOptions(),
+			},
+			statsListener()),
+	}).Status(p).IsValid()
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl: routeType,
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration(
+				path.Join("https", fqdn),
+				envoy_v3.VirtualHost(fqdn,
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/disabled"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+					},
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+						TypedPerFilterConfig: withFilterConfig("envoy.filters.http.jwt_authn",
+							&envoy_jwt_v3.PerRouteConfig{
+								RequirementSpecifier: &envoy_jwt_v3.PerRouteConfig_RequirementName{
+									RequirementName: "provider-1",
+								},
+							}),
+					},
+				),
+			),
+			envoy_v3.RouteConfiguration(
+				"ingress_http",
+				envoy_v3.VirtualHost(fqdn,
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/disabled"),
+						Action: withRedirect(),
+					},
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: withRedirect(),
+					},
+				),
+			),
+		),
+	})
+
+	c.Request(clusterType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl: clusterType,
+		Resources: resources(t,
+			cluster("default/app-server/80/da39a3ee5e", "default/app-server", "default_app-server_80"),
+			DefaultCluster(&envoy_cluster_v3.Cluster{
+				Name:                 "dnsname/https/jwt.example.com/443",
+				ClusterDiscoveryType: envoy_v3.ClusterDiscoveryType(envoy_cluster_v3.Cluster_STRICT_DNS),
+				LoadAssignment: envoy_v3.ClusterLoadAssignment(
+					"dnsname/https/jwt.example.com/443",
+					envoy_v3.SocketAddress("jwt.example.com", 443),
+				),
+				TransportSocket: envoy_v3.UpstreamTLSTransportSocket(
+					envoy_v3.UpstreamTLSContext(nil, "jwt.example.com", nil),
+				),
+			}),
+		),
+	})
+}
+
+func jwtLocalJWKS(t *testing.T, rh cache.ResourceEventHandler, c *Contour) {
+	const fqdn = "jwt.projectcontour.io"
+
+	rh.OnAdd(&corev1.Secret{
+		ObjectMeta: fixture.ObjectMeta("jwks"),
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"jwks": []byte(jwks),
+		},
+	})
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithSpec(contour_api_v1.HTTPProxySpec{
+			Routes: []contour_api_v1.Route{{
+				Services: []contour_api_v1.Service{{Name: "app-server", Port: 80}},
+				JWTVerificationPolicy: &contour_api_v1.JWTVerificationPolicy{
+					Require: "provider-1",
+				},
+			}},
+		})
+
+	p.Spec.VirtualHost.JWTProviders = []contour_api_v1.JWTProvider{{
+		Name: "provider-1",
+		LocalJWKS: &contour_api_v1.LocalJWKS{
+			SecretName: "jwks",
+		},
+	}}
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl: listenerType,
+		Resources: resources(t,
+			defaultHTTPListener(),
+			&envoy_listener_v3.Listener{
+				Name:    "ingress_https",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				FilterChains: []*envoy_listener_v3.FilterChain{
+					filterchaintls(fqdn,
+						&corev1.Secret{
+							ObjectMeta: fixture.ObjectMeta("certificate"),
+							Type:       "kubernetes.io/tls",
+							Data:       featuretests.Secretdata(featuretests.CERTIFICATE, featuretests.RSA_PRIVATE_KEY),
+						},
+						jwtAuthnFilterFor(fqdn, &envoy_jwt_v3.JwtAuthentication{
+							Providers: map[string]*envoy_jwt_v3.JwtProvider{
+								"provider-1": {
+									JwksSourceSpecifier: &envoy_jwt_v3.JwtProvider_LocalJwks{
+										LocalJwks: &envoy_core_v3.DataSource{
+											Specifier: &envoy_core_v3.DataSource_InlineString{
+												InlineString: jwks,
+											},
+										},
+									},
+								},
+							},
+							RequirementMap: map[string]*envoy_jwt_v3.JwtRequirement{
+								"provider-1": requireProvider("provider-1"),
+							},
+						}),
+						nil, "h2", "http/1.1"),
+				},
+				SocketOptions: envoy_v3.TCPKeepaliveSocketOptions(),
+			},
+			statsListener()),
+	}).Status(p).IsValid()
+
+	c.Request(clusterType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl: clusterType,
+		Resources: resources(t,
+			cluster("default/app-server/80/da39a3ee5e", "default/app-server", "default_app-server_80"),
+		),
+	})
+}
+
+func jwtRequiresTLS(t *testing.T, rh cache.ResourceEventHandler, c *Contour) {
+	p := fixture.NewProxy("proxy").
+		WithFQDN("jwt.projectcontour.io").
+		WithSpec(contour_api_v1.HTTPProxySpec{
+			Routes: []contour_api_v1.Route{{
+				Services: []contour_api_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	p.Spec.VirtualHost.JWTProviders = []contour_api_v1.JWTProvider{{
+		Name: "provider-1",
+		RemoteJWKS: &contour_api_v1.RemoteJWKS{
+			URI: "https://jwt.example.com/jwks.json",
+		},
+	}}
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_api_v1.ConditionTypeJWTVerificationError, "JWTVerificationNotPermitted", "Spec.VirtualHost.JWTProviders can only be defined for root HTTPProxies that terminate TLS")
+}
+
+func jwtFallbackIncompat(t *testing.T, rh cache.ResourceEventHandler, c *Contour) {
+	p := fixture.NewProxy("proxy").
+		WithFQDN("jwt.projectcontour.io").
+		WithCertificate("certificate").
+		WithSpec(contour_api_v1.HTTPProxySpec{
+			Routes: []contour_api_v1.Route{{
+				Services: []contour_api_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	p.Spec.VirtualHost.TLS.EnableFallbackCertificate = true
+	p.Spec.VirtualHost.JWTProviders = []contour_api_v1.JWTProvider{{
+		Name: "provider-1",
+		RemoteJWKS: &contour_api_v1.RemoteJWKS{
+			URI: "https://jwt.example.com/jwks.json",
+		},
+	}}
+
+	rh.OnAdd(p)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl:   listenerType,
+		Resources: resources(t, statsListener()),
+	}).Status(p).HasError(contour_api_v1.ConditionTypeTLSError, "TLSIncompatibleFeatures", "Spec.Virtualhost.TLS fallback & JWT verification are incompatible")
+}
+
+func jwtPermitInsecure(t *testing.T, rh cache.ResourceEventHandler, c *Contour) {
+	const fqdn = "jwt.projectcontour.io"
+
+	p := fixture.NewProxy("proxy").
+		WithFQDN(fqdn).
+		WithCertificate("certificate").
+		WithSpec(contour_api_v1.HTTPProxySpec{
+			Routes: []contour_api_v1.Route{{
+				Conditions:     matchconditions(prefixMatchCondition("/insecure")),
+				Services:       []contour_api_v1.Service{{Name: "app-server", Port: 80}},
+				PermitInsecure: true,
+			}, {
+				Conditions: matchconditions(prefixMatchCondition("/")),
+				Services:   []contour_api_v1.Service{{Name: "app-server", Port: 80}},
+			}},
+		})
+
+	p.Spec.VirtualHost.JWTProviders = []contour_api_v1.JWTProvider{{
+		Name:    "provider-1",
+		Default: true,
+		RemoteJWKS: &contour_api_v1.RemoteJWKS{
+			URI: "https://jwt.example.com/jwks.json",
+		},
+	}}
+
+	rh.OnAdd(p)
+
+	// The insecure route must not be served without verification.
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl: routeType,
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+		),
+	}).Status(p).HasError(contour_api_v1.ConditionTypeJWTVerificationError, "JWTVerificationNotPermitted", "route.permitInsecure cannot be used on a route that requires JWT verification")
+
+	// Disabling verification on the route allows it to be insecure.
+	p2 := p.DeepCopy()
+	p2.Spec.Routes[0].JWTVerificationPolicy = &contour_api_v1.JWTVerificationPolicy{
+		Disabled: true,
+	}
+	rh.OnUpdate(p, p2)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		TypeUrl: routeType,
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration(
+				path.Join("https", fqdn),
+				envoy_v3.VirtualHost(fqdn,
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/insecure"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+					},
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+						TypedPerFilterConfig: withFilterConfig("envoy.filters.http.jwt_authn",
+							&envoy_jwt_v3.PerRouteConfig{
+								RequirementSpecifier: &envoy_jwt_v3.PerRouteConfig_RequirementName{
+									RequirementName: "provider-1",
+								},
+							}),
+					},
+				),
+			),
+			envoy_v3.RouteConfiguration(
+				"ingress_http",
+				envoy_v3.VirtualHost(fqdn,
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/insecure"),
+						Action: routeCluster("default/app-server/80/da39a3ee5e"),
+					},
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: withRedirect(),
+					},
+				),
+			),
+		),
+	}).Status(p2).IsValid()
+}
+
+func TestJWTVerification(t *testing.T) {
+	subtests := map[string]func(*testing.T, cache.ResourceEventHandler, *Contour){
+		"RemoteJWKS":       jwtRemoteJWKS,
+		"LocalJWKS":        jwtLocalJWKS,
+		"RequiresTLS":      jwtRequiresTLS,
+		"FallbackIncompat": jwtFallbackIncompat,
+		"PermitInsecure":   jwtPermitInsecure,
+	}
+
+	for n, f := range subtests {
+		f := f
+		t.Run(n, func(t *testing.T) {
+			rh, c, done := setup(t)
+			defer done()
+
+			rh.OnAdd(fixture.NewService("app-server").
+				WithPorts(corev1.ServicePort{Port: 80}))
+
+			rh.OnAdd(&corev1.Secret{
+				ObjectMeta: fixture.ObjectMeta("certificate"),
+				Type:       "kubernetes.io/tls",
+				Data:       featuretests.Secretdata(featuretests.CERTIFICATE, featuretests.RSA_PRIVATE_KEY),
+			})
+
+			f(t, rh, c)
+		})
+	}
+}
@@ -16,15 +16,20 @@ package v3
 import (
 	"testing"
 
+	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	"github.com/projectcontour/contour/internal/contour"
 	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
 	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/gatewayapi"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 func TestMirrorPolicy(t *testing.T) {
@@ -84,3 +89,140 @@ func TestMirrorPolicy(t *testing.T) {
 		TypeUrl: clusterType,
 	})
 }
+
+func TestMirrorPolicy_HTTPRoute(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	svc1 := fixture.NewService("kuard").
+		WithPorts(v1.ServicePort{Port: 8080, TargetPort: intstr.FromInt(8080)})
+	svc2 := fixture.NewService("mirror").
+		WithPorts(v1.ServicePort{Port: 8080, TargetPort: intstr.FromInt(8080)})
+	svc3 := fixture.NewService("kuard2").
+		WithPorts(v1.ServicePort{Port: 8080, TargetPort: intstr.FromInt(8080)})
+	rh.OnAdd(svc1)
+	rh.OnAdd(svc2)
+	rh.OnAdd(svc3)
+
+	rh.OnAdd(gc)
+	rh.OnAdd(&gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: gatewayapi_v1alpha2.ObjectName(gc.Name),
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Port:     80,
+				Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	})
+
+	route := &gatewayapi_v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: svc1.Namespace,
+		},
+		Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Hostnames: []gatewayapi_v1alpha2.Hostname{
+				"example.com",
+			},
+			Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+				Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/"),
+				BackendRefs: gatewayapi.HTTPBackendRef(svc1.Name, 8080, 1),
+				Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+					Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+						BackendRef: gatewayapi.ServiceBackendObjectRef(svc2.Name, 8080),
+					},
+				}},
+			}},
+		},
+	}
+	rh.OnAdd(route)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("example.com",
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: withMirrorPolicy(routeCluster("default/kuard/8080/da39a3ee5e"), "default/mirror/8080/da39a3ee5e"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	})
+
+	// assert that are two clusters in CDS, one for the route service
+	// and one for the mirror service.
+	c.Request(clusterType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			cluster("default/kuard/8080/da39a3ee5e", "default/kuard", "default_kuard_8080"),
+			cluster("default/mirror/8080/da39a3ee5e", "default/mirror", "default_mirror_8080"),
+		),
+		TypeUrl: clusterType,
+	})
+	// A mirror on one of several backendRefs mirrors that
+	// backendRef's share of the requests.
+	route2 := route.DeepCopy()
+	route2.Spec.Rules[0].Filters = nil
+	route2.Spec.Rules[0].BackendRefs = []gatewayapi_v1alpha2.HTTPBackendRef{{
+		BackendRef: gatewayapi_v1alpha2.BackendRef{
+			BackendObjectReference: gatewayapi.ServiceBackendObjectRef(svc1.Name, 8080),
+			Weight:                 pointer.Int32(1),
+		},
+		Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+			Type: gatewayapi_v1alpha2.HTTPRouteFilterRequestMirror,
+			RequestMirror: &gatewayapi_v1alpha2.HTTPRequestMirrorFilter{
+				BackendRef: gatewayapi.ServiceBackendObjectRef(svc2.Name, 8080),
+			},
+		}},
+	}, {
+		BackendRef: gatewayapi_v1alpha2.BackendRef{
+			BackendObjectReference: gatewayapi.ServiceBackendObjectRef(svc3.Name, 8080),
+			Weight:                 pointer.Int32(3),
+		},
+	}}
+	rh.OnUpdate(route, route2)
+
+	mirrored := routeWeightedCluster(
+		weightedCluster{"default/kuard/8080/da39a3ee5e", 1},
+		weightedCluster{"default/kuard2/8080/da39a3ee5e", 3},
+	)
+	mirrored.Route.RequestMirrorPolicies = []*envoy_route_v3.RouteAction_RequestMirrorPolicy{{
+		Cluster: "default/mirror/8080/da39a3ee5e",
+		RuntimeFraction: &envoy_core_v3.RuntimeFractionalPercent{
+			DefaultValue: &envoy_type_v3.FractionalPercent{
+				Numerator:   250000,
+				Denominator: envoy_type_v3.FractionalPercent_MILLION,
+			},
+		},
+	}}
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("example.com",
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: mirrored,
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	})
+}
@@ -0,0 +1,246 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestConditions_ExactAndRegex(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	rh.OnAdd(fixture.NewService("svc1").
+		WithPorts(v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}))
+	rh.OnAdd(fixture.NewService("svc2").
+		WithPorts(v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}))
+	rh.OnAdd(fixture.NewService("svc3").
+		WithPorts(v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)}))
+
+	proxy := fixture.NewProxy("simple").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{Fqdn: "hello.world"},
+			Routes: []contour_api_v1.Route{{
+				Conditions: matchconditions(exactMatchCondition("/healthz")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc1",
+					Port: 80,
+				}},
+			}, {
+				Conditions: matchconditions(regexMatchCondition("/api/v[0-9]+/.*")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc2",
+					Port: 80,
+				}},
+			}, {
+				Conditions: matchconditions(prefixMatchCondition("/")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc3",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnAdd(proxy)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("hello.world",
+					&envoy_route_v3.Route{
+						Match:  routeExact("/healthz"),
+						Action: routeCluster("default/svc1/80/da39a3ee5e"),
+					},
+					&envoy_route_v3.Route{
+						Match:  routeRegex("/api/v[0-9]+/.*"),
+						Action: routeCluster("default/svc2/80/da39a3ee5e"),
+					},
+					&envoy_route_v3.Route{
+						Match:  routePrefix("/"),
+						Action: routeCluster("default/svc3/80/da39a3ee5e"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	}).Status(proxy).IsValid()
+
+	// Exact and regex conditions on included routes are
+	// combined with the prefix of the include.
+	child := fixture.NewProxy("child").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			Routes: []contour_api_v1.Route{{
+				Conditions: matchconditions(exactMatchCondition("/healthz")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc1",
+					Port: 80,
+				}},
+			}, {
+				Conditions: matchconditions(regexMatchCondition("/v[0-9]+/.*")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc2",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnAdd(child)
+
+	root := fixture.NewProxy("simple").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{Fqdn: "hello.world"},
+			Includes: []contour_api_v1.Include{{
+				Name:       child.Name,
+				Conditions: matchconditions(prefixMatchCondition("/api.v1/")),
+			}},
+		})
+	rh.OnUpdate(proxy, root)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("hello.world",
+					&envoy_route_v3.Route{
+						Match:  routeExact("/api.v1/healthz"),
+						Action: routeCluster("default/svc1/80/da39a3ee5e"),
+					},
+					&envoy_route_v3.Route{
+						Match:  routeRegex(`/api\.v1(?:/v[0-9]+/.*)`),
+						Action: routeCluster("default/svc2/80/da39a3ee5e"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	}).Status(root).IsValid()
+
+	// An alternation in an included regex cannot match paths
+	// outside the prefix of the include.
+	escape := fixture.NewProxy("child").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			Routes: []contour_api_v1.Route{{
+				Conditions: matchconditions(regexMatchCondition("/x|/admin.*")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc2",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnUpdate(child, escape)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("hello.world",
+					&envoy_route_v3.Route{
+						Match:  routeRegex(`/api\.v1(?:/x|/admin.*)`),
+						Action: routeCluster("default/svc2/80/da39a3ee5e"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	}).Status(escape).IsValid()
+
+	// Anchors are not permitted in included regexes.
+	anchored := fixture.NewProxy("child").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			Routes: []contour_api_v1.Route{{
+				Conditions: matchconditions(regexMatchCondition("/x|^/admin.*")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc2",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnUpdate(escape, anchored)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+		),
+		TypeUrl: routeType,
+	}).Status(anchored).HasError(contour_api_v1.ConditionTypeRouteError, "PathMatchConditionsNotValid",
+		`route: regex condition "/x|^/admin.*" may not contain ^ or $ anchors in an included HTTPProxy`)
+	rh.OnUpdate(anchored, child)
+
+	// Exact and regex conditions are not permitted on includes.
+	invalid := fixture.NewProxy("simple").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{Fqdn: "hello.world"},
+			Includes: []contour_api_v1.Include{{
+				Name:       child.Name,
+				Conditions: matchconditions(exactMatchCondition("/api")),
+			}},
+		})
+	rh.OnUpdate(root, invalid)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+		),
+		TypeUrl: routeType,
+	}).Status(invalid).HasError(contour_api_v1.ConditionTypeIncludeError, "PathMatchConditionsNotValid",
+		"include: exact and regex conditions are not allowed in an include condition block")
+
+	// A route may only have one prefix, exact or regex condition.
+	conflicting := fixture.NewProxy("simple").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{Fqdn: "hello.world"},
+			Routes: []contour_api_v1.Route{{
+				Conditions: matchconditions(prefixMatchCondition("/api"), exactMatchCondition("/api")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc1",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnUpdate(invalid, conflicting)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+		),
+		TypeUrl: routeType,
+	}).Status(conflicting).HasError(contour_api_v1.ConditionTypeRouteError, "PathMatchConditionsNotValid",
+		"route: more than one prefix, exact or regex condition is not allowed in a condition block")
+
+	// A regex that cannot match a path starting with / is rejected.
+	unmatchable := fixture.NewProxy("simple").WithSpec(
+		contour_api_v1.HTTPProxySpec{
+			VirtualHost: &contour_api_v1.VirtualHost{Fqdn: "hello.world"},
+			Routes: []contour_api_v1.Route{{
+				Conditions: matchconditions(regexMatchCondition("api/.*")),
+				Services: []contour_api_v1.Service{{
+					Name: "svc1",
+					Port: 80,
+				}},
+			}},
+		})
+	rh.OnUpdate(conflicting, unmatchable)
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http"),
+		),
+		TypeUrl: routeType,
+	}).Status(unmatchable).HasError(contour_api_v1.ConditionTypeRouteError, "PathMatchConditionsNotValid",
+		`route: regex condition "api/.*" cannot match any path, paths must start with /`)
+}
@@ -0,0 +1,170 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/gatewayapi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestTCPRoute(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	svc1 := fixture.NewService("backend-1").
+		WithPorts(v1.ServicePort{Port: 5432, TargetPort: intstr.FromInt(5432)})
+	svc2 := fixture.NewService("backend-2").
+		WithPorts(v1.ServicePort{Port: 5432, TargetPort: intstr.FromInt(5432)})
+
+	rh.OnAdd(svc1)
+	rh.OnAdd(svc2)
+
+	rh.OnAdd(&gatewayapi_v1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-gc",
+		},
+		Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+			ControllerName: "projectcontour.io/contour",
+		},
+		Status: gatewayapi_v1alpha2.GatewayClassStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(gatewayapi_v1alpha2.GatewayClassConditionStatusAccepted),
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+	})
+
+	rh.OnAdd(&gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Name:     "postgres",
+				Port:     5432,
+				Protocol: gatewayapi_v1alpha2.TCPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	})
+
+	route1 := &gatewayapi_v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "postgres",
+			Namespace: "default",
+		},
+		Spec: gatewayapi_v1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Rules: []gatewayapi_v1alpha2.TCPRouteRule{{
+				BackendRefs: gatewayapi.TCPRouteBackendRef("backend-1", 5432, nil),
+			}},
+		},
+	}
+
+	rh.OnAdd(route1)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			&envoy_listener_v3.Listener{
+				Name:    "ingress_tcp_5432",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 5432),
+				FilterChains: envoy_v3.FilterChains(
+					tcpproxy("ingress_tcp_5432", "default/backend-1/5432/da39a3ee5e"),
+				),
+				SocketOptions: envoy_v3.TCPKeepaliveSocketOptions(),
+			},
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+
+	c.Request(clusterType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			cluster("default/backend-1/5432/da39a3ee5e", "default/backend-1", "default_backend-1_5432"),
+		),
+		TypeUrl: clusterType,
+	})
+
+	// Split traffic between two backends.
+	route2 := &gatewayapi_v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "postgres",
+			Namespace: "default",
+		},
+		Spec: gatewayapi_v1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Rules: []gatewayapi_v1alpha2.TCPRouteRule{{
+				BackendRefs: gatewayapi.TLSRouteBackendRefs(
+					gatewayapi.TCPRouteBackendRef("backend-1", 5432, pointer.Int32(1)),
+					gatewayapi.TCPRouteBackendRef("backend-2", 5432, pointer.Int32(3)),
+				),
+			}},
+		},
+	}
+
+	rh.OnUpdate(route1, route2)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			&envoy_listener_v3.Listener{
+				Name:    "ingress_tcp_5432",
+				Address: envoy_v3.SocketAddress("0.0.0.0", 5432),
+				FilterChains: envoy_v3.FilterChains(
+					tcpproxyWeighted("ingress_tcp_5432",
+						clusterWeight{name: "default/backend-1/5432/da39a3ee5e", weight: 1},
+						clusterWeight{name: "default/backend-2/5432/da39a3ee5e", weight: 3},
+					),
+				),
+				SocketOptions: envoy_v3.TCPKeepaliveSocketOptions(),
+			},
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+
+	// Removing the route removes the listener.
+	rh.OnDelete(route2)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/gatewayapi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestUDPRoute(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	svc1 := fixture.NewService("backend-1").
+		WithPorts(v1.ServicePort{Protocol: v1.ProtocolUDP, Port: 53, TargetPort: intstr.FromInt(5353)})
+	svc2 := fixture.NewService("backend-2").
+		WithPorts(v1.ServicePort{Protocol: v1.ProtocolUDP, Port: 53, TargetPort: intstr.FromInt(5353)})
+
+	rh.OnAdd(svc1)
+	rh.OnAdd(svc2)
+
+	rh.OnAdd(&gatewayapi_v1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-gc",
+		},
+		Spec: gatewayapi_v1alpha2.GatewayClassSpec{
+			ControllerName: "projectcontour.io/contour",
+		},
+		Status: gatewayapi_v1alpha2.GatewayClassStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(gatewayapi_v1alpha2.GatewayClassConditionStatusAccepted),
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+	})
+
+	rh.OnAdd(&gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Name:     "dns",
+				Port:     53,
+				Protocol: gatewayapi_v1alpha2.UDPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	})
+
+	route1 := &gatewayapi_v1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dns",
+			Namespace: "default",
+		},
+		Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+				BackendRefs: gatewayapi.UDPRouteBackendRef("backend-1", 53, nil),
+			}},
+		},
+	}
+
+	rh.OnAdd(route1)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			&envoy_listener_v3.Listener{
+				Name:            "ingress_udp_53",
+				Address:         envoy_v3.UDPSocketAddress("0.0.0.0", 53),
+				ListenerFilters: envoy_v3.ListenerFilters(udpproxy("ingress_udp_53", "default/backend-1/53/da39a3ee5e")),
+			},
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+
+	c.Request(clusterType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			cluster("default/backend-1/53/da39a3ee5e", "default/backend-1", "default_backend-1_53"),
+		),
+		TypeUrl: clusterType,
+	})
+
+	// Move the route to a different backend.
+	route2 := &gatewayapi_v1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dns",
+			Namespace: "default",
+		},
+		Spec: gatewayapi_v1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Rules: []gatewayapi_v1alpha2.UDPRouteRule{{
+				BackendRefs: gatewayapi.UDPRouteBackendRef("backend-2", 53, nil),
+			}},
+		},
+	}
+
+	rh.OnUpdate(route1, route2)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			&envoy_listener_v3.Listener{
+				Name:            "ingress_udp_53",
+				Address:         envoy_v3.UDPSocketAddress("0.0.0.0", 53),
+				ListenerFilters: envoy_v3.ListenerFilters(udpproxy("ingress_udp_53", "default/backend-2/53/da39a3ee5e")),
+			},
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+
+	c.Request(clusterType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			cluster("default/backend-2/53/da39a3ee5e", "default/backend-2", "default_backend-2_53"),
+		),
+		TypeUrl: clusterType,
+	})
+
+	// Removing the route removes the listener.
+	rh.OnDelete(route2)
+
+	c.Request(listenerType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			statsListener(),
+		),
+		TypeUrl: listenerType,
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"testing"
+
+	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
+	"github.com/projectcontour/contour/internal/fixture"
+	"github.com/projectcontour/contour/internal/gatewayapi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func withRegexRewrite(route *envoy_route_v3.Route_Route, pattern, substitution string) *envoy_route_v3.Route_Route {
+	route.Route.RegexRewrite = &matcher.RegexMatchAndSubstitute{
+		Pattern:      envoy_v3.SafeRegexMatch(pattern),
+		Substitution: substitution,
+	}
+	return route
+}
+
+func TestURLRewrite_HTTPRoute(t *testing.T) {
+	rh, c, done := setup(t)
+	defer done()
+
+	svc1 := fixture.NewService("kuard").
+		WithPorts(v1.ServicePort{Port: 8080, TargetPort: intstr.FromInt(8080)})
+	rh.OnAdd(svc1)
+
+	rh.OnAdd(gc)
+	rh.OnAdd(&gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "contour",
+			Namespace: "projectcontour",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: gatewayapi_v1alpha2.ObjectName(gc.Name),
+			Listeners: []gatewayapi_v1alpha2.Listener{{
+				Port:     80,
+				Protocol: gatewayapi_v1alpha2.HTTPProtocolType,
+				AllowedRoutes: &gatewayapi_v1alpha2.AllowedRoutes{
+					Namespaces: &gatewayapi_v1alpha2.RouteNamespaces{
+						From: gatewayapi.FromNamespacesPtr(gatewayapi_v1alpha2.NamespacesFromAll),
+					},
+				},
+			}},
+		},
+	})
+
+	rh.OnAdd(&gatewayapi_v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: svc1.Namespace,
+		},
+		Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
+				ParentRefs: []gatewayapi_v1alpha2.ParentReference{
+					gatewayapi.GatewayParentRef("projectcontour", "contour"),
+				},
+			},
+			Hostnames: []gatewayapi_v1alpha2.Hostname{
+				"example.com",
+			},
+			Rules: []gatewayapi_v1alpha2.HTTPRouteRule{{
+				Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/prefix/one"),
+				BackendRefs: gatewayapi.HTTPBackendRef(svc1.Name, 8080, 1),
+				Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+					Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+					URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+						Hostname: gatewayapi.PreciseHostname("one.example.org"),
+						Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+							Type:               gatewayapi_v1alpha2.PrefixMatchHTTPPathModifier,
+							ReplacePrefixMatch: pointer.String("/one"),
+						},
+					},
+				}},
+			}, {
+				Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchPathPrefix, "/strip-prefix"),
+				BackendRefs: gatewayapi.HTTPBackendRef(svc1.Name, 8080, 1),
+				Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+					Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+					URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+						Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+							Type:               gatewayapi_v1alpha2.PrefixMatchHTTPPathModifier,
+							ReplacePrefixMatch: pointer.String("/"),
+						},
+					},
+				}},
+			}, {
+				Matches:     gatewayapi.HTTPRouteMatch(gatewayapi_v1alpha2.PathMatchExact, "/full/path"),
+				BackendRefs: gatewayapi.HTTPBackendRef(svc1.Name, 8080, 1),
+				Filters: []gatewayapi_v1alpha2.HTTPRouteFilter{{
+					Type: gatewayapi_v1alpha2.HTTPRouteFilterURLRewrite,
+					URLRewrite: &gatewayapi_v1alpha2.HTTPURLRewriteFilter{
+						Path: &gatewayapi_v1alpha2.HTTPPathModifier{
+							Type:            gatewayapi_v1alpha2.FullPathHTTPPathModifier,
+							ReplaceFullPath: pointer.String("/one"),
+						},
+					},
+				}},
+			}},
+		},
+	})
+
+	c.Request(routeType).Equals(&envoy_discovery_v3.DiscoveryResponse{
+		Resources: resources(t,
+			envoy_v3.RouteConfiguration("ingress_http",
+				envoy_v3.VirtualHost("example.com",
+					&envoy_route_v3.Route{
+						Match:  routeExact("/full/path"),
+						Action: withRegexRewrite(routeCluster("default/kuard/8080/da39a3ee5e"), "^/.*$", "/one"),
+					},
+					&envoy_route_v3.Route{
+						Match:  routeSegmentPrefix("/strip-prefix"),
+						Action: withRegexRewrite(routeCluster("default/kuard/8080/da39a3ee5e"), "^/strip-prefix/*", "/"),
+					},
+					&envoy_route_v3.Route{
+						Match:  routeSegmentPrefix("/prefix/one"),
+						Action: withRegexRewrite(routeHostRewrite("default/kuard/8080/da39a3ee5e", "one.example.org"), "^/prefix/one", "/one"),
+					},
+				),
+			),
+		),
+		TypeUrl: routeType,
+	})
+}
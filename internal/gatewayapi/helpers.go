@@ -40,6 +40,10 @@ func HeaderMatchTypePtr(val gatewayapi_v1alpha2.HeaderMatchType) *gatewayapi_v1a
 	return &val
 }
 
+func QueryParamMatchTypePtr(val gatewayapi_v1alpha2.QueryParamMatchType) *gatewayapi_v1alpha2.QueryParamMatchType {
+	return &val
+}
+
 func TLSModeTypePtr(mode gatewayapi_v1alpha2.TLSModeType) *gatewayapi_v1alpha2.TLSModeType {
 	return &mode
 }
@@ -62,8 +66,8 @@ func PreciseHostname(host string) *gatewayapi_v1alpha2.PreciseHostname {
 	return &h
 }
 
-func CertificateRef(name, namespace string) *gatewayapi_v1alpha2.SecretObjectReference {
-	ref := &gatewayapi_v1alpha2.SecretObjectReference{
+func CertificateRef(name, namespace string) gatewayapi_v1alpha2.SecretObjectReference {
+	ref := gatewayapi_v1alpha2.SecretObjectReference{
 		Group: GroupPtr(""),
 		Kind:  KindPtr("Secret"),
 		Name:  gatewayapi_v1alpha2.ObjectName(name),
@@ -76,8 +80,8 @@ func CertificateRef(name, namespace string) *gatewayapi_v1alpha2.SecretObjectRef
 	return ref
 }
 
-func GatewayParentRef(namespace, name string) gatewayapi_v1alpha2.ParentRef {
-	parentRef := gatewayapi_v1alpha2.ParentRef{
+func GatewayParentRef(namespace, name string) gatewayapi_v1alpha2.ParentReference {
+	parentRef := gatewayapi_v1alpha2.ParentReference{
 		Group: GroupPtr(gatewayapi_v1alpha2.GroupName),
 		Kind:  KindPtr("Gateway"),
 		Name:  gatewayapi_v1alpha2.ObjectName(name),
@@ -90,7 +94,7 @@ func GatewayParentRef(namespace, name string) gatewayapi_v1alpha2.ParentRef {
 	return parentRef
 }
 
-func GatewayListenerParentRef(namespace, name, listener string) gatewayapi_v1alpha2.ParentRef {
+func GatewayListenerParentRef(namespace, name, listener string) gatewayapi_v1alpha2.ParentReference {
 	parentRef := GatewayParentRef(namespace, name)
 
 	if listener != "" {
@@ -147,13 +151,23 @@ func HTTPRouteMatch(pathType gatewayapi_v1alpha2.PathMatchType, value string) []
 func HTTPHeaderMatch(matchType gatewayapi_v1alpha2.HeaderMatchType, name, value string) []gatewayapi_v1alpha2.HTTPHeaderMatch {
 	return []gatewayapi_v1alpha2.HTTPHeaderMatch{
 		{
-			Type:  HeaderMatchTypePtr(gatewayapi_v1alpha2.HeaderMatchExact),
+			Type:  HeaderMatchTypePtr(matchType),
 			Name:  gatewayapi_v1alpha2.HTTPHeaderName(name),
 			Value: value,
 		},
 	}
 }
 
+func HTTPQueryParamMatch(matchType gatewayapi_v1alpha2.QueryParamMatchType, name, value string) []gatewayapi_v1alpha2.HTTPQueryParamMatch {
+	return []gatewayapi_v1alpha2.HTTPQueryParamMatch{
+		{
+			Type:  QueryParamMatchTypePtr(matchType),
+			Name:  name,
+			Value: value,
+		},
+	}
+}
+
 func HTTPBackendRefs(backendRefs ...[]gatewayapi_v1alpha2.HTTPBackendRef) []gatewayapi_v1alpha2.HTTPBackendRef {
 	var res []gatewayapi_v1alpha2.HTTPBackendRef
 
@@ -191,3 +205,21 @@ func TLSRouteBackendRef(serviceName string, port int, weight *int32) []gatewayap
 		},
 	}
 }
+
+func TCPRouteBackendRef(serviceName string, port int, weight *int32) []gatewayapi_v1alpha2.BackendRef {
+	return []gatewayapi_v1alpha2.BackendRef{
+		{
+			BackendObjectReference: ServiceBackendObjectRef(serviceName, port),
+			Weight:                 weight,
+		},
+	}
+}
+
+func UDPRouteBackendRef(serviceName string, port int, weight *int32) []gatewayapi_v1alpha2.BackendRef {
+	return []gatewayapi_v1alpha2.BackendRef{
+		{
+			BackendObjectReference: ServiceBackendObjectRef(serviceName, port),
+			Weight:                 weight,
+		},
+	}
+}
@@ -26,6 +26,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	networking_v1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +44,7 @@ type StatusAddressUpdater struct {
 	LBStatus              v1.LoadBalancerStatus
 	IngressClassName      string
 	GatewayControllerName string
+	GatewayRef            *types.NamespacedName
 	StatusUpdater         StatusUpdater
 
 	// mu guards the LBStatus field, which can be updated dynamically.
@@ -131,6 +133,17 @@ func (s *StatusAddressUpdater) OnAdd(obj interface{}) {
 		))
 
 	case *gatewayapi_v1alpha2.Gateway:
+		// If this Contour serves a specific Gateway, only set
+		// the address on that Gateway.
+		if s.GatewayRef != nil && NamespacedNameOf(o) != *s.GatewayRef {
+			s.Logger.
+				WithField("name", o.Name).
+				WithField("namespace", o.Namespace).
+				WithField("gateway-ref", s.GatewayRef).
+				Debug("Gateway is not the configured gatewayRef, not setting address")
+			return
+		}
+
 		// Check if the Gateway's class is controlled by this Contour
 		gc := &gatewayapi_v1alpha2.GatewayClass{}
 		if err := s.Cache.Get(context.Background(), client.ObjectKey{Name: string(o.Spec.GatewayClassName)}, gc); err != nil {
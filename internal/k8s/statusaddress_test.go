@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	networking_v1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -369,6 +370,7 @@ func TestStatusAddressUpdater_Gateway(t *testing.T) {
 	testCases := map[string]struct {
 		status                     v1.LoadBalancerStatus
 		gatewayClassControllerName string
+		gatewayRef                 *types.NamespacedName
 		preop                      *gatewayapi_v1alpha2.Gateway
 		postop                     *gatewayapi_v1alpha2.Gateway
 	}{
@@ -536,6 +538,45 @@ func TestStatusAddressUpdater_Gateway(t *testing.T) {
 				},
 			},
 		},
+		"Gateway is not the configured gatewayRef": {
+			status:                     ipLBStatus,
+			gatewayClassControllerName: "projectcontour.io/contour",
+			gatewayRef:                 &types.NamespacedName{Namespace: "projectcontour", Name: "other-gateway"},
+			preop: &gatewayapi_v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "projectcontour",
+					Name:      "contour-gateway",
+				},
+				Spec: gatewayapi_v1alpha2.GatewaySpec{
+					GatewayClassName: gatewayapi_v1alpha2.ObjectName("contour-gatewayclass"),
+				},
+				Status: gatewayapi_v1alpha2.GatewayStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(gatewayapi_v1alpha2.GatewayConditionReady),
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			postop: &gatewayapi_v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "projectcontour",
+					Name:      "contour-gateway",
+				},
+				Spec: gatewayapi_v1alpha2.GatewaySpec{
+					GatewayClassName: gatewayapi_v1alpha2.ObjectName("contour-gatewayclass"),
+				},
+				Status: gatewayapi_v1alpha2.GatewayStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(gatewayapi_v1alpha2.GatewayConditionReady),
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -558,6 +599,7 @@ func TestStatusAddressUpdater_Gateway(t *testing.T) {
 			isu := StatusAddressUpdater{
 				Logger:                log,
 				GatewayControllerName: "projectcontour.io/contour",
+				GatewayRef:            tc.gatewayRef,
 				Cache:                 mockCache,
 				LBStatus:              tc.status,
 				StatusUpdater:         &suc,
@@ -588,6 +630,7 @@ func TestStatusAddressUpdater_Gateway(t *testing.T) {
 			isu := StatusAddressUpdater{
 				Logger:                log,
 				GatewayControllerName: "projectcontour.io/contour",
+				GatewayRef:            tc.gatewayRef,
 				Cache:                 mockCache,
 				LBStatus:              tc.status,
 				StatusUpdater:         &suc,
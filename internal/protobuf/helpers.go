@@ -52,6 +52,14 @@ func UInt32OrDefault(val uint32, def uint32) *wrappers.UInt32Value {
 	}
 }
 
+// UInt32OrUnset returns a wrapped UInt32Value. If val is nil, nil is returned.
+func UInt32OrUnset(val *uint32) *wrappers.UInt32Value {
+	if val == nil {
+		return nil
+	}
+	return UInt32(*val)
+}
+
 // UInt32OrNil returns a wrapped UInt32Value. If val is 0, nil is returned
 func UInt32OrNil(val uint32) *wrappers.UInt32Value {
 	switch val {
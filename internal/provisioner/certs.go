@@ -0,0 +1,48 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioner
+
+import (
+	"fmt"
+
+	"github.com/projectcontour/contour/internal/certgen"
+	"github.com/projectcontour/contour/pkg/certs"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+// CertificateSecrets generates a new CA and the xDS TLS certificates for
+// the provisioned Contour and Envoy of instance, returned as the Contour
+// Secret followed by the Envoy Secret.
+//
+// The certificates keep the default "contour" and "envoy" subject alternate
+// names, since those are what Envoy's bootstrap configuration and Contour's
+// xDS server verify.
+func CertificateSecrets(instance *Instance) ([]*core_v1.Secret, error) {
+	generated, err := certs.GenerateCerts(&certs.Configuration{
+		Namespace: instance.Namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificates: %w", err)
+	}
+
+	secrets := certgen.AsSecrets(instance.Namespace, generated)
+	secrets[0].Name = ContourCertName(instance)
+	secrets[1].Name = EnvoyCertName(instance)
+
+	for _, secret := range secrets {
+		secret.Labels = instance.Labels
+	}
+
+	return secrets, nil
+}
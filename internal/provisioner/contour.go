@@ -0,0 +1,299 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioner
+
+import (
+	"fmt"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/pkg/config"
+	"gopkg.in/yaml.v2"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	rbac_v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// ContourConfigMap returns the ConfigMap holding the configuration file
+// of the Contour provisioned for gateway. The configuration restricts
+// Contour to gateway so that provisioned Contours sharing a GatewayClass
+// do not program each other's Gateways.
+func ContourConfigMap(gateway *gatewayapi_v1alpha2.Gateway, cfg Config) (*core_v1.ConfigMap, error) {
+	contourConfig := struct {
+		Gateway *config.GatewayParameters `yaml:"gateway"`
+	}{
+		Gateway: &config.GatewayParameters{
+			ControllerName: cfg.GatewayControllerName,
+			GatewayRef: &config.NamespacedName{
+				Namespace: gateway.Namespace,
+				Name:      gateway.Name,
+			},
+		},
+	}
+
+	data, err := yaml.Marshal(contourConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal contour configuration: %w", err)
+	}
+
+	instance := GatewayInstance(gateway, nil)
+
+	return &core_v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      ContourName(instance),
+			Labels:    instance.Labels,
+		},
+		Data: map[string]string{
+			ContourConfigFileName: string(data),
+		},
+	}, nil
+}
+
+// GatewayContourConfiguration returns the ContourConfiguration that the Contour
+// provisioned for gateway reads its configuration from when the Gateway's
+// GatewayClass references params. It is the configuration of params, restricted
+// to gateway in the same way as the configuration returned by ContourConfigMap.
+func GatewayContourConfiguration(gateway *gatewayapi_v1alpha2.Gateway, params *contour_api_v1alpha1.ContourConfiguration, cfg Config) *contour_api_v1alpha1.ContourConfiguration {
+	contourConfig := contourConfiguration(GatewayInstance(gateway, params), &params.Spec)
+	contourConfig.Spec.Gateway = &contour_api_v1alpha1.GatewayConfig{
+		ControllerName: cfg.GatewayControllerName,
+		GatewayRef: &contour_api_v1alpha1.NamespacedName{
+			Namespace: gateway.Namespace,
+			Name:      gateway.Name,
+		},
+	}
+
+	return contourConfig
+}
+
+// contourConfiguration returns the ContourConfiguration named by instance,
+// holding a copy of spec with the xDS server and Envoy service settings
+// replaced by those of the objects provisioned for instance.
+func contourConfiguration(instance *Instance, spec *contour_api_v1alpha1.ContourConfigurationSpec) *contour_api_v1alpha1.ContourConfiguration {
+	spec = spec.DeepCopy()
+	if spec.XDSServer.Type == "" {
+		spec.XDSServer.Type = contour_api_v1alpha1.ContourServerType
+	}
+	spec.XDSServer.Address = "0.0.0.0"
+	spec.XDSServer.Port = XDSPort
+	spec.XDSServer.TLS = &contour_api_v1alpha1.TLS{
+		CAFile:   "/certs/ca.crt",
+		CertFile: "/certs/tls.crt",
+		KeyFile:  "/certs/tls.key",
+	}
+	spec.Envoy.Service = contour_api_v1alpha1.NamespacedName{
+		Namespace: instance.Namespace,
+		Name:      EnvoyName(instance),
+	}
+
+	return &contour_api_v1alpha1.ContourConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      instance.ContourConfigurationName,
+			Labels:    instance.Labels,
+		},
+		Spec: *spec,
+	}
+}
+
+// ContourServiceAccount returns the ServiceAccount the provisioned Contour runs as.
+func ContourServiceAccount(instance *Instance) *core_v1.ServiceAccount {
+	return &core_v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      ContourName(instance),
+			Labels:    instance.Labels,
+		},
+	}
+}
+
+// ContourClusterRoleBinding returns the ClusterRoleBinding that binds the
+// provisioned Contour's ServiceAccount to the shared Contour ClusterRole.
+func ContourClusterRoleBinding(instance *Instance) *rbac_v1.ClusterRoleBinding {
+	return &rbac_v1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ClusterRoleBindingName(instance),
+			Labels: instance.Labels,
+		},
+		RoleRef: rbac_v1.RoleRef{
+			APIGroup: rbac_v1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     ContourClusterRoleName,
+		},
+		Subjects: []rbac_v1.Subject{{
+			Kind:      rbac_v1.ServiceAccountKind,
+			Namespace: instance.Namespace,
+			Name:      ContourName(instance),
+		}},
+	}
+}
+
+// ContourService returns the Service that the provisioned Envoys use
+// to reach the provisioned Contour's xDS server.
+func ContourService(instance *Instance) *core_v1.Service {
+	return &core_v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      ContourName(instance),
+			Labels:    instance.Labels,
+		},
+		Spec: core_v1.ServiceSpec{
+			Type:     core_v1.ServiceTypeClusterIP,
+			Selector: contourPodLabels(instance),
+			Ports: []core_v1.ServicePort{{
+				Name:       "xds",
+				Protocol:   core_v1.ProtocolTCP,
+				Port:       XDSPort,
+				TargetPort: intstr.FromInt(XDSPort),
+			}},
+		},
+	}
+}
+
+// ContourDeployment returns the Deployment running the provisioned Contour.
+func ContourDeployment(instance *Instance, cfg Config) *apps_v1.Deployment {
+	labels := contourPodLabels(instance)
+
+	args := []string{
+		"serve",
+		"--incluster",
+		"--xds-address=0.0.0.0",
+		fmt.Sprintf("--xds-port=%d", XDSPort),
+		"--contour-cafile=/certs/ca.crt",
+		"--contour-cert-file=/certs/tls.crt",
+		"--contour-key-file=/certs/tls.key",
+		"--leader-election-resource-name=" + ContourName(instance),
+		"--leader-election-resource-namespace=" + instance.Namespace,
+		"--envoy-service-name=" + EnvoyName(instance),
+		"--envoy-service-namespace=" + instance.Namespace,
+	}
+	volumeMounts := []core_v1.VolumeMount{
+		{Name: "contourcert", MountPath: "/certs", ReadOnly: true},
+	}
+	volumes := []core_v1.Volume{{
+		Name: "contourcert",
+		VolumeSource: core_v1.VolumeSource{
+			Secret: &core_v1.SecretVolumeSource{
+				SecretName: ContourCertName(instance),
+			},
+		},
+	}}
+
+	if instance.ContourConfigurationName != "" {
+		args = append(args, "--contour-config-name="+instance.ContourConfigurationName)
+	} else {
+		args = append(args, "--config-path=/config/"+ContourConfigFileName)
+		volumeMounts = append(volumeMounts, core_v1.VolumeMount{
+			Name: "contour-config", MountPath: "/config", ReadOnly: true,
+		})
+		volumes = append(volumes, core_v1.Volume{
+			Name: "contour-config",
+			VolumeSource: core_v1.VolumeSource{
+				ConfigMap: &core_v1.ConfigMapVolumeSource{
+					LocalObjectReference: core_v1.LocalObjectReference{
+						Name: ContourName(instance),
+					},
+					Items: []core_v1.KeyToPath{{
+						Key:  ContourConfigFileName,
+						Path: ContourConfigFileName,
+					}},
+				},
+			},
+		})
+	}
+
+	return &apps_v1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      ContourName(instance),
+			Labels:    instance.Labels,
+		},
+		Spec: apps_v1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(instance.ContourReplicas),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: core_v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   "8000",
+					},
+				},
+				Spec: core_v1.PodSpec{
+					ServiceAccountName: ContourName(instance),
+					Containers: []core_v1.Container{{
+						Name:            "contour",
+						Image:           cfg.ContourImage,
+						ImagePullPolicy: core_v1.PullIfNotPresent,
+						Command:         []string{"contour"},
+						Args:            args,
+						Ports: []core_v1.ContainerPort{
+							{Name: "xds", ContainerPort: XDSPort, Protocol: core_v1.ProtocolTCP},
+							{Name: "metrics", ContainerPort: 8000, Protocol: core_v1.ProtocolTCP},
+							{Name: "debug", ContainerPort: 6060, Protocol: core_v1.ProtocolTCP},
+						},
+						LivenessProbe: &core_v1.Probe{
+							ProbeHandler: core_v1.ProbeHandler{
+								HTTPGet: &core_v1.HTTPGetAction{
+									Path: "/healthz",
+									Port: intstr.FromInt(8000),
+								},
+							},
+						},
+						ReadinessProbe: &core_v1.Probe{
+							ProbeHandler: core_v1.ProbeHandler{
+								TCPSocket: &core_v1.TCPSocketAction{
+									Port: intstr.FromInt(XDSPort),
+								},
+							},
+							InitialDelaySeconds: 15,
+							PeriodSeconds:       10,
+						},
+						VolumeMounts: volumeMounts,
+						Env: []core_v1.EnvVar{
+							fieldRefEnvVar("CONTOUR_NAMESPACE", "metadata.namespace"),
+							fieldRefEnvVar("POD_NAME", "metadata.name"),
+						},
+					}},
+					Volumes:         volumes,
+					SecurityContext: nonRootSecurityContext(),
+				},
+			},
+		},
+	}
+}
+
+func fieldRefEnvVar(name, fieldPath string) core_v1.EnvVar {
+	return core_v1.EnvVar{
+		Name: name,
+		ValueFrom: &core_v1.EnvVarSource{
+			FieldRef: &core_v1.ObjectFieldSelector{
+				APIVersion: "v1",
+				FieldPath:  fieldPath,
+			},
+		},
+	}
+}
+
+func nonRootSecurityContext() *core_v1.PodSecurityContext {
+	return &core_v1.PodSecurityContext{
+		RunAsNonRoot: pointer.BoolPtr(true),
+		RunAsUser:    pointer.Int64Ptr(65534),
+		RunAsGroup:   pointer.Int64Ptr(65534),
+	}
+}
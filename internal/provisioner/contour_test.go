@@ -0,0 +1,126 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioner
+
+import (
+	"strings"
+	"testing"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestContourConfigMap(t *testing.T) {
+	gw := gatewayWithListeners()
+
+	cm, err := ContourConfigMap(gw, Config{GatewayControllerName: "projectcontour.io/gateway-provisioner"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "projectcontour", cm.Namespace)
+	assert.Equal(t, "contour-example", cm.Name)
+	assert.Equal(t, OwnerLabels(gw), cm.Labels)
+
+	// The configuration must be loadable by "contour serve".
+	params, err := config.Parse(strings.NewReader(cm.Data[ContourConfigFileName]))
+	require.NoError(t, err)
+	require.NoError(t, params.Validate())
+
+	assert.Equal(t, &config.GatewayParameters{
+		ControllerName: "projectcontour.io/gateway-provisioner",
+		GatewayRef: &config.NamespacedName{
+			Namespace: "projectcontour",
+			Name:      "example",
+		},
+	}, params.GatewayConfig)
+}
+
+func TestGatewayContourConfiguration(t *testing.T) {
+	gw := gatewayWithListeners()
+	params := &contour_api_v1alpha1.ContourConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "params", Name: "contour-params"},
+		Spec: contour_api_v1alpha1.ContourConfigurationSpec{
+			XDSServer: contour_api_v1alpha1.XDSServerConfig{Address: "127.0.0.1"},
+			Gateway: &contour_api_v1alpha1.GatewayConfig{
+				ControllerName: "projectcontour.io/other",
+			},
+			EnableExternalNameService: true,
+		},
+	}
+
+	contourConfig := GatewayContourConfiguration(gw, params, Config{GatewayControllerName: "projectcontour.io/gateway-provisioner"})
+
+	assert.Equal(t, "projectcontour", contourConfig.Namespace)
+	assert.Equal(t, "contour-example", contourConfig.Name)
+	assert.Equal(t, OwnerLabels(gw), contourConfig.Labels)
+
+	// The provisioned Contour is restricted to the Gateway and
+	// connects to the provisioned objects.
+	assert.Equal(t, &contour_api_v1alpha1.GatewayConfig{
+		ControllerName: "projectcontour.io/gateway-provisioner",
+		GatewayRef: &contour_api_v1alpha1.NamespacedName{
+			Namespace: "projectcontour",
+			Name:      "example",
+		},
+	}, contourConfig.Spec.Gateway)
+	assert.Equal(t, contour_api_v1alpha1.ContourServerType, contourConfig.Spec.XDSServer.Type)
+	assert.Equal(t, "0.0.0.0", contourConfig.Spec.XDSServer.Address)
+	assert.Equal(t, contour_api_v1alpha1.NamespacedName{Namespace: "projectcontour", Name: "envoy-example"}, contourConfig.Spec.Envoy.Service)
+
+	// The rest of the parameters are passed through unchanged.
+	assert.True(t, contourConfig.Spec.EnableExternalNameService)
+
+	// The parameters are not modified.
+	assert.Equal(t, "127.0.0.1", params.Spec.XDSServer.Address)
+	assert.Equal(t, "projectcontour.io/other", params.Spec.Gateway.ControllerName)
+}
+
+func TestContourDeployment(t *testing.T) {
+	instance := GatewayInstance(gatewayWithListeners(), nil)
+
+	deployment := ContourDeployment(instance, Config{ContourImage: "ghcr.io/projectcontour/contour:test"})
+
+	assert.Equal(t, contourPodLabels(instance), deployment.Spec.Selector.MatchLabels)
+	assert.Equal(t, contourPodLabels(instance), deployment.Spec.Template.Labels)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "ghcr.io/projectcontour/contour:test", container.Image)
+	assert.Contains(t, container.Args, "--config-path=/config/contour.yaml")
+	assert.Contains(t, container.Args, "--envoy-service-name=envoy-example")
+	assert.Contains(t, container.Args, "--envoy-service-namespace=projectcontour")
+	assert.Contains(t, container.Args, "--leader-election-resource-name=contour-example")
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 2)
+}
+
+func TestContourDeploymentWithContourConfiguration(t *testing.T) {
+	instance := GatewayInstance(gatewayWithListeners(), nil)
+	instance.ContourReplicas = 3
+	instance.ContourConfigurationName = "contour-example"
+
+	deployment := ContourDeployment(instance, Config{})
+
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Args, "--contour-config-name=contour-example")
+	assert.NotContains(t, container.Args, "--config-path=/config/contour.yaml")
+	assert.Equal(t, []core_v1.VolumeMount{
+		{Name: "contourcert", MountPath: "/certs", ReadOnly: true},
+	}, container.VolumeMounts)
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 1)
+}
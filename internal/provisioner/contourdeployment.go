@@ -0,0 +1,85 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioner
+
+import (
+	"fmt"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/dag"
+)
+
+const (
+	// OwningContourDeploymentNameLabel and OwningContourDeploymentNamespaceLabel
+	// are set on every object provisioned for a ContourDeployment.
+	OwningContourDeploymentNameLabel      = "projectcontour.io/owning-contourdeployment-name"
+	OwningContourDeploymentNamespaceLabel = "projectcontour.io/owning-contourdeployment-namespace"
+)
+
+// ContourDeploymentInstance returns the Instance provisioned for contourDeployment.
+// The provisioned Contour reads its configuration from the ContourConfiguration
+// returned by ContourConfiguration, and the provisioned Envoy is exposed on ports
+// 80 and 443.
+func ContourDeploymentInstance(contourDeployment *contour_api_v1alpha1.ContourDeployment) *Instance {
+	replicas := contourDeployment.Spec.Replicas
+	if replicas == 0 {
+		replicas = 2
+	}
+
+	instance := &Instance{
+		Namespace: contourDeployment.Namespace,
+		Name:      contourDeployment.Name,
+		Labels: map[string]string{
+			OwningContourDeploymentNameLabel:      contourDeployment.Name,
+			OwningContourDeploymentNamespaceLabel: contourDeployment.Namespace,
+		},
+		ContourReplicas:        replicas,
+		clusterRoleBindingName: fmt.Sprintf("contourdeployment-%s-%s", contourDeployment.Namespace, contourDeployment.Name),
+		ports: []envoyPort{
+			{
+				name:          "http",
+				port:          dag.HTTP_LISTENER_PORT,
+				containerPort: listenerPort(contourDeployment.Spec.Config.Envoy.HTTPListener, EnvoyHTTPPort),
+				protocol:      "TCP",
+			},
+			{
+				name:          "https",
+				port:          dag.HTTPS_LISTENER_PORT,
+				containerPort: listenerPort(contourDeployment.Spec.Config.Envoy.HTTPSListener, EnvoyHTTPSPort),
+				protocol:      "TCP",
+			},
+		},
+	}
+	instance.ContourConfigurationName = ContourName(instance)
+
+	return instance
+}
+
+// ContourConfiguration returns the ContourConfiguration that the Contour
+// provisioned for contourDeployment reads its configuration from. It is the
+// configuration of contourDeployment, with the xDS server and Envoy service
+// settings replaced by those of the provisioned objects.
+func ContourConfiguration(contourDeployment *contour_api_v1alpha1.ContourDeployment) *contour_api_v1alpha1.ContourConfiguration {
+	instance := ContourDeploymentInstance(contourDeployment)
+
+	return contourConfiguration(instance, &contourDeployment.Spec.Config)
+}
+
+// listenerPort returns the port of listener, or def if it is unset.
+func listenerPort(listener contour_api_v1alpha1.EnvoyListener, def int32) int32 {
+	if listener.Port == 0 {
+		return def
+	}
+	return int32(listener.Port)
+}
@@ -0,0 +1,115 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioner
+
+import (
+	"testing"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestContourDeploymentInstance(t *testing.T) {
+	tests := map[string]struct {
+		spec         contour_api_v1alpha1.ContourDeploymentSpec
+		wantReplicas int32
+		wantPorts    []envoyPort
+	}{
+		"defaults": {
+			wantReplicas: 2,
+			wantPorts: []envoyPort{
+				{name: "http", port: 80, containerPort: EnvoyHTTPPort, protocol: "TCP"},
+				{name: "https", port: 443, containerPort: EnvoyHTTPSPort, protocol: "TCP"},
+			},
+		},
+		"replicas and listener ports": {
+			spec: contour_api_v1alpha1.ContourDeploymentSpec{
+				Replicas: 3,
+				Config: contour_api_v1alpha1.ContourConfigurationSpec{
+					Envoy: contour_api_v1alpha1.EnvoyConfig{
+						HTTPListener:  contour_api_v1alpha1.EnvoyListener{Port: 9080},
+						HTTPSListener: contour_api_v1alpha1.EnvoyListener{Port: 9443},
+					},
+				},
+			},
+			wantReplicas: 3,
+			wantPorts: []envoyPort{
+				{name: "http", port: 80, containerPort: 9080, protocol: "TCP"},
+				{name: "https", port: 443, containerPort: 9443, protocol: "TCP"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			instance := ContourDeploymentInstance(&contour_api_v1alpha1.ContourDeployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "example"},
+				Spec:       tc.spec,
+			})
+
+			assert.Equal(t, tc.wantReplicas, instance.ContourReplicas)
+			assert.Equal(t, tc.wantPorts, instance.ports)
+			assert.Equal(t, "contour-example", instance.ContourConfigurationName)
+			assert.Equal(t, "contourdeployment-projectcontour-example", ClusterRoleBindingName(instance))
+			assert.Equal(t, map[string]string{
+				OwningContourDeploymentNameLabel:      "example",
+				OwningContourDeploymentNamespaceLabel: "projectcontour",
+			}, instance.Labels)
+		})
+	}
+}
+
+func TestContourConfiguration(t *testing.T) {
+	contourDeployment := &contour_api_v1alpha1.ContourDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "projectcontour", Name: "example"},
+		Spec: contour_api_v1alpha1.ContourDeploymentSpec{
+			Config: contour_api_v1alpha1.ContourConfigurationSpec{
+				XDSServer: contour_api_v1alpha1.XDSServerConfig{
+					Type:    contour_api_v1alpha1.EnvoyServerType,
+					Address: "127.0.0.1",
+					Port:    1234,
+				},
+				Envoy: contour_api_v1alpha1.EnvoyConfig{
+					Service: contour_api_v1alpha1.NamespacedName{Namespace: "projectcontour", Name: "envoy"},
+				},
+				EnableExternalNameService: true,
+			},
+		},
+	}
+
+	config := ContourConfiguration(contourDeployment)
+
+	assert.Equal(t, "projectcontour", config.Namespace)
+	assert.Equal(t, "contour-example", config.Name)
+
+	// The xDS server and Envoy service are those of the provisioned objects.
+	assert.Equal(t, contour_api_v1alpha1.XDSServerConfig{
+		Type:    contour_api_v1alpha1.EnvoyServerType,
+		Address: "0.0.0.0",
+		Port:    XDSPort,
+		TLS: &contour_api_v1alpha1.TLS{
+			CAFile:   "/certs/ca.crt",
+			CertFile: "/certs/tls.crt",
+			KeyFile:  "/certs/tls.key",
+		},
+	}, config.Spec.XDSServer)
+	assert.Equal(t, contour_api_v1alpha1.NamespacedName{Namespace: "projectcontour", Name: "envoy-example"}, config.Spec.Envoy.Service)
+
+	// The rest of the configuration is passed through unchanged.
+	assert.True(t, config.Spec.EnableExternalNameService)
+
+	// The ContourDeployment is not modified.
+	assert.Equal(t, "127.0.0.1", contourDeployment.Spec.Config.XDSServer.Address)
+}
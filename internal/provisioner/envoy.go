@@ -0,0 +1,233 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioner
+
+import (
+	"fmt"
+
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+// EnvoyServiceAccount returns the ServiceAccount the provisioned Envoy runs as.
+func EnvoyServiceAccount(instance *Instance) *core_v1.ServiceAccount {
+	return &core_v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      EnvoyName(instance),
+			Labels:    instance.Labels,
+		},
+	}
+}
+
+// EnvoyService returns the LoadBalancer Service exposing the provisioned
+// Envoy on the ports of instance. Its load balancer address is what the
+// provisioned Contour reports in the status of the objects it programs.
+func EnvoyService(instance *Instance) *core_v1.Service {
+	var ports []core_v1.ServicePort
+	for _, p := range instance.ports {
+		ports = append(ports, core_v1.ServicePort{
+			Name:       p.name,
+			Protocol:   core_v1.Protocol(p.protocol),
+			Port:       p.port,
+			TargetPort: intstr.FromInt(int(p.containerPort)),
+		})
+	}
+
+	return &core_v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      EnvoyName(instance),
+			Labels:    instance.Labels,
+			Annotations: map[string]string{
+				// Put the AWS ELB into "TCP" mode so that it does not do
+				// HTTP negotiation for HTTPS connections at the ELB edge.
+				"service.beta.kubernetes.io/aws-load-balancer-backend-protocol": "tcp",
+			},
+		},
+		Spec: core_v1.ServiceSpec{
+			Type:                  core_v1.ServiceTypeLoadBalancer,
+			ExternalTrafficPolicy: core_v1.ServiceExternalTrafficPolicyTypeLocal,
+			Selector:              envoyPodLabels(instance),
+			Ports:                 ports,
+		},
+	}
+}
+
+// EnvoyDaemonSet returns the DaemonSet running the provisioned Envoy,
+// bootstrapped to fetch its configuration from the provisioned Contour.
+func EnvoyDaemonSet(instance *Instance, cfg Config) *apps_v1.DaemonSet {
+	labels := envoyPodLabels(instance)
+
+	var ports []core_v1.ContainerPort
+	for _, p := range instance.ports {
+		ports = append(ports, core_v1.ContainerPort{
+			Name:          p.name,
+			ContainerPort: p.containerPort,
+			Protocol:      core_v1.Protocol(p.protocol),
+		})
+	}
+
+	maxUnavailable := intstr.FromString("10%")
+
+	return &apps_v1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      EnvoyName(instance),
+			Labels:    instance.Labels,
+		},
+		Spec: apps_v1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			UpdateStrategy: apps_v1.DaemonSetUpdateStrategy{
+				Type: apps_v1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &apps_v1.RollingUpdateDaemonSet{
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			Template: core_v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   "8002",
+						"prometheus.io/path":   "/stats/prometheus",
+					},
+				},
+				Spec: core_v1.PodSpec{
+					ServiceAccountName:            EnvoyName(instance),
+					AutomountServiceAccountToken:  pointer.BoolPtr(false),
+					TerminationGracePeriodSeconds: pointer.Int64Ptr(300),
+					InitContainers: []core_v1.Container{{
+						Name:            "envoy-initconfig",
+						Image:           cfg.ContourImage,
+						ImagePullPolicy: core_v1.PullIfNotPresent,
+						Command:         []string{"contour"},
+						Args: []string{
+							"bootstrap",
+							"/config/envoy.json",
+							"--xds-address=" + ContourName(instance),
+							fmt.Sprintf("--xds-port=%d", XDSPort),
+							"--xds-resource-version=v3",
+							"--resources-dir=/config/resources",
+							"--envoy-cafile=/certs/ca.crt",
+							"--envoy-cert-file=/certs/tls.crt",
+							"--envoy-key-file=/certs/tls.key",
+						},
+						VolumeMounts: []core_v1.VolumeMount{
+							{Name: "envoy-config", MountPath: "/config"},
+							{Name: "envoycert", MountPath: "/certs", ReadOnly: true},
+						},
+						Env: []core_v1.EnvVar{
+							fieldRefEnvVar("CONTOUR_NAMESPACE", "metadata.namespace"),
+						},
+					}},
+					Containers: []core_v1.Container{
+						{
+							Name:            "shutdown-manager",
+							Image:           cfg.ContourImage,
+							ImagePullPolicy: core_v1.PullIfNotPresent,
+							Command:         []string{"/bin/contour"},
+							Args:            []string{"envoy", "shutdown-manager"},
+							Lifecycle: &core_v1.Lifecycle{
+								PreStop: &core_v1.LifecycleHandler{
+									Exec: &core_v1.ExecAction{
+										Command: []string{"/bin/contour", "envoy", "shutdown"},
+									},
+								},
+							},
+							LivenessProbe: &core_v1.Probe{
+								ProbeHandler: core_v1.ProbeHandler{
+									HTTPGet: &core_v1.HTTPGetAction{
+										Path: "/healthz",
+										Port: intstr.FromInt(8090),
+									},
+								},
+								InitialDelaySeconds: 3,
+								PeriodSeconds:       10,
+							},
+							VolumeMounts: []core_v1.VolumeMount{
+								{Name: "envoy-admin", MountPath: "/admin"},
+							},
+						},
+						{
+							Name:            "envoy",
+							Image:           cfg.EnvoyImage,
+							ImagePullPolicy: core_v1.PullIfNotPresent,
+							Command:         []string{"envoy"},
+							Args: []string{
+								"-c",
+								"/config/envoy.json",
+								"--service-cluster $(CONTOUR_NAMESPACE)",
+								"--service-node $(ENVOY_POD_NAME)",
+								"--log-level info",
+							},
+							Env: []core_v1.EnvVar{
+								fieldRefEnvVar("CONTOUR_NAMESPACE", "metadata.namespace"),
+								fieldRefEnvVar("ENVOY_POD_NAME", "metadata.name"),
+							},
+							Ports: ports,
+							ReadinessProbe: &core_v1.Probe{
+								ProbeHandler: core_v1.ProbeHandler{
+									HTTPGet: &core_v1.HTTPGetAction{
+										Path: "/ready",
+										Port: intstr.FromInt(8002),
+									},
+								},
+								InitialDelaySeconds: 3,
+								PeriodSeconds:       4,
+							},
+							Lifecycle: &core_v1.Lifecycle{
+								PreStop: &core_v1.LifecycleHandler{
+									HTTPGet: &core_v1.HTTPGetAction{
+										Path:   "/shutdown",
+										Port:   intstr.FromInt(8090),
+										Scheme: core_v1.URISchemeHTTP,
+									},
+								},
+							},
+							VolumeMounts: []core_v1.VolumeMount{
+								{Name: "envoy-config", MountPath: "/config", ReadOnly: true},
+								{Name: "envoycert", MountPath: "/certs", ReadOnly: true},
+								{Name: "envoy-admin", MountPath: "/admin"},
+							},
+						},
+					},
+					Volumes: []core_v1.Volume{
+						{
+							Name:         "envoy-admin",
+							VolumeSource: core_v1.VolumeSource{EmptyDir: &core_v1.EmptyDirVolumeSource{}},
+						},
+						{
+							Name:         "envoy-config",
+							VolumeSource: core_v1.VolumeSource{EmptyDir: &core_v1.EmptyDirVolumeSource{}},
+						},
+						{
+							Name: "envoycert",
+							VolumeSource: core_v1.VolumeSource{
+								Secret: &core_v1.SecretVolumeSource{
+									SecretName: EnvoyCertName(instance),
+								},
+							},
+						},
+					},
+					RestartPolicy:   core_v1.RestartPolicyAlways,
+					SecurityContext: nonRootSecurityContext(),
+				},
+			},
+		},
+	}
+}
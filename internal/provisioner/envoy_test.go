@@ -0,0 +1,60 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestEnvoyService(t *testing.T) {
+	gw := gatewayWithListeners(
+		gatewayapi_v1alpha2.Listener{Name: "http", Port: 80, Protocol: gatewayapi_v1alpha2.HTTPProtocolType},
+		gatewayapi_v1alpha2.Listener{Name: "tcp", Port: 9000, Protocol: gatewayapi_v1alpha2.TCPProtocolType},
+	)
+
+	instance := GatewayInstance(gw, nil)
+	svc := EnvoyService(instance)
+
+	assert.Equal(t, "envoy-example", svc.Name)
+	assert.Equal(t, core_v1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	assert.Equal(t, envoyPodLabels(instance), svc.Spec.Selector)
+	assert.Equal(t, []core_v1.ServicePort{
+		{Name: "http", Protocol: core_v1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(EnvoyHTTPPort)},
+		{Name: "port-9000", Protocol: core_v1.ProtocolTCP, Port: 9000, TargetPort: intstr.FromInt(9000)},
+	}, svc.Spec.Ports)
+}
+
+func TestEnvoyDaemonSet(t *testing.T) {
+	gw := gatewayWithListeners(
+		gatewayapi_v1alpha2.Listener{Name: "https", Port: 443, Protocol: gatewayapi_v1alpha2.HTTPSProtocolType},
+	)
+
+	instance := GatewayInstance(gw, nil)
+	ds := EnvoyDaemonSet(instance, Config{ContourImage: "contour:test", EnvoyImage: "envoy:test"})
+
+	assert.Equal(t, envoyPodLabels(instance), ds.Spec.Selector.MatchLabels)
+	assert.Contains(t, ds.Spec.Template.Spec.InitContainers[0].Args, "--xds-address=contour-example")
+
+	envoy := ds.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, "envoy:test", envoy.Image)
+	assert.Equal(t, []core_v1.ContainerPort{
+		{Name: "https", ContainerPort: EnvoyHTTPSPort, Protocol: core_v1.ProtocolTCP},
+	}, envoy.Ports)
+	assert.Equal(t, "envoycert-example", ds.Spec.Template.Spec.Volumes[2].Secret.SecretName)
+}
@@ -0,0 +1,233 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package provisioner builds the Kubernetes objects that make up the
+// Contour and Envoy data plane for a single Gateway.
+package provisioner
+
+import (
+	"fmt"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/projectcontour/contour/internal/dag"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// ContourConfigFileName is the key of the Contour configuration
+	// file in the provisioned ConfigMap.
+	ContourConfigFileName = "contour.yaml"
+
+	// XDSPort is the port the provisioned Contour serves xDS on.
+	XDSPort = 8001
+
+	// EnvoyHTTPPort and EnvoyHTTPSPort are the container ports that
+	// Contour programs Envoy to listen on for Gateway listeners on
+	// ports 80 and 443.
+	EnvoyHTTPPort  = 8080
+	EnvoyHTTPSPort = 8443
+
+	// OwningGatewayNameLabel and OwningGatewayNamespaceLabel are set
+	// on every provisioned object so that cluster-scoped objects, which
+	// cannot have a namespaced owner reference, can be found and
+	// cleaned up when the Gateway is deleted.
+	OwningGatewayNameLabel      = "projectcontour.io/owning-gateway-name"
+	OwningGatewayNamespaceLabel = "projectcontour.io/owning-gateway-namespace"
+
+	// ContourClusterRoleName is the name of the ClusterRole, installed
+	// alongside the provisioner, that provisioned Contours are bound to.
+	ContourClusterRoleName = "contour"
+)
+
+// Config holds the settings that are common to all provisioned Gateways.
+type Config struct {
+	// GatewayControllerName is the controller name of the GatewayClasses
+	// whose Gateways are provisioned. Provisioned Contours are configured
+	// with the same controller name.
+	GatewayControllerName string
+
+	// ContourImage is the container image used for Contour, the Envoy
+	// bootstrap init container and the Envoy shutdown manager.
+	ContourImage string
+
+	// EnvoyImage is the container image used for Envoy.
+	EnvoyImage string
+}
+
+// Instance describes a single Contour and Envoy deployed by the provisioner.
+// Every provisioned object is named after, and labelled with, the Instance.
+type Instance struct {
+	// Namespace is the namespace that the namespaced objects are created in.
+	Namespace string
+
+	// Name is the name of the object that the Instance was provisioned for.
+	Name string
+
+	// Labels are set on every provisioned object. They must uniquely
+	// identify the Instance, since they are used to find the cluster-scoped
+	// objects that cannot be garbage collected through owner references.
+	Labels map[string]string
+
+	// ContourReplicas is the number of Contour replicas to run.
+	ContourReplicas int32
+
+	// ContourConfigurationName, if set, is the name of the ContourConfiguration
+	// in Namespace that the provisioned Contour reads its configuration from.
+	// Otherwise the provisioned Contour reads its configuration file from the
+	// ConfigMap named by ContourName.
+	ContourConfigurationName string
+
+	// clusterRoleBindingName is the name of the cluster-scoped
+	// ClusterRoleBinding, which must not clash across namespaces
+	// or with Instances provisioned for other kinds of objects.
+	clusterRoleBindingName string
+
+	ports []envoyPort
+}
+
+// GatewayInstance returns the Instance provisioned for gateway. If params,
+// the ContourConfiguration referenced by the Gateway's GatewayClass, is not
+// nil, the provisioned Contour reads its configuration from the
+// ContourConfiguration returned by GatewayContourConfiguration, and Envoy
+// listens on the HTTP and HTTPS listener ports that params configures.
+func GatewayInstance(gateway *gatewayapi_v1alpha2.Gateway, params *contour_api_v1alpha1.ContourConfiguration) *Instance {
+	httpPort, httpsPort := int32(EnvoyHTTPPort), int32(EnvoyHTTPSPort)
+	if params != nil {
+		httpPort = listenerPort(params.Spec.Envoy.HTTPListener, EnvoyHTTPPort)
+		httpsPort = listenerPort(params.Spec.Envoy.HTTPSListener, EnvoyHTTPSPort)
+	}
+
+	instance := &Instance{
+		Namespace:              gateway.Namespace,
+		Name:                   gateway.Name,
+		Labels:                 OwnerLabels(gateway),
+		ContourReplicas:        2,
+		clusterRoleBindingName: fmt.Sprintf("contour-%s-%s", gateway.Namespace, gateway.Name),
+		ports:                  envoyPorts(gateway, httpPort, httpsPort),
+	}
+	if params != nil {
+		instance.ContourConfigurationName = ContourName(instance)
+	}
+
+	return instance
+}
+
+// ContourName returns the name of the provisioned Contour objects
+// (ConfigMap, Deployment, Service and ServiceAccount) for instance.
+func ContourName(instance *Instance) string {
+	return "contour-" + instance.Name
+}
+
+// EnvoyName returns the name of the provisioned Envoy objects
+// (DaemonSet, Service and ServiceAccount) for instance.
+func EnvoyName(instance *Instance) string {
+	return "envoy-" + instance.Name
+}
+
+// ContourCertName and EnvoyCertName return the names of the Secrets
+// holding the xDS TLS certificates for the provisioned Contour and Envoy.
+func ContourCertName(instance *Instance) string {
+	return "contourcert-" + instance.Name
+}
+
+func EnvoyCertName(instance *Instance) string {
+	return "envoycert-" + instance.Name
+}
+
+// ClusterRoleBindingName returns the name of the ClusterRoleBinding
+// granting the provisioned Contour its permissions.
+func ClusterRoleBindingName(instance *Instance) string {
+	return instance.clusterRoleBindingName
+}
+
+// OwnerLabels returns the labels that identify objects provisioned for gateway.
+func OwnerLabels(gateway *gatewayapi_v1alpha2.Gateway) map[string]string {
+	return map[string]string{
+		OwningGatewayNameLabel:      gateway.Name,
+		OwningGatewayNamespaceLabel: gateway.Namespace,
+	}
+}
+
+// contourPodLabels and envoyPodLabels return the labels used to select the
+// provisioned Contour and Envoy pods for instance.
+func contourPodLabels(instance *Instance) map[string]string {
+	return withLabel(instance.Labels, "app.kubernetes.io/name", "contour")
+}
+
+func envoyPodLabels(instance *Instance) map[string]string {
+	return withLabel(instance.Labels, "app.kubernetes.io/name", "envoy")
+}
+
+func withLabel(labels map[string]string, key, value string) map[string]string {
+	res := map[string]string{}
+	for k, v := range labels {
+		res[k] = v
+	}
+	res[key] = value
+	return res
+}
+
+// envoyPort is a port that the provisioned Envoy Service exposes.
+type envoyPort struct {
+	name          string
+	port          int32
+	containerPort int32
+	protocol      string
+}
+
+// envoyPorts returns the ports that the provisioned Envoy Service should
+// expose for the listeners of gateway, in listener order. Each listener
+// port is exposed once per transport protocol. HTTP listeners on port 80,
+// and HTTPS and TLS listeners on port 443, are mapped to the container
+// ports of Contour's default HTTP and HTTPS listeners, matching how the
+// DAG serves them. Any other listener is served on its own port.
+func envoyPorts(gateway *gatewayapi_v1alpha2.Gateway, httpPort, httpsPort int32) []envoyPort {
+	type socket struct {
+		port     int32
+		protocol string
+	}
+
+	var ports []envoyPort
+	seen := map[socket]bool{}
+
+	for _, listener := range gateway.Spec.Listeners {
+		port := int32(listener.Port)
+		protocol := "TCP"
+		if listener.Protocol == gatewayapi_v1alpha2.UDPProtocolType {
+			protocol = "UDP"
+		}
+
+		if seen[socket{port, protocol}] {
+			continue
+		}
+		seen[socket{port, protocol}] = true
+
+		// Port names must be unique, so UDP ports, which may
+		// share a number with a TCP port, are named apart.
+		if protocol == "UDP" {
+			ports = append(ports, envoyPort{name: fmt.Sprintf("port-%d-udp", port), port: port, containerPort: port, protocol: protocol})
+			continue
+		}
+
+		switch {
+		case port == dag.HTTP_LISTENER_PORT && listener.Protocol == gatewayapi_v1alpha2.HTTPProtocolType:
+			ports = append(ports, envoyPort{name: "http", port: port, containerPort: httpPort, protocol: protocol})
+		case port == dag.HTTPS_LISTENER_PORT && (listener.Protocol == gatewayapi_v1alpha2.HTTPSProtocolType || listener.Protocol == gatewayapi_v1alpha2.TLSProtocolType):
+			ports = append(ports, envoyPort{name: "https", port: port, containerPort: httpsPort, protocol: protocol})
+		default:
+			ports = append(ports, envoyPort{name: fmt.Sprintf("port-%d", port), port: port, containerPort: port, protocol: protocol})
+		}
+	}
+
+	return ports
+}
@@ -0,0 +1,140 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioner
+
+import (
+	"testing"
+
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapi_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func gatewayWithListeners(listeners ...gatewayapi_v1alpha2.Listener) *gatewayapi_v1alpha2.Gateway {
+	return &gatewayapi_v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "projectcontour",
+			Name:      "example",
+		},
+		Spec: gatewayapi_v1alpha2.GatewaySpec{
+			GatewayClassName: "contour",
+			Listeners:        listeners,
+		},
+	}
+}
+
+func TestGatewayInstance(t *testing.T) {
+	instance := GatewayInstance(gatewayWithListeners(), nil)
+
+	assert.Equal(t, "contour-example", ContourName(instance))
+	assert.Equal(t, "envoy-example", EnvoyName(instance))
+	assert.Equal(t, "contourcert-example", ContourCertName(instance))
+	assert.Equal(t, "envoycert-example", EnvoyCertName(instance))
+	assert.Equal(t, "contour-projectcontour-example", ClusterRoleBindingName(instance))
+	assert.Equal(t, map[string]string{
+		OwningGatewayNameLabel:      "example",
+		OwningGatewayNamespaceLabel: "projectcontour",
+	}, instance.Labels)
+	assert.Equal(t, int32(2), instance.ContourReplicas)
+	assert.Empty(t, instance.ContourConfigurationName)
+}
+
+func TestGatewayInstanceWithParameters(t *testing.T) {
+	gw := gatewayWithListeners(
+		gatewayapi_v1alpha2.Listener{Name: "http", Port: 80, Protocol: gatewayapi_v1alpha2.HTTPProtocolType},
+		gatewayapi_v1alpha2.Listener{Name: "https", Port: 443, Protocol: gatewayapi_v1alpha2.HTTPSProtocolType},
+	)
+	params := &contour_api_v1alpha1.ContourConfiguration{
+		Spec: contour_api_v1alpha1.ContourConfigurationSpec{
+			Envoy: contour_api_v1alpha1.EnvoyConfig{
+				HTTPListener: contour_api_v1alpha1.EnvoyListener{Port: 9080},
+			},
+		},
+	}
+
+	instance := GatewayInstance(gw, params)
+
+	assert.Equal(t, "contour-example", instance.ContourConfigurationName)
+	assert.Equal(t, []envoyPort{
+		{name: "http", port: 80, containerPort: 9080, protocol: "TCP"},
+		{name: "https", port: 443, containerPort: EnvoyHTTPSPort, protocol: "TCP"},
+	}, instance.ports)
+}
+
+func TestEnvoyPorts(t *testing.T) {
+	tests := map[string]struct {
+		listeners []gatewayapi_v1alpha2.Listener
+		want      []envoyPort
+	}{
+		"no listeners": {
+			want: nil,
+		},
+		"http and https": {
+			listeners: []gatewayapi_v1alpha2.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayapi_v1alpha2.HTTPProtocolType},
+				{Name: "https", Port: 443, Protocol: gatewayapi_v1alpha2.HTTPSProtocolType},
+			},
+			want: []envoyPort{
+				{name: "http", port: 80, containerPort: EnvoyHTTPPort, protocol: "TCP"},
+				{name: "https", port: 443, containerPort: EnvoyHTTPSPort, protocol: "TCP"},
+			},
+		},
+		"listeners sharing a port are exposed once": {
+			listeners: []gatewayapi_v1alpha2.Listener{
+				{Name: "https-1", Port: 443, Protocol: gatewayapi_v1alpha2.HTTPSProtocolType},
+				{Name: "tls", Port: 443, Protocol: gatewayapi_v1alpha2.TLSProtocolType},
+			},
+			want: []envoyPort{
+				{name: "https", port: 443, containerPort: EnvoyHTTPSPort, protocol: "TCP"},
+			},
+		},
+		"other ports are exposed on the same container port": {
+			listeners: []gatewayapi_v1alpha2.Listener{
+				{Name: "tcp", Port: 9000, Protocol: gatewayapi_v1alpha2.TCPProtocolType},
+				{Name: "udp", Port: 5353, Protocol: gatewayapi_v1alpha2.UDPProtocolType},
+			},
+			want: []envoyPort{
+				{name: "port-9000", port: 9000, containerPort: 9000, protocol: "TCP"},
+				{name: "port-5353-udp", port: 5353, containerPort: 5353, protocol: "UDP"},
+			},
+		},
+		"other protocols on ports 80 and 443 are exposed on the same container port": {
+			listeners: []gatewayapi_v1alpha2.Listener{
+				{Name: "tcp", Port: 80, Protocol: gatewayapi_v1alpha2.TCPProtocolType},
+				{Name: "http", Port: 443, Protocol: gatewayapi_v1alpha2.HTTPProtocolType},
+			},
+			want: []envoyPort{
+				{name: "port-80", port: 80, containerPort: 80, protocol: "TCP"},
+				{name: "port-443", port: 443, containerPort: 443, protocol: "TCP"},
+			},
+		},
+		"tcp and udp listeners sharing a port are both exposed": {
+			listeners: []gatewayapi_v1alpha2.Listener{
+				{Name: "tcp", Port: 53, Protocol: gatewayapi_v1alpha2.TCPProtocolType},
+				{Name: "udp", Port: 53, Protocol: gatewayapi_v1alpha2.UDPProtocolType},
+			},
+			want: []envoyPort{
+				{name: "port-53", port: 53, containerPort: 53, protocol: "TCP"},
+				{name: "port-53-udp", port: 53, containerPort: 53, protocol: "UDP"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, envoyPorts(gatewayWithListeners(tc.listeners...), EnvoyHTTPPort, EnvoyHTTPSPort))
+		})
+	}
+}
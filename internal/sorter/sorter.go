@@ -112,14 +112,25 @@ func (s headerMatchConditionSorter) Less(i, j int) bool {
 func longestRouteByHeaderConditions(lhs, rhs *dag.Route) bool {
 	if len(lhs.HeaderMatchConditions) == len(rhs.HeaderMatchConditions) {
 		pair := make([]dag.HeaderMatchCondition, 2)
+		identical := true
 
 		for i := 0; i < len(lhs.HeaderMatchConditions); i++ {
 			pair[0] = lhs.HeaderMatchConditions[i]
 			pair[1] = rhs.HeaderMatchConditions[i]
 
+			if pair[0] == pair[1] {
+				continue
+			}
 			if headerMatchConditionSorter(pair).Less(0, 1) {
 				return true
 			}
+			identical = false
+		}
+
+		// If the header conditions are the same, the route
+		// with more query parameter conditions sorts first.
+		if identical {
+			return len(lhs.QueryParamMatchConditions) > len(rhs.QueryParamMatchConditions)
 		}
 	}
 
@@ -129,8 +140,8 @@ func longestRouteByHeaderConditions(lhs, rhs *dag.Route) bool {
 // Sorts the given Route slice in place. Routes are ordered first by
 // type (exact sorts before regex, sorts before prefix) and then
 // longest path match value, then by the length of the HeaderMatch
-// slice (if any). The HeaderMatch slice is also ordered by the matching
-// header name.
+// slice (if any), then by the length of the QueryParamMatch slice (if
+// any). The HeaderMatch slice is also ordered by the matching header name.
 type routeSorter []*dag.Route
 
 func (s routeSorter) Len() int      { return len(s) }
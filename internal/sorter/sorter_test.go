@@ -283,6 +283,50 @@ func TestSortRoutesLongestHeaders(t *testing.T) {
 	assert.Equal(t, want, have)
 }
 
+func TestSortRoutesQueryParams(t *testing.T) {
+	want := []*dag.Route{
+		{
+			PathMatchCondition: matchPrefixString("/path"),
+			HeaderMatchConditions: []dag.HeaderMatchCondition{
+				presentHeader("header-name"),
+			},
+			QueryParamMatchConditions: []dag.QueryParamMatchCondition{
+				{Name: "param-1", MatchType: dag.QueryParamMatchTypePresent},
+				{Name: "param-2", Value: "value", MatchType: dag.QueryParamMatchTypeExact},
+			},
+		},
+		{
+			PathMatchCondition: matchPrefixString("/path"),
+			HeaderMatchConditions: []dag.HeaderMatchCondition{
+				presentHeader("header-name"),
+			},
+			QueryParamMatchConditions: []dag.QueryParamMatchCondition{
+				{Name: "param-1", MatchType: dag.QueryParamMatchTypePresent},
+			},
+		},
+		{
+			PathMatchCondition: matchPrefixString("/path"),
+			HeaderMatchConditions: []dag.HeaderMatchCondition{
+				presentHeader("header-name"),
+			},
+		},
+		{
+			PathMatchCondition: matchPrefixString("/path"),
+			QueryParamMatchConditions: []dag.QueryParamMatchCondition{
+				{Name: "param-1", MatchType: dag.QueryParamMatchTypePresent},
+			},
+		},
+		{
+			PathMatchCondition: matchPrefixString("/path"),
+		},
+	}
+
+	have := shuffleRoutes(want)
+
+	sort.Stable(For(have))
+	assert.Equal(t, want, have)
+}
+
 func TestSortSecrets(t *testing.T) {
 	want := []*envoy_tls_v3.Secret{
 		{Name: "first"},
@@ -26,3 +26,11 @@ func SetGatewayClassAccepted(ctx context.Context, cli client.Client, gc *gateway
 	gc.Status.Conditions = mergeConditions(gc.Status.Conditions, computeGatewayClassAcceptedCondition(gc, accepted))
 	return gc
 }
+
+// SetGatewayClassInvalidParameters inserts or updates the Accepted condition
+// for the provided GatewayClass, marking it as not accepted because its
+// parametersRef is invalid.
+func SetGatewayClassInvalidParameters(gc *gatewayapi_v1alpha2.GatewayClass, message string) *gatewayapi_v1alpha2.GatewayClass {
+	gc.Status.Conditions = mergeConditions(gc.Status.Conditions, computeGatewayClassInvalidParametersCondition(gc, message))
+	return gc
+}
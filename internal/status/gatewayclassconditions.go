@@ -48,6 +48,19 @@ func computeGatewayClassAcceptedCondition(gatewayClass *gatewayapi_v1alpha2.Gate
 	}
 }
 
+// computeGatewayClassInvalidParametersCondition computes the GatewayClass Accepted
+// status condition for a GatewayClass whose parametersRef is invalid.
+func computeGatewayClassInvalidParametersCondition(gatewayClass *gatewayapi_v1alpha2.GatewayClass, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(gatewayapi_v1alpha2.GatewayClassConditionStatusAccepted),
+		Status:             metav1.ConditionFalse,
+		Reason:             string(gatewayapi_v1alpha2.GatewayClassReasonInvalidParameters),
+		Message:            "Invalid GatewayClass: " + message,
+		ObservedGeneration: gatewayClass.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	}
+}
+
 // mergeConditions adds or updates matching conditions, and updates the transition
 // time if details of a condition have changed. Returns the updated condition array.
 func mergeConditions(conditions []metav1.Condition, updates ...metav1.Condition) []metav1.Condition {
@@ -33,13 +33,19 @@ type RouteReasonType string
 const ReasonNotImplemented RouteReasonType = "NotImplemented"
 const ReasonPathMatchType RouteReasonType = "PathMatchType"
 const ReasonHeaderMatchType RouteReasonType = "HeaderMatchType"
+const ReasonQueryParamMatchType RouteReasonType = "QueryParamMatchType"
 const ReasonHTTPRouteFilterType RouteReasonType = "HTTPRouteFilterType"
 const ReasonDegraded RouteReasonType = "Degraded"
 const ReasonValid RouteReasonType = "Valid"
 const ReasonErrorsExist RouteReasonType = "ErrorsExist"
 const ReasonGatewayAllowMismatch RouteReasonType = "GatewayAllowMismatch"
 const ReasonAllBackendRefsHaveZeroWeights RouteReasonType = "AllBackendRefsHaveZeroWeights"
+const ReasonMultipleBackendRefs RouteReasonType = "MultipleBackendRefs"
 const ReasonInvalidPathMatch RouteReasonType = "InvalidPathMatch"
+const ReasonInvalidHeaderMatch RouteReasonType = "InvalidHeaderMatch"
+const ReasonInvalidQueryParamMatch RouteReasonType = "InvalidQueryParamMatch"
+const ReasonNotAllowedByListeners RouteReasonType = "NotAllowedByListeners"
+const ReasonUnsupportedValue RouteReasonType = "UnsupportedValue"
 
 // clock is used to set lastTransitionTime on status conditions.
 var clock utilclock.Clock = utilclock.RealClock{}
@@ -130,6 +136,20 @@ func (routeUpdate *RouteConditionsUpdate) Mutate(obj client.Object) client.Objec
 		gatewayStatuses = append(gatewayStatuses, routeUpdate.combineConditions(route.Status.Parents)...)
 		route.Status.RouteStatus.Parents = gatewayStatuses
 		return route
+	case *gatewayapi_v1alpha2.TCPRoute:
+		route := o.DeepCopy()
+
+		// Set the TCPRoute status.
+		gatewayStatuses = append(gatewayStatuses, routeUpdate.combineConditions(route.Status.Parents)...)
+		route.Status.RouteStatus.Parents = gatewayStatuses
+		return route
+	case *gatewayapi_v1alpha2.UDPRoute:
+		route := o.DeepCopy()
+
+		// Set the UDPRoute status.
+		gatewayStatuses = append(gatewayStatuses, routeUpdate.combineConditions(route.Status.Parents)...)
+		route.Status.RouteStatus.Parents = gatewayStatuses
+		return route
 	default:
 		panic(fmt.Sprintf("Unsupported %T object %s/%s in RouteConditionsUpdate status mutator",
 			obj, routeUpdate.FullName.Namespace, routeUpdate.FullName.Name,
@@ -155,7 +175,7 @@ func (routeUpdate *RouteConditionsUpdate) combineConditions(gwStatus []gatewayap
 
 // isRefToGateway returns whether or not ref is a reference
 // to a Gateway with the given namespace & name.
-func isRefToGateway(ref gatewayapi_v1alpha2.ParentRef, gateway types.NamespacedName) bool {
+func isRefToGateway(ref gatewayapi_v1alpha2.ParentReference, gateway types.NamespacedName) bool {
 	return ref.Group != nil && *ref.Group == gatewayapi_v1alpha2.GroupName &&
 		ref.Kind != nil && *ref.Kind == "Gateway" &&
 		ref.Namespace != nil && *ref.Namespace == gatewayapi_v1alpha2.Namespace(gateway.Namespace) &&
@@ -164,14 +184,14 @@ func isRefToGateway(ref gatewayapi_v1alpha2.ParentRef, gateway types.NamespacedN
 
 // parentRefForGateway returns a ParentRef for a Gateway with
 // the given namespace and name.
-func parentRefForGateway(gateway types.NamespacedName) gatewayapi_v1alpha2.ParentRef {
+func parentRefForGateway(gateway types.NamespacedName) gatewayapi_v1alpha2.ParentReference {
 	var (
 		group     = gatewayapi_v1alpha2.Group(gatewayapi_v1alpha2.GroupName)
 		kind      = gatewayapi_v1alpha2.Kind("Gateway")
 		namespace = gatewayapi_v1alpha2.Namespace(gateway.Namespace)
 	)
 
-	return gatewayapi_v1alpha2.ParentRef{
+	return gatewayapi_v1alpha2.ParentReference{
 		Group:     &group,
 		Kind:      &kind,
 		Namespace: &namespace,
@@ -179,9 +199,20 @@ func parentRefForGateway(gateway types.NamespacedName) gatewayapi_v1alpha2.Paren
 	}
 }
 
-func (c *Cache) getRouteGatewayConditions(gatewayStatus []gatewayapi_v1alpha2.RouteParentStatus) map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition {
+// routeParentUpdates applies the RouteConditionsUpdates for each
+// of the Gateways that a route is attached to in turn.
+type routeParentUpdates []*RouteConditionsUpdate
+
+func (updates routeParentUpdates) Mutate(obj client.Object) client.Object {
+	for _, routeUpdate := range updates {
+		obj = routeUpdate.Mutate(obj)
+	}
+	return obj
+}
+
+func getRouteGatewayConditions(gateway types.NamespacedName, gatewayStatus []gatewayapi_v1alpha2.RouteParentStatus) map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition {
 	for _, gs := range gatewayStatus {
-		if isRefToGateway(gs.ParentRef, c.gatewayRef) {
+		if isRefToGateway(gs.ParentRef, gateway) {
 
 			conditions := make(map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition)
 			for _, gsCondition := range gs.Conditions {
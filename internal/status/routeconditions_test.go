@@ -30,7 +30,7 @@ func TestHTTPRouteAddCondition(t *testing.T) {
 	var testGeneration int64 = 7
 
 	simpleValidCondition := metav1.Condition{
-		Type:               string(gatewayapi_v1alpha2.ConditionRouteAccepted),
+		Type:               string(gatewayapi_v1alpha2.RouteConditionAccepted),
 		Status:             projectcontour.ConditionTrue,
 		Reason:             "Valid",
 		Message:            "Valid HTTPRoute",
@@ -43,7 +43,7 @@ func TestHTTPRouteAddCondition(t *testing.T) {
 		Conditions: make(map[gatewayapi_v1alpha2.RouteConditionType]metav1.Condition),
 	}
 
-	got := httpRouteUpdate.AddCondition(gatewayapi_v1alpha2.ConditionRouteAccepted, metav1.ConditionTrue, "Valid", "Valid HTTPRoute")
+	got := httpRouteUpdate.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionTrue, "Valid", "Valid HTTPRoute")
 
 	assert.Equal(t, simpleValidCondition.Message, got.Message)
 	assert.Equal(t, simpleValidCondition.Reason, got.Reason)
@@ -52,6 +52,43 @@ func TestHTTPRouteAddCondition(t *testing.T) {
 	assert.Equal(t, simpleValidCondition.ObservedGeneration, got.ObservedGeneration)
 }
 
+func TestRouteStatusUpdatesForMultipleGateways(t *testing.T) {
+	cache := NewCache("projectcontour.io/contour")
+
+	route := &gatewayapi_v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "basic"},
+		Status: gatewayapi_v1alpha2.HTTPRouteStatus{
+			RouteStatus: gatewayapi_v1alpha2.RouteStatus{
+				Parents: []gatewayapi_v1alpha2.RouteParentStatus{{
+					ParentRef:      parentRefForGateway(k8s.NamespacedNameFrom("other/gateway")),
+					ControllerName: "example.com/other",
+				}},
+			},
+		},
+	}
+
+	for _, gateway := range []string{"projectcontour/gateway-b", "projectcontour/gateway-a"} {
+		routeUpdate, commit := cache.RouteConditionsAccessor(k8s.NamespacedNameOf(route), route.Generation, &gatewayapi_v1alpha2.HTTPRoute{}, k8s.NamespacedNameFrom(gateway), route.Status.Parents)
+		routeUpdate.AddCondition(gatewayapi_v1alpha2.RouteConditionAccepted, metav1.ConditionTrue, "Valid", "Valid HTTPRoute")
+		commit()
+	}
+
+	assert.Len(t, cache.GetRouteUpdates(), 2)
+
+	// A single status update sets the route's status for both Gateways,
+	// and keeps the status set by other controllers.
+	updates := cache.GetStatusUpdates()
+	assert.Len(t, updates, 1)
+
+	updated := updates[0].Mutator.Mutate(route).(*gatewayapi_v1alpha2.HTTPRoute)
+
+	var parents []string
+	for _, parent := range updated.Status.Parents {
+		parents = append(parents, string(*parent.ParentRef.Namespace)+"/"+string(parent.ParentRef.Name))
+	}
+	assert.ElementsMatch(t, []string{"projectcontour/gateway-a", "projectcontour/gateway-b", "other/gateway"}, parents)
+}
+
 func newCondition(t string, status metav1.ConditionStatus, reason, msg string, lt time.Time) metav1.Condition {
 	return metav1.Condition{
 		Type:               t,
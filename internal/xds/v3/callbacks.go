@@ -24,13 +24,18 @@ import (
 // NewRequestLoggingCallbacks returns an implementation of the Envoy xDS server
 // callbacks for use when Contour is run in Envoy xDS server mode to provide
 // request detail logging. Currently only the xDS State of the World callback
-// OnStreamRequest is implemented.
+// OnStreamRequest and the incremental xDS callback OnStreamDeltaRequest are
+// implemented.
 func NewRequestLoggingCallbacks(log logrus.FieldLogger) envoy_server_v3.Callbacks {
 	return &envoy_server_v3.CallbackFuncs{
 		StreamRequestFunc: func(streamID int64, req *envoy_service_discovery_v3.DiscoveryRequest) error {
 			logDiscoveryRequestDetails(log, req)
 			return nil
 		},
+		StreamDeltaRequestFunc: func(streamID int64, req *envoy_service_discovery_v3.DeltaDiscoveryRequest) error {
+			logDeltaDiscoveryRequestDetails(log, req)
+			return nil
+		},
 	}
 }
 
@@ -59,3 +64,30 @@ func logDiscoveryRequestDetails(l logrus.FieldLogger, req *envoy_service_discove
 
 	return log
 }
+
+// Helper function for use in the Envoy xDS server callbacks and the Contour
+// xDS server to log incremental request details. Returns logger with fields
+// added for any subsequent error handling and logging.
+func logDeltaDiscoveryRequestDetails(l logrus.FieldLogger, req *envoy_service_discovery_v3.DeltaDiscoveryRequest) *logrus.Entry {
+	log := l.WithField("response_nonce", req.ResponseNonce)
+	if req.Node != nil {
+		log = log.WithField("node_id", req.Node.Id)
+
+		if bv := req.Node.GetUserAgentBuildVersion(); bv != nil && bv.Version != nil {
+			log = log.WithField("node_version", fmt.Sprintf("v%d.%d.%d", bv.Version.MajorNumber, bv.Version.MinorNumber, bv.Version.Patch))
+		}
+	}
+
+	if status := req.ErrorDetail; status != nil {
+		// if Envoy rejected the last update log the details here.
+		log.WithField("code", status.Code).Error(status.Message)
+	}
+
+	log = log.WithField("resource_names_subscribe", req.ResourceNamesSubscribe).
+		WithField("resource_names_unsubscribe", req.ResourceNamesUnsubscribe).
+		WithField("type_url", req.GetTypeUrl())
+
+	log.Debug("handling v3 xDS delta resource request")
+
+	return log
+}
@@ -39,7 +39,8 @@ type grpcStream interface {
 
 // NewContourServer creates an internally implemented Server that streams the
 // provided set of Resource objects. The returned Server implements the xDS
-// State of the World (SotW) variant.
+// State of the World (SotW) variant for each resource type, and the
+// incremental (delta) variant for each resource type and over ADS.
 func NewContourServer(log logrus.FieldLogger, resources ...xds.Resource) Server {
 	c := contourServer{
 		FieldLogger: log,
@@ -54,9 +55,10 @@ func NewContourServer(log logrus.FieldLogger, resources ...xds.Resource) Server
 }
 
 type contourServer struct {
-	// Since we only implement the streaming state of the world
-	// protocol, embed the default null implementations to handle
-	// the unimplemented gRPC endpoints.
+	// Since we don't implement every streaming variant of every
+	// service (e.g. state of the world ADS, or fetch), embed the
+	// default null implementations to handle the unimplemented
+	// gRPC endpoints.
 	envoy_service_discovery_v3.UnimplementedAggregatedDiscoveryServiceServer
 	envoy_service_secret_v3.UnimplementedSecretDiscoveryServiceServer
 	envoy_service_route_v3.UnimplementedRouteDiscoveryServiceServer
@@ -177,3 +179,27 @@ func (s *contourServer) StreamRoutes(srv envoy_service_route_v3.RouteDiscoverySe
 func (s *contourServer) StreamSecrets(srv envoy_service_secret_v3.SecretDiscoveryService_StreamSecretsServer) error {
 	return s.stream(srv)
 }
+
+func (s *contourServer) DeltaAggregatedResources(srv envoy_service_discovery_v3.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
+	return s.deltaStream(srv)
+}
+
+func (s *contourServer) DeltaClusters(srv envoy_service_cluster_v3.ClusterDiscoveryService_DeltaClustersServer) error {
+	return s.deltaStream(srv)
+}
+
+func (s *contourServer) DeltaEndpoints(srv envoy_service_endpoint_v3.EndpointDiscoveryService_DeltaEndpointsServer) error {
+	return s.deltaStream(srv)
+}
+
+func (s *contourServer) DeltaListeners(srv envoy_service_listener_v3.ListenerDiscoveryService_DeltaListenersServer) error {
+	return s.deltaStream(srv)
+}
+
+func (s *contourServer) DeltaRoutes(srv envoy_service_route_v3.RouteDiscoveryService_DeltaRoutesServer) error {
+	return s.deltaStream(srv)
+}
+
+func (s *contourServer) DeltaSecrets(srv envoy_service_secret_v3.SecretDiscoveryService_DeltaSecretsServer) error {
+	return s.deltaStream(srv)
+}
@@ -0,0 +1,406 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_cache_v3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/projectcontour/contour/internal/xds"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// wildcard is the resource name used by delta xDS clients to explicitly
+// subscribe to, or unsubscribe from, every resource of a type.
+const wildcard = "*"
+
+type deltaGrpcStream interface {
+	Context() context.Context
+	Send(*envoy_service_discovery_v3.DeltaDiscoveryResponse) error
+	Recv() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error)
+}
+
+// deltaWatch tracks the state of a single resource type on a
+// delta xDS stream.
+type deltaWatch struct {
+	resource xds.Resource
+
+	// wildcard is true if the client is subscribed to every
+	// resource of this type.
+	wildcard bool
+
+	// subscribed holds the resource names the client has
+	// explicitly subscribed to.
+	subscribed map[string]struct{}
+
+	// acked holds the version of each resource the client has
+	// acknowledged, keyed by resource name.
+	acked map[string]string
+
+	// pending holds the changes sent to the client which have
+	// not yet been acknowledged, in the order they were sent.
+	pending []deltaChanges
+
+	// ch receives notifications from resource, registered is
+	// true while the watch is waiting on ch.
+	ch         chan int
+	registered bool
+	last       int
+}
+
+// deltaChanges holds the changes sent in a single response. A nil
+// version records a removal.
+type deltaChanges struct {
+	nonce   string
+	changes map[string]*string
+}
+
+func newDeltaWatch(r xds.Resource) *deltaWatch {
+	return &deltaWatch{
+		resource:   r,
+		subscribed: map[string]struct{}{},
+		acked:      map[string]string{},
+		ch:         make(chan int, 1),
+		// internally all registration values start at zero so
+		// a last that is less than zero will guarantee that the
+		// first registration fires immediately.
+		last: -1,
+	}
+}
+
+// subscribe applies the subscription changes carried by req.
+func (w *deltaWatch) subscribe(req *envoy_service_discovery_v3.DeltaDiscoveryRequest, first bool) {
+	if first {
+		// An empty initial subscription list is an implicit
+		// wildcard subscription.
+		w.wildcard = len(req.ResourceNamesSubscribe) == 0
+
+		// Resources the client already has from a previous
+		// stream, so we don't need to send them again.
+		for name, version := range req.InitialResourceVersions {
+			w.acked[name] = version
+		}
+	}
+
+	for _, name := range req.ResourceNamesSubscribe {
+		if name == wildcard {
+			w.wildcard = true
+			continue
+		}
+		w.subscribed[name] = struct{}{}
+	}
+
+	for _, name := range req.ResourceNamesUnsubscribe {
+		if name == wildcard {
+			w.wildcard = false
+			continue
+		}
+		delete(w.subscribed, name)
+
+		// The client has forgotten this resource, so if it
+		// subscribes again it must be sent in full.
+		delete(w.acked, name)
+		for _, p := range w.pending {
+			delete(p.changes, name)
+		}
+	}
+}
+
+// interested returns true if the client is subscribed to the
+// named resource.
+func (w *deltaWatch) interested(name string) bool {
+	if w.wildcard {
+		return true
+	}
+	_, ok := w.subscribed[name]
+	return ok
+}
+
+// ack records the changes sent with nonce as acknowledged by the client.
+func (w *deltaWatch) ack(nonce string) {
+	if changes, ok := w.remove(nonce); ok {
+		apply(w.acked, changes)
+	}
+}
+
+// nack discards the changes sent with nonce. The client did not
+// apply them, so they will be resent on the next change.
+func (w *deltaWatch) nack(nonce string) {
+	w.remove(nonce)
+}
+
+// remove removes and returns the pending changes sent with nonce.
+func (w *deltaWatch) remove(nonce string) (map[string]*string, bool) {
+	for i, p := range w.pending {
+		if p.nonce == nonce {
+			w.pending = append(w.pending[:i], w.pending[i+1:]...)
+			return p.changes, true
+		}
+	}
+	return nil, false
+}
+
+// sent returns the version of each resource the client will have once
+// it has applied every response sent so far, keyed by resource name.
+func (w *deltaWatch) sent() map[string]string {
+	sent := make(map[string]string, len(w.acked))
+	for name, version := range w.acked {
+		sent[name] = version
+	}
+	for _, p := range w.pending {
+		apply(sent, p.changes)
+	}
+	return sent
+}
+
+// apply applies changes to the resource versions in versions.
+func apply(versions map[string]string, changes map[string]*string) {
+	for name, version := range changes {
+		if version == nil {
+			delete(versions, name)
+			continue
+		}
+		versions[name] = *version
+	}
+}
+
+// diff returns the response needed to bring the client up to date with
+// the current contents of the watched resource. If the client is
+// already up to date, diff returns nil.
+func (w *deltaWatch) diff(nonce string) (*envoy_service_discovery_v3.DeltaDiscoveryResponse, error) {
+	current := map[string]proto.Message{}
+	for _, m := range w.resource.Contents() {
+		if m == nil {
+			return nil, fmt.Errorf("nil resource in %q", w.resource.TypeURL())
+		}
+		current[envoy_cache_v3.GetResourceName(proto.MessageV2(m))] = m
+	}
+
+	resp := &envoy_service_discovery_v3.DeltaDiscoveryResponse{
+		SystemVersionInfo: strconv.Itoa(w.last),
+		TypeUrl:           w.resource.TypeURL(),
+		Nonce:             nonce,
+	}
+	changes := map[string]*string{}
+
+	// Compare against what has been sent rather than what has been
+	// acknowledged, so that a change made while an earlier response
+	// is unacknowledged is still sent.
+	sent := w.sent()
+
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !w.interested(name) {
+			continue
+		}
+
+		marshaled, err := envoy_cache_v3.MarshalResource(proto.MessageV2(current[name]))
+		if err != nil {
+			return nil, err
+		}
+		version := envoy_cache_v3.HashResource(marshaled)
+		if sent[name] == version {
+			continue
+		}
+
+		a, err := anypb.New(proto.MessageV2(current[name]))
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Resources = append(resp.Resources, &envoy_service_discovery_v3.Resource{
+			Name:     name,
+			Version:  version,
+			Resource: a,
+		})
+		changes[name] = &version
+	}
+
+	for name := range sent {
+		if _, ok := current[name]; ok && w.interested(name) {
+			continue
+		}
+		resp.RemovedResources = append(resp.RemovedResources, name)
+		changes[name] = nil
+	}
+	sort.Strings(resp.RemovedResources)
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	w.pending = append(w.pending, deltaChanges{nonce: nonce, changes: changes})
+	return resp, nil
+}
+
+// deltaStream processes a stream of DeltaDiscoveryRequests. The stream may
+// carry requests for a single type, or for any number of types in the case
+// of the aggregated discovery service.
+func (s *contourServer) deltaStream(st deltaGrpcStream) error {
+	// Bump connection counter and set it as a field on the logger.
+	log := s.WithField("connection", s.connections.Next()).WithField("delta", true)
+
+	// Notify whether the stream terminated on error.
+	done := func(log logrus.FieldLogger, err error) error {
+		if err != nil {
+			log.WithError(err).Error("stream terminated")
+		} else {
+			log.Info("stream terminated")
+		}
+
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(st.Context())
+	defer cancel()
+
+	// Receive requests in their own goroutine so that we can wait
+	// on requests and resource notifications at the same time.
+	type recvResult struct {
+		req *envoy_service_discovery_v3.DeltaDiscoveryRequest
+		err error
+	}
+	reqs := make(chan recvResult)
+	go func() {
+		for {
+			req, err := st.Recv()
+			select {
+			case reqs <- recvResult{req: req, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// Notifications from every watch on this stream are funneled
+	// into a single channel, tagged with the watch's typeURL.
+	type notification struct {
+		typeURL string
+		last    int
+	}
+	notifications := make(chan notification)
+
+	watches := map[string]*deltaWatch{}
+	var nonces xds.Counter
+
+	register := func(typeURL string, w *deltaWatch) {
+		if w.registered {
+			return
+		}
+		w.registered = true
+		w.resource.Register(w.ch, w.last)
+		go func() {
+			select {
+			case last := <-w.ch:
+				select {
+				case notifications <- notification{typeURL: typeURL, last: last}:
+				case <-ctx.Done():
+				}
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	respond := func(w *deltaWatch) error {
+		resp, err := w.diff(strconv.FormatUint(nonces.Next(), 10))
+		if err != nil || resp == nil {
+			return err
+		}
+		return st.Send(resp)
+	}
+
+	// now stick in this loop until the client disconnects.
+	for {
+		select {
+		case r := <-reqs:
+			if r.err != nil {
+				return done(log, r.err)
+			}
+
+			// Note: redeclare log in this scope so the next time around the loop all is forgotten.
+			log := logDeltaDiscoveryRequestDetails(log, r.req)
+
+			typeURL := r.req.GetTypeUrl()
+			w, ok := watches[typeURL]
+			if !ok {
+				res, ok := s.resources[typeURL]
+				if !ok {
+					return done(log, fmt.Errorf("no resource registered for typeURL %q", typeURL))
+				}
+				w = newDeltaWatch(res)
+				watches[typeURL] = w
+				w.subscribe(r.req, true)
+
+				// The first registration fires immediately, which
+				// sends the initial response.
+				register(typeURL, w)
+				continue
+			}
+
+			if nonce := r.req.GetResponseNonce(); nonce != "" {
+				if r.req.ErrorDetail != nil {
+					w.nack(nonce)
+				} else {
+					w.ack(nonce)
+				}
+			}
+
+			if len(r.req.ResourceNamesSubscribe) == 0 && len(r.req.ResourceNamesUnsubscribe) == 0 {
+				// A plain ACK or NACK, nothing further to send.
+				continue
+			}
+
+			w.subscribe(r.req, false)
+
+			// Only respond once the watch has received its first
+			// notification, otherwise the initial response will
+			// include the new subscriptions.
+			if w.last >= 0 {
+				if err := respond(w); err != nil {
+					return done(log, err)
+				}
+			}
+
+		case n := <-notifications:
+			w := watches[n.typeURL]
+			w.registered = false
+			w.last = n.last
+
+			// boom, something in the cache has changed, send the
+			// client whatever it does not already have.
+			if err := respond(w); err != nil {
+				return done(log, err)
+			}
+
+			register(n.typeURL, w)
+
+		case <-ctx.Done():
+			return done(log, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright Project Contour Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	envoy_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/projectcontour/contour/internal/xds"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+const clusterType = "type.googleapis.com/envoy.config.cluster.v3.Cluster"
+
+func TestXDSHandlerDeltaStreamErrors(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(ioutil.Discard)
+
+	tests := map[string]struct {
+		xh     contourServer
+		stream deltaGrpcStream
+		want   error
+	}{
+		"recv returns error immediately": {
+			xh: contourServer{FieldLogger: log},
+			stream: &mockDeltaStream{
+				context: context.Background,
+				recv: func() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error) {
+					return nil, io.EOF
+				},
+			},
+			want: io.EOF,
+		},
+		"no registered typeURL": {
+			xh: contourServer{FieldLogger: log},
+			stream: &mockDeltaStream{
+				context: context.Background,
+				recv: func() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error) {
+					return &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+						TypeUrl: "io.projectcontour.potato",
+					}, nil
+				},
+			},
+			want: fmt.Errorf("no resource registered for typeURL %q", "io.projectcontour.potato"),
+		},
+		"failed to send": {
+			xh: contourServer{
+				FieldLogger: log,
+				resources: map[string]xds.Resource{
+					clusterType: newDeltaResource(clusterType, &envoy_cluster_v3.Cluster{Name: "potato"}),
+				},
+			},
+			stream: &mockDeltaStream{
+				context: context.Background,
+				recv: onceThenBlock(&envoy_service_discovery_v3.DeltaDiscoveryRequest{
+					TypeUrl: clusterType,
+				}),
+				send: func(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) error {
+					return io.EOF
+				},
+			},
+			want: io.EOF,
+		},
+		"context canceled": {
+			xh: contourServer{
+				FieldLogger: log,
+				resources: map[string]xds.Resource{
+					clusterType: newDeltaResource(clusterType),
+				},
+			},
+			stream: &mockDeltaStream{
+				context: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+					return ctx
+				},
+				recv: onceThenBlock(&envoy_service_discovery_v3.DeltaDiscoveryRequest{
+					TypeUrl: clusterType,
+				}),
+			},
+			want: context.Canceled,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.xh.deltaStream(tc.stream)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestXDSHandlerDeltaStream(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(ioutil.Discard)
+
+	res := newDeltaResource(clusterType,
+		&envoy_cluster_v3.Cluster{Name: "a"},
+		&envoy_cluster_v3.Cluster{Name: "b"},
+	)
+	xh := contourServer{
+		FieldLogger: log,
+		resources:   map[string]xds.Resource{clusterType: res},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	st := &chanDeltaStream{
+		ctx:  ctx,
+		reqs: make(chan *envoy_service_discovery_v3.DeltaDiscoveryRequest),
+		resp: make(chan *envoy_service_discovery_v3.DeltaDiscoveryResponse, 1),
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- xh.deltaStream(st) }()
+
+	// Initial wildcard subscription gets every resource.
+	st.reqs <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{TypeUrl: clusterType}
+	resp := st.recv(t)
+	assert.Equal(t, []string{"a", "b"}, resourceNames(resp))
+	assert.Empty(t, resp.RemovedResources)
+	st.request(&envoy_service_discovery_v3.DeltaDiscoveryRequest{TypeUrl: clusterType, ResponseNonce: resp.Nonce})
+
+	// A notification without any change to the contents sends nothing.
+	res.set(&envoy_cluster_v3.Cluster{Name: "a"}, &envoy_cluster_v3.Cluster{Name: "b"})
+	st.nothing(t)
+
+	// Changing one resource and removing another only sends the difference.
+	res.set(&envoy_cluster_v3.Cluster{Name: "a", AltStatName: "changed"})
+	resp = st.recv(t)
+	assert.Equal(t, []string{"a"}, resourceNames(resp))
+	assert.Equal(t, []string{"b"}, resp.RemovedResources)
+
+	// A NACK means the client never applied the change, so the
+	// next notification must resend it.
+	st.request(&envoy_service_discovery_v3.DeltaDiscoveryRequest{
+		TypeUrl:       clusterType,
+		ResponseNonce: resp.Nonce,
+		ErrorDetail:   &status.Status{Message: "rejected"},
+	})
+	res.set(&envoy_cluster_v3.Cluster{Name: "a", AltStatName: "changed"})
+	resp = st.recv(t)
+	assert.Equal(t, []string{"a"}, resourceNames(resp))
+	assert.Equal(t, []string{"b"}, resp.RemovedResources)
+	st.request(&envoy_service_discovery_v3.DeltaDiscoveryRequest{TypeUrl: clusterType, ResponseNonce: resp.Nonce})
+
+	// Unsubscribing from the wildcard and subscribing to a single name
+	// removes everything else.
+	res.set(&envoy_cluster_v3.Cluster{Name: "a", AltStatName: "changed"}, &envoy_cluster_v3.Cluster{Name: "c"})
+	resp = st.recv(t)
+	assert.Equal(t, []string{"c"}, resourceNames(resp))
+	st.reqs <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+		TypeUrl:                  clusterType,
+		ResponseNonce:            resp.Nonce,
+		ResourceNamesSubscribe:   []string{"c"},
+		ResourceNamesUnsubscribe: []string{wildcard},
+	}
+	resp = st.recv(t)
+	assert.Empty(t, resp.Resources)
+	assert.Equal(t, []string{"a"}, resp.RemovedResources)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-errs)
+}
+
+func TestXDSHandlerDeltaStreamUnacknowledged(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(ioutil.Discard)
+
+	res := newDeltaResource(clusterType,
+		&envoy_cluster_v3.Cluster{Name: "a"},
+	)
+	xh := contourServer{
+		FieldLogger: log,
+		resources:   map[string]xds.Resource{clusterType: res},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	st := &chanDeltaStream{
+		ctx:  ctx,
+		reqs: make(chan *envoy_service_discovery_v3.DeltaDiscoveryRequest),
+		resp: make(chan *envoy_service_discovery_v3.DeltaDiscoveryResponse, 1),
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- xh.deltaStream(st) }()
+
+	st.reqs <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{TypeUrl: clusterType}
+	first := st.recv(t)
+	assert.Equal(t, []string{"a"}, resourceNames(first))
+
+	// Adding a resource before the first response is acknowledged
+	// only sends the new resource.
+	res.set(&envoy_cluster_v3.Cluster{Name: "a"}, &envoy_cluster_v3.Cluster{Name: "b"})
+	added := st.recv(t)
+	assert.Equal(t, []string{"b"}, resourceNames(added))
+
+	// Deleting the resource before its add is acknowledged must
+	// still send the removal.
+	res.set(&envoy_cluster_v3.Cluster{Name: "a"})
+	removed := st.recv(t)
+	assert.Empty(t, removed.Resources)
+	assert.Equal(t, []string{"b"}, removed.RemovedResources)
+
+	// Acknowledging the responses in order sends nothing further.
+	for _, resp := range []*envoy_service_discovery_v3.DeltaDiscoveryResponse{first, added, removed} {
+		st.request(&envoy_service_discovery_v3.DeltaDiscoveryRequest{TypeUrl: clusterType, ResponseNonce: resp.Nonce})
+	}
+	st.nothing(t)
+
+	// Re-adding a resource while its removal is unacknowledged
+	// must send it again.
+	res.set()
+	removed = st.recv(t)
+	assert.Equal(t, []string{"a"}, removed.RemovedResources)
+	res.set(&envoy_cluster_v3.Cluster{Name: "a"})
+	readded := st.recv(t)
+	assert.Equal(t, []string{"a"}, resourceNames(readded))
+	assert.Empty(t, readded.RemovedResources)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-errs)
+}
+
+func TestXDSHandlerDeltaStreamInitialVersions(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(ioutil.Discard)
+
+	res := newDeltaResource(clusterType,
+		&envoy_cluster_v3.Cluster{Name: "a"},
+		&envoy_cluster_v3.Cluster{Name: "b"},
+	)
+	xh := contourServer{
+		FieldLogger: log,
+		resources:   map[string]xds.Resource{clusterType: res},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	st := &chanDeltaStream{
+		ctx:  ctx,
+		reqs: make(chan *envoy_service_discovery_v3.DeltaDiscoveryRequest),
+		resp: make(chan *envoy_service_discovery_v3.DeltaDiscoveryResponse, 1),
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- xh.deltaStream(st) }()
+
+	// Learn the version of "a" from a first stream.
+	st.reqs <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{TypeUrl: clusterType}
+	resp := st.recv(t)
+	require.Len(t, resp.Resources, 2)
+	versionA := resp.Resources[0].Version
+	cancel()
+	<-errs
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	st = &chanDeltaStream{
+		ctx:  ctx,
+		reqs: make(chan *envoy_service_discovery_v3.DeltaDiscoveryRequest),
+		resp: make(chan *envoy_service_discovery_v3.DeltaDiscoveryResponse, 1),
+	}
+	go func() { errs <- xh.deltaStream(st) }()
+
+	// Reconnecting with "a" already present, and a stale "z", only
+	// sends "b" and removes "z".
+	st.reqs <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+		TypeUrl: clusterType,
+		InitialResourceVersions: map[string]string{
+			"a": versionA,
+			"z": "stale",
+		},
+	}
+	resp = st.recv(t)
+	assert.Equal(t, []string{"b"}, resourceNames(resp))
+	assert.Equal(t, []string{"z"}, resp.RemovedResources)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-errs)
+}
+
+func resourceNames(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) []string {
+	var names []string
+	for _, r := range resp.Resources {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func onceThenBlock(req *envoy_service_discovery_v3.DeltaDiscoveryRequest) func() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error) {
+	var once sync.Once
+	return func() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error) {
+		sent := false
+		once.Do(func() { sent = true })
+		if !sent {
+			select {}
+		}
+		return req, nil
+	}
+}
+
+type mockDeltaStream struct {
+	context func() context.Context
+	send    func(*envoy_service_discovery_v3.DeltaDiscoveryResponse) error
+	recv    func() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error)
+}
+
+func (m *mockDeltaStream) Context() context.Context { return m.context() }
+func (m *mockDeltaStream) Send(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) error {
+	return m.send(resp)
+}
+func (m *mockDeltaStream) Recv() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error) {
+	return m.recv()
+}
+
+type chanDeltaStream struct {
+	ctx  context.Context
+	reqs chan *envoy_service_discovery_v3.DeltaDiscoveryRequest
+	resp chan *envoy_service_discovery_v3.DeltaDiscoveryResponse
+}
+
+func (c *chanDeltaStream) Context() context.Context { return c.ctx }
+func (c *chanDeltaStream) Send(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) error {
+	c.resp <- resp
+	return nil
+}
+func (c *chanDeltaStream) Recv() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error) {
+	select {
+	case req := <-c.reqs:
+		return req, nil
+	case <-c.ctx.Done():
+		return nil, c.ctx.Err()
+	}
+}
+
+// request sends req to the server, and returns once the server
+// has finished handling it.
+func (c *chanDeltaStream) request(req *envoy_service_discovery_v3.DeltaDiscoveryRequest) {
+	c.reqs <- req
+	// The server reads requests in order, so once this no-op has
+	// been received, req has been handled.
+	c.reqs <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{TypeUrl: req.TypeUrl}
+}
+
+func (c *chanDeltaStream) recv(t *testing.T) *envoy_service_discovery_v3.DeltaDiscoveryResponse {
+	t.Helper()
+	select {
+	case resp := <-c.resp:
+		return resp
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for response")
+		return nil
+	}
+}
+
+func (c *chanDeltaStream) nothing(t *testing.T) {
+	t.Helper()
+	select {
+	case resp := <-c.resp:
+		t.Fatalf("unexpected response: %v", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// deltaResource is an xds.Resource whose contents can be replaced,
+// notifying every registered channel.
+type deltaResource struct {
+	mu       sync.Mutex
+	typeURL  string
+	contents []proto.Message
+	last     int
+	waiters  []chan int
+}
+
+func newDeltaResource(typeURL string, contents ...proto.Message) *deltaResource {
+	return &deltaResource{typeURL: typeURL, contents: contents}
+}
+
+func (d *deltaResource) set(contents ...proto.Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.contents = contents
+	d.last++
+	for _, ch := range d.waiters {
+		ch <- d.last
+	}
+	d.waiters = nil
+}
+
+func (d *deltaResource) Contents() []proto.Message {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.contents
+}
+
+func (d *deltaResource) Query([]string) []proto.Message { return nil }
+
+func (d *deltaResource) Register(ch chan int, last int, _ ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if last < d.last {
+		ch <- d.last
+		return
+	}
+	d.waiters = append(d.waiters, ch)
+}
+
+func (d *deltaResource) TypeURL() string { return d.typeURL }
@@ -15,9 +15,11 @@ package v3
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"sync"
 
+	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/golang/protobuf/proto"
@@ -29,6 +31,7 @@ import (
 	"github.com/projectcontour/contour/internal/sorter"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 )
@@ -79,6 +82,224 @@ func RecalculateEndpoints(port v1.ServicePort, ep *v1.Endpoints) []*LoadBalancin
 	return lb
 }
 
+// RecalculateEndpointSlices generates a slice of LocalityEndpoints
+// resources by matching the given service port to the given EndpointSlices,
+// which must all belong to the same Service. Endpoints are grouped into one
+// LocalityEndpoints per topology zone, ordered by zone name. Endpoints
+// that don't set a zone are in the zone of their node, as given by
+// nodeZones, which maps node names to the value of their
+// topology.kubernetes.io/zone label. Endpoints whose zone is still
+// unknown are grouped into a LocalityEndpoints with no locality.
+//
+// Ready endpoints are always preferred. If the Service has no ready
+// endpoints for the port, endpoints that are still serving while they
+// terminate are used instead, so that in-flight traffic can drain.
+//
+// slices may be empty, in which case, the result is nil.
+func RecalculateEndpointSlices(port v1.ServicePort, slices map[string]*discovery_v1.EndpointSlice, nodeZones map[string]string) []*LocalityEndpoints {
+	type endpoint struct {
+		zone string
+		ip   string
+		port int
+	}
+
+	var ready, serving []endpoint
+
+	// Iterate slices in name order so that the result is stable.
+	names := make([]string, 0, len(slices))
+	for name := range slices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := slices[name]
+
+		// FQDN endpoints aren't addresses Envoy can connect to.
+		if s.AddressType != discovery_v1.AddressTypeIPv4 && s.AddressType != discovery_v1.AddressTypeIPv6 {
+			continue
+		}
+
+		for _, p := range s.Ports {
+			if p.Port == nil {
+				continue
+			}
+
+			if p.Protocol != nil && port.Protocol != *p.Protocol && *p.Protocol != v1.ProtocolTCP {
+				// NOTE: we only support "TCP", which is the default.
+				continue
+			}
+
+			// If the port isn't named, it must be the
+			// only Service port, so it's a match by
+			// definition. Otherwise, only take endpoint
+			// ports that match the service port name.
+			if port.Name != "" && (p.Name == nil || port.Name != *p.Name) {
+				continue
+			}
+
+			for _, ep := range s.Endpoints {
+				zone := ""
+				switch {
+				case ep.Zone != nil:
+					zone = *ep.Zone
+				case ep.NodeName != nil:
+					zone = nodeZones[*ep.NodeName]
+				}
+
+				// A nil condition is interpreted as true, see
+				// the discovery.k8s.io/v1 EndpointConditions docs.
+				isReady := ep.Conditions.Ready == nil || *ep.Conditions.Ready
+				isServing := ep.Conditions.Serving == nil || *ep.Conditions.Serving
+				isTerminating := ep.Conditions.Terminating != nil && *ep.Conditions.Terminating
+
+				for _, addr := range ep.Addresses {
+					e := endpoint{zone: zone, ip: addr, port: int(*p.Port)}
+					switch {
+					case isReady:
+						ready = append(ready, e)
+					case isServing && isTerminating:
+						serving = append(serving, e)
+					}
+				}
+			}
+		}
+	}
+
+	endpoints := ready
+	if len(endpoints) == 0 {
+		endpoints = serving
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].zone != endpoints[j].zone {
+			return endpoints[i].zone < endpoints[j].zone
+		}
+		if endpoints[i].ip != endpoints[j].ip {
+			return endpoints[i].ip < endpoints[j].ip
+		}
+		return endpoints[i].port < endpoints[j].port
+	})
+
+	var localities []*LocalityEndpoints
+	var last *endpoint
+	for i, e := range endpoints {
+		// The same endpoint can transiently appear in more
+		// than one slice, only include it once.
+		if last != nil && *last == e {
+			continue
+		}
+
+		if last == nil || last.zone != e.zone {
+			la := &LocalityEndpoints{}
+			if e.zone != "" {
+				la.Locality = &envoy_core_v3.Locality{Zone: e.zone}
+			}
+			localities = append(localities, la)
+		}
+
+		la := localities[len(localities)-1]
+		la.LbEndpoints = append(la.LbEndpoints, envoy_v3.LBEndpoint(envoy_v3.SocketAddress(e.ip, e.port)))
+		last = &endpoints[i]
+	}
+
+	return localities
+}
+
+// weightedLocalities holds the localities of a single weighted service.
+type weightedLocalities struct {
+	weight     uint32
+	localities []*LocalityEndpoints
+}
+
+// weighLocalities sets the load balancing weight of the localities of
+// each service and returns them all. The weight of a service is shared
+// between its localities in proportion to their number of endpoints,
+// so that the total weight of each service's localities is proportional
+// to the weight of the service, however many zones it spans. As with
+// Endpoints, a service with a zero weight is assigned no load.
+func weighLocalities(services []weightedLocalities) []*LocalityEndpoints {
+	// Scale every service's total weight by the least common multiple
+	// of the services' endpoint counts, so that each locality's share
+	// is a whole number.
+	var sum uint64
+	scale := uint64(1)
+	exact := true
+	for _, s := range services {
+		n := uint64(endpointCount(s.localities))
+		if n == 0 || s.weight == 0 {
+			continue
+		}
+		sum += uint64(s.weight)
+		if exact {
+			scale = scale / gcd(scale, n) * n
+			exact = scale <= math.MaxUint32
+		}
+	}
+
+	// The sum of the locality weights must fit in a uint32.
+	exact = exact && sum <= math.MaxUint32/scale
+
+	var weights []uint64
+	var localities []*LocalityEndpoints
+	for _, s := range services {
+		n := uint64(endpointCount(s.localities))
+		for _, la := range s.localities {
+			var w uint64
+			switch {
+			case s.weight == 0:
+				// No load.
+			case exact:
+				w = uint64(s.weight) * (scale / n) * uint64(len(la.LbEndpoints))
+			default:
+				// The exact weights are too large for Envoy, so
+				// approximate them, keeping every locality in use.
+				share := float64(s.weight) / float64(sum) * float64(len(la.LbEndpoints)) / float64(n)
+				w = uint64(share * (1 << 24))
+				if w == 0 {
+					w = 1
+				}
+			}
+			weights = append(weights, w)
+			localities = append(localities, la)
+		}
+	}
+
+	// Keep the weights as small as possible.
+	var divisor uint64
+	for _, w := range weights {
+		divisor = gcd(divisor, w)
+	}
+	for i, la := range localities {
+		if divisor > 0 {
+			weights[i] /= divisor
+		}
+		la.LoadBalancingWeight = protobuf.UInt32OrNil(uint32(weights[i]))
+	}
+
+	return localities
+}
+
+// endpointCount returns the number of endpoints in localities.
+func endpointCount(localities []*LocalityEndpoints) int {
+	var n int
+	for _, la := range localities {
+		n += len(la.LbEndpoints)
+	}
+	return n
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
 // EndpointsCache is a cache of Endpoint and ServiceCluster objects.
 type EndpointsCache struct {
 	mu sync.Mutex // Protects all fields.
@@ -95,6 +316,18 @@ type EndpointsCache struct {
 
 	// Cache of endpoints, indexed by name.
 	endpoints map[types.NamespacedName]*v1.Endpoints
+
+	// useEndpointSlices is true if ClusterLoadAssignments are
+	// calculated from endpointSlices rather than endpoints.
+	useEndpointSlices bool
+
+	// Cache of endpoint slices, indexed by the name of the Service
+	// they belong to, then by the name of the slice.
+	endpointSlices map[types.NamespacedName]map[string]*discovery_v1.EndpointSlice
+
+	// Zones of the nodes, indexed by node name. Endpoints that
+	// don't set their zone are in the zone of their node.
+	nodeZones map[string]string
 }
 
 // Recalculate regenerates all the ClusterLoadAssignments from the
@@ -120,10 +353,28 @@ func (c *EndpointsCache) Recalculate() map[string]*envoy_endpoint_v3.ClusterLoad
 			Policy:      nil,
 		}
 
+		if c.useEndpointSlices {
+			// Each zone of each service is a separate locality, and
+			// the weight of the service is shared between its zones.
+			var services []weightedLocalities
+			for _, w := range cluster.Services {
+				n := types.NamespacedName{Namespace: w.ServiceNamespace, Name: w.ServiceName}
+				services = append(services, weightedLocalities{
+					weight:     w.Weight,
+					localities: RecalculateEndpointSlices(w.ServicePort, c.endpointSlices[n], c.nodeZones),
+				})
+			}
+			cla.Endpoints = weighLocalities(services)
+
+			assignments[cla.ClusterName] = &cla
+			continue
+		}
+
 		// Look up each service, and if we have endpoints for that service,
 		// attach them as a new LocalityEndpoints resource2.
 		for _, w := range cluster.Services {
 			n := types.NamespacedName{Namespace: w.ServiceNamespace, Name: w.ServiceName}
+
 			if lb := RecalculateEndpoints(w.ServicePort, c.endpoints[n]); lb != nil {
 				// Append the new set of endpoints. Users are allowed to set the load
 				// balancing weight to 0, which we reflect to Envoy as nil in order to
@@ -227,6 +478,144 @@ func (c *EndpointsCache) DeleteEndpoint(ep *v1.Endpoints) bool {
 	return false
 }
 
+// endpointSliceServiceName returns the name of the Service that the
+// EndpointSlice belongs to. ok is false if the slice isn't managed on
+// behalf of a Service.
+func endpointSliceServiceName(s *discovery_v1.EndpointSlice) (name types.NamespacedName, ok bool) {
+	svc, ok := s.Labels[discovery_v1.LabelServiceName]
+	if !ok || svc == "" {
+		return types.NamespacedName{}, false
+	}
+
+	return types.NamespacedName{Namespace: s.Namespace, Name: svc}, true
+}
+
+// UpdateEndpointSlice adds s to the cache, or replaces it if it is
+// already cached. Any ServiceClusters that are backed by the Service
+// that s belongs to become stale. Returns a boolean indicating whether
+// any ServiceClusters use s or not.
+func (c *EndpointsCache) UpdateEndpointSlice(s *discovery_v1.EndpointSlice) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name, ok := endpointSliceServiceName(s)
+	if !ok {
+		return false
+	}
+
+	if c.endpointSlices[name] == nil {
+		c.endpointSlices[name] = map[string]*discovery_v1.EndpointSlice{}
+	}
+	c.endpointSlices[name][s.Name] = s.DeepCopy()
+
+	// If any service clusters include this service, mark them
+	// all as stale.
+	if affected := c.services[name]; len(affected) > 0 {
+		c.stale = append(c.stale, affected...)
+		return true
+	}
+
+	return false
+}
+
+// DeleteEndpointSlice deletes s from the cache. Any ServiceClusters
+// that are backed by the Service that s belongs to become stale. Returns
+// a boolean indicating whether any ServiceClusters use s or not.
+func (c *EndpointsCache) DeleteEndpointSlice(s *discovery_v1.EndpointSlice) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name, ok := endpointSliceServiceName(s)
+	if !ok {
+		return false
+	}
+
+	delete(c.endpointSlices[name], s.Name)
+	if len(c.endpointSlices[name]) == 0 {
+		delete(c.endpointSlices, name)
+	}
+
+	// If any service clusters include this service, mark them
+	// all as stale.
+	if affected := c.services[name]; len(affected) > 0 {
+		c.stale = append(c.stale, affected...)
+		return true
+	}
+
+	return false
+}
+
+// UpdateNode records the zone of node, from its topology.kubernetes.io/zone
+// label. If the zone changed, any ServiceClusters that are backed by a
+// Service with an endpoint on the node that doesn't set its own zone
+// become stale. Returns a boolean indicating whether any ServiceClusters
+// became stale or not.
+func (c *EndpointsCache) UpdateNode(node *v1.Node) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	zone := node.Labels[v1.LabelTopologyZone]
+	if old, ok := c.nodeZones[node.Name]; ok && old == zone {
+		return false
+	}
+	c.nodeZones[node.Name] = zone
+
+	return c.markNodeStale(node.Name)
+}
+
+// DeleteNode forgets the zone of node. Any ServiceClusters that are
+// backed by a Service with an endpoint on the node that doesn't set
+// its own zone become stale. Returns a boolean indicating whether any
+// ServiceClusters became stale or not.
+func (c *EndpointsCache) DeleteNode(node *v1.Node) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.nodeZones[node.Name]; !ok {
+		return false
+	}
+	delete(c.nodeZones, node.Name)
+
+	return c.markNodeStale(node.Name)
+}
+
+// markNodeStale marks the ServiceClusters that are backed by a Service
+// with an endpoint on the named node that doesn't set its own zone as
+// stale. Returns a boolean indicating whether any ServiceClusters use
+// such an endpoint or not. c.mu must be held.
+func (c *EndpointsCache) markNodeStale(nodeName string) bool {
+	stale := false
+	for name, affected := range c.services {
+		if len(affected) > 0 && endpointSlicesUseNodeZone(c.endpointSlices[name], nodeName) {
+			c.stale = append(c.stale, affected...)
+			stale = true
+		}
+	}
+	return stale
+}
+
+// endpointSlicesUseNodeZone returns true if any endpoint of the given
+// slices is on the named node and doesn't set its own zone.
+func endpointSlicesUseNodeZone(slices map[string]*discovery_v1.EndpointSlice, nodeName string) bool {
+	for _, s := range slices {
+		for _, ep := range s.Endpoints {
+			if ep.Zone == nil && ep.NodeName != nil && *ep.NodeName == nodeName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewEndpointSliceTranslator allocates a new endpoints translator that
+// translates Kubernetes EndpointSlice objects, rather than Endpoints
+// objects, into Envoy ClusterLoadAssignment resources.
+func NewEndpointSliceTranslator(log logrus.FieldLogger) *EndpointsTranslator {
+	e := NewEndpointsTranslator(log)
+	e.cache.useEndpointSlices = true
+	return e
+}
+
 // NewEndpointsTranslator allocates a new endpoints translator.
 func NewEndpointsTranslator(log logrus.FieldLogger) *EndpointsTranslator {
 	return &EndpointsTranslator{
@@ -234,19 +623,26 @@ func NewEndpointsTranslator(log logrus.FieldLogger) *EndpointsTranslator {
 		FieldLogger: log,
 		entries:     map[string]*envoy_endpoint_v3.ClusterLoadAssignment{},
 		cache: EndpointsCache{
-			stale:     nil,
-			services:  map[types.NamespacedName][]*dag.ServiceCluster{},
-			endpoints: map[types.NamespacedName]*v1.Endpoints{},
+			stale:          nil,
+			services:       map[types.NamespacedName][]*dag.ServiceCluster{},
+			endpoints:      map[types.NamespacedName]*v1.Endpoints{},
+			endpointSlices: map[types.NamespacedName]map[string]*discovery_v1.EndpointSlice{},
+			nodeZones:      map[string]string{},
 		},
 	}
 }
 
-// A EndpointsTranslator translates Kubernetes Endpoints objects into Envoy
-// ClusterLoadAssignment resources.
+// A EndpointsTranslator translates Kubernetes Endpoints, or EndpointSlice,
+// objects into Envoy ClusterLoadAssignment resources.
 type EndpointsTranslator struct {
 	// Observer notifies when the endpoints cache has been updated.
 	Observer contour.Observer
 
+	// LocalCluster, if set, is translated along with the service
+	// clusters from the DAG. It holds the Envoy fleet, which Envoy
+	// uses as its local cluster when zone aware routing is enabled.
+	LocalCluster *dag.ServiceCluster
+
 	contour.Cond
 	logrus.FieldLogger
 
@@ -285,6 +681,14 @@ func (e *EndpointsTranslator) OnChange(root *dag.DAG) {
 		}
 	}
 
+	if e.LocalCluster != nil {
+		if _, ok := names[e.LocalCluster.ClusterName]; ok {
+			e.Errorf("dropping local cluster with duplicate name %q", e.LocalCluster.ClusterName)
+		} else {
+			clusters = append(clusters, e.LocalCluster.DeepCopy())
+		}
+	}
+
 	// Update the cache with the new clusters.
 	if err := e.cache.SetClusters(clusters); err != nil {
 		e.WithError(err).Error("failed to cache service clusters")
@@ -348,6 +752,28 @@ func (e *EndpointsTranslator) OnAdd(obj interface{}) {
 		if e.Observer != nil {
 			e.Observer.Refresh()
 		}
+	case *discovery_v1.EndpointSlice:
+		if !e.cache.UpdateEndpointSlice(obj) {
+			return
+		}
+
+		e.WithField("endpointslice", k8s.NamespacedNameOf(obj)).Debug("EndpointSlice is in use by a ServiceCluster, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
+	case *v1.Node:
+		if !e.cache.UpdateNode(obj) {
+			return
+		}
+
+		e.WithField("node", obj.Name).Debug("Node zone is in use by a ServiceCluster, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
 	default:
 		e.Errorf("OnAdd unexpected type %T: %#v", obj, obj)
 	}
@@ -385,6 +811,47 @@ func (e *EndpointsTranslator) OnUpdate(oldObj, newObj interface{}) {
 		if e.Observer != nil {
 			e.Observer.Refresh()
 		}
+	case *discovery_v1.EndpointSlice:
+		oldObj, ok := oldObj.(*discovery_v1.EndpointSlice)
+		if !ok {
+			e.Errorf("OnUpdate endpointslice %#v received invalid oldObj %T; %#v", newObj, oldObj, oldObj)
+			return
+		}
+
+		if oldObj == newObj {
+			return
+		}
+
+		// If there are no endpoints in this object, and the old
+		// object also had zero endpoints, ignore this update
+		// to avoid sending a noop notification to watchers.
+		if len(oldObj.Endpoints) == 0 && len(newObj.Endpoints) == 0 {
+			return
+		}
+
+		if !e.cache.UpdateEndpointSlice(newObj) {
+			return
+		}
+
+		e.WithField("endpointslice", k8s.NamespacedNameOf(newObj)).Debug("EndpointSlice is in use by a ServiceCluster, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
+	case *v1.Node:
+		// Only the zone label of a node is used, and UpdateNode
+		// ignores the update unless the zone changed.
+		if !e.cache.UpdateNode(newObj) {
+			return
+		}
+
+		e.WithField("node", newObj.Name).Debug("Node zone is in use by a ServiceCluster, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
 	default:
 		e.Errorf("OnUpdate unexpected type %T: %#v", newObj, newObj)
 	}
@@ -403,6 +870,28 @@ func (e *EndpointsTranslator) OnDelete(obj interface{}) {
 		if e.Observer != nil {
 			e.Observer.Refresh()
 		}
+	case *discovery_v1.EndpointSlice:
+		if !e.cache.DeleteEndpointSlice(obj) {
+			return
+		}
+
+		e.WithField("endpointslice", k8s.NamespacedNameOf(obj)).Debug("EndpointSlice was in use by a ServiceCluster, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
+	case *v1.Node:
+		if !e.cache.DeleteNode(obj) {
+			return
+		}
+
+		e.WithField("node", obj.Name).Debug("Node zone was in use by a ServiceCluster, recalculating ClusterLoadAssignments")
+		e.Merge(e.cache.Recalculate())
+		e.Notify()
+		if e.Observer != nil {
+			e.Observer.Refresh()
+		}
 	case cache.DeletedFinalStateUnknown:
 		e.OnDelete(obj.Obj) // recurse into ourselves with the tombstoned value
 	default:
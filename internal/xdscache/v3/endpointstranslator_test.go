@@ -16,6 +16,7 @@ package v3
 import (
 	"testing"
 
+	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	"github.com/golang/protobuf/proto"
 	"github.com/projectcontour/contour/internal/dag"
@@ -25,6 +26,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func TestEndpointsTranslatorContents(t *testing.T) {
@@ -862,6 +866,456 @@ func TestEndpointsTranslatorDefaultWeightedService(t *testing.T) {
 	protobuf.ExpectEqual(t, want, et.Contents())
 }
 
+func TestRecalculateEndpointSlices(t *testing.T) {
+	tests := map[string]struct {
+		port      v1.ServicePort
+		slices    []*discovery_v1.EndpointSlice
+		nodeZones map[string]string
+		want      []*LocalityEndpoints
+	}{
+		"no slices": {
+			port: v1.ServicePort{},
+			want: nil,
+		},
+		"single slice without zones": {
+			port: v1.ServicePort{},
+			slices: []*discovery_v1.EndpointSlice{
+				endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("", 8080)),
+					sliceEndpoint("", "10.0.0.2"),
+					sliceEndpoint("", "10.0.0.1"),
+				),
+			},
+			want: envoy_v3.Endpoints(
+				envoy_v3.SocketAddress("10.0.0.1", 8080),
+				envoy_v3.SocketAddress("10.0.0.2", 8080),
+			),
+		},
+		"slices are merged and grouped by zone": {
+			port: v1.ServicePort{},
+			slices: []*discovery_v1.EndpointSlice{
+				endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("", 8080)),
+					sliceEndpoint("zone-b", "10.0.0.3"),
+					sliceEndpoint("zone-a", "10.0.0.2"),
+				),
+				endpointSlice("default", "simple-def", "simple", slicePorts(slicePort("", 8080)),
+					sliceEndpoint("zone-a", "10.0.0.1"),
+					// duplicated across slices
+					sliceEndpoint("zone-b", "10.0.0.3"),
+				),
+			},
+			want: []*LocalityEndpoints{
+				{
+					Locality: &envoy_core_v3.Locality{Zone: "zone-a"},
+					LbEndpoints: []*LoadBalancingEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080)),
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.2", 8080)),
+					},
+				},
+				{
+					Locality: &envoy_core_v3.Locality{Zone: "zone-b"},
+					LbEndpoints: []*LoadBalancingEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.3", 8080)),
+					},
+				},
+			},
+		},
+		"endpoints without a zone are in the zone of their node": {
+			port: v1.ServicePort{},
+			slices: []*discovery_v1.EndpointSlice{
+				endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("", 8080)),
+					withNodeName(sliceEndpoint("", "10.0.0.1"), "node-1"),
+					withNodeName(sliceEndpoint("zone-a", "10.0.0.2"), "node-1"),
+					withNodeName(sliceEndpoint("", "10.0.0.3"), "node-2"),
+					withNodeName(sliceEndpoint("", "10.0.0.4"), "node-3"),
+				),
+			},
+			nodeZones: map[string]string{
+				"node-1": "zone-b",
+				"node-2": "zone-a",
+			},
+			want: []*LocalityEndpoints{
+				{
+					LbEndpoints: []*LoadBalancingEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.4", 8080)),
+					},
+				},
+				{
+					Locality: &envoy_core_v3.Locality{Zone: "zone-a"},
+					LbEndpoints: []*LoadBalancingEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.2", 8080)),
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.3", 8080)),
+					},
+				},
+				{
+					Locality: &envoy_core_v3.Locality{Zone: "zone-b"},
+					LbEndpoints: []*LoadBalancingEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080)),
+					},
+				},
+			},
+		},
+		"named port": {
+			port: v1.ServicePort{Name: "https"},
+			slices: []*discovery_v1.EndpointSlice{
+				endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("http", 8080), slicePort("https", 8443)),
+					sliceEndpoint("", "10.0.0.1"),
+				),
+			},
+			want: envoy_v3.Endpoints(
+				envoy_v3.SocketAddress("10.0.0.1", 8443),
+			),
+		},
+		"not ready endpoints are skipped": {
+			port: v1.ServicePort{},
+			slices: []*discovery_v1.EndpointSlice{
+				endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("", 8080)),
+					sliceEndpoint("", "10.0.0.1"),
+					withConditions(sliceEndpoint("", "10.0.0.2"), false, false, false),
+					withConditions(sliceEndpoint("", "10.0.0.3"), false, true, true),
+				),
+			},
+			want: envoy_v3.Endpoints(
+				envoy_v3.SocketAddress("10.0.0.1", 8080),
+			),
+		},
+		"serving terminating endpoints are used if none are ready": {
+			port: v1.ServicePort{},
+			slices: []*discovery_v1.EndpointSlice{
+				endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("", 8080)),
+					withConditions(sliceEndpoint("", "10.0.0.1"), false, false, true),
+					withConditions(sliceEndpoint("", "10.0.0.2"), false, true, true),
+				),
+			},
+			want: envoy_v3.Endpoints(
+				envoy_v3.SocketAddress("10.0.0.2", 8080),
+			),
+		},
+		"fqdn slices are skipped": {
+			port: v1.ServicePort{},
+			slices: []*discovery_v1.EndpointSlice{
+				func() *discovery_v1.EndpointSlice {
+					s := endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("", 8080)),
+						sliceEndpoint("", "example.com"),
+					)
+					s.AddressType = discovery_v1.AddressTypeFQDN
+					return s
+				}(),
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			slices := map[string]*discovery_v1.EndpointSlice{}
+			for _, s := range tc.slices {
+				slices[s.Name] = s
+			}
+
+			got := RecalculateEndpointSlices(tc.port, slices, tc.nodeZones)
+			protobuf.ExpectEqual(t, tc.want, got)
+		})
+	}
+}
+
+func TestEndpointSliceTranslator(t *testing.T) {
+	et := NewEndpointSliceTranslator(fixture.NewTestLogger(t))
+	clusters := []*dag.ServiceCluster{
+		{
+			ClusterName: "default/simple",
+			Services: []dag.WeightedService{
+				{
+					Weight:           1,
+					ServiceName:      "simple",
+					ServiceNamespace: "default",
+					ServicePort:      v1.ServicePort{},
+				},
+			},
+		},
+	}
+	require.NoError(t, et.cache.SetClusters(clusters))
+
+	a := endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("", 8080)),
+		sliceEndpoint("zone-a", "10.0.0.1"),
+	)
+	b := endpointSlice("default", "simple-def", "simple", slicePorts(slicePort("", 8080)),
+		sliceEndpoint("zone-b", "10.0.0.2"),
+	)
+
+	// Endpoints objects are ignored in EndpointSlice mode.
+	et.OnAdd(endpoints("default", "simple", v1.EndpointSubset{
+		Addresses: addresses("192.168.183.24"),
+		Ports:     ports(port("", 8080)),
+	}))
+	et.OnAdd(a)
+	et.OnAdd(b)
+
+	protobuf.ExpectEqual(t, []proto.Message{
+		&envoy_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: "default/simple",
+			Endpoints: []*envoy_endpoint_v3.LocalityLbEndpoints{
+				{
+					Locality:            &envoy_core_v3.Locality{Zone: "zone-a"},
+					LbEndpoints:         []*LoadBalancingEndpoint{envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080))},
+					LoadBalancingWeight: protobuf.UInt32(1),
+				},
+				{
+					Locality:            &envoy_core_v3.Locality{Zone: "zone-b"},
+					LbEndpoints:         []*LoadBalancingEndpoint{envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.2", 8080))},
+					LoadBalancingWeight: protobuf.UInt32(1),
+				},
+			},
+		},
+	}, et.Contents())
+
+	et.OnDelete(a)
+
+	protobuf.ExpectEqual(t, []proto.Message{
+		&envoy_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: "default/simple",
+			Endpoints: []*envoy_endpoint_v3.LocalityLbEndpoints{
+				{
+					Locality:            &envoy_core_v3.Locality{Zone: "zone-b"},
+					LbEndpoints:         []*LoadBalancingEndpoint{envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.2", 8080))},
+					LoadBalancingWeight: protobuf.UInt32(1),
+				},
+			},
+		},
+	}, et.Contents())
+
+	et.OnDelete(b)
+
+	protobuf.ExpectEqual(t, []proto.Message{
+		&envoy_endpoint_v3.ClusterLoadAssignment{ClusterName: "default/simple"},
+	}, et.Contents())
+}
+
+func TestEndpointSliceTranslatorNodeZones(t *testing.T) {
+	et := NewEndpointSliceTranslator(fixture.NewTestLogger(t))
+	clusters := []*dag.ServiceCluster{
+		{
+			ClusterName: "default/simple",
+			Services: []dag.WeightedService{
+				{
+					Weight:           1,
+					ServiceName:      "simple",
+					ServiceNamespace: "default",
+					ServicePort:      v1.ServicePort{},
+				},
+			},
+		},
+	}
+	require.NoError(t, et.cache.SetClusters(clusters))
+
+	node := func(name, zone string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{v1.LabelTopologyZone: zone},
+			},
+		}
+	}
+	assignment := func(zone string) []proto.Message {
+		la := &envoy_endpoint_v3.LocalityLbEndpoints{
+			LbEndpoints:         []*LoadBalancingEndpoint{envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080))},
+			LoadBalancingWeight: protobuf.UInt32(1),
+		}
+		if zone != "" {
+			la.Locality = &envoy_core_v3.Locality{Zone: zone}
+		}
+		return []proto.Message{
+			&envoy_endpoint_v3.ClusterLoadAssignment{
+				ClusterName: "default/simple",
+				Endpoints:   []*envoy_endpoint_v3.LocalityLbEndpoints{la},
+			},
+		}
+	}
+
+	et.OnAdd(endpointSlice("default", "simple-abc", "simple", slicePorts(slicePort("", 8080)),
+		withNodeName(sliceEndpoint("", "10.0.0.1"), "node-1"),
+	))
+
+	// The zone of the node isn't known yet.
+	protobuf.ExpectEqual(t, assignment(""), et.Contents())
+
+	et.OnAdd(node("node-1", "zone-a"))
+	protobuf.ExpectEqual(t, assignment("zone-a"), et.Contents())
+
+	et.OnUpdate(node("node-1", "zone-a"), node("node-1", "zone-b"))
+	protobuf.ExpectEqual(t, assignment("zone-b"), et.Contents())
+
+	et.OnDelete(node("node-1", "zone-b"))
+	protobuf.ExpectEqual(t, assignment(""), et.Contents())
+}
+
+func TestEndpointSliceTranslatorWeightedServices(t *testing.T) {
+	et := NewEndpointSliceTranslator(fixture.NewTestLogger(t))
+	clusters := []*dag.ServiceCluster{
+		{
+			ClusterName: "default/weighted",
+			Services: []dag.WeightedService{
+				{
+					Weight:           1,
+					ServiceName:      "spread",
+					ServiceNamespace: "default",
+				},
+				{
+					Weight:           3,
+					ServiceName:      "single",
+					ServiceNamespace: "default",
+				},
+			},
+		},
+	}
+	require.NoError(t, et.cache.SetClusters(clusters))
+
+	et.OnAdd(endpointSlice("default", "spread-abc", "spread", slicePorts(slicePort("", 8080)),
+		sliceEndpoint("zone-a", "10.0.0.1"),
+		sliceEndpoint("zone-a", "10.0.0.2"),
+		sliceEndpoint("zone-b", "10.0.0.3"),
+	))
+	et.OnAdd(endpointSlice("default", "single-abc", "single", slicePorts(slicePort("", 8080)),
+		sliceEndpoint("zone-a", "10.0.1.1"),
+		sliceEndpoint("zone-a", "10.0.1.2"),
+	))
+
+	// The weight of each service is shared between its zones in
+	// proportion to their endpoints, so "spread" still gets a
+	// quarter of the load even though it spans two zones.
+	protobuf.ExpectEqual(t, []proto.Message{
+		&envoy_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: "default/weighted",
+			Endpoints: []*envoy_endpoint_v3.LocalityLbEndpoints{
+				{
+					Locality: &envoy_core_v3.Locality{Zone: "zone-a"},
+					LbEndpoints: []*LoadBalancingEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080)),
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.2", 8080)),
+					},
+					LoadBalancingWeight: protobuf.UInt32(2),
+				},
+				{
+					Locality:            &envoy_core_v3.Locality{Zone: "zone-b"},
+					LbEndpoints:         []*LoadBalancingEndpoint{envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.3", 8080))},
+					LoadBalancingWeight: protobuf.UInt32(1),
+				},
+				{
+					Locality: &envoy_core_v3.Locality{Zone: "zone-a"},
+					LbEndpoints: []*LoadBalancingEndpoint{
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.1.1", 8080)),
+						envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.1.2", 8080)),
+					},
+					LoadBalancingWeight: protobuf.UInt32(9),
+				},
+			},
+		},
+	}, et.Contents())
+}
+
+func TestWeighLocalities(t *testing.T) {
+	locality := func(zone string, endpoints int) *LocalityEndpoints {
+		la := &LocalityEndpoints{Locality: &envoy_core_v3.Locality{Zone: zone}}
+		for i := 0; i < endpoints; i++ {
+			la.LbEndpoints = append(la.LbEndpoints, envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080+i)))
+		}
+		return la
+	}
+	weights := func(localities []*LocalityEndpoints) []uint32 {
+		var w []uint32
+		for _, la := range localities {
+			w = append(w, la.GetLoadBalancingWeight().GetValue())
+		}
+		return w
+	}
+
+	tests := map[string]struct {
+		services []weightedLocalities
+		want     []uint32
+	}{
+		"single service across zones": {
+			services: []weightedLocalities{
+				{weight: 1, localities: []*LocalityEndpoints{locality("a", 1), locality("b", 1), locality("c", 1)}},
+			},
+			want: []uint32{1, 1, 1},
+		},
+		"services across different numbers of zones": {
+			services: []weightedLocalities{
+				{weight: 50, localities: []*LocalityEndpoints{locality("a", 1), locality("b", 1), locality("c", 1)}},
+				{weight: 50, localities: []*LocalityEndpoints{locality("a", 2)}},
+			},
+			want: []uint32{1, 1, 1, 3},
+		},
+		"zero weight service": {
+			services: []weightedLocalities{
+				{weight: 1, localities: []*LocalityEndpoints{locality("a", 1), locality("b", 1)}},
+				{weight: 0, localities: []*LocalityEndpoints{locality("a", 1)}},
+			},
+			want: []uint32{1, 1, 0},
+		},
+		"service without endpoints": {
+			services: []weightedLocalities{
+				{weight: 1, localities: []*LocalityEndpoints{locality("a", 2)}},
+				{weight: 1},
+			},
+			want: []uint32{1},
+		},
+		"weights too large to scale exactly": {
+			services: []weightedLocalities{
+				{weight: 1 << 30, localities: []*LocalityEndpoints{locality("a", 1), locality("b", 2)}},
+				{weight: 1 << 30, localities: []*LocalityEndpoints{locality("a", 7)}},
+			},
+			want: []uint32{(1 << 24) / 6, (1 << 24) / 3, (1 << 24) / 2},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, weights(weighLocalities(tc.services)))
+		})
+	}
+}
+
+func TestEndpointSliceTranslatorLocalCluster(t *testing.T) {
+	et := NewEndpointSliceTranslator(fixture.NewTestLogger(t))
+	et.LocalCluster = &dag.ServiceCluster{
+		ClusterName: "envoy-local",
+		Services: []dag.WeightedService{
+			{
+				Weight:           1,
+				ServiceName:      "envoy",
+				ServiceNamespace: "projectcontour",
+			},
+		},
+	}
+
+	// The local cluster is translated even though the DAG
+	// holds no service clusters.
+	et.OnChange(&dag.DAG{})
+
+	et.OnAdd(endpointSlice("projectcontour", "envoy-abc", "envoy", slicePorts(slicePort("http", 8080)),
+		sliceEndpoint("zone-a", "10.0.0.1"),
+		sliceEndpoint("zone-b", "10.0.0.2"),
+	))
+
+	protobuf.ExpectEqual(t, []proto.Message{
+		&envoy_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: "envoy-local",
+			Endpoints: []*envoy_endpoint_v3.LocalityLbEndpoints{
+				{
+					Locality:            &envoy_core_v3.Locality{Zone: "zone-a"},
+					LbEndpoints:         []*LoadBalancingEndpoint{envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.1", 8080))},
+					LoadBalancingWeight: protobuf.UInt32(1),
+				},
+				{
+					Locality:            &envoy_core_v3.Locality{Zone: "zone-b"},
+					LbEndpoints:         []*LoadBalancingEndpoint{envoy_v3.LBEndpoint(envoy_v3.SocketAddress("10.0.0.2", 8080))},
+					LoadBalancingWeight: protobuf.UInt32(1),
+				},
+			},
+		},
+	}, et.Contents())
+}
+
 func TestEqual(t *testing.T) {
 	tests := map[string]struct {
 		a, b map[string]*envoy_endpoint_v3.ClusterLoadAssignment
@@ -973,3 +1427,54 @@ func clusterloadassignments(clas ...*envoy_endpoint_v3.ClusterLoadAssignment) ma
 	}
 	return m
 }
+
+func endpointSlice(ns, name, service string, ports []discovery_v1.EndpointPort, eps ...discovery_v1.Endpoint) *discovery_v1.EndpointSlice {
+	return &discovery_v1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				discovery_v1.LabelServiceName: service,
+			},
+		},
+		AddressType: discovery_v1.AddressTypeIPv4,
+		Ports:       ports,
+		Endpoints:   eps,
+	}
+}
+
+func sliceEndpoint(zone string, addrs ...string) discovery_v1.Endpoint {
+	ep := discovery_v1.Endpoint{
+		Addresses: addrs,
+	}
+	if zone != "" {
+		ep.Zone = pointer.String(zone)
+	}
+	return ep
+}
+
+func withNodeName(ep discovery_v1.Endpoint, nodeName string) discovery_v1.Endpoint {
+	ep.NodeName = pointer.String(nodeName)
+	return ep
+}
+
+func withConditions(ep discovery_v1.Endpoint, ready, serving, terminating bool) discovery_v1.Endpoint {
+	ep.Conditions = discovery_v1.EndpointConditions{
+		Ready:       pointer.Bool(ready),
+		Serving:     pointer.Bool(serving),
+		Terminating: pointer.Bool(terminating),
+	}
+	return ep
+}
+
+func slicePorts(eps ...discovery_v1.EndpointPort) []discovery_v1.EndpointPort {
+	return eps
+}
+
+func slicePort(name string, port int32) discovery_v1.EndpointPort {
+	return discovery_v1.EndpointPort{
+		Name:     pointer.String(name),
+		Port:     pointer.Int32(port),
+		Protocol: (*v1.Protocol)(pointer.String("TCP")),
+	}
+}
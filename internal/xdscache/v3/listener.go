@@ -14,7 +14,6 @@
 package v3
 
 import (
-	"path"
 	"sort"
 	"sync"
 
@@ -131,6 +130,19 @@ type ListenerConfig struct {
 	// RateLimitConfig optionally configures the global Rate Limit Service to be
 	// used.
 	RateLimitConfig *RateLimitConfig
+
+	// AccessLogService optionally configures an access log service
+	// to which access logs are streamed.
+	AccessLogService *AccessLogServiceConfig
+
+	// AccessLogFilter optionally restricts the HTTP requests that
+	// are access logged. It does not apply to requests that match an
+	// access log policy.
+	AccessLogFilter *envoy_v3.AccessLogFilterConfig
+
+	// TracingConfig optionally configures the collector to which
+	// spans are exported.
+	TracingConfig *TracingConfig
 }
 
 type RateLimitConfig struct {
@@ -141,6 +153,29 @@ type RateLimitConfig struct {
 	EnableXRateLimitHeaders bool
 }
 
+type AccessLogServiceConfig struct {
+	Type                 contour_api_v1alpha1.AccessLogServiceType
+	ExtensionService     types.NamespacedName
+	LogName              string
+	BufferFlushInterval  timeout.Setting
+	BufferSizeBytes      uint32
+	DisableFileAccessLog bool
+}
+
+type TracingConfig struct {
+	ExtensionService  types.NamespacedName
+	CollectorEndpoint string
+	OverallSampling   float64
+	MaxPathTagLength  uint32
+	CustomTags        []*CustomTag
+}
+
+type CustomTag struct {
+	TagName           string
+	Literal           string
+	RequestHeaderName string
+}
+
 // DefaultListeners returns the configured Listeners or a single
 // Insecure (http) & single Secure (https) default listeners
 // if not provided.
@@ -234,21 +269,150 @@ func (lvc *ListenerConfig) accesslogFields() contour_api_v1alpha1.AccessLogField
 	return contour_api_v1alpha1.DefaultFields
 }
 
-func (lvc *ListenerConfig) newInsecureAccessLog() []*envoy_accesslog_v3.AccessLog {
-	switch lvc.accesslogType() {
-	case string(config.JSONAccessLog):
-		return envoy_v3.FileAccessLogJSON(lvc.httpAccessLog(), lvc.accesslogFields(), lvc.AccessLogFormatterExtensions)
-	default:
-		return envoy_v3.FileAccessLogEnvoy(lvc.httpAccessLog(), lvc.AccessLogFormatString, lvc.AccessLogFormatterExtensions)
+func (lvc *ListenerConfig) newInsecureAccessLog(vhosts []*dag.VirtualHost) []*envoy_accesslog_v3.AccessLog {
+	var marked []*dag.AccessLogPolicy
+	for _, vh := range vhosts {
+		if vh.AccessLogPolicy != nil {
+			marked = append(marked, vh.AccessLogPolicy)
+		}
+		marked = append(marked, routeAccessLogPolicies(vh)...)
 	}
+	return lvc.newPolicyAccessLog(lvc.httpAccessLog(), nil, marked)
 }
 
-func (lvc *ListenerConfig) newSecureAccessLog() []*envoy_accesslog_v3.AccessLog {
-	switch lvc.accesslogType() {
-	case "json":
-		return envoy_v3.FileAccessLogJSON(lvc.httpsAccessLog(), lvc.accesslogFields(), lvc.AccessLogFormatterExtensions)
+func (lvc *ListenerConfig) newSecureAccessLog(vh *dag.VirtualHost) []*envoy_accesslog_v3.AccessLog {
+	if vh == nil {
+		return lvc.newPolicyAccessLog(lvc.httpsAccessLog(), nil, nil)
+	}
+	return lvc.newPolicyAccessLog(lvc.httpsAccessLog(), vh.AccessLogPolicy, routeAccessLogPolicies(vh))
+}
+
+func (lvc *ListenerConfig) newInsecureTCPAccessLog() []*envoy_accesslog_v3.AccessLog {
+	return lvc.newAccessLog(lvc.httpAccessLog(), envoy_v3.TCPGRPCAccessLog, nil)
+}
+
+func (lvc *ListenerConfig) newSecureTCPAccessLog() []*envoy_accesslog_v3.AccessLog {
+	return lvc.newAccessLog(lvc.httpsAccessLog(), envoy_v3.TCPGRPCAccessLog, nil)
+}
+
+// routeAccessLogPolicies returns the access log policies of the
+// routes of the given virtual host.
+func routeAccessLogPolicies(vh *dag.VirtualHost) []*dag.AccessLogPolicy {
+	var policies []*dag.AccessLogPolicy
+	for _, r := range vhostRoutes(vh) {
+		if r.AccessLogPolicy != nil {
+			policies = append(policies, r.AccessLogPolicy)
+		}
+	}
+	return policies
+}
+
+// newPolicyAccessLog returns the HTTP access logs for the requests
+// marked with the given access log policies, each logged according
+// to its policy. Requests not marked with any of them are logged
+// according to the given policy, or the global configuration if nil.
+func (lvc *ListenerConfig) newPolicyAccessLog(path string, policy *dag.AccessLogPolicy, marked []*dag.AccessLogPolicy) []*envoy_accesslog_v3.AccessLog {
+	// A policy may be marked on several routes, such as
+	// when its HTTPProxy is included more than once.
+	policies := map[string]*dag.AccessLogPolicy{}
+	for _, p := range marked {
+		policies[p.Name] = p
+	}
+
+	var names []string
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var accessLogs []*envoy_accesslog_v3.AccessLog
+	for _, name := range names {
+		p := policies[name]
+		if p.Disabled {
+			continue
+		}
+		accessLogs = append(accessLogs, envoy_v3.FilterAccessLogs(
+			lvc.newAccessLog(path, envoy_v3.HTTPGRPCAccessLog, p),
+			envoy_v3.AndAccessLogFilter(envoy_v3.MarkedAccessLogFilter(name), lvc.accessLogFilter(p)))...)
+	}
+
+	if policy == nil || !policy.Disabled {
+		var unmarked *envoy_accesslog_v3.AccessLogFilter
+		if len(names) > 0 {
+			unmarked = envoy_v3.UnmarkedAccessLogFilter()
+		}
+		accessLogs = append(accessLogs, envoy_v3.FilterAccessLogs(
+			lvc.newAccessLog(path, envoy_v3.HTTPGRPCAccessLog, policy),
+			envoy_v3.AndAccessLogFilter(unmarked, lvc.accessLogFilter(policy)))...)
+	}
+
+	return accessLogs
+}
+
+// accessLogFilter returns the filter for the HTTP access log of the
+// requests the given policy applies to. The globally configured filter
+// applies when there is no policy, since a policy overrides it.
+func (lvc *ListenerConfig) accessLogFilter(policy *dag.AccessLogPolicy) *envoy_accesslog_v3.AccessLogFilter {
+	if policy != nil {
+		return envoy_v3.AccessLogFilter(policy)
+	}
+	return envoy_v3.GlobalAccessLogFilter(lvc.AccessLogFilter)
+}
+
+// newAccessLog returns the file access log for path, unless disabled,
+// followed by the access log service sink if one is configured. The
+// grpcAccessLog function builds the sink for the gRPC access log service
+// protocol, which differs between HTTP and TCP proxies. The format of
+// the access log may be overridden by the given policy.
+func (lvc *ListenerConfig) newAccessLog(path string, grpcAccessLog func(*envoy_v3.AccessLogServiceConfig) []*envoy_accesslog_v3.AccessLog, policy *dag.AccessLogPolicy) []*envoy_accesslog_v3.AccessLog {
+	var accessLogs []*envoy_accesslog_v3.AccessLog
+
+	logType := lvc.accesslogType()
+	formatString := lvc.AccessLogFormatString
+	fields := lvc.accesslogFields()
+	extensions := lvc.AccessLogFormatterExtensions
+
+	if policy != nil && (policy.Format != "" || policy.FormatString != "" || len(policy.Fields) > 0) {
+		if policy.Format != "" {
+			logType = policy.Format
+		}
+		if policy.FormatString != "" {
+			formatString = policy.FormatString
+		}
+		if len(policy.Fields) > 0 {
+			fields = policy.Fields
+		}
+		extensions = contour_api_v1alpha1.AccessLogFormatterExtensions(contour_api_v1alpha1.AccessLogType(logType), fields, &formatString)
+	}
+
+	als := lvc.AccessLogService
+	if als == nil || !als.DisableFileAccessLog {
+		switch logType {
+		case string(config.JSONAccessLog):
+			accessLogs = envoy_v3.FileAccessLogJSON(path, fields, extensions)
+		default:
+			accessLogs = envoy_v3.FileAccessLogEnvoy(path, formatString, extensions)
+		}
+	}
+
+	if als == nil {
+		return accessLogs
+	}
+
+	alsConfig := &envoy_v3.AccessLogServiceConfig{
+		ExtensionService:    als.ExtensionService,
+		LogName:             als.LogName,
+		BufferFlushInterval: als.BufferFlushInterval,
+		BufferSizeBytes:     als.BufferSizeBytes,
+	}
+
+	switch {
+	case als.Type != contour_api_v1alpha1.OpenTelemetryAccessLogService:
+		return append(accessLogs, grpcAccessLog(alsConfig)...)
+	case logType == string(config.JSONAccessLog):
+		return append(accessLogs, envoy_v3.OpenTelemetryAccessLogJSON(alsConfig, fields)...)
 	default:
-		return envoy_v3.FileAccessLogEnvoy(lvc.httpsAccessLog(), lvc.AccessLogFormatString, lvc.AccessLogFormatterExtensions)
+		return append(accessLogs, envoy_v3.OpenTelemetryAccessLogEnvoy(alsConfig, formatString)...)
 	}
 }
 
@@ -344,6 +508,15 @@ func (c *ListenerCache) Query(names []string) []proto.Message {
 
 func (*ListenerCache) TypeURL() string { return resource.ListenerType }
 
+// listenerAddress returns the address of the given DAG
+// listener or DEFAULT_HTTP_LISTENER_ADDRESS if not set.
+func listenerAddress(listener *dag.Listener) string {
+	if listener.Address != "" {
+		return listener.Address
+	}
+	return DEFAULT_HTTP_LISTENER_ADDRESS
+}
+
 func (c *ListenerCache) OnChange(root *dag.DAG) {
 	cfg := c.Config.defaultListeners()
 	listeners := c.Config.secureListeners()
@@ -360,33 +533,80 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 	// by the listener processor.
 	for _, listener := range root.Listeners {
 		if len(listener.VirtualHosts) > 0 {
-			if httpListener, ok := cfg.HTTPListeners[listener.Name]; ok {
-				// Add a listener if there are vhosts bound to http.
-				cm := envoy_v3.HTTPConnectionManagerBuilder().
-					Codec(envoy_v3.CodecForVersions(cfg.DefaultHTTPVersions...)).
-					DefaultFilters().
-					RouteConfigName(httpListener.Name).
-					MetricsPrefix(httpListener.Name).
-					AccessLoggers(cfg.newInsecureAccessLog()).
-					RequestTimeout(cfg.Timeouts.Request).
-					ConnectionIdleTimeout(cfg.Timeouts.ConnectionIdle).
-					StreamIdleTimeout(cfg.Timeouts.StreamIdle).
-					DelayedCloseTimeout(cfg.Timeouts.DelayedClose).
-					MaxConnectionDuration(cfg.Timeouts.MaxConnectionDuration).
-					ConnectionShutdownGracePeriod(cfg.Timeouts.ConnectionShutdownGracePeriod).
-					AllowChunkedLength(cfg.AllowChunkedLength).
-					AddFilter(envoy_v3.OriginalIPDetectionFilter(cfg.XffNumTrustedHops)).
-					AddFilter(envoy_v3.GlobalRateLimitFilter(envoyGlobalRateLimitConfig(cfg.RateLimitConfig))).
-					Get()
-
-				listeners[httpListener.Name] = envoy_v3.Listener(
-					httpListener.Name,
-					httpListener.Address,
-					httpListener.Port,
-					proxyProtocol(cfg.UseProxyProto),
-					cm,
-				)
+			// The default HTTP listener is bound to the configured
+			// address and port, other HTTP listeners are bound to
+			// their own port.
+			httpListener, ok := cfg.HTTPListeners[listener.Name]
+			if !ok {
+				httpListener = Listener{
+					Name:    listener.Name,
+					Address: listenerAddress(listener),
+					Port:    listener.Port,
+				}
 			}
+
+			// Add a listener if there are vhosts bound to http.
+			cm := envoy_v3.HTTPConnectionManagerBuilder().
+				Codec(envoy_v3.CodecForVersions(cfg.DefaultHTTPVersions...)).
+				DefaultFilters().
+				RouteConfigName(httpListener.Name).
+				MetricsPrefix(httpListener.Name).
+				AccessLoggers(cfg.newInsecureAccessLog(listener.VirtualHosts)).
+				RequestTimeout(cfg.Timeouts.Request).
+				ConnectionIdleTimeout(cfg.Timeouts.ConnectionIdle).
+				StreamIdleTimeout(cfg.Timeouts.StreamIdle).
+				DelayedCloseTimeout(cfg.Timeouts.DelayedClose).
+				MaxConnectionDuration(cfg.Timeouts.MaxConnectionDuration).
+				ConnectionShutdownGracePeriod(cfg.Timeouts.ConnectionShutdownGracePeriod).
+				AllowChunkedLength(cfg.AllowChunkedLength).
+				AddFilter(envoy_v3.OriginalIPDetectionFilter(cfg.XffNumTrustedHops)).
+				AddFilter(envoy_v3.GlobalRateLimitFilter(envoyGlobalRateLimitConfig(cfg.RateLimitConfig))).
+				Tracing(envoy_v3.TracingConfigFor(envoyTracingConfig(cfg.TracingConfig))).
+				Get()
+
+			listeners[httpListener.Name] = envoy_v3.Listener(
+				httpListener.Name,
+				httpListener.Address,
+				httpListener.Port,
+				proxyProtocol(cfg.UseProxyProto),
+				cm,
+			)
+		}
+
+		// Add a secure listener for the secure vhosts bound to
+		// a port other than that of the default HTTPS listener.
+		if _, ok := listeners[listener.Name]; !ok && len(listener.SecureVirtualHosts) > 0 {
+			listeners[listener.Name] = envoy_v3.Listener(
+				listener.Name,
+				listenerAddress(listener),
+				listener.Port,
+				secureProxyProtocol(cfg.UseProxyProto),
+			)
+		}
+
+		// Add a listener that proxies all connections if there is a
+		// TCP proxy bound to the port.
+		if listener.TCPProxy != nil {
+			listeners[listener.Name] = envoy_v3.Listener(
+				listener.Name,
+				listenerAddress(listener),
+				listener.Port,
+				proxyProtocol(cfg.UseProxyProto),
+				envoy_v3.TCPProxy(listener.Name,
+					listener.TCPProxy,
+					cfg.newInsecureTCPAccessLog()),
+			)
+		}
+
+		// Add a listener that proxies all datagrams if there is a
+		// UDP proxy bound to the port.
+		if listener.UDPProxy != nil {
+			listeners[listener.Name] = envoy_v3.UDPListener(
+				listener.Name,
+				listenerAddress(listener),
+				listener.Port,
+				envoy_v3.UDPProxy(listener.Name, listener.UDPProxy),
+			)
 		}
 
 		for _, vh := range listener.SecureVirtualHosts {
@@ -415,10 +635,11 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 					Codec(envoy_v3.CodecForVersions(cfg.DefaultHTTPVersions...)).
 					AddFilter(envoy_v3.FilterMisdirectedRequests(vh.VirtualHost.Name)).
 					DefaultFilters().
+					AddFilter(envoy_v3.FilterJWTAuthN(vh.JWTProviders)).
 					AddFilter(authFilter).
-					RouteConfigName(path.Join("https", vh.VirtualHost.Name)).
+					RouteConfigName(secureRouteConfigName(listener, vh)).
 					MetricsPrefix(listener.Name).
-					AccessLoggers(cfg.newSecureAccessLog()).
+					AccessLoggers(cfg.newSecureAccessLog(&vh.VirtualHost)).
 					RequestTimeout(cfg.Timeouts.Request).
 					ConnectionIdleTimeout(cfg.Timeouts.ConnectionIdle).
 					StreamIdleTimeout(cfg.Timeouts.StreamIdle).
@@ -428,6 +649,7 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 					AllowChunkedLength(cfg.AllowChunkedLength).
 					AddFilter(envoy_v3.OriginalIPDetectionFilter(cfg.XffNumTrustedHops)).
 					AddFilter(envoy_v3.GlobalRateLimitFilter(envoyGlobalRateLimitConfig(cfg.RateLimitConfig))).
+					Tracing(envoy_v3.TracingConfigFor(envoyTracingConfig(cfg.TracingConfig))).
 					Get()
 
 				filters = envoy_v3.Filters(cm)
@@ -437,7 +659,7 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 				filters = envoy_v3.Filters(
 					envoy_v3.TCPProxy(listener.Name,
 						vh.TCPProxy,
-						cfg.newSecureAccessLog()),
+						cfg.newSecureTCPAccessLog()),
 				)
 
 				// Do not offer ALPN for TCP proxying, since
@@ -482,7 +704,7 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 					DefaultFilters().
 					RouteConfigName(ENVOY_FALLBACK_ROUTECONFIG).
 					MetricsPrefix(listener.Name).
-					AccessLoggers(cfg.newSecureAccessLog()).
+					AccessLoggers(cfg.newSecureAccessLog(nil)).
 					RequestTimeout(cfg.Timeouts.Request).
 					ConnectionIdleTimeout(cfg.Timeouts.ConnectionIdle).
 					StreamIdleTimeout(cfg.Timeouts.StreamIdle).
@@ -492,6 +714,7 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 					AllowChunkedLength(cfg.AllowChunkedLength).
 					AddFilter(envoy_v3.OriginalIPDetectionFilter(cfg.XffNumTrustedHops)).
 					AddFilter(envoy_v3.GlobalRateLimitFilter(envoyGlobalRateLimitConfig(cfg.RateLimitConfig))).
+					Tracing(envoy_v3.TracingConfigFor(envoyTracingConfig(cfg.TracingConfig))).
 					Get()
 
 				// Default filter chain
@@ -505,10 +728,16 @@ func (c *ListenerCache) OnChange(root *dag.DAG) {
 	// Remove the https listener if there are no vhosts bound to it.
 	if len(listeners[ENVOY_HTTPS_LISTENER].FilterChains) == 0 {
 		delete(listeners, ENVOY_HTTPS_LISTENER)
-	} else {
-		// there's some https listeners, we need to sort the filter chains
-		// to ensure that the LDS entries are identical.
-		sort.Stable(sorter.For(listeners[ENVOY_HTTPS_LISTENER].FilterChains))
+	}
+
+	// There may be some https listeners, we need to sort their filter
+	// chains to ensure that the LDS entries are identical.
+	for _, listener := range root.Listeners {
+		if len(listener.SecureVirtualHosts) > 0 {
+			if l, ok := listeners[listener.Name]; ok {
+				sort.Stable(sorter.For(l.FilterChains))
+			}
+		}
 	}
 
 	// support more params of envoy listener
@@ -541,6 +770,29 @@ func envoyGlobalRateLimitConfig(config *RateLimitConfig) *envoy_v3.GlobalRateLim
 	}
 }
 
+func envoyTracingConfig(config *TracingConfig) *envoy_v3.TracingConfig {
+	if config == nil {
+		return nil
+	}
+
+	var customTags []*envoy_v3.CustomTag
+	for _, tag := range config.CustomTags {
+		customTags = append(customTags, &envoy_v3.CustomTag{
+			TagName:           tag.TagName,
+			Literal:           tag.Literal,
+			RequestHeaderName: tag.RequestHeaderName,
+		})
+	}
+
+	return &envoy_v3.TracingConfig{
+		ExtensionService:  config.ExtensionService,
+		CollectorEndpoint: config.CollectorEndpoint,
+		OverallSampling:   config.OverallSampling,
+		MaxPathTagLength:  config.MaxPathTagLength,
+		CustomTags:        customTags,
+	}
+}
+
 func proxyProtocol(useProxy bool) []*envoy_listener_v3.ListenerFilter {
 	if useProxy {
 		return envoy_v3.ListenerFilters(
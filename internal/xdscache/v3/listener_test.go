@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	envoy_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	ratelimit_config_v3 "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
@@ -27,6 +28,7 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/proto"
 	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
+	contour_api_v1alpha1 "github.com/projectcontour/contour/apis/projectcontour/v1alpha1"
 	"github.com/projectcontour/contour/internal/contourconfig"
 	"github.com/projectcontour/contour/internal/dag"
 	envoy_v3 "github.com/projectcontour/contour/internal/envoy/v3"
@@ -3170,6 +3172,112 @@ func TestListenerVisit(t *testing.T) {
 				SocketOptions: envoy_v3.TCPKeepaliveSocketOptions(),
 			}),
 		},
+		"secure httpproxy with tracing config": {
+			ListenerConfig: ListenerConfig{
+				TracingConfig: &TracingConfig{
+					ExtensionService:  types.NamespacedName{Namespace: "projectcontour", Name: "zipkin"},
+					CollectorEndpoint: "/api/v2/spans",
+					OverallSampling:   50,
+					MaxPathTagLength:  256,
+					CustomTags: []*CustomTag{
+						{TagName: "cluster", Literal: "production"},
+					},
+				},
+			},
+			objs: []interface{}{
+				&contour_api_v1.HTTPProxy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "simple",
+						Namespace: "default",
+					},
+					Spec: contour_api_v1.HTTPProxySpec{
+						VirtualHost: &contour_api_v1.VirtualHost{
+							Fqdn: "www.example.com",
+							TLS: &contour_api_v1.TLS{
+								SecretName: "secret",
+							},
+						},
+						Routes: []contour_api_v1.Route{{
+							Services: []contour_api_v1.Service{{
+								Name: "backend",
+								Port: 80,
+							}},
+						}},
+					},
+				},
+				&v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "secret",
+						Namespace: "default",
+					},
+					Type: "kubernetes.io/tls",
+					Data: secretdata(CERTIFICATE, RSA_PRIVATE_KEY),
+				},
+				&v1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backend",
+						Namespace: "default",
+					},
+					Spec: v1.ServiceSpec{
+						Ports: []v1.ServicePort{{
+							Name:     "http",
+							Protocol: "TCP",
+							Port:     80,
+						}},
+					},
+				},
+			},
+			want: listenermap(&envoy_listener_v3.Listener{
+				Name:    ENVOY_HTTP_LISTENER,
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8080),
+				FilterChains: envoy_v3.FilterChains(envoy_v3.HTTPConnectionManagerBuilder().
+					RouteConfigName(ENVOY_HTTP_LISTENER).
+					MetricsPrefix(ENVOY_HTTP_LISTENER).
+					AccessLoggers(envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil)).
+					DefaultFilters().
+					Tracing(envoy_v3.TracingConfigFor(&envoy_v3.TracingConfig{
+						ExtensionService:  k8s.NamespacedNameFrom("projectcontour/zipkin"),
+						CollectorEndpoint: "/api/v2/spans",
+						OverallSampling:   50,
+						MaxPathTagLength:  256,
+						CustomTags: []*envoy_v3.CustomTag{
+							{TagName: "cluster", Literal: "production"},
+						},
+					})).
+					Get(),
+				),
+				SocketOptions: envoy_v3.TCPKeepaliveSocketOptions(),
+			}, &envoy_listener_v3.Listener{
+				Name:    ENVOY_HTTPS_LISTENER,
+				Address: envoy_v3.SocketAddress("0.0.0.0", 8443),
+				FilterChains: []*envoy_listener_v3.FilterChain{{
+					FilterChainMatch: &envoy_listener_v3.FilterChainMatch{
+						ServerNames: []string{"www.example.com"},
+					},
+					TransportSocket: transportSocket("secret", envoy_tls_v3.TlsParameters_TLSv1_2, nil, "h2", "http/1.1"),
+					Filters: envoy_v3.Filters(envoy_v3.HTTPConnectionManagerBuilder().
+						AddFilter(envoy_v3.FilterMisdirectedRequests("www.example.com")).
+						DefaultFilters().
+						MetricsPrefix(ENVOY_HTTPS_LISTENER).
+						RouteConfigName(path.Join("https", "www.example.com")).
+						AccessLoggers(envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil)).
+						Tracing(envoy_v3.TracingConfigFor(&envoy_v3.TracingConfig{
+							ExtensionService:  k8s.NamespacedNameFrom("projectcontour/zipkin"),
+							CollectorEndpoint: "/api/v2/spans",
+							OverallSampling:   50,
+							MaxPathTagLength:  256,
+							CustomTags: []*envoy_v3.CustomTag{
+								{TagName: "cluster", Literal: "production"},
+							},
+						})).
+						Get()),
+				}},
+				ListenerFilters: envoy_v3.ListenerFilters(
+					envoy_v3.TLSInspector(),
+				),
+				SocketOptions: envoy_v3.TCPKeepaliveSocketOptions(),
+			}),
+		},
 		"secure httpproxy using fallback certificate with rate limit config": {
 			fallbackCertificate: &types.NamespacedName{
 				Name:      "fallbacksecret",
@@ -3386,3 +3494,214 @@ func listenermap(listeners ...*envoy_listener_v3.Listener) map[string]*envoy_lis
 	}
 	return m
 }
+
+func TestListenerConfigAccessLogService(t *testing.T) {
+	als := AccessLogServiceConfig{
+		ExtensionService: types.NamespacedName{Namespace: "projectcontour", Name: "als"},
+		LogName:          "contour",
+	}
+	alsConfig := &envoy_v3.AccessLogServiceConfig{
+		ExtensionService: types.NamespacedName{Namespace: "projectcontour", Name: "als"},
+		LogName:          "contour",
+	}
+	concat := func(logs ...[]*envoy_accesslog_v3.AccessLog) []*envoy_accesslog_v3.AccessLog {
+		var all []*envoy_accesslog_v3.AccessLog
+		for _, l := range logs {
+			all = append(all, l...)
+		}
+		return all
+	}
+
+	tests := map[string]struct {
+		config   ListenerConfig
+		wantHTTP []*envoy_accesslog_v3.AccessLog
+		wantTCP  []*envoy_accesslog_v3.AccessLog
+	}{
+		"no access log service": {
+			config:   ListenerConfig{},
+			wantHTTP: envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil),
+			wantTCP:  envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil),
+		},
+		"grpc alongside file logs": {
+			config: ListenerConfig{
+				AccessLogService: &AccessLogServiceConfig{
+					Type:             contour_api_v1alpha1.GRPCAccessLogService,
+					ExtensionService: als.ExtensionService,
+					LogName:          als.LogName,
+				},
+			},
+			wantHTTP: concat(envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil), envoy_v3.HTTPGRPCAccessLog(alsConfig)),
+			wantTCP:  concat(envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil), envoy_v3.TCPGRPCAccessLog(alsConfig)),
+		},
+		"opentelemetry instead of json file logs": {
+			config: ListenerConfig{
+				AccessLogType:   contour_api_v1alpha1.JSONAccessLog,
+				AccessLogFields: contour_api_v1alpha1.AccessLogFields{"method"},
+				AccessLogService: &AccessLogServiceConfig{
+					Type:                 contour_api_v1alpha1.OpenTelemetryAccessLogService,
+					ExtensionService:     als.ExtensionService,
+					LogName:              als.LogName,
+					DisableFileAccessLog: true,
+				},
+			},
+			wantHTTP: envoy_v3.OpenTelemetryAccessLogJSON(alsConfig, contour_api_v1alpha1.AccessLogFields{"method"}),
+			wantTCP:  envoy_v3.OpenTelemetryAccessLogJSON(alsConfig, contour_api_v1alpha1.AccessLogFields{"method"}),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			protobuf.ExpectEqual(t, tc.wantHTTP, tc.config.newInsecureAccessLog(nil))
+			protobuf.ExpectEqual(t, tc.wantTCP, tc.config.newInsecureTCPAccessLog())
+		})
+	}
+}
+
+func TestListenerConfigAccessLogPolicy(t *testing.T) {
+	prefixRoute := func(prefix string) *dag.Route {
+		return &dag.Route{PathMatchCondition: &dag.PrefixMatchCondition{Prefix: prefix}}
+	}
+	routes := func(rs ...*dag.Route) map[string]*dag.Route {
+		m := make(map[string]*dag.Route)
+		for _, r := range rs {
+			m[r.PathMatchCondition.String()] = r
+		}
+		return m
+	}
+	concat := func(logs ...[]*envoy_accesslog_v3.AccessLog) []*envoy_accesslog_v3.AccessLog {
+		var all []*envoy_accesslog_v3.AccessLog
+		for _, l := range logs {
+			all = append(all, l...)
+		}
+		return all
+	}
+
+	jsonPolicy := &dag.AccessLogPolicy{Name: "default/www", Format: "json", Fields: []string{"method"}}
+	errorsPolicy := &dag.AccessLogPolicy{Name: "default/www/routes/1", MinStatusCode: 500}
+
+	healthz := prefixRoute("/healthz")
+	healthz.AccessLogPolicy = &dag.AccessLogPolicy{Name: "default/www/routes/0", Disabled: true}
+	api := prefixRoute("/api")
+	api.AccessLogPolicy = errorsPolicy
+	root := prefixRoute("/")
+
+	tests := map[string]struct {
+		vhost        *dag.VirtualHost
+		wantSecure   []*envoy_accesslog_v3.AccessLog
+		wantInsecure []*envoy_accesslog_v3.AccessLog
+	}{
+		"no policy": {
+			vhost: &dag.VirtualHost{
+				Name:   "www.example.com",
+				Routes: routes(root),
+			},
+			wantSecure:   envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTPS_ACCESS_LOG, "", nil),
+			wantInsecure: envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil),
+		},
+		"virtual host policy": {
+			vhost: &dag.VirtualHost{
+				Name:            "www.example.com",
+				AccessLogPolicy: jsonPolicy,
+				Routes:          routes(root),
+			},
+			wantSecure: envoy_v3.FileAccessLogJSON(DEFAULT_HTTPS_ACCESS_LOG, jsonPolicy.Fields, nil),
+			wantInsecure: concat(
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogJSON(DEFAULT_HTTP_ACCESS_LOG, jsonPolicy.Fields, nil),
+					envoy_v3.MarkedAccessLogFilter("default/www")),
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil),
+					envoy_v3.UnmarkedAccessLogFilter()),
+			),
+		},
+		"route policies": {
+			vhost: &dag.VirtualHost{
+				Name:   "www.example.com",
+				Routes: routes(root, healthz, api),
+			},
+			wantSecure: concat(
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTPS_ACCESS_LOG, "", nil),
+					envoy_v3.AndAccessLogFilter(
+						envoy_v3.MarkedAccessLogFilter("default/www/routes/1"),
+						envoy_v3.AccessLogFilter(errorsPolicy))),
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTPS_ACCESS_LOG, "", nil),
+					envoy_v3.UnmarkedAccessLogFilter()),
+			),
+		},
+		"route and virtual host policies": {
+			vhost: &dag.VirtualHost{
+				Name:            "www.example.com",
+				AccessLogPolicy: jsonPolicy,
+				Routes:          routes(root, api),
+			},
+			wantSecure: concat(
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTPS_ACCESS_LOG, "", nil),
+					envoy_v3.AndAccessLogFilter(
+						envoy_v3.MarkedAccessLogFilter("default/www/routes/1"),
+						envoy_v3.AccessLogFilter(errorsPolicy))),
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogJSON(DEFAULT_HTTPS_ACCESS_LOG, jsonPolicy.Fields, nil),
+					envoy_v3.UnmarkedAccessLogFilter()),
+			),
+			wantInsecure: concat(
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogJSON(DEFAULT_HTTP_ACCESS_LOG, jsonPolicy.Fields, nil),
+					envoy_v3.MarkedAccessLogFilter("default/www")),
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil),
+					envoy_v3.AndAccessLogFilter(
+						envoy_v3.MarkedAccessLogFilter("default/www/routes/1"),
+						envoy_v3.AccessLogFilter(errorsPolicy))),
+				envoy_v3.FilterAccessLogs(
+					envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil),
+					envoy_v3.UnmarkedAccessLogFilter()),
+			),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lvc ListenerConfig
+			protobuf.ExpectEqual(t, tc.wantSecure, lvc.newSecureAccessLog(tc.vhost))
+			if tc.wantInsecure != nil {
+				protobuf.ExpectEqual(t, tc.wantInsecure, lvc.newInsecureAccessLog([]*dag.VirtualHost{tc.vhost}))
+			}
+		})
+	}
+}
+
+func TestListenerConfigAccessLogFilter(t *testing.T) {
+	lvc := ListenerConfig{
+		AccessLogFilter: &envoy_v3.AccessLogFilterConfig{
+			ResponseFlags: []string{"UH"},
+		},
+	}
+	global := envoy_v3.GlobalAccessLogFilter(lvc.AccessLogFilter)
+
+	// The global filter applies to every request without a policy.
+	protobuf.ExpectEqual(t,
+		envoy_v3.FilterAccessLogs(envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil), global),
+		lvc.newInsecureAccessLog(nil))
+	protobuf.ExpectEqual(t,
+		envoy_v3.FilterAccessLogs(envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTPS_ACCESS_LOG, "", nil), global),
+		lvc.newSecureAccessLog(nil))
+
+	// A policy overrides the global filter.
+	policy := &dag.AccessLogPolicy{MinStatusCode: 500}
+	protobuf.ExpectEqual(t,
+		envoy_v3.FilterAccessLogs(
+			envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTPS_ACCESS_LOG, "", nil),
+			envoy_v3.AccessLogFilter(policy)),
+		lvc.newSecureAccessLog(&dag.VirtualHost{
+			Name:            "www.example.com",
+			AccessLogPolicy: policy,
+		}))
+
+	// TCP proxies are not filtered.
+	protobuf.ExpectEqual(t,
+		envoy_v3.FileAccessLogEnvoy(DEFAULT_HTTP_ACCESS_LOG, "", nil),
+		lvc.newInsecureTCPAccessLog())
+}
@@ -89,40 +89,60 @@ func (*RouteCache) TypeURL() string { return resource.RouteType }
 func (c *RouteCache) OnChange(root *dag.DAG) {
 	// RouteConfigs keyed by RouteConfig name:
 	// 	- one for all the HTTP vhost routes -- "ingress_http"
+	//	- one for all the HTTP vhost routes of each additional HTTP listener -- "<listener name>"
 	//	- one per svhost -- "https/<vhost fqdn>"
+	//	- one per svhost of each additional HTTPS listener -- "<listener name>/<vhost fqdn>"
 	//	- one for fallback cert (if configured) -- "ingress_fallbackcert"
 	routeConfigs := map[string]*envoy_route_v3.RouteConfiguration{
 		ENVOY_HTTP_LISTENER: envoy_v3.RouteConfiguration(ENVOY_HTTP_LISTENER),
 	}
 
-	for vhost, routes := range root.GetVirtualHostRoutes() {
-		sortRoutes(routes)
-		routeConfigs[ENVOY_HTTP_LISTENER].VirtualHosts = append(routeConfigs[ENVOY_HTTP_LISTENER].VirtualHosts,
-			envoy_v3.VirtualHostAndRoutes(vhost, routes, false, nil))
-	}
+	for _, listener := range root.Listeners {
+		for _, vhost := range listener.VirtualHosts {
+			routes := vhostRoutes(vhost)
+			if len(routes) == 0 {
+				continue
+			}
+
+			// Add the listener's route config if not already present.
+			name := listener.Name
+			if _, ok := routeConfigs[name]; !ok {
+				routeConfigs[name] = envoy_v3.RouteConfiguration(name)
+			}
 
-	for vhost, routes := range root.GetSecureVirtualHostRoutes() {
-		// Add secure vhost route config if not already present.
-		name := path.Join("https", vhost.VirtualHost.Name)
-		if _, ok := routeConfigs[name]; !ok {
-			routeConfigs[name] = envoy_v3.RouteConfiguration(name)
+			sortRoutes(routes)
+			routeConfigs[name].VirtualHosts = append(routeConfigs[name].VirtualHosts,
+				envoy_v3.VirtualHostAndRoutes(vhost, routes, false, nil))
 		}
 
-		sortRoutes(routes)
-		routeConfigs[name].VirtualHosts = append(routeConfigs[name].VirtualHosts,
-			envoy_v3.VirtualHostAndRoutes(&vhost.VirtualHost, routes, true, vhost.AuthorizationService))
-
-		// A fallback route configuration contains routes for all the vhosts that have the fallback certificate enabled.
-		// When a request is received, the default TLS filterchain will accept the connection,
-		// and this routing table in RDS defines where the request proxies next.
-		if vhost.FallbackCertificate != nil {
-			// Add fallback route config if not already present.
-			if _, ok := routeConfigs[ENVOY_FALLBACK_ROUTECONFIG]; !ok {
-				routeConfigs[ENVOY_FALLBACK_ROUTECONFIG] = envoy_v3.RouteConfiguration(ENVOY_FALLBACK_ROUTECONFIG)
+		for _, vhost := range listener.SecureVirtualHosts {
+			routes := vhostRoutes(&vhost.VirtualHost)
+			if len(routes) == 0 {
+				continue
 			}
 
-			routeConfigs[ENVOY_FALLBACK_ROUTECONFIG].VirtualHosts = append(routeConfigs[ENVOY_FALLBACK_ROUTECONFIG].VirtualHosts,
+			// Add secure vhost route config if not already present.
+			name := secureRouteConfigName(listener, vhost)
+			if _, ok := routeConfigs[name]; !ok {
+				routeConfigs[name] = envoy_v3.RouteConfiguration(name)
+			}
+
+			sortRoutes(routes)
+			routeConfigs[name].VirtualHosts = append(routeConfigs[name].VirtualHosts,
 				envoy_v3.VirtualHostAndRoutes(&vhost.VirtualHost, routes, true, vhost.AuthorizationService))
+
+			// A fallback route configuration contains routes for all the vhosts that have the fallback certificate enabled.
+			// When a request is received, the default TLS filterchain will accept the connection,
+			// and this routing table in RDS defines where the request proxies next.
+			if vhost.FallbackCertificate != nil {
+				// Add fallback route config if not already present.
+				if _, ok := routeConfigs[ENVOY_FALLBACK_ROUTECONFIG]; !ok {
+					routeConfigs[ENVOY_FALLBACK_ROUTECONFIG] = envoy_v3.RouteConfiguration(ENVOY_FALLBACK_ROUTECONFIG)
+				}
+
+				routeConfigs[ENVOY_FALLBACK_ROUTECONFIG].VirtualHosts = append(routeConfigs[ENVOY_FALLBACK_ROUTECONFIG].VirtualHosts,
+					envoy_v3.VirtualHostAndRoutes(&vhost.VirtualHost, routes, true, vhost.AuthorizationService))
+			}
 		}
 	}
 
@@ -133,6 +153,24 @@ func (c *RouteCache) OnChange(root *dag.DAG) {
 	c.Update(routeConfigs)
 }
 
+// vhostRoutes returns the routes of the given virtual host as a slice.
+func vhostRoutes(vhost *dag.VirtualHost) []*dag.Route {
+	var routes []*dag.Route
+	for _, r := range vhost.Routes {
+		routes = append(routes, r)
+	}
+	return routes
+}
+
+// secureRouteConfigName returns the name of the route configuration
+// for the given secure virtual host on the given listener.
+func secureRouteConfigName(listener *dag.Listener, vhost *dag.SecureVirtualHost) string {
+	if listener.Name == ENVOY_HTTPS_LISTENER {
+		return path.Join("https", vhost.VirtualHost.Name)
+	}
+	return path.Join(listener.Name, vhost.VirtualHost.Name)
+}
+
 // sortRoutes sorts the given Route slice in place. Routes are ordered
 // first by path match type, path match value via string comparison and
 // then by the length of the HeaderMatch slice (if any). The HeaderMatch
@@ -23,6 +23,7 @@ import (
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	contour_api_v1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	"github.com/projectcontour/contour/internal/dag"
@@ -273,6 +274,79 @@ func TestRouteVisit(t *testing.T) {
 				),
 			),
 		},
+		"httpproxy with access log policies": {
+			objs: []interface{}{
+				&contour_api_v1.HTTPProxy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "simple",
+						Namespace: "default",
+					},
+					Spec: contour_api_v1.HTTPProxySpec{
+						VirtualHost: &contour_api_v1.VirtualHost{
+							Fqdn: "www.example.com",
+							AccessLogPolicy: &contour_api_v1.AccessLogPolicy{
+								Format: "json",
+							},
+						},
+						Routes: []contour_api_v1.Route{{
+							Conditions: []contour_api_v1.MatchCondition{{
+								Prefix: "/healthz",
+							}},
+							AccessLogPolicy: &contour_api_v1.AccessLogPolicy{
+								Disabled: true,
+							},
+							Services: []contour_api_v1.Service{{
+								Name: "backend",
+								Port: 80,
+							}},
+						}, {
+							Services: []contour_api_v1.Service{{
+								Name: "backend",
+								Port: 80,
+							}},
+						}},
+					},
+				},
+				&v1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backend",
+						Namespace: "default",
+					},
+					Spec: v1.ServiceSpec{
+						Ports: []v1.ServicePort{{
+							Protocol:   "TCP",
+							Port:       80,
+							TargetPort: intstr.FromInt(8080),
+						}},
+					},
+				},
+			},
+			want: routeConfigurations(
+				envoy_v3.RouteConfiguration("ingress_http",
+					&envoy_route_v3.VirtualHost{
+						Name:    "www.example.com",
+						Domains: []string{"www.example.com"},
+						Routes: []*envoy_route_v3.Route{{
+							Match:  routePrefix("/healthz"),
+							Action: routecluster("default/backend/80/da39a3ee5e"),
+							TypedPerFilterConfig: map[string]*any.Any{
+								"envoy.filters.http.header_to_metadata": envoy_v3.AccessLogPolicyMark(&dag.AccessLogPolicy{
+									Name: "default/simple/routes/0",
+								}),
+							},
+						}, {
+							Match:  routePrefix("/"),
+							Action: routecluster("default/backend/80/da39a3ee5e"),
+						}},
+						TypedPerFilterConfig: map[string]*any.Any{
+							"envoy.filters.http.header_to_metadata": envoy_v3.AccessLogPolicyMark(&dag.AccessLogPolicy{
+								Name: "default/simple",
+							}),
+						},
+					},
+				),
+			),
+		},
 		"default backend ingress with secret": {
 			objs: []interface{}{
 				&networking_v1.Ingress{
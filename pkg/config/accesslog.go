@@ -42,6 +42,38 @@ var DefaultFields = AccessLogFields([]string{
 // nolint:revive
 const DEFAULT_ACCESS_LOG_TYPE = EnvoyAccessLog
 
+// envoyResponseFlags are the response flags that an access log
+// filter may match, see
+// https://www.envoyproxy.io/docs/envoy/v1.21.0/configuration/observability/access_log/usage#config-access-log-format-response-flags
+var envoyResponseFlags = map[string]struct{}{
+	"LH":    {},
+	"UH":    {},
+	"UT":    {},
+	"LR":    {},
+	"UR":    {},
+	"UF":    {},
+	"UC":    {},
+	"UO":    {},
+	"NR":    {},
+	"DI":    {},
+	"FI":    {},
+	"RL":    {},
+	"UAEX":  {},
+	"RLSE":  {},
+	"DC":    {},
+	"URX":   {},
+	"SI":    {},
+	"IH":    {},
+	"DPE":   {},
+	"UMSDR": {},
+	"RFCF":  {},
+	"NFCF":  {},
+	"DT":    {},
+	"UPE":   {},
+	"NC":    {},
+	"OM":    {},
+}
+
 // jsonFields is the canonical translation table for JSON fields to Envoy log template formats,
 // used for specifying fields for Envoy to log when JSON logging is enabled.
 var jsonFields = map[string]string{
@@ -58,8 +90,10 @@ var jsonFields = map[string]string{
 	"x_trace_id":            "%REQ(X-TRACE-ID)%",
 }
 
-// envoySimpleOperators is the list of known supported Envoy log template keywords that do not
-// have arguments nor require canonical translations.
+// envoySimpleOperators is the list of known supported Envoy log template keywords that may
+// be used without arguments and do not require canonical translations. The list matches
+// the command operators of Envoy v1.21, the version bundled with Contour, see
+// https://www.envoyproxy.io/docs/envoy/v1.21.0/configuration/observability/access_log/usage#command-operators
 var envoySimpleOperators = map[string]struct{}{
 	"BYTES_RECEIVED":                                {},
 	"BYTES_SENT":                                    {},
@@ -67,6 +101,8 @@ var envoySimpleOperators = map[string]struct{}{
 	"CONNECTION_TERMINATION_DETAILS":                {},
 	"DOWNSTREAM_DIRECT_REMOTE_ADDRESS":              {},
 	"DOWNSTREAM_DIRECT_REMOTE_ADDRESS_WITHOUT_PORT": {},
+	"DOWNSTREAM_HEADER_BYTES_RECEIVED":              {},
+	"DOWNSTREAM_HEADER_BYTES_SENT":                  {},
 	"DOWNSTREAM_LOCAL_ADDRESS":                      {},
 	"DOWNSTREAM_LOCAL_ADDRESS_WITHOUT_PORT":         {},
 	"DOWNSTREAM_LOCAL_PORT":                         {},
@@ -86,6 +122,8 @@ var envoySimpleOperators = map[string]struct{}{
 	"DOWNSTREAM_TLS_CIPHER":                         {},
 	"DOWNSTREAM_TLS_SESSION_ID":                     {},
 	"DOWNSTREAM_TLS_VERSION":                        {},
+	"DOWNSTREAM_WIRE_BYTES_RECEIVED":                {},
+	"DOWNSTREAM_WIRE_BYTES_SENT":                    {},
 	"DURATION":                                      {},
 	"GRPC_STATUS":                                   {},
 	"HOSTNAME":                                      {},
@@ -93,25 +131,41 @@ var envoySimpleOperators = map[string]struct{}{
 	"PROTOCOL":                                      {},
 	"REQUESTED_SERVER_NAME":                         {},
 	"REQUEST_DURATION":                              {},
+	"REQUEST_HEADERS_BYTES":                         {},
+	"REQUEST_TX_DURATION":                           {},
 	"RESPONSE_CODE":                                 {},
 	"RESPONSE_CODE_DETAILS":                         {},
 	"RESPONSE_DURATION":                             {},
 	"RESPONSE_FLAGS":                                {},
+	"RESPONSE_HEADERS_BYTES":                        {},
+	"RESPONSE_TRAILERS_BYTES":                       {},
 	"RESPONSE_TX_DURATION":                          {},
 	"ROUTE_NAME":                                    {},
 	"START_TIME":                                    {},
 	"UPSTREAM_CLUSTER":                              {},
+	"UPSTREAM_HEADER_BYTES_RECEIVED":                {},
+	"UPSTREAM_HEADER_BYTES_SENT":                    {},
 	"UPSTREAM_HOST":                                 {},
 	"UPSTREAM_LOCAL_ADDRESS":                        {},
+	"UPSTREAM_REQUEST_ATTEMPT_COUNT":                {},
 	"UPSTREAM_TRANSPORT_FAILURE_REASON":             {},
+	"UPSTREAM_WIRE_BYTES_RECEIVED":                  {},
+	"UPSTREAM_WIRE_BYTES_SENT":                      {},
 }
 
-// envoyComplexOperators is the list of known Envoy log template keywords that require
-// arguments.
+// envoyComplexOperators is the list of known Envoy log template keywords that accept
+// arguments. Operators that are not also in envoySimpleOperators require arguments.
 var envoyComplexOperators = map[string]struct{}{
-	"REQ":               {},
-	"RESP":              {},
-	"START_TIME":        {},
-	"TRAILER":           {},
-	"REQ_WITHOUT_QUERY": {},
+	"CLUSTER_METADATA":             {},
+	"DOWNSTREAM_PEER_CERT_V_END":   {},
+	"DOWNSTREAM_PEER_CERT_V_START": {},
+	"DYNAMIC_METADATA":             {},
+	"FILTER_STATE":                 {},
+	"GRPC_STATUS":                  {},
+	"REQ":                          {},
+	"REQ_WITHOUT_QUERY":            {},
+	"RESP":                         {},
+	"START_TIME":                   {},
+	"TRAILER":                      {},
+	"UPSTREAM_METADATA":            {},
 }
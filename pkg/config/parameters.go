@@ -20,6 +20,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -58,6 +59,15 @@ func (g *GatewayParameters) Validate() error {
 		errorString = strings.TrimSpace(fmt.Sprintf("%s controllerName required", errorString))
 	}
 
+	if g.GatewayRef != nil {
+		if len(strings.TrimSpace(g.GatewayRef.Namespace)) == 0 || len(strings.TrimSpace(g.GatewayRef.Name)) == 0 {
+			if len(errorString) > 0 {
+				errorString += ","
+			}
+			errorString = strings.TrimSpace(fmt.Sprintf("%s gatewayRef namespace and name required", errorString))
+		}
+	}
+
 	if len(errorString) > 0 {
 		return fmt.Errorf("invalid Gateway parameters specified: %s", errorString)
 	}
@@ -111,6 +121,144 @@ func (a AccessLogType) Validate() error {
 const EnvoyAccessLog AccessLogType = "envoy"
 const JSONAccessLog AccessLogType = "json"
 
+// AccessLogServiceType is the protocol used to stream access logs
+// to an access log service.
+type AccessLogServiceType string
+
+func (a AccessLogServiceType) Validate() error {
+	switch a {
+	case GRPCAccessLogService, OpenTelemetryAccessLogService:
+		return nil
+	default:
+		return fmt.Errorf("invalid access log service type %q", a)
+	}
+}
+
+const GRPCAccessLogService AccessLogServiceType = "grpc"
+const OpenTelemetryAccessLogService AccessLogServiceType = "opentelemetry"
+
+// AccessLogServiceParameters defines properties of an access log service.
+type AccessLogServiceParameters struct {
+	// Type is the protocol used to stream access logs to the service.
+	// Valid options are 'grpc' or 'opentelemetry'.
+	Type AccessLogServiceType `yaml:"type,omitempty"`
+
+	// ExtensionService identifies the extension service defining the
+	// access log service, formatted as <namespace>/<name>.
+	ExtensionService string `yaml:"extension-service,omitempty"`
+
+	// LogName identifies the access log stream to the service.
+	// Defaults to "contour".
+	LogName string `yaml:"log-name,omitempty"`
+
+	// BufferFlushInterval is the interval after which Envoy flushes
+	// buffered access log entries to the service. Defaults to 1s.
+	BufferFlushInterval string `yaml:"buffer-flush-interval,omitempty"`
+
+	// BufferSizeBytes is the size of the buffer of access log entries
+	// which, when exceeded, is flushed to the service. Defaults to 16384.
+	BufferSizeBytes *uint32 `yaml:"buffer-size-bytes,omitempty"`
+
+	// DisableFileAccessLog disables the file access logs, so that
+	// access logs are only sent to the service.
+	DisableFileAccessLog bool `yaml:"disable-file-access-log,omitempty"`
+}
+
+// Validate ensures that the access log service parameters are consistent.
+func (a *AccessLogServiceParameters) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	if err := a.Type.Validate(); err != nil {
+		return err
+	}
+
+	if parts := strings.Split(a.ExtensionService, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("accesslog-service.extension-service %q must be formatted as <namespace>/<name>", a.ExtensionService)
+	}
+
+	if a.BufferFlushInterval != "" {
+		if _, err := time.ParseDuration(a.BufferFlushInterval); err != nil {
+			return fmt.Errorf("accesslog-service.buffer-flush-interval %q is invalid: %v", a.BufferFlushInterval, err)
+		}
+	}
+
+	return nil
+}
+
+// AccessLogFilterParameters selects the HTTP requests that are access
+// logged. A request is logged only if it matches all of the rules that
+// are set.
+type AccessLogFilterParameters struct {
+	// StatusCodes restricts logging to responses with a status
+	// code within any of the given ranges.
+	StatusCodes []StatusCodeRange `yaml:"status-codes,omitempty"`
+
+	// MinDuration restricts logging to requests that take at
+	// least the given duration, for example "500ms".
+	MinDuration string `yaml:"min-duration,omitempty"`
+
+	// ResponseFlags restricts logging to responses with any of
+	// the given Envoy response flags, for example "UH" or "UF".
+	ResponseFlags []string `yaml:"response-flags,omitempty"`
+
+	// RequestHeaders restricts logging to requests in which all
+	// of the given headers are present.
+	RequestHeaders []string `yaml:"request-headers,omitempty"`
+
+	// SamplingPercent restricts logging to the given percentage
+	// of requests, chosen at random.
+	SamplingPercent *uint32 `yaml:"sampling-percent,omitempty"`
+}
+
+// StatusCodeRange is an inclusive range of HTTP status codes.
+type StatusCodeRange struct {
+	Min uint32 `yaml:"min"`
+	Max uint32 `yaml:"max"`
+}
+
+// Validate ensures that the access log filter parameters are valid.
+func (a *AccessLogFilterParameters) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	for _, r := range a.StatusCodes {
+		if r.Min < 100 || r.Max > 599 || r.Min > r.Max {
+			return fmt.Errorf("accesslog-filter.status-codes range %d-%d is invalid", r.Min, r.Max)
+		}
+	}
+
+	if a.MinDuration != "" {
+		d, err := time.ParseDuration(a.MinDuration)
+		if err != nil {
+			return fmt.Errorf("accesslog-filter.min-duration %q is invalid: %v", a.MinDuration, err)
+		}
+		if d < 0 {
+			return fmt.Errorf("accesslog-filter.min-duration %q must not be negative", a.MinDuration)
+		}
+	}
+
+	for _, flag := range a.ResponseFlags {
+		if _, ok := envoyResponseFlags[flag]; !ok {
+			return fmt.Errorf("accesslog-filter.response-flags %q is not a valid response flag", flag)
+		}
+	}
+
+	for _, h := range a.RequestHeaders {
+		if h == "" {
+			return fmt.Errorf("accesslog-filter.request-headers must not contain an empty header name")
+		}
+	}
+
+	if a.SamplingPercent != nil && *a.SamplingPercent > 100 {
+		return fmt.Errorf("accesslog-filter.sampling-percent %d must be from 0 to 100", *a.SamplingPercent)
+	}
+
+	return nil
+}
+
 type AccessLogFields []string
 
 func (a AccessLogFields) Validate() error {
@@ -188,7 +336,7 @@ func validateAccessLogFormatString(format string) error {
 //   2. Operator Name: "START_TIME"
 //   3. Arguments: "(%s)"
 //   4. Truncation length: ":3"
-var commandOperatorRegexp = regexp.MustCompile(`%(([A-Z_]+)(\([^)]+\)(:[0-9]+)?)?%)?`)
+var commandOperatorRegexp = regexp.MustCompile(`%(([A-Z0-9_]+)(\([^)]+\)(:[0-9]+)?)?%)?`)
 
 func parseAccessLogFormat(format string) error {
 
@@ -211,10 +359,14 @@ func parseAccessLogFormat(format string) error {
 			return fmt.Errorf("invalid Envoy format: %s, invalid Envoy operator: %s", f, op)
 		}
 
-		if (op == "REQ" || op == "RESP" || op == "TRAILER" || op == "REQ_WITHOUT_QUERY") && f[3] == "" {
+		if !okSimple && f[3] == "" {
 			return fmt.Errorf("invalid Envoy format: %s, arguments required for operator: %s", f, op)
 		}
 
+		if !okComplex && f[3] != "" {
+			return fmt.Errorf("invalid Envoy format: %s, operator %s cannot have arguments", f, op)
+		}
+
 		// START_TIME cannot not have truncation length.
 		if op == "START_TIME" && f[4] != "" {
 			return fmt.Errorf("invalid Envoy format: %s, operator %s cannot have truncation length", f, op)
@@ -355,6 +507,12 @@ type GatewayParameters struct {
 	// GatewayClass. The string takes the form of "projectcontour.io/<namespace>/contour".
 	// If unset, the gatewayclass controller will not be started.
 	ControllerName string `yaml:"controllerName,omitempty"`
+
+	// GatewayRef defines a specific Gateway that this Contour
+	// instance corresponds to. If set, Contour will reconcile
+	// only this Gateway, rather than every Gateway of its
+	// GatewayClass.
+	GatewayRef *NamespacedName `yaml:"gatewayRef,omitempty"`
 }
 
 // LeaderElectionParameters holds the config bits for leader election
@@ -519,6 +677,46 @@ type ClusterParameters struct {
 	// See https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/cluster/v3/cluster.proto.html#envoy-v3-api-enum-config-cluster-v3-cluster-dnslookupfamily
 	// for more information.
 	DNSLookupFamily ClusterDNSFamilyType `yaml:"dns-lookup-family"`
+
+	// ZoneAwareRouting configures Envoy to prefer upstream endpoints
+	// in the same zone as the Envoy handling the request.
+	ZoneAwareRouting ZoneAwareRoutingParameters `yaml:"zone-aware-routing,omitempty"`
+
+	// CircuitBreakers holds the default circuit breaker thresholds
+	// for clusters backed by Kubernetes Services.
+	CircuitBreakers CircuitBreakerParameters `yaml:"circuit-breakers,omitempty"`
+}
+
+// CircuitBreakerParameters holds the configurable circuit breaker thresholds.
+// A threshold that is not set uses the Envoy default.
+type CircuitBreakerParameters struct {
+	// MaxConnections is the maximum number of connections
+	// that Envoy will make to an upstream cluster.
+	MaxConnections *uint32 `yaml:"max-connections,omitempty"`
+
+	// MaxPendingRequests is the maximum number of pending
+	// requests that Envoy will allow to an upstream cluster.
+	MaxPendingRequests *uint32 `yaml:"max-pending-requests,omitempty"`
+
+	// MaxRequests is the maximum number of parallel requests
+	// that Envoy will make to an upstream cluster.
+	MaxRequests *uint32 `yaml:"max-requests,omitempty"`
+
+	// MaxRetries is the maximum number of parallel retries
+	// that Envoy will allow to an upstream cluster.
+	MaxRetries *uint32 `yaml:"max-retries,omitempty"`
+}
+
+// ZoneAwareRoutingParameters holds the configurable zone aware routing values.
+type ZoneAwareRoutingParameters struct {
+	// Enabled enables zone aware routing for clusters backed
+	// by Kubernetes Services.
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// MinClusterSize is the minimum number of endpoints an upstream
+	// cluster must have for zone aware routing to be applied.
+	// Defaults to 6 when unset.
+	MinClusterSize uint32 `yaml:"min-cluster-size,omitempty"`
 }
 
 // NetworkParameters hold various configurable network values.
@@ -589,6 +787,14 @@ type Parameters struct {
 	// output when AccessLogFormat is json.
 	AccessLogFields AccessLogFields `yaml:"json-fields,omitempty"`
 
+	// AccessLogService optionally configures Envoy to stream access logs
+	// to an access log service over gRPC.
+	AccessLogService *AccessLogServiceParameters `yaml:"accesslog-service,omitempty"`
+
+	// AccessLogFilter optionally restricts the HTTP requests
+	// that are access logged.
+	AccessLogFilter *AccessLogFilterParameters `yaml:"accesslog-filter,omitempty"`
+
 	// TLS contains TLS policy parameters.
 	TLS TLSParameters `yaml:"tls,omitempty"`
 
@@ -609,6 +815,11 @@ type Parameters struct {
 	// TODO(youngnick): put a link to the issue and CVE here.
 	EnableExternalNameService bool `yaml:"enableExternalNameService,omitempty"`
 
+	// EnableEndpointSlices configures Contour to watch discovery.k8s.io/v1
+	// EndpointSlices, rather than v1 Endpoints, to determine the addresses
+	// of the endpoints of each Service.
+	EnableEndpointSlices bool `yaml:"enableEndpointSlices,omitempty"`
+
 	// LeaderElection contains leader election parameters.
 	// Note: This method of configuring leader election is deprecated,
 	// please use command line flags instead.
@@ -649,6 +860,10 @@ type Parameters struct {
 	// to be used for global rate limiting.
 	RateLimitService RateLimitService `yaml:"rateLimitService,omitempty"`
 
+	// Tracing optionally holds properties of the collector to which
+	// Envoy exports the spans of the requests it proxies.
+	Tracing *TracingParameters `yaml:"tracing,omitempty"`
+
 	// MetricsParameters holds configurable parameters for Contour and Envoy metrics.
 	Metrics MetricsParameters `yaml:"metrics,omitempty"`
 }
@@ -676,6 +891,105 @@ type RateLimitService struct {
 	EnableXRateLimitHeaders bool `yaml:"enableXRateLimitHeaders,omitempty"`
 }
 
+// TracingProvider is the protocol used to export spans to a
+// trace collector.
+type TracingProvider string
+
+func (t TracingProvider) Validate() error {
+	switch t {
+	case ZipkinTracingProvider:
+		return nil
+	case OpenTelemetryTracingProvider:
+		// Envoy's OpenTelemetry tracer is only available in Envoy 1.24
+		// and later, so it can't be configured for the bundled Envoy.
+		return fmt.Errorf("tracing provider %q requires Envoy 1.24 or later", t)
+	default:
+		return fmt.Errorf("invalid tracing provider %q", t)
+	}
+}
+
+const ZipkinTracingProvider TracingProvider = "zipkin"
+const OpenTelemetryTracingProvider TracingProvider = "opentelemetry"
+
+// TracingParameters defines properties for exporting trace data to a
+// collector.
+type TracingParameters struct {
+	// Provider is the protocol used to export spans to the collector.
+	// The only supported provider is "zipkin", the default.
+	Provider TracingProvider `yaml:"provider,omitempty"`
+
+	// ExtensionService identifies the extension service defining the
+	// trace collector, formatted as <namespace>/<name>.
+	ExtensionService string `yaml:"extensionService,omitempty"`
+
+	// CollectorEndpoint is the path of the collector's API endpoint to
+	// which spans are sent. Defaults to "/api/v2/spans".
+	CollectorEndpoint string `yaml:"collectorEndpoint,omitempty"`
+
+	// OverallSampling is the percentage of requests that are traced,
+	// from 0 to 100. Defaults to 100.
+	OverallSampling string `yaml:"overallSampling,omitempty"`
+
+	// MaxPathTagLength is the maximum length of the request path to
+	// include in the http.url span tag. Defaults to 256.
+	MaxPathTagLength *uint32 `yaml:"maxPathTagLength,omitempty"`
+
+	// CustomTags defines additional tags to add to each span.
+	CustomTags []CustomTag `yaml:"customTags,omitempty"`
+}
+
+// CustomTag defines a span tag whose value is either a literal
+// or the value of a request header.
+type CustomTag struct {
+	TagName           string `yaml:"tagName,omitempty"`
+	Literal           string `yaml:"literal,omitempty"`
+	RequestHeaderName string `yaml:"requestHeaderName,omitempty"`
+}
+
+// Validate ensures that the tracing parameters are consistent.
+func (t *TracingParameters) Validate() error {
+	if t == nil {
+		return nil
+	}
+
+	if t.ExtensionService == "" {
+		return errors.New("tracing.extensionService must be defined")
+	}
+	if parts := strings.Split(t.ExtensionService, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("tracing.extensionService %q must be formatted as <namespace>/<name>", t.ExtensionService)
+	}
+
+	if t.Provider != "" {
+		if err := t.Provider.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if t.OverallSampling != "" {
+		sampling, err := strconv.ParseFloat(t.OverallSampling, 64)
+		if err != nil || sampling < 0 || sampling > 100 {
+			return fmt.Errorf("tracing.overallSampling %q must be a number from 0 to 100", t.OverallSampling)
+		}
+	}
+
+	tagNames := map[string]bool{}
+	for _, tag := range t.CustomTags {
+		if tag.TagName == "" {
+			return errors.New("tracing.customTags: tagName must be defined")
+		}
+		if tagNames[tag.TagName] {
+			return fmt.Errorf("tracing.customTags: duplicate tag %q", tag.TagName)
+		}
+		tagNames[tag.TagName] = true
+
+		if (tag.Literal == "") == (tag.RequestHeaderName == "") {
+			return fmt.Errorf("tracing.customTags: tag %q must set exactly one of literal or requestHeaderName", tag.TagName)
+		}
+	}
+
+	return nil
+}
+
 // MetricsParameters defines configuration for metrics server endpoints in both
 // Contour and Envoy.
 type MetricsParameters struct {
@@ -760,6 +1074,14 @@ func (p *Parameters) Validate() error {
 		return err
 	}
 
+	if err := p.AccessLogService.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.AccessLogFilter.Validate(); err != nil {
+		return err
+	}
+
 	if err := p.TLS.Validate(); err != nil {
 		return err
 	}
@@ -782,6 +1104,10 @@ func (p *Parameters) Validate() error {
 		return err
 	}
 
+	if err := p.Tracing.Validate(); err != nil {
+		return err
+	}
+
 	return p.Listener.Validate()
 }
 
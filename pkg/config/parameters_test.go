@@ -186,6 +186,68 @@ func TestValidateGatewayParameters(t *testing.T) {
 	// ControllerName is required.
 	gw = &GatewayParameters{ControllerName: "controller"}
 	assert.Equal(t, nil, gw.Validate())
+
+	// GatewayRef needs both a namespace and a name.
+	gw = &GatewayParameters{ControllerName: "controller", GatewayRef: &NamespacedName{Namespace: "projectcontour", Name: "contour"}}
+	assert.Equal(t, nil, gw.Validate())
+
+	gw = &GatewayParameters{ControllerName: "controller", GatewayRef: &NamespacedName{Name: "contour"}}
+	assert.Error(t, gw.Validate())
+}
+
+func TestValidateTracingParameters(t *testing.T) {
+	// Not required if nothing is passed.
+	var tracing *TracingParameters
+	assert.NoError(t, tracing.Validate())
+
+	tracing = &TracingParameters{
+		ExtensionService: "projectcontour/zipkin",
+		OverallSampling:  "12.5",
+		CustomTags: []CustomTag{
+			{TagName: "cluster", Literal: "production"},
+			{TagName: "user-agent", RequestHeaderName: "User-Agent"},
+		},
+	}
+	assert.NoError(t, tracing.Validate())
+
+	assert.NoError(t, (&TracingParameters{
+		Provider:         ZipkinTracingProvider,
+		ExtensionService: "projectcontour/zipkin",
+	}).Validate())
+
+	assert.Error(t, (&TracingParameters{}).Validate())
+	assert.Error(t, (&TracingParameters{Provider: "jaeger", ExtensionService: "projectcontour/zipkin"}).Validate())
+	assert.Error(t, (&TracingParameters{
+		Provider:         OpenTelemetryTracingProvider,
+		ExtensionService: "projectcontour/otel-collector",
+	}).Validate())
+	assert.Error(t, (&TracingParameters{ExtensionService: "zipkin"}).Validate())
+	assert.Error(t, (&TracingParameters{ExtensionService: "projectcontour/zipkin", OverallSampling: "101"}).Validate())
+	assert.Error(t, (&TracingParameters{ExtensionService: "projectcontour/zipkin", OverallSampling: "all"}).Validate())
+	assert.Error(t, (&TracingParameters{
+		ExtensionService: "projectcontour/zipkin",
+		CustomTags:       []CustomTag{{TagName: "cluster"}},
+	}).Validate())
+	assert.Error(t, (&TracingParameters{
+		ExtensionService: "projectcontour/zipkin",
+		CustomTags:       []CustomTag{{TagName: "cluster", Literal: "a", RequestHeaderName: "b"}},
+	}).Validate())
+	assert.Error(t, (&TracingParameters{
+		ExtensionService: "projectcontour/zipkin",
+		CustomTags: []CustomTag{
+			{TagName: "cluster", Literal: "a"},
+			{TagName: "cluster", Literal: "b"},
+		},
+	}).Validate())
+}
+
+func TestValidateTracingProvider(t *testing.T) {
+	assert.Error(t, TracingProvider("").Validate())
+	assert.Error(t, TracingProvider("jaeger").Validate())
+	// Envoy's OpenTelemetry tracer needs a newer Envoy than the bundled one.
+	assert.Error(t, OpenTelemetryTracingProvider.Validate())
+
+	assert.NoError(t, ZipkinTracingProvider.Validate())
 }
 
 func TestValidateAccessLogType(t *testing.T) {
@@ -196,6 +258,53 @@ func TestValidateAccessLogType(t *testing.T) {
 	assert.NoError(t, JSONAccessLog.Validate())
 }
 
+func TestValidateAccessLogServiceParameters(t *testing.T) {
+	// Not required if nothing is passed.
+	var als *AccessLogServiceParameters
+	assert.NoError(t, als.Validate())
+
+	als = &AccessLogServiceParameters{
+		Type:                GRPCAccessLogService,
+		ExtensionService:    "projectcontour/als",
+		BufferFlushInterval: "500ms",
+	}
+	assert.NoError(t, als.Validate())
+
+	assert.NoError(t, (&AccessLogServiceParameters{Type: OpenTelemetryAccessLogService, ExtensionService: "projectcontour/otel"}).Validate())
+
+	assert.Error(t, (&AccessLogServiceParameters{ExtensionService: "projectcontour/als"}).Validate())
+	assert.Error(t, (&AccessLogServiceParameters{Type: "kafka", ExtensionService: "projectcontour/als"}).Validate())
+	assert.Error(t, (&AccessLogServiceParameters{Type: GRPCAccessLogService}).Validate())
+	assert.Error(t, (&AccessLogServiceParameters{Type: GRPCAccessLogService, ExtensionService: "als"}).Validate())
+	assert.Error(t, (&AccessLogServiceParameters{Type: GRPCAccessLogService, ExtensionService: "projectcontour/als", BufferFlushInterval: "soon"}).Validate())
+}
+
+func TestValidateAccessLogFilterParameters(t *testing.T) {
+	// Not required if nothing is passed.
+	var alf *AccessLogFilterParameters
+	assert.NoError(t, alf.Validate())
+
+	percent := uint32(25)
+	alf = &AccessLogFilterParameters{
+		StatusCodes:     []StatusCodeRange{{Min: 400, Max: 499}, {Min: 500, Max: 599}},
+		MinDuration:     "500ms",
+		ResponseFlags:   []string{"UH", "UF"},
+		RequestHeaders:  []string{"x-debug"},
+		SamplingPercent: &percent,
+	}
+	assert.NoError(t, alf.Validate())
+
+	tooMany := uint32(101)
+	assert.Error(t, (&AccessLogFilterParameters{StatusCodes: []StatusCodeRange{{Min: 500, Max: 400}}}).Validate())
+	assert.Error(t, (&AccessLogFilterParameters{StatusCodes: []StatusCodeRange{{Min: 0, Max: 400}}}).Validate())
+	assert.Error(t, (&AccessLogFilterParameters{StatusCodes: []StatusCodeRange{{Min: 500, Max: 600}}}).Validate())
+	assert.Error(t, (&AccessLogFilterParameters{MinDuration: "slow"}).Validate())
+	assert.Error(t, (&AccessLogFilterParameters{MinDuration: "-1s"}).Validate())
+	assert.Error(t, (&AccessLogFilterParameters{ResponseFlags: []string{"XX"}}).Validate())
+	assert.Error(t, (&AccessLogFilterParameters{RequestHeaders: []string{""}}).Validate())
+	assert.Error(t, (&AccessLogFilterParameters{SamplingPercent: &tooMany}).Validate())
+}
+
 func TestValidateAccessLogFields(t *testing.T) {
 	errorCases := [][]string{
 		{"dog", "cat"},
@@ -213,6 +322,11 @@ func TestValidateAccessLogFields(t *testing.T) {
 		{"invalid=%RESP%"},
 		{"invalid=%REQ_WITHOUT_QUERY%"},
 		{"@timestamp", "invalid=%START_TIME(%s.%6f):10%"},
+		{"invalid=%DYNAMIC_METADATA%"},
+		{"invalid=%FILTER_STATE%"},
+		{"invalid=%DOWNSTREAM_PEER_SUBJECT(dog)%"},
+		{"peer=%UPSTREAM_PEER_SUBJECT%"},
+		{"chain=%FILTER_CHAIN_NAME%"},
 	}
 
 	for _, c := range errorCases {
@@ -233,6 +347,12 @@ func TestValidateAccessLogFields(t *testing.T) {
 		{"@timestamp", "duration=my durations are %DURATION%.0 and method is %REQ(:METHOD)%"},
 		{"path=%REQ_WITHOUT_QUERY(X-ENVOY-ORIGINAL-PATH?:PATH)%"},
 		{"dog=pug", "cat=black"},
+		{"authz=%DYNAMIC_METADATA(envoy.filters.http.ext_authz:user)%"},
+		{"state=%FILTER_STATE(envoy.string:PLAIN):10%"},
+		{"downstream_peer_subject", "downstream_peer_fingerprint_256"},
+		{"peer=%DOWNSTREAM_PEER_SUBJECT%", "fingerprint=%DOWNSTREAM_PEER_FINGERPRINT_256%"},
+		{"cluster=%CLUSTER_METADATA(envoy.lb:canary)%", "grpc=%GRPC_STATUS(NUMBER)%"},
+		{"sampled=true", "version=2", "ratio=0.5"},
 	}
 
 	for _, c := range successCases {
@@ -441,6 +561,8 @@ listener:
 }
 
 func TestConfigFileDefaultOverrideImport(t *testing.T) {
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+
 	check := func(verifier func(*testing.T, *Parameters), yamlIn string) {
 		t.Helper()
 
@@ -534,6 +656,44 @@ network:
   num-trusted-hops: 1
   admin-port: 9001
 `)
+
+	check(func(t *testing.T, conf *Parameters) {
+		assert.Equal(t, ZoneAwareRoutingParameters{
+			Enabled:        true,
+			MinClusterSize: 3,
+		}, conf.Cluster.ZoneAwareRouting)
+	}, `
+cluster:
+  zone-aware-routing:
+    enabled: true
+    min-cluster-size: 3
+`)
+
+	check(func(t *testing.T, conf *Parameters) {
+		assert.Equal(t, CircuitBreakerParameters{
+			MaxConnections:     uint32Ptr(1),
+			MaxPendingRequests: uint32Ptr(2),
+			MaxRequests:        uint32Ptr(3),
+			MaxRetries:         uint32Ptr(4),
+		}, conf.Cluster.CircuitBreakers)
+	}, `
+cluster:
+  circuit-breakers:
+    max-connections: 1
+    max-pending-requests: 2
+    max-requests: 3
+    max-retries: 4
+`)
+
+	check(func(t *testing.T, conf *Parameters) {
+		assert.Equal(t, CircuitBreakerParameters{
+			MaxRetries: uint32Ptr(0),
+		}, conf.Cluster.CircuitBreakers)
+	}, `
+cluster:
+  circuit-breakers:
+    max-retries: 0
+`)
 }
 
 func TestAccessLogFormatString(t *testing.T) {
@@ -548,6 +708,9 @@ func TestAccessLogFormatString(t *testing.T) {
 		"%RESP%\n",
 		"%REQ_WITHOUT_QUERY%\n",
 		"%START_TIME(%s.%6f):10%\n",
+		"%DYNAMIC_METADATA%\n",
+		"%RESPONSE_CODE(dog)%\n",
+		"%UPSTREAM_TLS_VERSION%\n",
 		"no newline at the end",
 	}
 
@@ -566,6 +729,8 @@ func TestAccessLogFormatString(t *testing.T) {
 		"my durations are %DURATION%.0 and method is %REQ(:METHOD)%\n",
 		"queries %REQ_WITHOUT_QUERY(X-ENVOY-ORIGINAL-PATH?:PATH)% removed\n",
 		"just a string\n",
+		"%DYNAMIC_METADATA(envoy.filters.http.ext_authz)% %DOWNSTREAM_PEER_SUBJECT%\n",
+		"%DOWNSTREAM_PEER_FINGERPRINT_256% %DOWNSTREAM_PEER_CERT_V_START(%s)%\n",
 	}
 
 	for _, c := range successCases {
@@ -45,7 +45,7 @@ func testRouteParentRefs(namespace string) {
 			Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 				Hostnames: []gatewayapi_v1alpha2.Hostname{"routeparentrefs.gateway.projectcontour.io"},
 				CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-					ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+					ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 						gatewayapi.GatewayParentRef("", "http"), // TODO need a better way to inform the test case of the Gateway it should use
 					},
 				},
@@ -78,7 +78,7 @@ func testRouteParentRefs(namespace string) {
 			Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 				Hostnames: []gatewayapi_v1alpha2.Hostname{"routeparentrefs.gateway.projectcontour.io"},
 				CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-					ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+					ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 						gatewayapi.GatewayParentRef("", "invalid-name"),
 					},
 				},
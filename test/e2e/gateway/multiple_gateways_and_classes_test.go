@@ -143,7 +143,7 @@ var _ = Describe("GatewayClass/Gateway admission tests", func() {
 	})
 
 	f.NamespacedTest("gateway-multiple-gateways", func(namespace string) {
-		Specify("only the oldest gateway for the accepted gatewayclass should be accepted", func() {
+		Specify("all gateways for the accepted gatewayclass should be accepted", func() {
 			// Create a matching gateway class.
 			gc := &gatewayapi_v1alpha2.GatewayClass{
 				ObjectMeta: metav1.ObjectMeta{
@@ -181,7 +181,7 @@ var _ = Describe("GatewayClass/Gateway admission tests", func() {
 			_, valid = f.CreateGatewayAndWaitFor(oldest, gatewayValid)
 			require.True(f.T(), valid)
 
-			// Create another matching gateway and verify it's not accepted.
+			// Create another matching gateway and verify it's also accepted.
 			secondOldest := &gatewayapi_v1alpha2.Gateway{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "second-oldest",
@@ -203,24 +203,15 @@ var _ = Describe("GatewayClass/Gateway admission tests", func() {
 					},
 				},
 			}
-			_, notScheduled := f.CreateGatewayAndWaitFor(secondOldest, func(gw *gatewayapi_v1alpha2.Gateway) bool {
-				for _, cond := range gw.Status.Conditions {
-					if cond.Type == "Scheduled" &&
-						cond.Status == metav1.ConditionFalse &&
-						cond.Reason == "OlderGatewayExists" {
-						return true
-					}
-				}
-				return false
-			})
-			require.True(f.T(), notScheduled)
+			_, valid = f.CreateGatewayAndWaitFor(secondOldest, gatewayValid)
+			require.True(f.T(), valid)
 
 			// Double-check that the oldest gateway is still accepted.
 			require.NoError(f.T(), f.Client.Get(context.Background(), k8s.NamespacedNameOf(oldest), oldest))
 			require.True(f.T(), gatewayValid(oldest))
 
 			// Delete the oldest gateway and verify that the second
-			// oldest is now accepted.
+			// oldest is still accepted.
 			require.NoError(f.T(), f.Client.Delete(context.Background(), oldest))
 			require.Eventually(f.T(), func() bool {
 				if err := f.Client.Get(context.Background(), k8s.NamespacedNameOf(secondOldest), secondOldest); err != nil {
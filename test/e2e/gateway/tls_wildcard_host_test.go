@@ -42,7 +42,7 @@ func testTLSWildcardHost(namespace string) {
 			Spec: gatewayapi_v1alpha2.HTTPRouteSpec{
 				Hostnames: []gatewayapi_v1alpha2.Hostname{"*.wildcardhost.gateway.projectcontour.io"},
 				CommonRouteSpec: gatewayapi_v1alpha2.CommonRouteSpec{
-					ParentRefs: []gatewayapi_v1alpha2.ParentRef{
+					ParentRefs: []gatewayapi_v1alpha2.ParentReference{
 						{
 							Name:        "https", // TODO need a better way to inform the test case of the Gateway it should use
 							SectionName: gatewayapi.SectionNamePtr("secure"),